@@ -1,7 +1,19 @@
 package main
 
-import "github.com/jt00721/meeting-notes-manager/config"
+import (
+	// The runtime image has no zoneinfo, so embed it for the tz query
+	// parameter and APP_TIMEZONE.
+	_ "time/tzdata"
 
+	"github.com/jt00721/meeting-notes-manager/config"
+)
+
+//go:generate swag init --dir .. --generalInfo cmd/main.go --output ../docs --outputTypes go,json --propertyStrategy pascalcase
+
+// @title Meeting Notes Manager API
+// @version 1.0
+// @description Create, search and organise meeting notes.
+// @BasePath /
 func main() {
 	application := config.NewApp()
 
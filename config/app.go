@@ -7,16 +7,29 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
-	"github.com/jt00721/meeting-notes-manager/infrastructure"
+	"github.com/jt00721/meeting-notes-manager/docs"
 	"github.com/jt00721/meeting-notes-manager/internal/handler"
-	"github.com/jt00721/meeting-notes-manager/internal/repository"
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+	"github.com/jt00721/meeting-notes-manager/internal/middleware"
+	"github.com/jt00721/meeting-notes-manager/internal/outbox"
 	"github.com/jt00721/meeting-notes-manager/internal/routes"
 	"github.com/jt00721/meeting-notes-manager/internal/usecase"
 )
 
 type App struct {
-	Router      *gin.Engine
-	NoteHandler *handler.NoteHandler
+	Router          *gin.Engine
+	NoteHandler     *handler.NoteHandler
+	HealthHandler   *handler.HealthHandler
+	PresetHandler   *handler.PresetHandler
+	TemplateHandler *handler.TemplateHandler
+	MetricsHandler  *handler.MetricsHandler
+	DocsHandler     *handler.DocsHandler
+	Metrics         *middleware.PrometheusMetrics
+	// OutboxRelay delivers note events to webhooks. Nil when none are
+	// configured.
+	OutboxRelay *outbox.Relay
+
+	countNotes func() (int64, error)
 }
 
 func NewApp() *App {
@@ -26,24 +39,55 @@ func NewApp() *App {
 		log.Println("Warning: Could not load .env file, using system environment variables")
 	}
 
-	log.Println("Initialising DB...")
-	if err := infrastructure.InitDB(); err != nil {
-		log.Fatalf("Database initialization failed: %v", err)
-	}
+	appLogger := logger.NewWithLevel(os.Stderr, loadLogLevel())
+
+	usecaseConfig := loadUsecaseConfig()
+	usecaseConfig.Logger = appLogger
+
+	repos := loadRepositories(&usecaseConfig, appLogger)
+	noteUsecase := usecase.NewNoteUsecaseWithConfig(repos.notes, usecaseConfig)
+	noteHandler := handler.NewNoteHandlerWithLogger(noteUsecase, appLogger)
+	noteHandler.Redactor = loadRedactor()
+	noteHandler.StrictJSON = strictJSON()
+	noteHandler.PageLimit = loadPageLimit()
+	noteHandler.Location = usecaseConfig.Location
+
+	presetUsecase := usecase.NewPresetUsecaseWithLogger(repos.presets, appLogger)
+	presetHandler := handler.NewPresetHandlerWithLogger(presetUsecase, appLogger)
+	noteHandler.Presets = presetUsecase
+
+	templateHandler := handler.NewTemplateHandlerWithLogger(usecaseConfig.Templates, appLogger)
 
-	noteRepository := repository.NewNoteRepository(infrastructure.DB)
-	noteUsecase := usecase.NewNoteUsecase(noteRepository)
-	noteHandler := handler.NewNoteHandler(noteUsecase)
+	healthHandler := handler.NewHealthHandlerWithLogger(repos.health, appLogger)
 
-	router := gin.Default()
+	metricsRegistry := newMetricsRegistry()
+	metrics := middleware.NewPrometheusMetrics(metricsRegistry)
+	metricsHandler := handler.NewMetricsHandler(metricsRegistry)
+
+	docsHandler, err := handler.NewDocsHandler(docs.SwaggerInfo.ReadDoc())
+	if err != nil {
+		log.Fatalf("API docs initialization failed: %v", err)
+	}
+
+	router := gin.New()
+	buildMiddlewareRegistry(metrics, appLogger).Apply(router)
+	routes.Configure(router, loadRouteOptions())
 
 	router.Static("/static", "./static")
 
-	routes.SetupRoutes(router, noteHandler)
+	routes.SetupRoutes(router, noteHandler, healthHandler, presetHandler, templateHandler, metricsHandler, docsHandler, middleware.MaxBodySize(maxBodyBytes()), middleware.Admin(adminUserIDs()))
 
 	return &App{
-		Router:      router,
-		NoteHandler: noteHandler,
+		Router:          router,
+		NoteHandler:     noteHandler,
+		HealthHandler:   healthHandler,
+		PresetHandler:   presetHandler,
+		TemplateHandler: templateHandler,
+		MetricsHandler:  metricsHandler,
+		DocsHandler:     docsHandler,
+		Metrics:         metrics,
+		OutboxRelay:     repos.outboxRelay,
+		countNotes:      repos.notes.CountNotes,
 	}
 }
 
@@ -59,6 +103,12 @@ func (app *App) Run() {
 		ip = ":"
 	}
 
+	app.startPurgeWorkerFromEnv()
+	app.startAutoArchiveWorkerFromEnv()
+	app.startNotesGaugeWorkerFromEnv()
+	app.startReminderWorkerFromEnv()
+	app.startOutboxWorkerFromEnv()
+
 	fmt.Println("Server running on port", port)
 	app.Router.Run(ip + port)
 }
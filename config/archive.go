@@ -0,0 +1,49 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// StartAutoArchiveWorker archives notes whose meeting was more than
+// olderThan ago every interval until the returned stop function is called.
+func (app *App) StartAutoArchiveWorker(interval, olderThan time.Duration) (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := app.NoteHandler.Usecase.AutoArchiveNotes(olderThan); err != nil {
+					log.Println("Scheduled auto-archive failed:", err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// startAutoArchiveWorkerFromEnv starts the auto-archive worker when
+// AUTO_ARCHIVE_INTERVAL_HOURS is set. AUTO_ARCHIVE_AFTER_DAYS sets how old a
+// meeting must be and defaults to 180.
+func (app *App) startAutoArchiveWorkerFromEnv() {
+	intervalHours, err := strconv.Atoi(os.Getenv("AUTO_ARCHIVE_INTERVAL_HOURS"))
+	if err != nil || intervalHours <= 0 {
+		return
+	}
+
+	olderThan := 180 * 24 * time.Hour
+	if days := envDays("AUTO_ARCHIVE_AFTER_DAYS"); days > 0 {
+		olderThan = days
+	}
+
+	log.Printf("Archiving notes with meetings older than %s every %dh", olderThan, intervalHours)
+	app.StartAutoArchiveWorker(time.Duration(intervalHours)*time.Hour, olderThan)
+}
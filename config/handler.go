@@ -0,0 +1,64 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jt00721/meeting-notes-manager/internal/handler"
+)
+
+// loadRedactor builds the export redactor when EXPORT_REDACTION is enabled.
+// Extra patterns are read from EXPORT_REDACT_PATTERNS, separated by ";".
+func loadRedactor() *handler.Redactor {
+	if enabled := strings.ToLower(os.Getenv("EXPORT_REDACTION")); enabled != "true" && enabled != "1" {
+		return nil
+	}
+
+	var patterns []string
+	for _, p := range strings.Split(os.Getenv("EXPORT_REDACT_PATTERNS"), ";") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+
+	redactor, err := handler.NewRedactor(patterns)
+	if err != nil {
+		log.Fatalf("Export redaction configuration failed: %v", err)
+	}
+	return redactor
+}
+
+// strictJSON reports whether STRICT_JSON asks for note bodies with unknown
+// fields to be rejected. Lenient binding is the default.
+func strictJSON() bool {
+	value := os.Getenv("STRICT_JSON")
+	if value == "" {
+		return false
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Warning: Invalid STRICT_JSON %q, unknown JSON fields are ignored", value)
+		return false
+	}
+	return enabled
+}
+
+// loadPageLimit reads DEFAULT_PAGE_LIMIT, the page size of list endpoints
+// when a request gives no limit. Unset or invalid means
+// handler.DefaultPageLimit.
+func loadPageLimit() int {
+	value := os.Getenv("DEFAULT_PAGE_LIMIT")
+	if value == "" {
+		return handler.DefaultPageLimit
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		log.Printf("Warning: Invalid DEFAULT_PAGE_LIMIT %q, using %d", value, handler.DefaultPageLimit)
+		return handler.DefaultPageLimit
+	}
+	return limit
+}
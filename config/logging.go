@@ -0,0 +1,41 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+)
+
+// loadLogLevel reads LOG_LEVEL, the least severe entries the app logs:
+// info, warn or error. It defaults to info.
+func loadLogLevel() logger.Level {
+	value := os.Getenv("LOG_LEVEL")
+	if value == "" {
+		return logger.LevelInfo
+	}
+
+	level, err := logger.ParseLevel(value)
+	if err != nil {
+		log.Printf("Warning: Invalid LOG_LEVEL %q, using info", value)
+		return logger.LevelInfo
+	}
+	return level
+}
+
+// accessLogEnabled reads ACCESS_LOG. Per-request access logs are on unless it
+// is set to false.
+func accessLogEnabled() bool {
+	value := os.Getenv("ACCESS_LOG")
+	if value == "" {
+		return true
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Warning: Invalid ACCESS_LOG %q, using true", value)
+		return true
+	}
+	return enabled
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// newMetricsRegistry returns the registry served at /metrics, with the
+// standard Go runtime and process collectors already registered.
+func newMetricsRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+// StartNotesGaugeWorker refreshes the notes_total gauge every interval until
+// the returned stop function is called.
+func (app *App) StartNotesGaugeWorker(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+
+	refresh := func() {
+		count, err := app.countNotes()
+		if err != nil {
+			log.Println("Refreshing notes gauge failed:", err)
+			return
+		}
+		app.Metrics.NotesTotal.Set(float64(count))
+	}
+
+	go func() {
+		defer ticker.Stop()
+		refresh()
+		for {
+			select {
+			case <-ticker.C:
+				refresh()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// startNotesGaugeWorkerFromEnv starts the gauge worker, refreshing every
+// NOTES_GAUGE_INTERVAL_SECONDS (default 60).
+func (app *App) startNotesGaugeWorkerFromEnv() {
+	interval := time.Minute
+	if value := os.Getenv("NOTES_GAUGE_INTERVAL_SECONDS"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds <= 0 {
+			log.Printf("Warning: Invalid NOTES_GAUGE_INTERVAL_SECONDS %q, using %s", value, interval)
+		} else {
+			interval = time.Duration(seconds) * time.Second
+		}
+	}
+
+	app.StartNotesGaugeWorker(interval)
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+	"github.com/jt00721/meeting-notes-manager/internal/middleware"
+)
+
+// buildMiddlewareRegistry registers every available middleware and disables
+// the ones listed in DISABLED_MIDDLEWARES (comma separated names). Access
+// logs go to l and are also turned off by ACCESS_LOG=false. Recovery can't be
+// disabled.
+func buildMiddlewareRegistry(metrics *middleware.PrometheusMetrics, l logger.Logger) *middleware.Registry {
+	registry := middleware.NewRegistry()
+
+	mustRegister(registry, middleware.Recovery, gin.Recovery())
+	mustRegister(registry, middleware.RequestLogging, middleware.AccessLog(l))
+	mustRegister(registry, middleware.Metrics, metrics.Middleware())
+	mustRegister(registry, middleware.Auth, middleware.UserID())
+	mustRegister(registry, middleware.Gzip, middleware.Compress(gzipMinSize()))
+
+	for _, name := range strings.Split(os.Getenv("DISABLED_MIDDLEWARES"), ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "":
+		case middleware.Recovery:
+			log.Println("Warning: The recovery middleware cannot be disabled")
+		default:
+			registry.SetEnabled(name, false)
+		}
+	}
+	if !accessLogEnabled() {
+		registry.SetEnabled(middleware.RequestLogging, false)
+	}
+
+	log.Println("Middleware chain:", registry.Names())
+	return registry
+}
+
+// gzipMinSize reads GZIP_MIN_BYTES, falling back to the middleware default.
+func gzipMinSize() int {
+	size, err := strconv.Atoi(os.Getenv("GZIP_MIN_BYTES"))
+	if err != nil || size < 0 {
+		return middleware.DefaultGzipMinSize
+	}
+	return size
+}
+
+// maxBodyBytes reads MAX_BODY_BYTES, falling back to the middleware default.
+func maxBodyBytes() int64 {
+	size, err := strconv.ParseInt(os.Getenv("MAX_BODY_BYTES"), 10, 64)
+	if err != nil || size <= 0 {
+		return middleware.DefaultMaxBodyBytes
+	}
+	return size
+}
+
+// adminUserIDs reads ADMIN_USER_IDS, a comma separated list of the user ids
+// allowed to call the admin-only routes. Invalid ids are skipped.
+func adminUserIDs() []uint {
+	var ids []uint
+	for _, value := range strings.Split(os.Getenv("ADMIN_USER_IDS"), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || id == 0 {
+			log.Printf("Warning: Invalid admin user id %q in ADMIN_USER_IDS", value)
+			continue
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids
+}
+
+func mustRegister(registry *middleware.Registry, name string, h gin.HandlerFunc) {
+	if err := registry.Register(name, h); err != nil {
+		log.Fatalf("Middleware registration failed: %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+	"github.com/jt00721/meeting-notes-manager/internal/outbox"
+	"github.com/jt00721/meeting-notes-manager/internal/repository"
+	"github.com/jt00721/meeting-notes-manager/internal/usecase"
+	"github.com/jt00721/meeting-notes-manager/internal/webhook"
+	"gorm.io/gorm"
+)
+
+// loadEventDelivery returns the note repository to use and, when WEBHOOK_URLS
+// lists any webhooks, the relay that delivers note events to them. Events are
+// written to the outbox in the same transaction as the note change and sent by
+// the relay, so they survive a crash. Without webhooks events are discarded
+// and the relay is nil.
+func loadEventDelivery(db *gorm.DB, cfg *usecase.Config, l logger.Logger) (repository.NoteRepository, *outbox.Relay) {
+	var urls []string
+	for _, url := range strings.Split(os.Getenv("WEBHOOK_URLS"), ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
+		}
+	}
+
+	opts := repository.NoteRepositoryOptions{HardDelete: cfg.HardDelete, WriteRetries: loadWriteRetries()}
+	if len(urls) == 0 {
+		cfg.Publisher = usecase.NopPublisher()
+		return repository.NewNoteRepositoryWithOptions(db, opts), nil
+	}
+
+	log.Printf("Publishing note events to %d webhook(s) through the outbox", len(urls))
+	outboxRepository := repository.NewOutboxRepository(db)
+	cfg.Publisher = outbox.NewPublisherWithLogger(outboxRepository, l)
+	cfg.NoteEventsInOutbox = true
+
+	dispatcher := webhook.NewDispatcherWithLogger(urls, l)
+	opts.Outbox = true
+	return repository.NewNoteRepositoryWithOptions(db, opts), outbox.NewRelayWithLogger(outboxRepository, dispatcher, l)
+}
+
+// StartOutboxWorker relays due outbox events every interval until the
+// returned stop function is called.
+func (app *App) StartOutboxWorker(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := app.OutboxRelay.RelayOnce(); err != nil {
+					log.Println("Outbox relay failed:", err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// startOutboxWorkerFromEnv starts the outbox worker when webhooks are
+// configured. OUTBOX_POLL_SECONDS controls how often it checks for due events
+// and defaults to 5.
+func (app *App) startOutboxWorkerFromEnv() {
+	if app.OutboxRelay == nil {
+		return
+	}
+
+	interval := 5 * time.Second
+	if value := os.Getenv("OUTBOX_POLL_SECONDS"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds <= 0 {
+			log.Printf("Warning: Invalid OUTBOX_POLL_SECONDS %q, using %s", value, interval)
+		} else {
+			interval = time.Duration(seconds) * time.Second
+		}
+	}
+
+	log.Printf("Relaying outbox events every %s", interval)
+	app.StartOutboxWorker(interval)
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// StartPurgeWorker purges notes soft-deleted more than olderThan ago every
+// interval until the returned stop function is called.
+func (app *App) StartPurgeWorker(interval, olderThan time.Duration) (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := app.NoteHandler.Usecase.PurgeDeletedNotes(olderThan); err != nil {
+					log.Println("Scheduled purge failed:", err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// startPurgeWorkerFromEnv starts the purge worker when PURGE_INTERVAL_HOURS
+// is set. PURGE_AFTER_DAYS controls the undo window and defaults to 30.
+func (app *App) startPurgeWorkerFromEnv() {
+	intervalHours, err := strconv.Atoi(os.Getenv("PURGE_INTERVAL_HOURS"))
+	if err != nil || intervalHours <= 0 {
+		return
+	}
+
+	olderThan := 30 * 24 * time.Hour
+	if days := envDays("PURGE_AFTER_DAYS"); days > 0 {
+		olderThan = days
+	}
+
+	log.Printf("Purging deleted notes older than %s every %dh", olderThan, intervalHours)
+	app.StartPurgeWorker(time.Duration(intervalHours)*time.Hour, olderThan)
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// StartReminderWorker publishes note.reminder events every interval for
+// meetings starting lead from now, until the returned stop function is
+// called.
+func (app *App) StartReminderWorker(interval, lead time.Duration) (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := app.NoteHandler.Usecase.SendMeetingReminders(lead, interval); err != nil {
+					log.Println("Sending meeting reminders failed:", err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// startReminderWorkerFromEnv starts the reminder worker when
+// REMINDER_LEAD_MINUTES is set. REMINDER_INTERVAL_MINUTES controls how often
+// it checks and defaults to 5.
+func (app *App) startReminderWorkerFromEnv() {
+	leadMinutes, err := strconv.Atoi(os.Getenv("REMINDER_LEAD_MINUTES"))
+	if err != nil || leadMinutes <= 0 {
+		return
+	}
+
+	interval := 5 * time.Minute
+	if value := os.Getenv("REMINDER_INTERVAL_MINUTES"); value != "" {
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes <= 0 {
+			log.Printf("Warning: Invalid REMINDER_INTERVAL_MINUTES %q, using %s", value, interval)
+		} else {
+			interval = time.Duration(minutes) * time.Minute
+		}
+	}
+
+	lead := time.Duration(leadMinutes) * time.Minute
+	log.Printf("Sending meeting reminders %s ahead, checking every %s", lead, interval)
+	app.StartReminderWorker(interval, lead)
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jt00721/meeting-notes-manager/infrastructure"
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+	"github.com/jt00721/meeting-notes-manager/internal/outbox"
+	"github.com/jt00721/meeting-notes-manager/internal/repository"
+	"github.com/jt00721/meeting-notes-manager/internal/repository/memory"
+	"github.com/jt00721/meeting-notes-manager/internal/usecase"
+)
+
+// repositories are the stores the app runs on.
+type repositories struct {
+	notes   repository.NoteRepository
+	presets repository.PresetRepository
+	health  repository.HealthRepository
+	// outboxRelay delivers note events to webhooks. Nil when none are
+	// configured.
+	outboxRelay *outbox.Relay
+}
+
+// loadRepositories opens the store DB_DRIVER selects and sets cfg's audit
+// log and publisher to match. "memory" keeps everything in process memory
+// and loses it on exit, for demos; any other driver is opened by
+// infrastructure.InitDB.
+func loadRepositories(cfg *usecase.Config, l logger.Logger) repositories {
+	if os.Getenv("DB_DRIVER") == "memory" {
+		return loadMemoryRepositories(cfg)
+	}
+
+	log.Println("Initialising DB...")
+	if err := infrastructure.InitDB(); err != nil {
+		log.Fatalf("Database initialization failed: %v", err)
+	}
+
+	if uniqueTitleDateEnabled() {
+		if err := repository.EnsureTitleDateIndex(infrastructure.DB); err != nil {
+			log.Fatalf("Unique title and meeting date index failed, remove duplicate notes first: %v", err)
+		}
+	}
+
+	cfg.AuditLog = repository.NewAuditRepository(infrastructure.DB)
+
+	// Notes stored before durations existed get the configured default.
+	backfilled, err := repository.BackfillMeetingDurations(infrastructure.DB, cfg.DefaultMeetingDurationMinutes)
+	if err != nil {
+		log.Fatalf("Meeting duration backfill failed: %v", err)
+	}
+	if backfilled > 0 {
+		log.Printf("Gave %d notes the default meeting duration of %d minutes", backfilled, cfg.DefaultMeetingDurationMinutes)
+	}
+
+	notes, outboxRelay := loadEventDelivery(infrastructure.DB, cfg, l)
+	return repositories{
+		notes:       notes,
+		presets:     repository.NewPresetRepository(infrastructure.DB),
+		health:      repository.NewHealthRepository(infrastructure.DB),
+		outboxRelay: outboxRelay,
+	}
+}
+
+// loadMemoryRepositories returns empty in-memory repositories. The outbox
+// needs a database, so note events are discarded and WEBHOOK_URLS is
+// ignored.
+func loadMemoryRepositories(cfg *usecase.Config) repositories {
+	log.Println("Using in-memory storage; notes are lost when the server stops")
+	if strings.TrimSpace(os.Getenv("WEBHOOK_URLS")) != "" {
+		log.Println("Warning: WEBHOOK_URLS is ignored with DB_DRIVER=memory")
+	}
+
+	audit := memory.NewAuditRepository()
+	cfg.AuditLog = audit
+	cfg.Publisher = usecase.NopPublisher()
+
+	return repositories{
+		notes: memory.NewNoteRepositoryWithOptions(memory.NoteRepositoryOptions{
+			HardDelete:      cfg.HardDelete,
+			AuditLog:        audit,
+			UniqueTitleDate: uniqueTitleDateEnabled(),
+		}),
+		presets: memory.NewPresetRepository(),
+		health:  memory.NewHealthRepository(),
+	}
+}
+
+// loadWriteRetries reads DB_WRITE_RETRIES, how many times a note write that
+// hit a transient database error is retried. It defaults to
+// repository.DefaultWriteRetries; 0 turns retries off.
+func loadWriteRetries() int {
+	value := os.Getenv("DB_WRITE_RETRIES")
+	if value == "" {
+		return repository.DefaultWriteRetries
+	}
+
+	retries, err := strconv.Atoi(value)
+	if err != nil || retries < 0 {
+		log.Printf("Warning: Invalid DB_WRITE_RETRIES %q, using %d", value, repository.DefaultWriteRetries)
+		return repository.DefaultWriteRetries
+	}
+	return retries
+}
+
+// uniqueTitleDateEnabled reads UNIQUE_TITLE_DATE. When true, a note can't
+// share its title and meeting date with another of its owner's live notes.
+// It is off by default.
+func uniqueTitleDateEnabled() bool {
+	value := os.Getenv("UNIQUE_TITLE_DATE")
+	if value == "" {
+		return false
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Warning: Invalid UNIQUE_TITLE_DATE %q, using false", value)
+		return false
+	}
+	return enabled
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/jt00721/meeting-notes-manager/internal/routes"
+)
+
+// loadRouteOptions reads REDIRECT_TRAILING_SLASH and REDIRECT_FIXED_PATH.
+// Both default to on.
+func loadRouteOptions() routes.Options {
+	opts := routes.DefaultOptions()
+	opts.RedirectTrailingSlash = envBool("REDIRECT_TRAILING_SLASH", opts.RedirectTrailingSlash)
+	opts.RedirectFixedPath = envBool("REDIRECT_FIXED_PATH", opts.RedirectFixedPath)
+	return opts
+}
+
+// envBool reads a boolean from key, returning fallback when it is unset or
+// invalid.
+func envBool(key string, fallback bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Warning: Invalid %s %q, using %t", key, value, fallback)
+		return fallback
+	}
+	return enabled
+}
@@ -0,0 +1,265 @@
+package config
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/timeutil"
+	"github.com/jt00721/meeting-notes-manager/internal/usecase"
+)
+
+func loadUsecaseConfig() usecase.Config {
+	cfg := usecase.DefaultConfig()
+
+	if tz := os.Getenv("TIMEZONE"); tz != "" {
+		loc, err := timeutil.LoadLocation(tz, nil)
+		if err != nil {
+			log.Printf("Warning: Invalid TIMEZONE %q, falling back to UTC: %v", tz, err)
+		} else {
+			cfg.Location = loc
+		}
+	}
+
+	switch rule := usecase.WeekendRule(strings.ToLower(os.Getenv("WEEKEND_MEETING_RULE"))); rule {
+	case "":
+	case usecase.WeekendRuleOff, usecase.WeekendRuleWarn, usecase.WeekendRuleReject:
+		cfg.WeekendMeetingRule = rule
+	default:
+		log.Printf("Warning: Invalid WEEKEND_MEETING_RULE %q, weekend meetings are allowed", rule)
+	}
+
+	if value := os.Getenv("DEFAULT_MEETING_DATE_TO_NOW"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Printf("Warning: Invalid DEFAULT_MEETING_DATE_TO_NOW %q, meeting date is required", value)
+		} else {
+			cfg.DefaultMeetingDateToNow = enabled
+		}
+	}
+
+	switch policy := usecase.ContentPolicy(strings.ToLower(os.Getenv("CONTENT_POLICY"))); policy {
+	case "":
+	case usecase.ContentPolicyStripAll, usecase.ContentPolicySafeFormatting:
+		cfg.ContentPolicy = policy
+	default:
+		log.Printf("Warning: Invalid CONTENT_POLICY %q, stripping all HTML from content", policy)
+	}
+
+	switch policy := usecase.SlugPolicy(strings.ToLower(os.Getenv("SLUG_POLICY"))); policy {
+	case "":
+	case usecase.SlugPolicyPreserve, usecase.SlugPolicyRegenerate:
+		cfg.SlugPolicy = policy
+	default:
+		log.Printf("Warning: Invalid SLUG_POLICY %q, slugs are kept when titles change", policy)
+	}
+
+	if value := os.Getenv("DEFAULT_MEETING_DURATION_MINUTES"); value != "" {
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes <= 0 || minutes > usecase.MaxMeetingDurationMinutes {
+			log.Printf("Warning: Invalid DEFAULT_MEETING_DURATION_MINUTES %q, using %d", value, cfg.DefaultMeetingDurationMinutes)
+		} else {
+			cfg.DefaultMeetingDurationMinutes = minutes
+		}
+	}
+
+	if value := os.Getenv("FUZZY_SEARCH_THRESHOLD"); value != "" {
+		threshold, err := strconv.ParseFloat(value, 64)
+		if err != nil || threshold < 0 || threshold > 1 {
+			log.Printf("Warning: Invalid FUZZY_SEARCH_THRESHOLD %q, using %g", value, cfg.FuzzySearchThreshold)
+		} else {
+			cfg.FuzzySearchThreshold = threshold
+		}
+	}
+
+	if value := os.Getenv("SUMMARY_LENGTH"); value != "" {
+		length, err := strconv.Atoi(value)
+		if err != nil || length <= 0 {
+			log.Printf("Warning: Invalid SUMMARY_LENGTH %q, using %d", value, cfg.SummaryLength)
+		} else {
+			cfg.SummaryLength = length
+		}
+	}
+
+	if value := os.Getenv("MAX_LIST_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 {
+			log.Printf("Warning: Invalid MAX_LIST_SIZE %q, using %d", value, cfg.MaxListSize)
+		} else {
+			cfg.MaxListSize = size
+		}
+	}
+
+	cfg.MeetingDatePastWindow = envDays("MEETING_DATE_PAST_WINDOW_DAYS")
+	cfg.MeetingDateFutureWindow = envDays("MEETING_DATE_FUTURE_WINDOW_DAYS")
+	cfg.CategoryQuotas = loadCategoryQuotas()
+	cfg.AllowedCategories = loadAllowedCategories()
+	cfg.BlockedTerms = loadBlockedTerms()
+
+	switch mode := strings.ToLower(os.Getenv("DELETE_MODE")); mode {
+	case "", "soft":
+	case "hard":
+		cfg.HardDelete = true
+	default:
+		log.Printf("Warning: Invalid DELETE_MODE %q, deleted notes are kept in the trash", mode)
+	}
+
+	return cfg
+}
+
+// loadCategoryQuotas reads per-category note caps, which apply to each owner
+// separately, from CATEGORY_QUOTAS_FILE, a JSON object such as
+// {"Standup": 200}, then from CATEGORY_QUOTAS, a comma separated list such as
+// "Standup=200,1:1=50". Entries in CATEGORY_QUOTAS replace the file's.
+// Invalid entries are skipped.
+func loadCategoryQuotas() map[string]int {
+	quotas := map[string]int{}
+
+	if path := os.Getenv("CATEGORY_QUOTAS_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Printf("Warning: Could not read CATEGORY_QUOTAS_FILE %q, ignoring: %v", path, err)
+		} else {
+			var fromFile map[string]int
+			if err := json.Unmarshal(data, &fromFile); err != nil {
+				log.Printf("Warning: Invalid CATEGORY_QUOTAS_FILE %q, ignoring: %v", path, err)
+			}
+			for category, quota := range fromFile {
+				setCategoryQuota(quotas, category, quota)
+			}
+		}
+	}
+
+	for _, entry := range strings.Split(os.Getenv("CATEGORY_QUOTAS"), ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		i := strings.LastIndex(entry, "=")
+		if i < 0 {
+			log.Printf("Warning: Invalid CATEGORY_QUOTAS entry %q, ignoring", entry)
+			continue
+		}
+		quota, err := strconv.Atoi(strings.TrimSpace(entry[i+1:]))
+		if err != nil {
+			log.Printf("Warning: Invalid CATEGORY_QUOTAS entry %q, ignoring", entry)
+			continue
+		}
+		setCategoryQuota(quotas, entry[:i], quota)
+	}
+
+	if len(quotas) == 0 {
+		return nil
+	}
+	return quotas
+}
+
+func setCategoryQuota(quotas map[string]int, category string, quota int) {
+	category = strings.TrimSpace(category)
+	if category == "" || quota < 0 {
+		log.Printf("Warning: Invalid category quota %q=%d, ignoring", category, quota)
+		return
+	}
+	for existing := range quotas {
+		if strings.EqualFold(existing, category) {
+			delete(quotas, existing)
+		}
+	}
+	quotas[category] = quota
+}
+
+// loadAllowedCategories reads ALLOWED_CATEGORIES, a comma separated list such
+// as "Standup,1:1,Planning". Unset or empty allows any category.
+func loadAllowedCategories() []string {
+	var allowed []string
+	for _, category := range strings.Split(os.Getenv("ALLOWED_CATEGORIES"), ",") {
+		category = strings.TrimSpace(category)
+		if category == "" {
+			continue
+		}
+		duplicate := false
+		for _, existing := range allowed {
+			if strings.EqualFold(existing, category) {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			log.Printf("Warning: Duplicate ALLOWED_CATEGORIES entry %q, ignoring", category)
+			continue
+		}
+		allowed = append(allowed, category)
+	}
+	return allowed
+}
+
+// loadBlockedTerms reads the content blocklist from BLOCKED_TERMS_FILE, a
+// JSON object of category to terms such as {"profanity": ["darn"]}, then
+// from BLOCKED_TERMS, a comma separated list such as
+// "profanity=darn,confidential=project x". Both add to the same list.
+// Unset leaves the blocklist off.
+func loadBlockedTerms() map[string][]string {
+	terms := map[string][]string{}
+
+	if path := os.Getenv("BLOCKED_TERMS_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Printf("Warning: Could not read BLOCKED_TERMS_FILE %q, ignoring: %v", path, err)
+		} else {
+			var fromFile map[string][]string
+			if err := json.Unmarshal(data, &fromFile); err != nil {
+				log.Printf("Warning: Invalid BLOCKED_TERMS_FILE %q, ignoring: %v", path, err)
+			}
+			for category, list := range fromFile {
+				for _, term := range list {
+					addBlockedTerm(terms, category, term)
+				}
+			}
+		}
+	}
+
+	for _, entry := range strings.Split(os.Getenv("BLOCKED_TERMS"), ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		category, term, ok := strings.Cut(entry, "=")
+		if !ok {
+			log.Printf("Warning: Invalid BLOCKED_TERMS entry %q, ignoring", entry)
+			continue
+		}
+		addBlockedTerm(terms, category, term)
+	}
+
+	if len(terms) == 0 {
+		return nil
+	}
+	return terms
+}
+
+func addBlockedTerm(terms map[string][]string, category, term string) {
+	category = strings.TrimSpace(category)
+	term = strings.TrimSpace(term)
+	if category == "" || term == "" {
+		log.Printf("Warning: Invalid blocked term %q=%q, ignoring", category, term)
+		return
+	}
+	terms[category] = append(terms[category], term)
+}
+
+// envDays reads a whole number of days from key. Unset or invalid values
+// return zero.
+func envDays(key string) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0
+	}
+
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		log.Printf("Warning: Invalid %s %q, ignoring", key, value)
+		return 0
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
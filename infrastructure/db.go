@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/repository"
 	"github.com/jt00721/meeting-notes-manager/internal/seed"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
@@ -20,36 +23,64 @@ func InitDB() error {
 		log.Println("Failed to load env variables")
 	}
 
-	dsn := os.Getenv("DATABASE_URL")
+	// The database may still be starting, for example when both come up
+	// together in Kubernetes, so a failed connection is retried before
+	// InitDB gives up.
+	dialector := openDialector()
+	db, err := connectWithRetry(func() (*gorm.DB, error) {
+		return gorm.Open(dialector, &gorm.Config{})
+	}, LoadRetryConfig(), time.Sleep)
+	if err != nil {
+		return err
+	}
 
-	if env := os.Getenv("ENV"); env == "Dev" || env == "development" {
-		dsn = fmt.Sprintf(
-			"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-			os.Getenv("POSTGRES_HOST"),
-			os.Getenv("POSTGRES_USER"),
-			os.Getenv("POSTGRES_PASSWORD"),
-			os.Getenv("POSTGRES_DB"),
-			os.Getenv("POSTGRES_PORT"),
-		)
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database handle: %w", err)
 	}
+	pool := LoadPoolConfig()
+	pool.Apply(sqlDB)
 
-	if dsn == "" {
-		log.Fatal("DATABASE_URL is not set")
+	if err := useReadReplica(db, pool); err != nil {
+		return err
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
-		return fmt.Errorf("failed to connect to database: %w", err)
+	// External IDs used to be unique across every owner. The index is
+	// dropped so one owner's imports can't clash with another's.
+	if db.Migrator().HasIndex(&domain.Note{}, "idx_notes_external_id") {
+		if err := db.Migrator().DropIndex(&domain.Note{}, "idx_notes_external_id"); err != nil {
+			return fmt.Errorf("failed to drop idx_notes_external_id: %w", err)
+		}
 	}
 
-	err = db.AutoMigrate(&domain.Note{})
+	// Preset names used to be unique across every user. The index is dropped
+	// so each user can have their own preset of a given name.
+	if db.Migrator().HasIndex(&domain.FilterPreset{}, "idx_filter_presets_name") {
+		if err := db.Migrator().DropIndex(&domain.FilterPreset{}, "idx_filter_presets_name"); err != nil {
+			return fmt.Errorf("failed to drop idx_filter_presets_name: %w", err)
+		}
+	}
+
+	err = db.AutoMigrate(&domain.Note{}, &domain.NoteRevision{}, &domain.FilterPreset{}, &domain.OutboxEvent{}, &domain.Attachment{}, &domain.AuditLog{}, &domain.NoteLink{})
 	if err != nil {
 		log.Fatal("Migration failed:", err)
 		return fmt.Errorf("failed to auto-migrate database models: %w", err)
 	}
 
-	if err := seed.Seed(db); err != nil {
+	// Fuzzy search still works without the trigram indexes on SQLite, but on
+	// Postgres it needs pg_trgm; without it searches only match exactly.
+	if err := repository.EnsureTrigramIndexes(db); err != nil {
+		log.Printf("Warning: Could not enable trigram search, fuzzy search is unavailable: %v", err)
+	}
+
+	// SEED_FILE points at a JSON dataset for demo environments; without it
+	// the built-in notes are used. Either way an existing table is left alone.
+	if path := os.Getenv("SEED_FILE"); path != "" {
+		err = seed.SeedFromFile(db, path)
+	} else {
+		err = seed.Seed(db)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -58,3 +89,36 @@ func InitDB() error {
 	log.Println("Database initialised & migrated successfully")
 	return nil
 }
+
+// openDialector picks the database from DB_DRIVER. "sqlite" opens the file
+// at SQLITE_PATH (default meeting_notes.db) for local development; anything
+// else connects to Postgres.
+func openDialector() gorm.Dialector {
+	if os.Getenv("DB_DRIVER") == "sqlite" {
+		path := os.Getenv("SQLITE_PATH")
+		if path == "" {
+			path = "meeting_notes.db"
+		}
+		log.Println("Using SQLite database:", path)
+		return sqlite.Open(path)
+	}
+
+	dsn := os.Getenv("DATABASE_URL")
+
+	if env := os.Getenv("ENV"); env == "Dev" || env == "development" {
+		dsn = fmt.Sprintf(
+			"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
+			os.Getenv("POSTGRES_HOST"),
+			os.Getenv("POSTGRES_USER"),
+			os.Getenv("POSTGRES_PASSWORD"),
+			os.Getenv("POSTGRES_DB"),
+			os.Getenv("POSTGRES_PORT"),
+		)
+	}
+
+	if dsn == "" {
+		log.Fatal("DATABASE_URL is not set")
+	}
+
+	return postgres.Open(dsn)
+}
@@ -0,0 +1,70 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Connection pool defaults, sized to stay well under Postgres's default
+// max_connections of 100 even with a few app instances running.
+const (
+	DefaultMaxOpenConns    = 25
+	DefaultMaxIdleConns    = 10
+	DefaultConnMaxLifetime = 30 * time.Minute
+)
+
+// PoolConfig holds the connection pool limits applied to the database
+// handle.
+type PoolConfig struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// LoadPoolConfig reads DB_MAX_OPEN_CONNS, DB_MAX_IDLE_CONNS and
+// DB_CONN_MAX_LIFETIME_MINUTES. Missing or non-positive values fall back to
+// the defaults, and idle connections are capped at the open limit.
+func LoadPoolConfig() PoolConfig {
+	cfg := PoolConfig{
+		MaxOpenConns:    positiveEnvInt("DB_MAX_OPEN_CONNS", DefaultMaxOpenConns),
+		MaxIdleConns:    positiveEnvInt("DB_MAX_IDLE_CONNS", DefaultMaxIdleConns),
+		ConnMaxLifetime: DefaultConnMaxLifetime,
+	}
+
+	if minutes := positiveEnvInt("DB_CONN_MAX_LIFETIME_MINUTES", 0); minutes > 0 {
+		cfg.ConnMaxLifetime = time.Duration(minutes) * time.Minute
+	}
+
+	if cfg.MaxIdleConns > cfg.MaxOpenConns {
+		log.Printf("Warning: DB_MAX_IDLE_CONNS %d exceeds DB_MAX_OPEN_CONNS, using %d", cfg.MaxIdleConns, cfg.MaxOpenConns)
+		cfg.MaxIdleConns = cfg.MaxOpenConns
+	}
+
+	return cfg
+}
+
+// Apply sets the pool limits on sqlDB and logs them.
+func (cfg PoolConfig) Apply(sqlDB *sql.DB) {
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	log.Printf("Database pool: max open %d, max idle %d, max lifetime %s", cfg.MaxOpenConns, cfg.MaxIdleConns, cfg.ConnMaxLifetime)
+}
+
+func positiveEnvInt(name string, fallback int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("Warning: Invalid %s %q, must be a positive integer", name, value)
+		return fallback
+	}
+	return n
+}
@@ -0,0 +1,54 @@
+package infrastructure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadPoolConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected PoolConfig
+	}{
+		{
+			name:     "Defaults",
+			expected: PoolConfig{MaxOpenConns: DefaultMaxOpenConns, MaxIdleConns: DefaultMaxIdleConns, ConnMaxLifetime: DefaultConnMaxLifetime},
+		},
+		{
+			name: "Overrides",
+			env: map[string]string{
+				"DB_MAX_OPEN_CONNS":            "50",
+				"DB_MAX_IDLE_CONNS":            "20",
+				"DB_CONN_MAX_LIFETIME_MINUTES": "5",
+			},
+			expected: PoolConfig{MaxOpenConns: 50, MaxIdleConns: 20, ConnMaxLifetime: 5 * time.Minute},
+		},
+		{
+			name: "Non-positive and malformed values fall back",
+			env: map[string]string{
+				"DB_MAX_OPEN_CONNS":            "0",
+				"DB_MAX_IDLE_CONNS":            "-3",
+				"DB_CONN_MAX_LIFETIME_MINUTES": "soon",
+			},
+			expected: PoolConfig{MaxOpenConns: DefaultMaxOpenConns, MaxIdleConns: DefaultMaxIdleConns, ConnMaxLifetime: DefaultConnMaxLifetime},
+		},
+		{
+			name:     "Idle capped at open",
+			env:      map[string]string{"DB_MAX_OPEN_CONNS": "4"},
+			expected: PoolConfig{MaxOpenConns: 4, MaxIdleConns: 4, ConnMaxLifetime: DefaultConnMaxLifetime},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"DB_MAX_OPEN_CONNS", "DB_MAX_IDLE_CONNS", "DB_CONN_MAX_LIFETIME_MINUTES"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			assert.Equal(t, tt.expected, LoadPoolConfig())
+		})
+	}
+}
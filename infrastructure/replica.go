@@ -0,0 +1,40 @@
+package infrastructure
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jt00721/meeting-notes-manager/internal/repository"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// useReadReplica connects to the replica at DATABASE_REPLICA_URL and registers
+// it for the repository's read-heavy queries, with the same pool limits as the
+// primary. Without it, or on SQLite, every query goes to the primary.
+func useReadReplica(db *gorm.DB, pool PoolConfig) error {
+	dsn := os.Getenv("DATABASE_REPLICA_URL")
+	if dsn == "" {
+		return nil
+	}
+	if db.Dialector.Name() == "sqlite" {
+		log.Println("Warning: DATABASE_REPLICA_URL is ignored with SQLite")
+		return nil
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{postgres.Open(dsn)},
+	}, repository.ReplicaResolver).
+		SetMaxOpenConns(pool.MaxOpenConns).
+		SetMaxIdleConns(pool.MaxIdleConns).
+		SetConnMaxLifetime(pool.ConnMaxLifetime)
+
+	if err := db.Use(resolver); err != nil {
+		return fmt.Errorf("failed to connect to read replica: %w", err)
+	}
+
+	log.Println("Read replica enabled")
+	return nil
+}
@@ -0,0 +1,77 @@
+package infrastructure
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Connection retry defaults. With the delay doubling up to
+// maxConnectRetryDelay, the defaults wait a little over two minutes in total
+// before giving up.
+const (
+	DefaultConnectAttempts   = 10
+	DefaultConnectRetryDelay = 2 * time.Second
+	maxConnectRetryDelay     = 30 * time.Second
+)
+
+// RetryConfig controls how InitDB waits for a database that isn't accepting
+// connections yet, such as one starting alongside the app.
+type RetryConfig struct {
+	// Attempts is how many times to try connecting, including the first.
+	Attempts int
+	// Delay is the wait after the first failure. It doubles after each
+	// further failure, up to 30 seconds.
+	Delay time.Duration
+}
+
+// LoadRetryConfig reads DB_CONNECT_ATTEMPTS and
+// DB_CONNECT_RETRY_DELAY_SECONDS. Missing or non-positive values fall back to
+// the defaults.
+func LoadRetryConfig() RetryConfig {
+	cfg := RetryConfig{
+		Attempts: positiveEnvInt("DB_CONNECT_ATTEMPTS", DefaultConnectAttempts),
+		Delay:    DefaultConnectRetryDelay,
+	}
+
+	if seconds := positiveEnvInt("DB_CONNECT_RETRY_DELAY_SECONDS", 0); seconds > 0 {
+		cfg.Delay = time.Duration(seconds) * time.Second
+	}
+
+	return cfg
+}
+
+// connectWithRetry calls open until it succeeds or cfg.Attempts have failed,
+// sleeping with exponential backoff in between. It returns the last error
+// once every attempt has failed.
+func connectWithRetry(open func() (*gorm.DB, error), cfg RetryConfig, sleep func(time.Duration)) (*gorm.DB, error) {
+	delay := cfg.Delay
+
+	var err error
+	for attempt := 1; attempt <= cfg.Attempts; attempt++ {
+		var db *gorm.DB
+		db, err = open()
+		if err == nil {
+			if attempt > 1 {
+				log.Printf("Connected to database on attempt %d/%d", attempt, cfg.Attempts)
+			}
+			return db, nil
+		}
+
+		if attempt == cfg.Attempts {
+			break
+		}
+
+		log.Printf("Database connection attempt %d/%d failed, retrying in %s: %v", attempt, cfg.Attempts, delay, err)
+		sleep(delay)
+
+		delay *= 2
+		if delay > maxConnectRetryDelay {
+			delay = maxConnectRetryDelay
+		}
+	}
+
+	return nil, fmt.Errorf("failed to connect to database after %d attempts: %w", cfg.Attempts, err)
+}
@@ -0,0 +1,103 @@
+package infrastructure
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestConnectWithRetry(t *testing.T) {
+	errRefused := errors.New("connection refused")
+
+	tests := []struct {
+		name       string
+		failures   int
+		cfg        RetryConfig
+		wantErr    bool
+		wantCalls  int
+		wantSleeps []time.Duration
+	}{
+		{
+			name:      "First attempt succeeds",
+			cfg:       RetryConfig{Attempts: 3, Delay: time.Second},
+			wantCalls: 1,
+		},
+		{
+			name:       "Succeeds after retries",
+			failures:   2,
+			cfg:        RetryConfig{Attempts: 3, Delay: time.Second},
+			wantCalls:  3,
+			wantSleeps: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:       "Gives up after every attempt fails",
+			failures:   10,
+			cfg:        RetryConfig{Attempts: 4, Delay: 10 * time.Second},
+			wantErr:    true,
+			wantCalls:  4,
+			wantSleeps: []time.Duration{10 * time.Second, 20 * time.Second, 30 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			var sleeps []time.Duration
+			open := func() (*gorm.DB, error) {
+				calls++
+				if calls <= tt.failures {
+					return nil, errRefused
+				}
+				return &gorm.DB{}, nil
+			}
+
+			db, err := connectWithRetry(open, tt.cfg, func(d time.Duration) { sleeps = append(sleeps, d) })
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errRefused)
+				assert.Nil(t, db)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, db)
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Equal(t, tt.wantSleeps, sleeps)
+		})
+	}
+}
+
+func TestLoadRetryConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected RetryConfig
+	}{
+		{
+			name:     "Defaults",
+			expected: RetryConfig{Attempts: DefaultConnectAttempts, Delay: DefaultConnectRetryDelay},
+		},
+		{
+			name:     "Overrides",
+			env:      map[string]string{"DB_CONNECT_ATTEMPTS": "3", "DB_CONNECT_RETRY_DELAY_SECONDS": "5"},
+			expected: RetryConfig{Attempts: 3, Delay: 5 * time.Second},
+		},
+		{
+			name:     "Non-positive and malformed values fall back",
+			env:      map[string]string{"DB_CONNECT_ATTEMPTS": "0", "DB_CONNECT_RETRY_DELAY_SECONDS": "soon"},
+			expected: RetryConfig{Attempts: DefaultConnectAttempts, Delay: DefaultConnectRetryDelay},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"DB_CONNECT_ATTEMPTS", "DB_CONNECT_RETRY_DELAY_SECONDS"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			assert.Equal(t, tt.expected, LoadRetryConfig())
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Package diff computes line-based differences between two texts.
+package diff
+
+import "strings"
+
+type Op string
+
+const (
+	Equal  Op = " "
+	Insert Op = "+"
+	Delete Op = "-"
+)
+
+// Line is one line of a diff: kept, added or removed.
+type Line struct {
+	Op   Op     `json:"op"`
+	Text string `json:"text"`
+}
+
+func (l Line) String() string {
+	return string(l.Op) + " " + l.Text
+}
+
+// Lines returns the edits that turn a into b, using the longest common
+// subsequence of their lines. Removed lines come before the lines that
+// replace them.
+func Lines(a, b string) []Line {
+	from, to := splitLines(a), splitLines(b)
+
+	// lcs[i][j] is the length of the longest common subsequence of
+	// from[i:] and to[j:].
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]Line, 0, len(from)+len(to))
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			lines = append(lines, Line{Op: Equal, Text: from[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Op: Delete, Text: from[i]})
+			i++
+		default:
+			lines = append(lines, Line{Op: Insert, Text: to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		lines = append(lines, Line{Op: Delete, Text: from[i]})
+	}
+	for ; j < len(to); j++ {
+		lines = append(lines, Line{Op: Insert, Text: to[j]})
+	}
+	return lines
+}
+
+// Format renders lines as text, one "+", "-" or " " prefixed line each.
+func Format(lines []Line) string {
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(l.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
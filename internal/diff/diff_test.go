@@ -0,0 +1,56 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want []Line
+	}{
+		{
+			name: "Identical",
+			a:    "one\ntwo",
+			b:    "one\ntwo",
+			want: []Line{{Equal, "one"}, {Equal, "two"}},
+		},
+		{
+			name: "Line changed",
+			a:    "one\ntwo\nthree",
+			b:    "one\n2\nthree",
+			want: []Line{{Equal, "one"}, {Delete, "two"}, {Insert, "2"}, {Equal, "three"}},
+		},
+		{
+			name: "Lines added and removed",
+			a:    "a\nb\nc",
+			b:    "b\nc\nd",
+			want: []Line{{Delete, "a"}, {Equal, "b"}, {Equal, "c"}, {Insert, "d"}},
+		},
+		{
+			name: "From empty",
+			a:    "",
+			b:    "new\n",
+			want: []Line{{Insert, "new"}},
+		},
+		{
+			name: "Both empty",
+			want: []Line{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Lines(tt.a, tt.b))
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	got := Format(Lines("one\ntwo", "one\n2"))
+	assert.Equal(t, "  one\n- two\n+ 2\n", got)
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Attachment records a file referenced by a note. Only the metadata and a URL
+// are stored; the file itself lives wherever URL points.
+type Attachment struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	NoteID      uint           `gorm:"not null;index" json:"note_id"`
+	Filename    string         `gorm:"not null" json:"filename"`
+	URL         string         `gorm:"not null" json:"url"`
+	SizeBytes   int64          `json:"size_bytes"`
+	ContentType string         `json:"content_type"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
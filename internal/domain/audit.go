@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type AuditOperation string
+
+const (
+	AuditCreate AuditOperation = "create"
+	AuditUpdate AuditOperation = "update"
+	AuditDelete AuditOperation = "delete"
+)
+
+// FieldChange is one field's value before and after an operation. From is nil
+// on create and To is nil on delete.
+type FieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// FieldChanges maps a note field, such as "title", to how it changed. It is stored
+// as a JSON object in a text column.
+type FieldChanges map[string]FieldChange
+
+func (c FieldChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (c *FieldChanges) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	case []byte:
+		return json.Unmarshal(v, c)
+	}
+	return fmt.Errorf("cannot scan %T into FieldChanges", value)
+}
+
+// AuditLog records who created, changed or deleted a note, and which fields
+// were affected. ActorID is nil when the request was anonymous.
+type AuditLog struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	NoteID    uint           `gorm:"not null;index" json:"note_id"`
+	Operation AuditOperation `gorm:"not null" json:"operation"`
+	ActorID   *uint          `json:"actor_id"`
+	Changes   FieldChanges   `gorm:"type:text;not null" json:"changes"`
+	CreatedAt time.Time      `gorm:"autoCreateTime;index" json:"created_at"`
+}
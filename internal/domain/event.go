@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+type NoteEventType string
+
+const (
+	NoteCreated NoteEventType = "note.created"
+	NoteUpdated NoteEventType = "note.updated"
+	NoteDeleted NoteEventType = "note.deleted"
+
+	// NoteReminder is sent ahead of a note's upcoming meeting rather than
+	// for a change to the note.
+	NoteReminder NoteEventType = "note.reminder"
+)
+
+// NoteEvent describes a change to a note that has already been committed.
+type NoteEvent struct {
+	Type       NoteEventType `json:"type"`
+	Note       Note          `json:"note"`
+	OccurredAt time.Time     `json:"occurred_at"`
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// The relations a NoteLink can record.
+const (
+	// LinkFollowUp marks the target as a follow-up meeting to the source.
+	LinkFollowUp = "followup"
+	// LinkRelated marks the two notes as covering related ground.
+	LinkRelated = "related"
+)
+
+// LinkRelations lists the valid NoteLink relations.
+var LinkRelations = []string{LinkFollowUp, LinkRelated}
+
+// NoteLink records that the note FromNoteID refers to the note ToNoteID.
+// Links are directed, and a note links to another at most once.
+type NoteLink struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	FromNoteID uint      `gorm:"not null;uniqueIndex:idx_note_links_pair" json:"from_note_id"`
+	ToNoteID   uint      `gorm:"not null;uniqueIndex:idx_note_links_pair;index" json:"to_note_id"`
+	Relation   string    `gorm:"not null" json:"relation"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// LinkedNote is a link seen from one of its notes: the link itself and the
+// note at its other end.
+type LinkedNote struct {
+	Link NoteLink `json:"link"`
+	Note Note     `json:"note"`
+}
@@ -8,18 +8,300 @@ import (
 
 type Note struct {
 	ID          uint   `gorm:"primaryKey"`
+	OwnerID     uint   `gorm:"index;uniqueIndex:idx_notes_owner_external_id,where:external_id <> ''"`
 	Title       string `gorm:"not null"`
-	Content     string `gorm:"not null"`
-	Category    string `gorm:"index"`
+	Content     string `gorm:"not null" json:"Content,omitempty"`
+	Category    string `gorm:"index;index:idx_notes_category_lower,expression:LOWER(category)"`
 	MeetingDate time.Time
+	Version     int            `gorm:"not null;default:1"`
 	CreatedAt   time.Time      `gorm:"autoCreateTime"`
 	UpdatedAt   time.Time      `gorm:"autoUpdateTime"`
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" swaggertype:"string" format:"date-time"`
+
+	// DurationMinutes is how long the meeting runs. Notes saved without one
+	// get the configured default.
+	DurationMinutes int `gorm:"not null;default:0"`
+	// EndTime is MeetingDate plus DurationMinutes, filled in whenever the
+	// note is loaded or saved. It is never stored.
+	EndTime time.Time `gorm:"-"`
+
+	// Location is where the meeting is held in person, such as a room.
+	// MeetingURL is the link to join it remotely; a note with one counts as
+	// a remote meeting. Hybrid meetings have both.
+	Location   string `gorm:"not null;default:''"`
+	MeetingURL string `gorm:"not null;default:''"`
+
+	// ExternalID identifies a note imported from another system. It is
+	// unique among the owner's notes that have one; native notes leave it
+	// empty.
+	ExternalID string `gorm:"uniqueIndex:idx_notes_owner_external_id,where:external_id <> ''"`
+
+	// Slug is a readable, unique name for the note derived from its title,
+	// such as "q3-planning-kickoff". Notes created before slugs existed
+	// have none until their next update.
+	Slug string `gorm:"uniqueIndex:idx_notes_slug,where:slug <> ''"`
+
+	// Archived hides a note from the default listings without deleting it.
+	// It is independent of DeletedAt: a note can be archived, deleted, or
+	// both.
+	Archived bool `gorm:"not null;default:false;index"`
+
+	// Format says how Content should be rendered: NoteFormatPlaintext or
+	// NoteFormatMarkdown.
+	Format string `gorm:"not null;default:plaintext"`
+
+	// Priority is how urgent the note is, one of Priorities. Notes saved
+	// without one, and those from before priorities existed, are
+	// PriorityNormal.
+	Priority string `gorm:"not null;default:normal;index"`
+
+	// Visibility says who besides the owner can read the note, one of
+	// VisibilityPrivate, VisibilityShared or VisibilityPublic. Only the owner
+	// can change it.
+	Visibility string `gorm:"not null;default:private;index"`
+
+	// Attachments is only loaded by the attachment endpoints. Attachments
+	// are trashed, restored and purged together with their note.
+	Attachments []Attachment `gorm:"constraint:OnDelete:CASCADE" json:"attachments,omitempty"`
+
+	// Summary is the start of Content, cut at a word boundary, for list
+	// views, which leave Content out. It is never stored.
+	Summary string `gorm:"-" json:"summary,omitempty"`
+
+	// Warnings holds non-blocking validation messages for the current
+	// request. It is never persisted.
+	Warnings []string `gorm:"-" json:"warnings,omitempty"`
+}
+
+// VisibleTo reports whether userID may read the note: it is theirs, it is
+// shared and they are signed in, or it is public. User 0 is an anonymous
+// caller.
+func (n Note) VisibleTo(userID uint) bool {
+	switch {
+	case n.OwnerID == userID, n.Visibility == VisibilityPublic:
+		return true
+	case n.Visibility == VisibilityShared:
+		return userID != 0
+	}
+	return false
+}
+
+// SetEndTime works out EndTime from MeetingDate and DurationMinutes. It is
+// left zero when either is missing.
+func (n *Note) SetEndTime() {
+	if n.MeetingDate.IsZero() || n.DurationMinutes <= 0 {
+		n.EndTime = time.Time{}
+		return
+	}
+	n.EndTime = n.MeetingDate.Add(time.Duration(n.DurationMinutes) * time.Minute)
+}
+
+// AfterFind is a gorm hook that fills in EndTime on every loaded note.
+func (n *Note) AfterFind(tx *gorm.DB) error {
+	n.SetEndTime()
+	return nil
+}
+
+// AfterSave is a gorm hook that fills in EndTime on created and saved notes.
+func (n *Note) AfterSave(tx *gorm.DB) error {
+	n.SetEndTime()
+	return nil
+}
+
+// NoteChange is a note as reported by the changes feed. A Deleted change is
+// a tombstone: the note was deleted and a synced copy should be removed.
+type NoteChange struct {
+	Note
+	Deleted bool `json:"deleted"`
+}
+
+// ChangeCursor is a position in the changes feed, which is ordered by
+// UpdatedAt and then ID. It points just past the change to note AfterID at
+// Since; a zero AfterID points past every change at Since.
+type ChangeCursor struct {
+	Since   time.Time
+	AfterID uint
+}
+
+// The formats a note's Content can be written in.
+const (
+	NoteFormatPlaintext = "plaintext"
+	NoteFormatMarkdown  = "markdown"
+)
+
+// The priorities a note can have.
+const (
+	PriorityLow      = "low"
+	PriorityNormal   = "normal"
+	PriorityHigh     = "high"
+	PriorityCritical = "critical"
+)
+
+// Priorities lists the note priorities from lowest to highest.
+var Priorities = []string{PriorityLow, PriorityNormal, PriorityHigh, PriorityCritical}
+
+// PriorityRank returns where priority falls in Priorities, or -1 when it
+// isn't one of them.
+func PriorityRank(priority string) int {
+	for i, p := range Priorities {
+		if p == priority {
+			return i
+		}
+	}
+	return -1
 }
 
+// Who can read a note: only its owner, every signed-in user, or anyone.
+const (
+	VisibilityPrivate = "private"
+	VisibilityShared  = "shared"
+	VisibilityPublic  = "public"
+)
+
+// The meeting modes a NoteFilter can select: remote meetings have a
+// MeetingURL and in-person ones don't.
+const (
+	MeetingModeRemote   = "remote"
+	MeetingModeInPerson = "in_person"
+)
+
+// The fields a NoteFilter can sort its results by.
+const (
+	SortMeetingDate = "meeting_date"
+	SortCreatedAt   = "created_at"
+	SortUpdatedAt   = "updated_at"
+	SortTitle       = "title"
+	SortPriority    = "priority"
+)
+
+// The directions a NoteFilter can sort in.
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// How a NoteFilter with several keywords combines them.
+const (
+	KeywordMatchAny = "any"
+	KeywordMatchAll = "all"
+)
+
 type NoteFilter struct {
-	Keyword  string
-	Category string
-	FromDate *time.Time
-	ToDate   *time.Time
+	// Keyword is a single keyword, matched like one more entry of Keywords.
+	// Presets saved before Keywords existed only have this.
+	Keyword string `json:"keyword,omitempty"`
+	// Keywords are matched against the title and content. KeywordMatch is
+	// KeywordMatchAll to require every one of them, or KeywordMatchAny, the
+	// default, to require at least one.
+	Keywords     []string   `json:"keywords,omitempty"`
+	KeywordMatch string     `json:"keyword_match,omitempty"`
+	Categories   []string   `json:"categories,omitempty"`
+	FromDate     *time.Time `json:"from_date,omitempty"`
+	ToDate       *time.Time `json:"to_date,omitempty"`
+	// Year, and optionally Month, are a shortcut for a FromDate and ToDate
+	// spanning that calendar year or month. They can't be combined with an
+	// explicit FromDate or ToDate.
+	Year  int `json:"year,omitempty"`
+	Month int `json:"month,omitempty"`
+	// Timezone is the IANA timezone Year and Month are read in, taken from
+	// the request rather than saved with a preset. Blank means the server's
+	// configured timezone.
+	Timezone string `json:"-"`
+	// CreatedFrom and CreatedTo bound when the note was entered, independent
+	// of its MeetingDate.
+	CreatedFrom *time.Time `json:"created_from,omitempty"`
+	CreatedTo   *time.Time `json:"created_to,omitempty"`
+	// IncludeArchived also matches archived notes, which are left out by
+	// default.
+	IncludeArchived bool `json:"include_archived,omitempty"`
+	// UncategorizedOnly and MaxContentLength help find notes that need
+	// cleaning up: ones with no category, or with content of at most
+	// MaxContentLength characters. A zero MaxContentLength is no limit.
+	UncategorizedOnly bool `json:"uncategorized_only,omitempty"`
+	MaxContentLength  int  `json:"max_content_length,omitempty"`
+	// MeetingMode is MeetingModeRemote or MeetingModeInPerson to match only
+	// notes with or without a MeetingURL. Empty matches both.
+	MeetingMode string `json:"meeting_mode,omitempty"`
+	// Priority matches only notes of that priority. Empty matches all.
+	Priority string `json:"priority,omitempty"`
+	// ViewerID is the user the filter runs for. Only notes visible to them
+	// match. It is set per request and never saved with a preset.
+	ViewerID uint `json:"-"`
+	// Sort is the field matches are ordered by and Order is SortAsc or
+	// SortDesc. They default to the newest meeting first. Counting ignores
+	// them.
+	Sort  string `json:"sort,omitempty"`
+	Order string `json:"order,omitempty"`
+}
+
+// AllKeywords returns Keyword followed by Keywords, leaving out blank ones.
+func (f NoteFilter) AllKeywords() []string {
+	var keywords []string
+	for _, keyword := range append([]string{f.Keyword}, f.Keywords...) {
+		if keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	return keywords
+}
+
+// Page limits a list query to a window of results. A zero Limit returns
+// every result from Offset onwards.
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// DateRange limits a search to meetings from From to To, both inclusive.
+// Either end may be nil to leave that side open.
+type DateRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// Contains reports whether t falls within the range.
+func (r DateRange) Contains(t time.Time) bool {
+	return (r.From == nil || !t.Before(*r.From)) && (r.To == nil || !t.After(*r.To))
+}
+
+type NoteLength struct {
+	ID     uint   `json:"id"`
+	Title  string `json:"title"`
+	Length int    `json:"length"`
+}
+
+// NoteExtremes holds the notes with the longest and shortest content. Either
+// side is nil when there is no matching note.
+type NoteExtremes struct {
+	Longest  *NoteLength `json:"longest"`
+	Shortest *NoteLength `json:"shortest"`
+}
+
+type CategoryCount struct {
+	Category string `json:"category"`
+	Count    int64  `json:"count"`
+}
+
+type MonthCount struct {
+	Month time.Time `json:"month"`
+	Count int64     `json:"count"`
+}
+
+// NoteStats summarises the notes table for dashboards. LatestMeeting is nil
+// when there are no notes.
+type NoteStats struct {
+	Total         int64           `json:"total"`
+	ByCategory    []CategoryCount `json:"by_category"`
+	ByMonth       []MonthCount    `json:"by_month"`
+	LatestMeeting *time.Time      `json:"latest_meeting"`
+}
+
+// SearchResult pairs a matching note with a highlighted excerpt of where the
+// keyword was found.
+type SearchResult struct {
+	Note    Note   `json:"note"`
+	Snippet string `json:"snippet"`
+	// Fuzzy marks a result found by similarity after the keyword matched
+	// nothing exactly. Fuzzy results have no snippet.
+	Fuzzy bool `json:"fuzzy,omitempty"`
 }
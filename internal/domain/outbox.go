@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// OutboxEvent is a NoteEvent waiting to be delivered. It is written in the
+// same transaction as the note change it describes, so a crash can delay an
+// event but never lose it.
+type OutboxEvent struct {
+	ID      uint          `gorm:"primaryKey" json:"id"`
+	Type    NoteEventType `gorm:"not null" json:"type"`
+	NoteID  uint          `gorm:"index" json:"note_id"`
+	Payload string        `gorm:"not null" json:"payload"`
+	// Attempts counts failed deliveries. NextAttemptAt is when the event is
+	// next due, pushed back after each failure.
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	NextAttemptAt time.Time  `gorm:"not null;index" json:"next_attempt_at"`
+	SentAt        *time.Time `gorm:"index" json:"sent_at,omitempty"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// FilterPreset is a named, saved NoteFilter that can be applied with
+// GET /notes/filter?preset=<name>. Presets belong to the user who saved
+// them, and names only need to be unique among that user's presets.
+type FilterPreset struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	OwnerID   uint       `gorm:"not null;default:0;uniqueIndex:idx_filter_presets_owner_name,priority:1" json:"owner_id"`
+	Name      string     `gorm:"not null;uniqueIndex:idx_filter_presets_owner_name,priority:2" json:"name"`
+	Filter    NoteFilter `gorm:"serializer:json;not null" json:"filter"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// NoteRevision is a snapshot of a note as it stood at Version. One is written
+// whenever a note is created or changed, so the newest revision always
+// matches the note itself.
+type NoteRevision struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	NoteID    uint      `gorm:"not null;uniqueIndex:idx_note_revisions_note_version" json:"note_id"`
+	Version   int       `gorm:"not null;uniqueIndex:idx_note_revisions_note_version" json:"version"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	Category  string    `json:"category"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
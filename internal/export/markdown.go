@@ -0,0 +1,63 @@
+package export
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+// markdownSpecial matches characters that are markup anywhere in a line.
+var markdownSpecial = regexp.MustCompile("[\\\\`*_\\[\\]<>#|~]")
+
+// markdownBlockStart matches line openings that would start a list or a
+// heading-like block.
+var markdownBlockStart = regexp.MustCompile(`^(\s*)([-+=]|\d+[.)])`)
+
+// NoteToMarkdown renders n as a Markdown document: the title as a heading, a
+// metadata block and the content.
+//
+// Content written as markdown is copied as it is. Plain text content is
+// escaped and its line breaks kept, so it renders exactly as it was typed.
+func NoteToMarkdown(n domain.Note) []byte {
+	var b strings.Builder
+
+	b.WriteString("# " + escapeMarkdownLine(n.Title) + "\n\n")
+	b.WriteString("**Meeting date:** " + n.MeetingDate.Format("2 January 2006") + "  \n")
+	if n.Category != "" {
+		b.WriteString("**Category:** " + escapeMarkdownLine(n.Category) + "  \n")
+	}
+	if !n.UpdatedAt.IsZero() {
+		b.WriteString("**Last updated:** " + n.UpdatedAt.Format("2 January 2006 15:04 MST") + "  \n")
+	}
+	b.WriteString("\n---\n\n")
+
+	if n.Format == domain.NoteFormatMarkdown {
+		b.WriteString(n.Content)
+	} else {
+		b.WriteString(escapeMarkdown(n.Content))
+	}
+	b.WriteString("\n")
+
+	return []byte(b.String())
+}
+
+// escapeMarkdown escapes plain text line by line. Lines that are followed by
+// another non-blank line end in a hard break, so single newlines survive.
+func escapeMarkdown(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = escapeMarkdownLine(line)
+		if i+1 < len(lines) && strings.TrimSpace(line) != "" && strings.TrimSpace(lines[i+1]) != "" {
+			lines[i] += "\\"
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func escapeMarkdownLine(line string) string {
+	line = markdownSpecial.ReplaceAllString(line, `\$0`)
+	return markdownBlockStart.ReplaceAllStringFunc(line, func(s string) string {
+		return s[:len(s)-1] + `\` + s[len(s)-1:]
+	})
+}
@@ -0,0 +1,49 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoteToMarkdown(t *testing.T) {
+	meetingDate := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		note        domain.Note
+		wantHeader  string
+		wantContent string
+	}{
+		{
+			name:        "Markdown content is kept",
+			note:        domain.Note{Title: "Sprint planning", Content: "## Actions\n- *ship* it", Format: domain.NoteFormatMarkdown, MeetingDate: meetingDate},
+			wantHeader:  "# Sprint planning\n\n**Meeting date:** 4 March 2024  \n",
+			wantContent: "## Actions\n- *ship* it\n",
+		},
+		{
+			name:        "Plain text is escaped",
+			note:        domain.Note{Title: "Retro #3", Content: "- *not* a list\n1. nor this\n\nsnake_case", Category: "Team", Format: domain.NoteFormatPlaintext, MeetingDate: meetingDate},
+			wantHeader:  "# Retro \\#3\n\n**Meeting date:** 4 March 2024  \n**Category:** Team  \n",
+			wantContent: "\\- \\*not\\* a list\\\n1\\. nor this\n\nsnake\\_case\n",
+		},
+		{
+			name:        "Missing format is plain text",
+			note:        domain.Note{Title: "Sync", Content: "a <b>tag</b>", MeetingDate: meetingDate},
+			wantHeader:  "# Sync\n\n",
+			wantContent: "a \\<b\\>tag\\</b\\>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := string(NoteToMarkdown(tt.note))
+
+			assert.True(t, strings.HasPrefix(out, tt.wantHeader), out)
+			assert.True(t, strings.HasSuffix(out, "---\n\n"+tt.wantContent), out)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+const (
+	pdfMargin     = 20.0
+	pdfLineHeight = 5.5
+)
+
+// NoteToPDF renders n as an A4 document: the title, a metadata block and the
+// content, wrapped and continued onto as many pages as it needs.
+//
+// It uses the built-in Helvetica font, so text is encoded as Windows-1252.
+// That covers accented Latin letters, curly quotes, dashes and the euro sign;
+// any other character is printed as ".".
+func NoteToPDF(n domain.Note) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.SetTitle(n.Title, true)
+	pdf.SetCreator("meeting-notes-manager", false)
+	pdf.AliasNbPages("")
+
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 10, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.MultiCell(0, 8, tr(n.Title), "", "L", false)
+	pdf.Ln(2)
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetTextColor(96, 96, 96)
+	pdf.CellFormat(0, pdfLineHeight, "Meeting date: "+n.MeetingDate.Format("2 January 2006"), "", 1, "L", false, 0, "")
+	if n.Category != "" {
+		pdf.CellFormat(0, pdfLineHeight, tr("Category: "+n.Category), "", 1, "L", false, 0, "")
+	}
+	if !n.UpdatedAt.IsZero() {
+		pdf.CellFormat(0, pdfLineHeight, "Last updated: "+n.UpdatedAt.Format("2 January 2006 15:04 MST"), "", 1, "L", false, 0, "")
+	}
+
+	pdf.Ln(3)
+	width, _ := pdf.GetPageSize()
+	pdf.Line(pdfMargin, pdf.GetY(), width-pdfMargin, pdf.GetY())
+	pdf.Ln(5)
+
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.MultiCell(0, pdfLineHeight, tr(n.Content), "", "L", false)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render note %d to pdf: %w", n.ID, err)
+	}
+	return buf.Bytes(), nil
+}
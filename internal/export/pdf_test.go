@@ -0,0 +1,48 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoteToPDF(t *testing.T) {
+	meetingDate := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		note      domain.Note
+		wantPages int
+	}{
+		{
+			name:      "Short note",
+			note:      domain.Note{ID: 1, Title: "Sprint planning", Content: "Agreed scope.", Category: "Planning", MeetingDate: meetingDate},
+			wantPages: 1,
+		},
+		{
+			name:      "Unicode text",
+			note:      domain.Note{ID: 2, Title: "Café retro – “wins” €", Content: "Zoë 日本 naïve", MeetingDate: meetingDate},
+			wantPages: 1,
+		},
+		{
+			name:      "Long content spans pages",
+			note:      domain.Note{ID: 3, Title: "All hands", Content: strings.Repeat("A long line of meeting minutes that wraps across the page width.\n", 200), MeetingDate: meetingDate},
+			wantPages: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := NoteToPDF(tt.note)
+
+			require.NoError(t, err)
+			assert.True(t, bytes.HasPrefix(out, []byte("%PDF-")))
+			assert.Equal(t, tt.wantPages, bytes.Count(out, []byte("/Type /Page\n")))
+		})
+	}
+}
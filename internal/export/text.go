@@ -0,0 +1,26 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+// Filename is the download name for n exported with extension ext, such as
+// "md" or "pdf".
+func Filename(n domain.Note, ext string) string {
+	return fmt.Sprintf("note-%d.%s", n.ID, ext)
+}
+
+// NoteToText renders n as plain text: the title, a blank line and the content
+// as written. Line breaks are written as CRLF when crlf is set and as LF
+// otherwise, whichever the content was stored with.
+func NoteToText(n domain.Note, crlf bool) []byte {
+	text := n.Title + "\n\n" + n.Content + "\n"
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if crlf {
+		text = strings.ReplaceAll(text, "\n", "\r\n")
+	}
+	return []byte(text)
+}
@@ -0,0 +1,19 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoteToText(t *testing.T) {
+	note := domain.Note{ID: 7, Title: "Retro", Content: "- *went well*\r\nshipping\n\n# kept as typed"}
+
+	assert.Equal(t, "Retro\n\n- *went well*\nshipping\n\n# kept as typed\n", string(NoteToText(note, false)))
+	assert.Equal(t, "Retro\r\n\r\n- *went well*\r\nshipping\r\n\r\n# kept as typed\r\n", string(NoteToText(note, true)))
+}
+
+func TestFilename(t *testing.T) {
+	assert.Equal(t, "note-7.txt", Filename(domain.Note{ID: 7}, "txt"))
+}
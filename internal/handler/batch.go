@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/middleware"
+	"github.com/jt00721/meeting-notes-manager/internal/usecase"
+)
+
+// maxBatchItems caps how many notes one batch request may create or delete.
+const maxBatchItems = 100
+
+// BatchItemResult is the outcome of one item of a batch request. Index is the
+// item's position in the request and Status the code it would have got on
+// its own.
+type BatchItemResult struct {
+	Index  int            `json:"index"`
+	Status int            `json:"status"`
+	Data   interface{}    `json:"data,omitempty"`
+	Error  *ResponseError `json:"error,omitempty"`
+}
+
+// BatchResult lists the outcome of every item of a batch request, in request
+// order.
+type BatchResult struct {
+	Items []BatchItemResult `json:"items"`
+}
+
+func (b *BatchResult) succeed(index, status int, data interface{}) {
+	b.Items = append(b.Items, BatchItemResult{Index: index, Status: status, Data: data})
+}
+
+func (b *BatchResult) fail(index, status int, err *ResponseError) {
+	b.Items = append(b.Items, BatchItemResult{Index: index, Status: status, Error: err})
+}
+
+func (b *BatchResult) failed() int {
+	failed := 0
+	for _, item := range b.Items {
+		if item.Error != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// respondBatch writes result with status when every item succeeded, and with
+// 207 Multi-Status when any failed. Either way meta counts both outcomes.
+func respondBatch(c *gin.Context, status int, result BatchResult) {
+	failed := result.failed()
+	if failed > 0 {
+		status = http.StatusMultiStatus
+	}
+	respondOK(c, status, result, gin.H{"succeeded": len(result.Items) - failed, "failed": failed})
+}
+
+// CreateNotesBatchApi godoc
+// @Summary Create several notes
+// @Description Creates each note in the array as CreateNoteApi would, independently of the others. Answers 201 when every note was created and 207 when any failed, with each item's status and error in data.items.
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param notes body []domain.Note true "Notes to create"
+// @Param force query bool false "Skip the meeting date window check"
+// @Success 201 {object} Response{data=BatchResult}
+// @Success 207 {object} Response{data=BatchResult}
+// @Failure 400 {object} Response
+// @Failure 413 {object} Response
+// @Router /notes/batch [post]
+func (handler *NoteHandler) CreateNotesBatchApi(c *gin.Context) {
+	var notes []domain.Note
+	if !handler.bindJSON(c, "create_batch", &notes, "Invalid input to create notes") {
+		return
+	}
+	if !handler.checkBatchSize(c, "create_batch", len(notes)) {
+		return
+	}
+
+	ownerID := middleware.CurrentUserID(c)
+	opts := usecase.CreateOptions{Force: c.Query("force") == "true"}
+
+	var result BatchResult
+	for i := range notes {
+		note := notes[i]
+		note.OwnerID = ownerID
+
+		if err := handler.Usecase.CreateNoteWithOptions(&note, opts); err != nil {
+			status, body := createNoteError(err)
+			if status == http.StatusInternalServerError {
+				handler.Logger.Error("error creating note", "operation", "create_batch", "index", i, "error", err)
+			}
+			result.fail(i, status, body)
+			continue
+		}
+		result.succeed(i, http.StatusCreated, note)
+	}
+
+	handler.Logger.Info("note batch created", "operation", "create_batch", "count", len(notes), "failed", result.failed())
+	respondBatch(c, http.StatusCreated, result)
+}
+
+// DeleteNotesApi godoc
+// @Summary Move several notes to the trash
+// @Description Deletes each note as DeleteNoteApi would, independently of the others. Answers 200 when every note was deleted and 207 when any failed, with each item's status and error in data.items.
+// @Tags notes
+// @Produce json
+// @Param ids query string true "Comma separated note IDs"
+// @Success 200 {object} Response{data=BatchResult}
+// @Success 207 {object} Response{data=BatchResult}
+// @Failure 400 {object} Response
+// @Router /notes [delete]
+func (handler *NoteHandler) DeleteNotesApi(c *gin.Context) {
+	var ids []uint
+	for _, value := range splitCommaList(c.Query("ids")) {
+		id, err := strconv.ParseUint(value, 10, 0)
+		if err != nil {
+			handler.Logger.Warn("invalid note id", "operation", "delete_batch", "note_id", value, "error", err)
+			respondError(c, http.StatusBadRequest, "Invalid note ID")
+			return
+		}
+		ids = append(ids, uint(id))
+	}
+	if !handler.checkBatchSize(c, "delete_batch", len(ids)) {
+		return
+	}
+
+	ownerID := middleware.CurrentUserID(c)
+
+	var result BatchResult
+	for i, id := range ids {
+		err := handler.Usecase.DeleteNote(id, ownerID)
+		switch {
+		case err == nil:
+			result.succeed(i, http.StatusOK, gin.H{"id": id})
+		case errors.Is(err, usecase.ErrNoteNotFound):
+			result.fail(i, http.StatusNotFound, &ResponseError{Message: "note not found"})
+		default:
+			handler.Logger.Error("error deleting note", "operation", "delete_batch", "note_id", id, "error", err)
+			result.fail(i, http.StatusInternalServerError, &ResponseError{Message: "Failed to delete note. Please try again later."})
+		}
+	}
+
+	handler.Logger.Info("note batch deleted", "operation", "delete_batch", "count", len(ids), "failed", result.failed())
+	respondBatch(c, http.StatusOK, result)
+}
+
+// checkBatchSize writes a 400 and returns false unless a batch of count items
+// is allowed.
+func (handler *NoteHandler) checkBatchSize(c *gin.Context, operation string, count int) bool {
+	switch {
+	case count == 0:
+		respondError(c, http.StatusBadRequest, "at least one note is required")
+		return false
+	case count > maxBatchItems:
+		handler.Logger.Warn("batch too large", "operation", operation, "count", count)
+		respondError(c, http.StatusBadRequest, fmt.Sprintf("a batch can have at most %d notes", maxBatchItems))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/usecase"
+)
+
+type batchBody struct {
+	Data BatchResult            `json:"data"`
+	Meta map[string]interface{} `json:"meta"`
+}
+
+func TestCreateNotesBatchApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		body         string
+		expectedCode int
+		itemCodes    []int
+	}{
+		{
+			name:         "All created",
+			body:         `[{"title":"A","content":"a"},{"title":"B","content":"b"}]`,
+			expectedCode: http.StatusCreated,
+			itemCodes:    []int{http.StatusCreated, http.StatusCreated},
+		},
+		{
+			name:         "Some failed",
+			body:         `[{"title":"A","content":"a"},{"title":"","content":"b"},{"title":"Broken","content":"c"}]`,
+			expectedCode: http.StatusMultiStatus,
+			itemCodes:    []int{http.StatusCreated, http.StatusBadRequest, http.StatusInternalServerError},
+		},
+		{
+			name:         "Empty batch",
+			body:         `[]`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Too many notes",
+			body:         "[" + strings.TrimSuffix(strings.Repeat(`{"title":"A","content":"a"},`, maxBatchItems+1), ",") + "]",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Not an array",
+			body:         `{"title":"A","content":"a"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockCreateNote: func(n *domain.Note) error {
+					switch n.Title {
+					case "":
+						return usecase.ErrEmptyTitle
+					case "Broken":
+						return errors.New("db error")
+					}
+					n.ID = 1
+					return nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.POST("/notes/batch", handler.CreateNotesBatchApi)
+
+			req := httptest.NewRequest(http.MethodPost, "/notes/batch", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.itemCodes == nil {
+				return
+			}
+
+			var body batchBody
+			assert.Equal(t, nil, json.Unmarshal(resp.Body.Bytes(), &body))
+			assert.Equal(t, len(tt.itemCodes), len(body.Data.Items))
+			for i, item := range body.Data.Items {
+				assert.Equal(t, i, item.Index)
+				assert.Equal(t, tt.itemCodes[i], item.Status)
+				assert.Equal(t, item.Status >= 400, item.Error != nil)
+			}
+		})
+	}
+}
+
+func TestDeleteNotesApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		query        string
+		expectedCode int
+		itemCodes    []int
+		failed       float64
+	}{
+		{
+			name:         "All deleted",
+			query:        "?ids=1,2",
+			expectedCode: http.StatusOK,
+			itemCodes:    []int{http.StatusOK, http.StatusOK},
+		},
+		{
+			name:         "Some failed",
+			query:        "?ids=1,999,5",
+			expectedCode: http.StatusMultiStatus,
+			itemCodes:    []int{http.StatusOK, http.StatusNotFound, http.StatusInternalServerError},
+			failed:       2,
+		},
+		{
+			name:         "Missing ids",
+			query:        "",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Invalid id",
+			query:        "?ids=1,abc",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockDeleteNote: func(id, ownerID uint) error {
+					switch id {
+					case 999:
+						return usecase.ErrNoteNotFound
+					case 5:
+						return errors.New("db error")
+					}
+					return nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.DELETE("/notes", handler.DeleteNotesApi)
+
+			req := httptest.NewRequest(http.MethodDelete, "/notes"+tt.query, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.itemCodes == nil {
+				return
+			}
+
+			var body batchBody
+			assert.Equal(t, nil, json.Unmarshal(resp.Body.Bytes(), &body))
+			assert.Equal(t, len(tt.itemCodes), len(body.Data.Items))
+			for i, item := range body.Data.Items {
+				assert.Equal(t, tt.itemCodes[i], item.Status)
+			}
+			assert.Equal(t, tt.failed, body.Meta["failed"])
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+// specPath is where the UI fetches the spec from, relative to /swagger.
+const specPath = "/doc.json"
+
+// DocsHandler serves the OpenAPI 3 spec and the Swagger UI that renders it.
+type DocsHandler struct {
+	Spec []byte
+	ui   gin.HandlerFunc
+}
+
+// NewDocsHandler converts the Swagger 2.0 document generated by swag (see
+// the go:generate line in cmd/main.go) to OpenAPI 3 once, up front.
+func NewDocsHandler(swagger2 string) (*DocsHandler, error) {
+	var doc2 openapi2.T
+	if err := json.Unmarshal([]byte(swagger2), &doc2); err != nil {
+		return nil, fmt.Errorf("failed to parse swagger document: %w", err)
+	}
+
+	doc3, err := openapi2conv.ToV3(&doc2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert swagger document to openapi 3: %w", err)
+	}
+
+	spec, err := json.Marshal(doc3)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode openapi document: %w", err)
+	}
+
+	return &DocsHandler{
+		Spec: spec,
+		ui:   ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/swagger"+specPath)),
+	}, nil
+}
+
+// SwaggerApi serves the spec at /swagger/doc.json and the UI for every other
+// path under /swagger.
+func (handler *DocsHandler) SwaggerApi(c *gin.Context) {
+	if c.Param("any") == specPath {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", handler.Spec)
+		return
+	}
+
+	handler.ui(c)
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+)
+
+const minimalSwagger = `{
+	"swagger": "2.0",
+	"info": {"title": "test", "version": "1.0"},
+	"paths": {
+		"/notes/{id}": {
+			"get": {
+				"parameters": [{"name": "id", "in": "path", "required": true, "type": "integer"}],
+				"responses": {"200": {"description": "OK"}}
+			}
+		}
+	}
+}`
+
+func TestNewDocsHandler_InvalidDocument(t *testing.T) {
+	_, err := NewDocsHandler("not json")
+	assert.NotEqual(t, nil, err)
+}
+
+func TestSwaggerApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	docsHandler, err := NewDocsHandler(minimalSwagger)
+	assert.Equal(t, nil, err)
+
+	router := gin.New()
+	router.GET("/swagger/*any", docsHandler.SwaggerApi)
+
+	tests := []struct {
+		name         string
+		path         string
+		expectedCode int
+		expectedBody string
+	}{
+		{name: "spec is converted to openapi 3", path: "/swagger/doc.json", expectedCode: http.StatusOK, expectedBody: `"openapi":"3.0.3"`},
+		{name: "ui", path: "/swagger/index.html", expectedCode: http.StatusOK, expectedBody: "swagger-ui"},
+		{name: "unknown file", path: "/swagger/missing.js", expectedCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, true, strings.Contains(resp.Body.String(), tt.expectedBody))
+		})
+	}
+}
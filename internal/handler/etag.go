@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+// noteETag derives a strong ETag from the note's identity, version and last
+// update time, so any successful update produces a new tag.
+func noteETag(n domain.Note) string {
+	return fmt.Sprintf(`"%d-%d-%d"`, n.ID, n.Version, n.UpdatedAt.UnixNano())
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag.
+// The header may hold a comma separated list, weak tags or "*".
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
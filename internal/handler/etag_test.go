@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/assert/v2"
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+func TestNoteETagChangesOnUpdate(t *testing.T) {
+	note := domain.Note{ID: 1, Version: 1, UpdatedAt: time.Date(2025, time.June, 15, 10, 30, 0, 0, time.UTC)}
+	before := noteETag(note)
+
+	note.Version++
+	note.UpdatedAt = note.UpdatedAt.Add(time.Second)
+	after := noteETag(note)
+
+	assert.NotEqual(t, before, after)
+	assert.Equal(t, before, noteETag(domain.Note{ID: 1, Version: 1, UpdatedAt: time.Date(2025, time.June, 15, 10, 30, 0, 0, time.UTC)}))
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := `"1-2-3"`
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        bool
+	}{
+		{name: "Exact match", ifNoneMatch: `"1-2-3"`, want: true},
+		{name: "Weak match", ifNoneMatch: `W/"1-2-3"`, want: true},
+		{name: "Match in list", ifNoneMatch: `"0-0-0", "1-2-3"`, want: true},
+		{name: "Wildcard", ifNoneMatch: `*`, want: true},
+		{name: "No match", ifNoneMatch: `"1-1-3"`, want: false},
+		{name: "Empty header", ifNoneMatch: ``, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, etagMatches(tt.ifNoneMatch, etag))
+		})
+	}
+}
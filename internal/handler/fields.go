@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+// noteField is a note field a client can ask for with the fields query
+// param. key is the field's name in a whole note's JSON, so a projected note
+// has the same keys as the note it came from.
+type noteField struct {
+	key   string
+	value func(n domain.Note) interface{}
+}
+
+// noteFields are the note fields a client can ask for, keyed by the name it
+// asks for them by.
+var noteFields = map[string]noteField{
+	"id":               {"ID", func(n domain.Note) interface{} { return n.ID }},
+	"title":            {"Title", func(n domain.Note) interface{} { return n.Title }},
+	"content":          {"Content", func(n domain.Note) interface{} { return n.Content }},
+	"summary":          {"summary", func(n domain.Note) interface{} { return n.Summary }},
+	"category":         {"Category", func(n domain.Note) interface{} { return n.Category }},
+	"meeting_date":     {"MeetingDate", func(n domain.Note) interface{} { return n.MeetingDate }},
+	"end_time":         {"EndTime", func(n domain.Note) interface{} { return n.EndTime }},
+	"duration_minutes": {"DurationMinutes", func(n domain.Note) interface{} { return n.DurationMinutes }},
+	"location":         {"Location", func(n domain.Note) interface{} { return n.Location }},
+	"meeting_url":      {"MeetingURL", func(n domain.Note) interface{} { return n.MeetingURL }},
+	"slug":             {"Slug", func(n domain.Note) interface{} { return n.Slug }},
+	"format":           {"Format", func(n domain.Note) interface{} { return n.Format }},
+	"priority":         {"Priority", func(n domain.Note) interface{} { return n.Priority }},
+	"visibility":       {"Visibility", func(n domain.Note) interface{} { return n.Visibility }},
+	"archived":         {"Archived", func(n domain.Note) interface{} { return n.Archived }},
+	"version":          {"Version", func(n domain.Note) interface{} { return n.Version }},
+	"created_at":       {"CreatedAt", func(n domain.Note) interface{} { return n.CreatedAt }},
+	"updated_at":       {"UpdatedAt", func(n domain.Note) interface{} { return n.UpdatedAt }},
+}
+
+// noteFieldNames lists the keys of noteFields in order, for error messages.
+var noteFieldNames = func() []string {
+	names := make([]string, 0, len(noteFields))
+	for name := range noteFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}()
+
+// projection is the note fields a client asked for. A nil projection means
+// the whole note.
+type projection []string
+
+// parseFields reads the optional fields query param, such as
+// fields=id,title,meeting_date. It writes a 400 and returns false when it
+// names a field that isn't in noteFields.
+func parseFields(c *gin.Context) (projection, bool) {
+	fields := splitCommaList(c.Query("fields"))
+	for _, field := range fields {
+		if _, ok := noteFields[field]; !ok {
+			respondError(c, http.StatusBadRequest, fmt.Sprintf("Invalid field %q. Use any of: %s.", field, strings.Join(noteFieldNames, ", ")))
+			return nil, false
+		}
+	}
+	return fields, true
+}
+
+// note returns n limited to the projected fields, or n itself when there is
+// no projection.
+func (p projection) note(n domain.Note) interface{} {
+	if p == nil {
+		return n
+	}
+	projected := make(map[string]interface{}, len(p))
+	for _, field := range p {
+		f := noteFields[field]
+		projected[f.key] = f.value(n)
+	}
+	return projected
+}
+
+// notes is note for each of notes.
+func (p projection) notes(notes []domain.Note) interface{} {
+	if p == nil {
+		return notes
+	}
+	projected := make([]interface{}, 0, len(notes))
+	for _, n := range notes {
+		projected = append(projected, p.note(n))
+	}
+	return projected
+}
+
+// projectedSearchResult is a domain.SearchResult whose note is projected.
+type projectedSearchResult struct {
+	Note    interface{} `json:"note"`
+	Snippet string      `json:"snippet"`
+	Fuzzy   bool        `json:"fuzzy,omitempty"`
+}
+
+// searchResults projects the note of each result, leaving the rest of the
+// result as it is.
+func (p projection) searchResults(results []domain.SearchResult) interface{} {
+	if p == nil {
+		return results
+	}
+	projected := make([]projectedSearchResult, 0, len(results))
+	for _, r := range results {
+		projected = append(projected, projectedSearchResult{Note: p.note(r.Note), Snippet: r.Snippet, Fuzzy: r.Fuzzy})
+	}
+	return projected
+}
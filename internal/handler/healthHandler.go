@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+	"github.com/jt00721/meeting-notes-manager/internal/repository"
+)
+
+type HealthHandler struct {
+	Repo   repository.HealthRepository
+	Logger logger.Logger
+}
+
+func NewHealthHandler(r repository.HealthRepository) *HealthHandler {
+	return NewHealthHandlerWithLogger(r, logger.Default())
+}
+
+func NewHealthHandlerWithLogger(r repository.HealthRepository, l logger.Logger) *HealthHandler {
+	return &HealthHandler{Repo: r, Logger: l}
+}
+
+func (handler *HealthHandler) HealthCheckApi(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+	defer cancel()
+
+	if err := handler.Repo.Ping(ctx); err != nil {
+		handler.Logger.Error("health check failed, database unreachable", "operation", "health", "error", err)
+		respondError(c, http.StatusServiceUnavailable, "unavailable")
+		return
+	}
+
+	respondOK(c, http.StatusOK, gin.H{"status": "ok"}, nil)
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+)
+
+type mockHealthRepository struct {
+	mockPing func(ctx context.Context) error
+}
+
+func (m *mockHealthRepository) Ping(ctx context.Context) error {
+	if m.mockPing != nil {
+		return m.mockPing(ctx)
+	}
+	return nil
+}
+
+func TestHealthCheckApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		mockError    error
+		expectedCode int
+	}{
+		{
+			name:         "DB reachable",
+			mockError:    nil,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "DB unreachable",
+			mockError:    errors.New("connection refused"),
+			expectedCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockHealthRepository{
+				mockPing: func(ctx context.Context) error {
+					return tt.mockError
+				},
+			}
+
+			handler := NewHealthHandler(mockRepo)
+			router := gin.Default()
+			router.GET("/health", handler.HealthCheckApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/health", nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/middleware"
+	"github.com/jt00721/meeting-notes-manager/internal/usecase"
+)
+
+// maxImportLineBytes caps one NDJSON line. A line is a single note, so it
+// gets the same default limit as a single note body.
+const maxImportLineBytes = int(middleware.DefaultMaxBodyBytes)
+
+// importColumns are the CSV header names ImportNotesApi understands. Other
+// columns are ignored.
+var importColumns = []string{"title", "content", "category", "meeting_date", "format", "priority", "visibility", "location", "meeting_url"}
+
+var errUnsupportedImportType = errors.New("unsupported import content type")
+
+// ImportNotesApi godoc
+// @Summary Import notes from CSV or NDJSON
+// @Description The body, or the "file" part of a multipart upload, is read as CSV when its content type is text/csv and as NDJSON when it is application/x-ndjson. CSV needs a header row naming the title, content, category, meeting_date, format, priority, visibility, location and meeting_url columns it has. Each row becomes a new note owned by the caller. Invalid rows are reported by line number and skipped; with atomic=true any invalid row means nothing is imported.
+// @Tags notes
+// @Accept text/csv,application/x-ndjson,multipart/form-data
+// @Produce json
+// @Param tz query string false "IANA timezone date-only meeting dates are read in. Defaults to the server's timezone."
+// @Param atomic query bool false "Import nothing unless every row is valid"
+// @Success 200 {object} Response{data=usecase.ImportSummary}
+// @Failure 400 {object} Response
+// @Failure 415 {object} Response
+// @Failure 422 {object} Response{data=usecase.ImportSummary}
+// @Failure 500 {object} Response
+// @Router /notes/import [post]
+func (handler *NoteHandler) ImportNotesApi(c *gin.Context) {
+	loc, ok := handler.requestLocation(c, "import")
+	if !ok {
+		return
+	}
+
+	body, contentType, err := importSource(c)
+	if err != nil {
+		if errors.Is(err, errUnsupportedImportType) {
+			handler.Logger.Warn("unsupported import content type", "operation", "import", "content_type", contentType)
+			respondError(c, http.StatusUnsupportedMediaType, "import must be text/csv or application/x-ndjson")
+			return
+		}
+		handler.Logger.Warn("invalid import upload", "operation", "import", "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid import upload")
+		return
+	}
+
+	var next func() (usecase.ImportRow, error)
+	if contentType == "text/csv" {
+		next, err = csvRows(body, loc)
+		if err != nil {
+			handler.Logger.Warn("invalid csv header", "operation", "import", "error", err)
+			respondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	} else {
+		next = ndjsonRows(body, loc)
+	}
+
+	opts := usecase.ImportOptions{Atomic: c.Query("atomic") == "true"}
+	summary, err := handler.Usecase.ImportNotes(middleware.CurrentUserID(c), next, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrImportRejected):
+			c.JSON(http.StatusUnprocessableEntity, Response{Data: summary, Meta: gin.H{}, Error: &ResponseError{Message: err.Error()}})
+		case isImportReadError(err):
+			handler.Logger.Warn("import stopped on unreadable input", "operation", "import", "imported", summary.Imported, "error", err)
+			c.JSON(http.StatusBadRequest, Response{Data: summary, Meta: gin.H{}, Error: &ResponseError{Message: err.Error()}})
+		default:
+			handler.Logger.Error("error importing notes", "operation", "import", "error", err)
+			respondError(c, http.StatusInternalServerError, "Failed to import notes. Please try again later.")
+		}
+		return
+	}
+
+	handler.Logger.Info("notes imported", "operation", "import", "imported", summary.Imported, "failed", len(summary.Failed))
+	respondOK(c, http.StatusOK, summary, nil)
+}
+
+// importSource returns the reader holding the file to import and its media
+// type: the request body itself, or the "file" part of a multipart upload,
+// which is read as it arrives rather than saved first.
+func importSource(c *gin.Context) (io.Reader, string, error) {
+	if c.ContentType() != "multipart/form-data" {
+		return checkImportType(c.Request.Body, c.ContentType())
+	}
+
+	parts, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, "", err
+	}
+	for {
+		part, err := parts.NextPart()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, "", errors.New(`multipart upload has no "file" part`)
+			}
+			return nil, "", err
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		mediaType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if err != nil {
+			return nil, "", errUnsupportedImportType
+		}
+		return checkImportType(part, mediaType)
+	}
+}
+
+// checkImportType normalises the accepted aliases of each media type.
+func checkImportType(r io.Reader, mediaType string) (io.Reader, string, error) {
+	switch mediaType {
+	case "text/csv", "application/csv":
+		return r, "text/csv", nil
+	case "application/x-ndjson", "application/ndjson":
+		return r, "application/x-ndjson", nil
+	}
+	return nil, mediaType, errUnsupportedImportType
+}
+
+// importReadError wraps a failure to read the import file itself, as
+// opposed to one of its rows.
+type importReadError struct {
+	err error
+}
+
+func (e *importReadError) Error() string { return e.err.Error() }
+func (e *importReadError) Unwrap() error { return e.err }
+
+func isImportReadError(err error) bool {
+	var readErr *importReadError
+	return errors.As(err, &readErr)
+}
+
+// csvRows reads the header row of r and returns a function yielding one row
+// per following record, reading date-only meeting dates in loc. A record
+// with the wrong number of fields or a bad meeting date is a failed row, not
+// the end of the import.
+func csvRows(r io.Reader, loc *time.Location) (func() (usecase.ImportRow, error), error) {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv import needs a header row")
+		}
+		return nil, fmt.Errorf("invalid csv header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		for _, known := range importColumns {
+			if name == known {
+				columns[name] = i
+			}
+		}
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New(`csv header must include a "title" column`)
+	}
+
+	return func() (usecase.ImportRow, error) {
+		record, err := reader.Read()
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return usecase.ImportRow{Line: parseErr.StartLine, Err: parseErr.Err}, nil
+			}
+			if errors.Is(err, io.EOF) {
+				return usecase.ImportRow{}, io.EOF
+			}
+			return usecase.ImportRow{}, &importReadError{err: err}
+		}
+		line, _ := reader.FieldPos(0)
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return record[i]
+			}
+			return ""
+		}
+
+		meetingDate, err := parseMeetingDate(strings.TrimSpace(field("meeting_date")), loc)
+		if err != nil {
+			return usecase.ImportRow{Line: line, Err: err}, nil
+		}
+
+		return usecase.ImportRow{Line: line, Note: domain.Note{
+			Title:       field("title"),
+			Content:     field("content"),
+			Category:    field("category"),
+			MeetingDate: meetingDate,
+			Format:      field("format"),
+			Priority:    field("priority"),
+			Visibility:  field("visibility"),
+			Location:    field("location"),
+			MeetingURL:  field("meeting_url"),
+		}}, nil
+	}, nil
+}
+
+// ndjsonRows returns a function yielding one row per non-blank line of r.
+// Each line is a JSON note in the same shape the create endpoint accepts,
+// with date-only meeting dates read in loc.
+func ndjsonRows(r io.Reader, loc *time.Location) func() (usecase.ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineBytes)
+	line := 0
+
+	return func() (usecase.ImportRow, error) {
+		for scanner.Scan() {
+			line++
+			text := bytes.TrimSpace(scanner.Bytes())
+			if len(text) == 0 {
+				continue
+			}
+
+			meetingDate, rest, err := extractMeetingDate(text, loc)
+			if err != nil {
+				return usecase.ImportRow{Line: line, Err: err}, nil
+			}
+
+			var note domain.Note
+			if err := json.Unmarshal(rest, &note); err != nil {
+				return usecase.ImportRow{Line: line, Err: errors.New("invalid JSON")}, nil
+			}
+			if !meetingDate.IsZero() {
+				note.MeetingDate = meetingDate
+			}
+			return usecase.ImportRow{Line: line, Note: note}, nil
+		}
+
+		if err := scanner.Err(); err != nil {
+			if errors.Is(err, bufio.ErrTooLong) {
+				err = fmt.Errorf("line %d is longer than %d bytes", line+1, maxImportLineBytes)
+			}
+			return usecase.ImportRow{}, &importReadError{err: err}
+		}
+		return usecase.ImportRow{}, io.EOF
+	}
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/usecase"
+)
+
+func TestImportNotesApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	june16 := time.Date(2025, time.June, 16, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		query       string
+		mockError   error
+		wantCode    int
+		wantRows    []usecase.ImportRow
+		wantAtomic  bool
+		wantBody    string
+	}{
+		{
+			name:        "CSV",
+			contentType: "text/csv",
+			body:        "Title,Content,Meeting_Date,Ignored\nPlanning,\"Agenda, actions\",2025-06-16,x\nRetro,Went well,not a date,x\nShort,row\n",
+			wantCode:    http.StatusOK,
+			wantRows: []usecase.ImportRow{
+				{Line: 2, Note: domain.Note{Title: "Planning", Content: "Agenda, actions", MeetingDate: june16}},
+				{Line: 3, Err: errInvalidMeetingDateFormat},
+				{Line: 4, Err: errors.New("wrong number of fields")},
+			},
+		},
+		{
+			name:        "CSV without a title column",
+			contentType: "text/csv; charset=utf-8",
+			body:        "content\nAgenda\n",
+			wantCode:    http.StatusBadRequest,
+			wantBody:    `csv header must include a \"title\" column`,
+		},
+		{
+			name:        "NDJSON",
+			contentType: "application/x-ndjson",
+			body:        "{\"title\":\"Planning\",\"content\":\"Agenda\",\"meeting_date\":\"2025-06-16\"}\n\n{\"title\":\n{\"Title\":\"Retro\",\"Category\":\"Team\"}\n",
+			query:       "?atomic=true",
+			wantCode:    http.StatusOK,
+			wantRows: []usecase.ImportRow{
+				{Line: 1, Note: domain.Note{Title: "Planning", Content: "Agenda", MeetingDate: june16}},
+				{Line: 3, Err: errors.New("invalid JSON")},
+				{Line: 4, Note: domain.Note{Title: "Retro", Category: "Team"}},
+			},
+			wantAtomic: true,
+		},
+		{
+			name:        "Unsupported type",
+			contentType: "application/json",
+			body:        `[{"title":"Planning"}]`,
+			wantCode:    http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "Atomic import rejected",
+			contentType: "application/x-ndjson",
+			body:        "{\"title\":\"\"}\n",
+			query:       "?atomic=true",
+			mockError:   usecase.ErrImportRejected,
+			wantCode:    http.StatusUnprocessableEntity,
+			wantBody:    `"error":{"message":"import has invalid rows, no notes were created"}`,
+			wantAtomic:  true,
+		},
+		{
+			name:        "Usecase error",
+			contentType: "application/x-ndjson",
+			body:        "{\"title\":\"Planning\"}\n",
+			mockError:   errors.New("failed to import notes"),
+			wantCode:    http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotRows []usecase.ImportRow
+			var gotAtomic bool
+			mockUC := &mockNoteUsecase{
+				mockImportNotes: func(ownerID uint, next func() (usecase.ImportRow, error), opts usecase.ImportOptions) (usecase.ImportSummary, error) {
+					gotAtomic = opts.Atomic
+					for {
+						row, err := next()
+						if errors.Is(err, io.EOF) {
+							break
+						}
+						if err != nil {
+							return usecase.ImportSummary{}, err
+						}
+						gotRows = append(gotRows, row)
+					}
+					return usecase.ImportSummary{Imported: len(gotRows)}, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.POST("/notes/import", handler.ImportNotesApi)
+
+			req := httptest.NewRequest(http.MethodPost, "/notes/import"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, true, strings.Contains(resp.Body.String(), tt.wantBody))
+			}
+			if tt.wantRows != nil {
+				assert.Equal(t, len(tt.wantRows), len(gotRows))
+				for i, want := range tt.wantRows {
+					assert.Equal(t, want.Line, gotRows[i].Line)
+					assert.Equal(t, want.Note, gotRows[i].Note)
+					if want.Err == nil {
+						assert.Equal(t, nil, gotRows[i].Err)
+					} else {
+						assert.Equal(t, want.Err.Error(), gotRows[i].Err.Error())
+					}
+				}
+				assert.Equal(t, tt.wantAtomic, gotAtomic)
+			}
+		})
+	}
+}
+
+func TestImportNotesApiMultipart(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	upload := func(partType string) (*bytes.Buffer, string) {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		_ = form.WriteField("note", "ignored")
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="file"; filename="notes.csv"`)
+		header.Set("Content-Type", partType)
+		part, _ := form.CreatePart(header)
+		_, _ = part.Write([]byte("title,content\nPlanning,Agenda\n"))
+		_ = form.Close()
+		return &body, form.FormDataContentType()
+	}
+
+	tests := []struct {
+		name     string
+		partType string
+		wantCode int
+		wantRows int
+	}{
+		{name: "CSV file part", partType: "text/csv", wantCode: http.StatusOK, wantRows: 1},
+		{name: "Unsupported file part", partType: "application/octet-stream", wantCode: http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := 0
+			mockUC := &mockNoteUsecase{
+				mockImportNotes: func(ownerID uint, next func() (usecase.ImportRow, error), opts usecase.ImportOptions) (usecase.ImportSummary, error) {
+					for {
+						if _, err := next(); err != nil {
+							break
+						}
+						rows++
+					}
+					return usecase.ImportSummary{Imported: rows}, nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.POST("/notes/import", handler.ImportNotesApi)
+
+			body, contentType := upload(tt.partType)
+			req := httptest.NewRequest(http.MethodPost, "/notes/import", body)
+			req.Header.Set("Content-Type", contentType)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			assert.Equal(t, tt.wantRows, rows)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/timeutil"
+)
+
+var errInvalidMeetingDateFormat = errors.New("invalid meeting_date format")
+
+// parseMeetingDate accepts an RFC 3339 timestamp or a YYYY-MM-DD date, which
+// is taken as midnight in loc. An empty string is no date at all.
+func parseMeetingDate(value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := timeutil.ParseDate(value, loc); err == nil {
+		return t, nil
+	}
+	return time.Time{}, errInvalidMeetingDateFormat
+}
+
+// extractMeetingDate takes the meeting date out of a JSON note body, under
+// either meeting_date or MeetingDate, and returns it parsed in loc along with
+// the body minus that key. A body that isn't a JSON object is returned as is for
+// the caller's normal decoding to reject.
+func extractMeetingDate(body []byte, loc *time.Location) (time.Time, []byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+		return time.Time{}, body, nil
+	}
+
+	var raw json.RawMessage
+	found := false
+	for key, value := range fields {
+		if key == "meeting_date" || strings.EqualFold(key, "MeetingDate") {
+			// meeting_date wins if a client sends both.
+			if !found || key == "meeting_date" {
+				raw = value
+			}
+			found = true
+			delete(fields, key)
+		}
+	}
+	if !found {
+		return time.Time{}, body, nil
+	}
+
+	rest, err := json.Marshal(fields)
+	if err != nil {
+		return time.Time{}, body, err
+	}
+
+	if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return time.Time{}, rest, nil
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return time.Time{}, rest, errInvalidMeetingDateFormat
+	}
+	date, err := parseMeetingDate(strings.TrimSpace(value), loc)
+	return date, rest, err
+}
+
+// bindNote binds a note from the request body like bindJSON, but parses the
+// meeting date itself so a date without a time is accepted rather than
+// failing the whole bind. Such a date is read in the request's timezone. A
+// malformed meeting date or tz writes a 400 and returns false.
+func (handler *NoteHandler) bindNote(c *gin.Context, operation string, note *domain.Note, invalidMessage string) bool {
+	loc, ok := handler.requestLocation(c, operation)
+	if !ok {
+		return false
+	}
+
+	var meetingDate time.Time
+	if c.Request.Body != nil && c.Request.Body != http.NoBody {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return handler.bindFailed(c, operation, err, invalidMessage)
+		}
+
+		var rest []byte
+		meetingDate, rest, err = extractMeetingDate(body, loc)
+		if err != nil {
+			handler.Logger.Warn("invalid meeting date", "operation", operation, "error", err)
+			respondError(c, http.StatusBadRequest, errInvalidMeetingDateFormat.Error())
+			return false
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(rest))
+	}
+
+	if !handler.bindJSON(c, operation, note, invalidMessage) {
+		return false
+	}
+	if !meetingDate.IsZero() {
+		note.MeetingDate = meetingDate
+	}
+	return true
+}
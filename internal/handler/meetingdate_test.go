@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+func TestMeetingDateBinding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.Equal(t, nil, err)
+
+	tests := []struct {
+		name         string
+		strict       bool
+		query        string
+		location     *time.Location
+		body         string
+		expectedCode int
+		expectedDate time.Time
+		expectedBody string
+	}{
+		{
+			name:         "RFC 3339",
+			body:         `{"title":"Standup","content":"Notes","meeting_date":"2025-06-15T10:30:00Z"}`,
+			expectedCode: http.StatusCreated,
+			expectedDate: time.Date(2025, time.June, 15, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:         "RFC 3339 with offset",
+			body:         `{"title":"Standup","content":"Notes","meeting_date":"2025-06-15T10:30:00+02:00"}`,
+			expectedCode: http.StatusCreated,
+			expectedDate: time.Date(2025, time.June, 15, 8, 30, 0, 0, time.UTC),
+		},
+		{
+			name:         "Date only is midnight UTC",
+			body:         `{"title":"Standup","content":"Notes","meeting_date":"2025-06-15"}`,
+			expectedCode: http.StatusCreated,
+			expectedDate: time.Date(2025, time.June, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "Date only in the requested timezone",
+			query:        "?tz=America/New_York",
+			body:         `{"title":"Standup","content":"Notes","meeting_date":"2025-06-15"}`,
+			expectedCode: http.StatusCreated,
+			expectedDate: time.Date(2025, time.June, 15, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "Date only in the server's timezone",
+			location:     newYork,
+			body:         `{"title":"Standup","content":"Notes","meeting_date":"2025-06-15"}`,
+			expectedCode: http.StatusCreated,
+			expectedDate: time.Date(2025, time.June, 15, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "Timezone leaves full timestamps alone",
+			query:        "?tz=America/New_York",
+			body:         `{"title":"Standup","content":"Notes","meeting_date":"2025-06-15T10:30:00Z"}`,
+			expectedCode: http.StatusCreated,
+			expectedDate: time.Date(2025, time.June, 15, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:         "Unknown timezone",
+			query:        "?tz=Nowhere",
+			body:         `{"title":"Standup","content":"Notes","meeting_date":"2025-06-15"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"data":null,"meta":{},"error":{"message":"tz must be an IANA timezone name, such as America/New_York"}}`,
+		},
+		{
+			name:         "Field name as in responses",
+			body:         `{"Title":"Standup","Content":"Notes","MeetingDate":"2025-06-15"}`,
+			expectedCode: http.StatusCreated,
+			expectedDate: time.Date(2025, time.June, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "Accepted in strict mode",
+			strict:       true,
+			body:         `{"title":"Standup","content":"Notes","meeting_date":"2025-06-15"}`,
+			expectedCode: http.StatusCreated,
+			expectedDate: time.Date(2025, time.June, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "Empty string is no date",
+			body:         `{"title":"Standup","content":"Notes","meeting_date":""}`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "Wrong format",
+			body:         `{"title":"Standup","content":"Notes","meeting_date":"15/06/2025"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"data":null,"meta":{},"error":{"message":"invalid meeting_date format"}}`,
+		},
+		{
+			name:         "Not a string",
+			body:         `{"title":"Standup","content":"Notes","meeting_date":20250615}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"data":null,"meta":{},"error":{"message":"invalid meeting_date format"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got domain.Note
+			mockUC := &mockNoteUsecase{
+				mockCreateNote: func(n *domain.Note) error {
+					got = *n
+					return nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			handler.StrictJSON = tt.strict
+			handler.Location = tt.location
+			router := gin.Default()
+			router.POST("/notes", handler.CreateNoteApi)
+
+			req := httptest.NewRequest(http.MethodPost, "/notes"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, resp.Body.String())
+			}
+			if tt.expectedCode == http.StatusCreated {
+				assert.Equal(t, "Standup", got.Title)
+				assert.Equal(t, true, got.MeetingDate.Equal(tt.expectedDate))
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type MetricsHandler struct {
+	Gatherer prometheus.Gatherer
+}
+
+func NewMetricsHandler(g prometheus.Gatherer) *MetricsHandler {
+	return &MetricsHandler{Gatherer: g}
+}
+
+// MetricsApi serves every collector in Gatherer in the Prometheus text format.
+func (handler *MetricsHandler) MetricsApi(c *gin.Context) {
+	promhttp.HandlerFor(handler.Gatherer, promhttp.HandlerOpts{}).ServeHTTP(c.Writer, c.Request)
+}
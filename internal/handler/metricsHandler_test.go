@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"github.com/jt00721/meeting-notes-manager/internal/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestMetricsApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	reg := prometheus.NewRegistry()
+	metrics := middleware.NewPrometheusMetrics(reg)
+	metrics.NotesTotal.Set(7)
+
+	handler := NewMetricsHandler(reg)
+	router := gin.New()
+	router.Use(metrics.Middleware())
+	router.GET("/metrics", handler.MetricsApi)
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	body := resp.Body.String()
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, true, strings.Contains(body, "notes_total 7"))
+	assert.Equal(t, true, strings.Contains(body, `http_requests_total{method="GET",route="/health",status="200"} 1`))
+}
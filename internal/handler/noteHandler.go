@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"encoding/json"
 	"errors"
-	"log"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,271 +12,2299 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/export"
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+	"github.com/jt00721/meeting-notes-manager/internal/middleware"
+	"github.com/jt00721/meeting-notes-manager/internal/render"
+	"github.com/jt00721/meeting-notes-manager/internal/textstats"
+	"github.com/jt00721/meeting-notes-manager/internal/timeutil"
 	"github.com/jt00721/meeting-notes-manager/internal/usecase"
 )
 
+type restoreNotesRequest struct {
+	IDs []uint `json:"ids"`
+}
+
+type renameCategoryRequest struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
 type NoteHandler struct {
 	Usecase usecase.NoteUsecase
+	Logger  logger.Logger
+	// Redactor is applied to exported content only. Nil disables redaction.
+	Redactor *Redactor
+	// Presets resolves ?preset= on the filter endpoint. Nil disables presets.
+	Presets usecase.PresetUsecase
+	// StrictJSON rejects note bodies with fields the API doesn't know, such
+	// as a misspelled "titel", instead of silently ignoring them.
+	StrictJSON bool
+	// PageLimit is the page size of the paginated, search, filter and
+	// changes endpoints when a request gives no limit. Zero means
+	// DefaultPageLimit.
+	PageLimit int
+	// Location is the timezone date-only values are read in when a request
+	// gives no tz. Nil means UTC.
+	Location *time.Location
 }
 
+// DefaultPageLimit is the page size used when neither the request nor
+// NoteHandler.PageLimit gives one.
+const DefaultPageLimit = 10
+
 func NewNoteHandler(u usecase.NoteUsecase) *NoteHandler {
-	return &NoteHandler{Usecase: u}
+	return NewNoteHandlerWithLogger(u, logger.Default())
+}
+
+func NewNoteHandlerWithLogger(u usecase.NoteUsecase, l logger.Logger) *NoteHandler {
+	return &NoteHandler{Usecase: u, Logger: l}
 }
 
+// CreateNoteApi godoc
+// @Summary Create a note
+// @Description Creates a note owned by the caller. Validation failures return a map of field to message.
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param note body domain.Note true "Note to create"
+// @Param force query bool false "Skip the meeting date window check"
+// @Param useTemplate query bool false "Fill empty content from the category template"
+// @Param tz query string false "IANA timezone a YYYY-MM-DD meeting_date is read in. Defaults to the server's timezone."
+// @Success 201 {object} Response{data=domain.Note}
+// @Failure 400 {object} Response
+// @Failure 409 {object} Response
+// @Failure 413 {object} Response
+// @Failure 422 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes [post]
 func (handler *NoteHandler) CreateNoteApi(c *gin.Context) {
 	var note domain.Note
-	if err := c.ShouldBindJSON(&note); err != nil {
-		log.Printf("Error binding json request body to create note: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input to create note"})
+	if !handler.bindNote(c, "create", &note, "Invalid input to create note") {
 		return
 	}
 
-	err := handler.Usecase.CreateNote(&note)
+	note.OwnerID = middleware.CurrentUserID(c)
+
+	err := handler.Usecase.CreateNoteWithOptions(&note, createOptions(c))
 	if err != nil {
-		if errors.Is(err, usecase.ErrEmptyTitle) {
-			log.Println("Error: Cannot create note without title")
-			c.JSON(http.StatusBadRequest, gin.H{"error": "note title cannot be empty"})
+		status, body := createNoteError(err)
+		if status == http.StatusInternalServerError {
+			handler.Logger.Error("error creating note", "operation", "create", "error", err)
+		} else {
+			handler.Logger.Warn("note not created", "operation", "create", "status", status, "error", err)
+		}
+		c.JSON(status, Response{Meta: gin.H{}, Error: body})
+		return
+	}
+
+	handler.Logger.Info("note created", "operation", "create", "note_id", note.ID)
+	respondOK(c, http.StatusCreated, note, nil)
+}
+
+// createOptions reads the force and useTemplate query params shared by the
+// create and validate endpoints.
+func createOptions(c *gin.Context) usecase.CreateOptions {
+	return usecase.CreateOptions{
+		Force:       c.Query("force") == "true",
+		UseTemplate: c.Query("useTemplate") == "true",
+	}
+}
+
+// createNoteError maps an error from creating a note to the status and error
+// to answer with.
+func createNoteError(err error) (int, *ResponseError) {
+	var windowErr *usecase.MeetingDateWindowError
+	if errors.As(err, &windowErr) {
+		return http.StatusUnprocessableEntity, &ResponseError{Message: windowErr.Error()}
+	}
+
+	if body, ok := blockedContentError(err); ok {
+		return http.StatusUnprocessableEntity, body
+	}
+
+	if fields, ok := validationErrorFields(err); ok {
+		return http.StatusBadRequest, &ResponseError{Message: "note failed validation", Fields: fields}
+	}
+
+	switch {
+	case errors.Is(err, usecase.ErrEmptyTitle):
+		return http.StatusBadRequest, &ResponseError{Message: "note title cannot be empty"}
+	case errors.Is(err, usecase.ErrEmptyContent):
+		return http.StatusBadRequest, &ResponseError{Message: "note content cannot be empty"}
+	case errors.Is(err, usecase.ErrWeekendMeetingDate):
+		return http.StatusBadRequest, &ResponseError{Message: "meeting date cannot fall on a weekend"}
+	case errors.Is(err, usecase.ErrInvalidMeetingDate):
+		return http.StatusBadRequest, &ResponseError{Message: "meeting date is required"}
+	case errors.Is(err, usecase.ErrCategoryQuotaExceeded):
+		return http.StatusUnprocessableEntity, &ResponseError{Message: "category has reached its note quota"}
+	case errors.Is(err, usecase.ErrDuplicateNote):
+		return http.StatusConflict, &ResponseError{Message: "a note with this title and meeting date already exists"}
+	}
+	return http.StatusInternalServerError, &ResponseError{Message: "Failed to create note. Please try again later."}
+}
+
+// bindJSON binds the request body into obj. It writes a 413 when the body is
+// over the MaxBodySize limit, a 400 naming the field when StrictJSON is set and
+// the body has an unknown field, a 400 with invalidMessage for any other bind
+// failure, and returns false in each case.
+func (handler *NoteHandler) bindJSON(c *gin.Context, operation string, obj interface{}, invalidMessage string) bool {
+	var err error
+	if handler.StrictJSON {
+		err = decodeStrictJSON(c.Request, obj)
+	} else {
+		err = c.ShouldBindJSON(obj)
+	}
+	if err == nil {
+		return true
+	}
+	return handler.bindFailed(c, operation, err, invalidMessage)
+}
+
+// bindFailed writes the response for a body that couldn't be bound and
+// returns false.
+func (handler *NoteHandler) bindFailed(c *gin.Context, operation string, err error, invalidMessage string) bool {
+	if middleware.IsBodyTooLarge(err) {
+		handler.Logger.Warn("request body too large", "operation", operation)
+		respondError(c, http.StatusRequestEntityTooLarge, "request body too large")
+		return false
+	}
+
+	if field, ok := unknownField(err); ok {
+		handler.Logger.Warn("unknown field in request body", "operation", operation, "field", field)
+		respondFieldErrors(c, http.StatusBadRequest, "unknown field in request body", map[string]string{field: "unknown field"})
+		return false
+	}
+
+	handler.Logger.Warn("invalid request body", "operation", operation, "error", err)
+	respondError(c, http.StatusBadRequest, invalidMessage)
+	return false
+}
+
+// decodeStrictJSON decodes the request body into obj, failing on any field obj
+// doesn't have, at whatever depth it appears.
+func decodeStrictJSON(req *http.Request, obj interface{}) error {
+	if req == nil || req.Body == nil {
+		return errors.New("invalid request")
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(obj)
+}
+
+// unknownField reports the field name from a DisallowUnknownFields error.
+// encoding/json only exposes it in the message.
+func unknownField(err error) (string, bool) {
+	quoted, ok := strings.CutPrefix(err.Error(), "json: unknown field ")
+	if !ok {
+		return "", false
+	}
+
+	field, unquoteErr := strconv.Unquote(quoted)
+	if unquoteErr != nil {
+		return quoted, true
+	}
+	return field, true
+}
+
+// validationErrorFields maps each failing field of a *usecase.ValidationError
+// to its message. When a field failed more than one check, the first wins.
+func validationErrorFields(err error) (map[string]string, bool) {
+	var validationErr *usecase.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil, false
+	}
+
+	fields := make(map[string]string, len(validationErr.Fields))
+	for _, f := range validationErr.Fields {
+		if _, seen := fields[f.Field]; !seen {
+			fields[f.Field] = f.Err.Error()
+		}
+	}
+	return fields, true
+}
+
+// blockedContentError reports whether err is a note rejected by the content
+// blocklist and, if so, the error to answer with. The content field names
+// the blocked term's category, never the term.
+func blockedContentError(err error) (*ResponseError, bool) {
+	if !errors.Is(err, usecase.ErrBlockedContent) {
+		return nil, false
+	}
+	fields, _ := validationErrorFields(err)
+	return &ResponseError{Message: "note content contains a blocked term", Fields: fields}, true
+}
+
+// DuplicateNoteApi godoc
+// @Summary Duplicate a note
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Success 201 {object} Response{data=domain.Note}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 422 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/{id}/duplicate [post]
+func (handler *NoteHandler) DuplicateNoteApi(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handler.Logger.Warn("invalid note id", "operation", "duplicate", "note_id", c.Param("id"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+
+	note, err := handler.Usecase.DuplicateNote(uint(id), middleware.CurrentUserID(c))
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoteNotFound) {
+			respondError(c, http.StatusNotFound, "note not found")
 			return
-		} else if errors.Is(err, usecase.ErrEmptyContent) {
-			log.Println("Error: Cannot create note without content")
-			c.JSON(http.StatusBadRequest, gin.H{"error": "note content cannot be empty"})
+		}
+
+		if body, ok := blockedContentError(err); ok {
+			handler.Logger.Warn("duplicate has blocked content", "operation", "duplicate", "note_id", id)
+			respondFieldErrors(c, http.StatusUnprocessableEntity, body.Message, body.Fields)
+			return
+		}
+
+		if fields, ok := validationErrorFields(err); ok {
+			handler.Logger.Warn("duplicate failed validation", "operation", "duplicate", "note_id", id, "error", err)
+			respondFieldErrors(c, http.StatusBadRequest, "note failed validation", fields)
+			return
+		}
+
+		if errors.Is(err, usecase.ErrCategoryQuotaExceeded) {
+			handler.Logger.Warn("category quota reached", "operation", "duplicate", "note_id", id)
+			respondError(c, http.StatusUnprocessableEntity, "category has reached its note quota")
+			return
+		}
+
+		handler.Logger.Error("error duplicating note", "operation", "duplicate", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to duplicate note. Please try again later.")
+		return
+	}
+
+	handler.Logger.Info("note duplicated", "operation", "duplicate", "note_id", id, "duplicate_id", note.ID)
+	respondOK(c, http.StatusCreated, note, nil)
+}
+
+// UpsertNoteApi godoc
+// @Summary Create or replace an imported note
+// @Description Replaces the caller's note with this external ID, or creates one if they have none. External IDs are unique per owner, so another user's note with the same external ID is never touched. 409 means the note would duplicate the title and meeting date of another of the caller's notes.
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param externalID path string true "ID of the note in the source system"
+// @Param note body domain.Note true "Note to import"
+// @Success 200 {object} Response{data=domain.Note}
+// @Failure 400 {object} Response
+// @Failure 409 {object} Response
+// @Failure 413 {object} Response
+// @Failure 422 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/external/{externalID} [put]
+func (handler *NoteHandler) UpsertNoteApi(c *gin.Context) {
+	var note domain.Note
+	if !handler.bindNote(c, "upsert", &note, "Invalid input to import note") {
+		return
+	}
+
+	note.ExternalID = c.Param("externalID")
+	note.OwnerID = middleware.CurrentUserID(c)
+
+	err := handler.Usecase.UpsertNote(&note)
+	if err != nil {
+		// An imported note is validated like a new one, so it fails the
+		// same way.
+		status, body := createNoteError(err)
+		if status == http.StatusInternalServerError {
+			handler.Logger.Error("error upserting note", "operation", "upsert", "external_id", note.ExternalID, "error", err)
+		} else {
+			handler.Logger.Warn("note not upserted", "operation", "upsert", "external_id", note.ExternalID, "status", status, "error", err)
+		}
+		c.JSON(status, Response{Meta: gin.H{}, Error: body})
+		return
+	}
+
+	handler.Logger.Info("note upserted", "operation", "upsert", "note_id", note.ID, "external_id", note.ExternalID)
+	respondOK(c, http.StatusOK, note, nil)
+}
+
+// ValidateNoteApi godoc
+// @Summary Validate a note without saving it
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param note body domain.Note true "Note to validate"
+// @Param force query bool false "Skip the meeting date window check"
+// @Param useTemplate query bool false "Fill empty content from the category template"
+// @Success 200 {object} Response{data=map[string]any}
+// @Failure 400 {object} Response
+// @Failure 413 {object} Response
+// @Failure 422 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/validate [post]
+func (handler *NoteHandler) ValidateNoteApi(c *gin.Context) {
+	var note domain.Note
+	if !handler.bindNote(c, "validate", &note, "Invalid input to validate note") {
+		return
+	}
+
+	err := handler.Usecase.ValidateNote(&note, createOptions(c))
+	if err != nil {
+		if fields, ok := validationErrorFields(err); ok {
+			respondFieldErrors(c, http.StatusUnprocessableEntity, "note failed validation", fields)
 			return
 		}
 
-		log.Printf("Error creating note: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create note. Please try again later."})
+		handler.Logger.Error("error validating note", "operation", "validate", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to validate note. Please try again later.")
 		return
 	}
 
-	log.Println("Successfully created note")
-	c.JSON(http.StatusCreated, note)
+	response := gin.H{"valid": true}
+	if len(note.Warnings) > 0 {
+		response["warnings"] = note.Warnings
+	}
+	respondOK(c, http.StatusOK, response, nil)
 }
 
+// GetAllNotesApi godoc
+// @Summary List the notes the caller can read
+// @Description Lists the caller's notes and other users' shared or public ones. Without afterID, returns at most MAX_LIST_SIZE notes, newest meeting first, with meta.truncated set when there were more. With afterID set, returns one page of the same notes in ID order with meta.last_id set. Pass last_id as the next afterID.
+// @Tags notes
+// @Produce json
+// @Param includeArchived query bool false "Include archived notes"
+// @Param afterID query int false "Return notes with an ID above this cursor"
+// @Param limit query int false "Page size when afterID is set, at most 100, 0 for the most allowed" default(10)
+// @Param fields query string false "Comma separated note fields to return, such as id,title,meeting_date"
+// @Success 200 {object} Response{data=[]domain.Note}
+// @Header 200 {bool} X-Truncated "Set when the list was cut off at the configured maximum"
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes [get]
 func (handler *NoteHandler) GetAllNotesApi(c *gin.Context) {
-	notes, err := handler.Usecase.GetAllNotes()
+	fields, ok := parseFields(c)
+	if !ok {
+		return
+	}
+
+	includeArchived := c.Query("includeArchived") == "true"
+	if _, ok := c.GetQuery("afterID"); ok {
+		handler.getNotesAfterID(c, includeArchived, fields)
+		return
+	}
+
+	notes, truncated, err := handler.Usecase.GetAllNotes(middleware.CurrentUserID(c), includeArchived)
 	if err != nil {
-		log.Printf("Error retrieving all notes: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve all notes. Please try again later.",
-		})
+		handler.Logger.Error("error retrieving all notes", "operation", "get_all", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve all notes. Please try again later.")
 		return
 	}
 
 	if len(notes) == 0 {
-		c.JSON(http.StatusOK, gin.H{
-			"message": "No notes found",
-			"notes":   notes,
-		})
+		respondOK(c, http.StatusOK, notes, gin.H{"message": "No notes found"})
 		return
 	}
 
-	log.Println("Successfully retrieved all notes")
-	c.JSON(http.StatusOK, notes)
-}
+	var meta gin.H
+	if truncated {
+		c.Header(truncatedHeader, "true")
+		meta = gin.H{"truncated": true}
+	}
 
-func (handler *NoteHandler) GetPaginatedNotesApi(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "10")
-	offsetStr := c.DefaultQuery("offset", "0")
+	handler.Logger.Info("all notes retrieved", "operation", "get_all", "truncated", truncated)
+	respondOK(c, http.StatusOK, listNotes(notes, fields), meta)
+}
 
-	limit, err := strconv.Atoi(limitStr)
+// getNotesAfterID serves the keyset paginated form of GET /notes. last_id is
+// the cursor for the next page, and stays at afterID once there are no more
+// notes.
+func (handler *NoteHandler) getNotesAfterID(c *gin.Context, includeArchived bool, fields projection) {
+	afterID, err := strconv.ParseUint(c.Query("afterID"), 10, 0)
 	if err != nil {
-		log.Printf("Error converting limit URL query: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		handler.Logger.Warn("invalid afterID query", "operation", "get_after_id", "after_id", c.Query("afterID"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid afterID")
 		return
 	}
 
-	offset, err := strconv.Atoi(offsetStr)
+	limit, ok := handler.bindLimit(c, "get_after_id", usecase.MaxCursorLimit)
+	if !ok {
+		return
+	}
+
+	notes, err := handler.Usecase.GetNotesAfterID(middleware.CurrentUserID(c), uint(afterID), limit, includeArchived)
 	if err != nil {
-		log.Printf("Error converting offset URL query: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+		handler.Logger.Error("error retrieving notes after cursor", "operation", "get_after_id", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve notes. Please try again later.")
+		return
+	}
+
+	lastID := uint(afterID)
+	if len(notes) > 0 {
+		lastID = notes[len(notes)-1].ID
+	}
+
+	respondOK(c, http.StatusOK, listNotes(notes, fields), gin.H{"last_id": lastID})
+}
+
+// GetNotesGroupedApi godoc
+// @Summary List the caller's notes grouped by category
+// @Description Returns an object of category to notes, each group newest meeting first. Notes without a category are under "". Groups the same notes as GET /notes, at most MAX_LIST_SIZE, with meta.truncated set when there were more.
+// @Tags notes
+// @Produce json
+// @Param includeArchived query bool false "Include archived notes"
+// @Param limit query int false "Notes per category, newest first. Unset returns every note."
+// @Param fields query string false "Comma separated note fields to return, such as id,title,meeting_date"
+// @Success 200 {object} Response{data=map[string][]domain.Note}
+// @Header 200 {bool} X-Truncated "Set when the notes were cut off at the configured maximum"
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/grouped [get]
+func (handler *NoteHandler) GetNotesGroupedApi(c *gin.Context) {
+	fields, ok := parseFields(c)
+	if !ok {
 		return
 	}
 
-	notes, err := handler.Usecase.GetPaginatedNotes(limit, offset)
+	limit := 0
+	if limitStr, ok := c.GetQuery("limit"); ok {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			handler.Logger.Warn("invalid limit query", "operation", "get_grouped", "limit", limitStr, "error", err)
+			respondError(c, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+	}
+
+	groups, truncated, err := handler.Usecase.GetNotesByCategory(middleware.CurrentUserID(c), c.Query("includeArchived") == "true", limit)
 	if err != nil {
-		log.Printf("Error retrieving all notes (paginated): %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve all notes. Please try again later.",
-		})
+		handler.Logger.Error("error retrieving grouped notes", "operation", "get_grouped", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve notes. Please try again later.")
 		return
 	}
 
-	if len(notes) == 0 {
-		c.JSON(http.StatusOK, gin.H{
-			"message": "No notes found",
-			"notes":   notes,
-		})
+	data := make(map[string]interface{}, len(groups))
+	for category, notes := range groups {
+		data[category] = listNotes(notes, fields)
+	}
+
+	var meta gin.H
+	if truncated {
+		c.Header(truncatedHeader, "true")
+		meta = gin.H{"truncated": true}
+	}
+
+	handler.Logger.Info("notes grouped by category", "operation", "get_grouped", "groups", len(groups), "truncated", truncated)
+	respondOK(c, http.StatusOK, data, meta)
+}
+
+// ndjsonFlushEvery is how many notes ExportNotesNDJSONApi writes between
+// flushes.
+const ndjsonFlushEvery = 100
+
+// ExportNotesNDJSONApi godoc
+// @Summary Stream the caller's notes as NDJSON
+// @Description Writes one JSON note per line in ID order, reading them from the database as they are sent. If the database fails part way through, the stream ends after the last complete line. When export redaction is enabled, each title and content is redacted first.
+// @Tags notes
+// @Produce application/x-ndjson
+// @Param includeArchived query bool false "Include archived notes"
+// @Success 200 {string} string
+// @Failure 500 {object} Response
+// @Router /notes/export.ndjson [get]
+func (handler *NoteHandler) ExportNotesNDJSONApi(c *gin.Context) {
+	includeArchived := c.Query("includeArchived") == "true"
+
+	// The status and content type are only sent with the first note, so a
+	// failure before then can still be reported as a JSON error.
+	started := false
+	start := func() {
+		if !started {
+			c.Header("Content-Type", "application/x-ndjson")
+			c.Status(http.StatusOK)
+			started = true
+		}
+	}
+
+	encoder := json.NewEncoder(c.Writer)
+	sent := 0
+	err := handler.Usecase.StreamNotes(middleware.CurrentUserID(c), includeArchived, func(n domain.Note) error {
+		start()
+		n.Title = handler.Redactor.Redact(n.Title)
+		n.Content = handler.Redactor.Redact(n.Content)
+		if err := encoder.Encode(n); err != nil {
+			return err
+		}
+		sent++
+		if sent%ndjsonFlushEvery == 0 {
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		if !started {
+			handler.Logger.Error("error streaming notes", "operation", "export_ndjson", "error", err)
+			respondError(c, http.StatusInternalServerError, "Failed to export notes. Please try again later.")
+			return
+		}
+		handler.Logger.Error("notes stream ended early", "operation", "export_ndjson", "sent", sent, "error", err)
+		c.Writer.Flush()
 		return
 	}
 
-	log.Println("Successfully retrieved all notes (paginated)")
-	c.JSON(http.StatusOK, notes)
+	start()
+	c.Writer.Flush()
+	handler.Logger.Info("notes streamed", "operation", "export_ndjson", "sent", sent)
 }
 
-func (handler *NoteHandler) GetNoteByIDApi(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+// GetNoteChangesApi godoc
+// @Summary List notes changed since a point in time
+// @Description Returns the caller's notes created, updated, archived or deleted after since, oldest change first, a page at a time, for keeping a copy in sync. Deleted notes are tombstones with deleted set to true. meta.next_since and meta.next_after_id are the since and after_id to send next, and meta.has_more says whether to ask again straight away; leave both out for a full sync.
+// @Tags notes
+// @Produce json
+// @Param since query string false "RFC 3339 timestamp, exclusive"
+// @Param after_id query int false "ID of the last change already seen at since"
+// @Param limit query int false "Page size, 0 for all" default(10)
+// @Success 200 {object} Response{data=[]domain.NoteChange}
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/changes [get]
+func (handler *NoteHandler) GetNoteChangesApi(c *gin.Context) {
+	var since time.Time
+	if sinceStr := c.Query("since"); sinceStr != "" {
+		parsed, err := time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			handler.Logger.Warn("invalid since query", "operation", "get_changes", "since", sinceStr, "error", err)
+			respondError(c, http.StatusBadRequest, "Invalid since, expected an RFC 3339 timestamp")
+			return
+		}
+		since = parsed
+	}
+
+	var afterID uint
+	if afterIDStr := c.Query("after_id"); afterIDStr != "" {
+		parsed, err := strconv.ParseUint(afterIDStr, 10, 0)
+		if err != nil {
+			handler.Logger.Warn("invalid after_id query", "operation", "get_changes", "after_id", afterIDStr, "error", err)
+			respondError(c, http.StatusBadRequest, "Invalid after_id")
+			return
+		}
+		afterID = uint(parsed)
+	}
+
+	page, ok := handler.bindPage(c, "get_changes")
+	if !ok {
+		return
+	}
+	if page.Offset != 0 {
+		respondError(c, http.StatusBadRequest, "changes are paged with since and after_id, not offset")
+		return
+	}
+
+	cursor := domain.ChangeCursor{Since: since, AfterID: afterID}
+	changes, more, err := handler.Usecase.GetNoteChanges(middleware.CurrentUserID(c), cursor, page.Limit)
 	if err != nil {
-		log.Printf("Error converting note ID URL query: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid note ID"})
+		handler.Logger.Error("error retrieving note changes", "operation", "get_changes", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve note changes. Please try again later.")
+		return
+	}
+
+	if len(changes) > 0 {
+		last := changes[len(changes)-1]
+		cursor = domain.ChangeCursor{Since: last.UpdatedAt, AfterID: last.ID}
+	}
+
+	handler.Logger.Info("note changes retrieved", "operation", "get_changes", "count", len(changes))
+	respondOK(c, http.StatusOK, changes, gin.H{
+		"next_since":    cursor.Since.UTC().Format(time.RFC3339Nano),
+		"next_after_id": cursor.AfterID,
+		"has_more":      more,
+	})
+}
+
+// GetPaginatedNotesApi godoc
+// @Summary List notes a page at a time
+// @Tags notes
+// @Produce json
+// @Param limit query int false "Page size, 0 for all" default(10)
+// @Param offset query int false "Number of notes to skip" default(0)
+// @Param includeArchived query bool false "Include archived notes"
+// @Param fields query string false "Comma separated note fields to return, such as id,title,meeting_date"
+// @Success 200 {object} Response{data=[]domain.Note}
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/paginated [get]
+func (handler *NoteHandler) GetPaginatedNotesApi(c *gin.Context) {
+	fields, ok := parseFields(c)
+	if !ok {
 		return
 	}
 
-	note, err := handler.Usecase.GetNoteByID(uint(id))
+	page, ok := handler.bindPage(c, "get_paginated")
+	if !ok {
+		return
+	}
+
+	notes, err := handler.Usecase.GetPaginatedNotes(middleware.CurrentUserID(c), page.Limit, page.Offset, c.Query("includeArchived") == "true")
 	if err != nil {
-		log.Printf("Error retrieving note with ID(%d): %v", id, err)
-		c.JSON(http.StatusNotFound, gin.H{
-			"error": "Note not found",
-		})
+		handler.Logger.Error("error retrieving paginated notes", "operation", "get_paginated", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve all notes. Please try again later.")
+		return
+	}
+
+	if len(notes) == 0 {
+		respondOK(c, http.StatusOK, notes, gin.H{"message": "No notes found"})
 		return
 	}
 
-	log.Println("Successfully retrieved note")
-	c.JSON(http.StatusOK, note)
+	handler.Logger.Info("paginated notes retrieved", "operation", "get_paginated")
+	respondOK(c, http.StatusOK, listNotes(notes, fields), nil)
 }
 
-func (handler *NoteHandler) UpdateNoteApi(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+// GetRecentNotesApi godoc
+// @Summary List the most recently created notes
+// @Tags notes
+// @Produce json
+// @Param limit query int false "Number of notes, at most 50, 0 for the most allowed" default(10)
+// @Param fields query string false "Comma separated note fields to return, such as id,title,meeting_date"
+// @Success 200 {object} Response{data=[]domain.Note}
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/recent [get]
+func (handler *NoteHandler) GetRecentNotesApi(c *gin.Context) {
+	fields, ok := parseFields(c)
+	if !ok {
+		return
+	}
+
+	limit, ok := handler.bindLimit(c, "get_recent", usecase.MaxRecentLimit)
+	if !ok {
+		return
+	}
+
+	notes, err := handler.Usecase.GetRecentNotes(middleware.CurrentUserID(c), limit)
 	if err != nil {
-		log.Printf("Error converting note ID URL query: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid note ID"})
+		handler.Logger.Error("error retrieving recent notes", "operation", "get_recent", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve recent notes. Please try again later.")
 		return
 	}
 
-	var note domain.Note
-	if err := c.ShouldBindJSON(&note); err != nil {
-		log.Printf("Error binding json request body to update note: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid input to update note",
-		})
+	handler.Logger.Info("recent notes retrieved", "operation", "get_recent", "count", len(notes))
+	respondOK(c, http.StatusOK, listNotes(notes, fields), nil)
+}
+
+// GetUpcomingMeetingsApi godoc
+// @Summary List notes for upcoming meetings
+// @Tags notes
+// @Produce json
+// @Param withinHours query int false "Look-ahead window in hours" default(24)
+// @Param fields query string false "Comma separated note fields to return, such as id,title,meeting_date"
+// @Success 200 {object} Response{data=[]domain.Note}
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/upcoming [get]
+func (handler *NoteHandler) GetUpcomingMeetingsApi(c *gin.Context) {
+	fields, ok := parseFields(c)
+	if !ok {
 		return
 	}
 
-	note.ID = uint(id)
-	err = handler.Usecase.UpdateNote(&note)
+	hoursStr := c.DefaultQuery("withinHours", "24")
+
+	hours, err := strconv.Atoi(hoursStr)
+	if err != nil || hours <= 0 {
+		handler.Logger.Warn("invalid withinHours query", "operation", "get_upcoming", "within_hours", hoursStr, "error", err)
+		respondError(c, http.StatusBadRequest, "withinHours must be a positive number of hours")
+		return
+	}
+
+	notes, err := handler.Usecase.GetUpcomingMeetings(middleware.CurrentUserID(c), time.Duration(hours)*time.Hour)
 	if err != nil {
-		if errors.Is(err, usecase.ErrEmptyTitle) {
-			log.Println("Error: Cannot create note without title")
-			c.JSON(http.StatusBadRequest, gin.H{"error": "note title cannot be empty"})
+		if errors.Is(err, usecase.ErrInvalidWindow) {
+			respondError(c, http.StatusBadRequest, "withinHours must be a positive number of hours")
 			return
-		} else if errors.Is(err, usecase.ErrEmptyContent) {
-			log.Println("Error: Cannot create note without content")
-			c.JSON(http.StatusBadRequest, gin.H{"error": "note content cannot be empty"})
+		}
+
+		handler.Logger.Error("error retrieving upcoming meetings", "operation", "get_upcoming", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve upcoming meetings. Please try again later.")
+		return
+	}
+
+	respondOK(c, http.StatusOK, listNotes(notes, fields), nil)
+}
+
+// GetTodaysMeetingsApi godoc
+// @Summary List notes for today's meetings
+// @Description Returns notes whose meeting falls on the current calendar day in the given timezone, earliest first.
+// @Tags notes
+// @Produce json
+// @Param tz query string false "IANA timezone name, such as America/New_York. Defaults to the server's timezone."
+// @Param fields query string false "Comma separated note fields to return, such as id,title,meeting_date"
+// @Success 200 {object} Response{data=[]domain.Note}
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/today [get]
+func (handler *NoteHandler) GetTodaysMeetingsApi(c *gin.Context) {
+	fields, ok := parseFields(c)
+	if !ok {
+		return
+	}
+
+	tz := c.Query("tz")
+
+	notes, err := handler.Usecase.GetTodaysMeetings(middleware.CurrentUserID(c), tz)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidTimezone) {
+			handler.Logger.Warn("invalid tz query", "operation", "get_today", "tz", tz)
+			respondError(c, http.StatusBadRequest, invalidTimezoneMessage)
 			return
 		}
 
-		log.Printf("Error updating note with ID(%d): %v", id, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update note. Please try again later."})
+		handler.Logger.Error("error retrieving today's meetings", "operation", "get_today", "tz", tz, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve today's meetings. Please try again later.")
 		return
 	}
 
-	log.Println("Successfully updated note")
-	c.JSON(http.StatusOK, note)
+	respondOK(c, http.StatusOK, listNotes(notes, fields), nil)
 }
 
-func (handler *NoteHandler) DeleteNoteApi(c *gin.Context) {
+// GetRelatedNotesApi godoc
+// @Summary List notes related to a note
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param limit query int false "Number of notes"
+// @Param fields query string false "Comma separated note fields to return, such as id,title,meeting_date"
+// @Success 200 {object} Response{data=[]domain.Note}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/{id}/related [get]
+func (handler *NoteHandler) GetRelatedNotesApi(c *gin.Context) {
+	fields, ok := parseFields(c)
+	if !ok {
+		return
+	}
+
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		log.Printf("Error converting note ID URL query: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid note ID"})
+		handler.Logger.Warn("invalid note id", "operation", "get_related", "note_id", c.Param("id"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+
+	limitStr := c.DefaultQuery("limit", strconv.Itoa(usecase.DefaultRelatedLimit))
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit <= 0 {
+		handler.Logger.Warn("invalid limit query", "operation", "get_related", "limit", limitStr, "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid limit")
 		return
 	}
 
-	err = handler.Usecase.DeleteNote(uint(id))
+	notes, err := handler.Usecase.GetRelatedNotes(uint(id), middleware.CurrentUserID(c), limit)
 	if err != nil {
 		if errors.Is(err, usecase.ErrNoteNotFound) {
-			log.Println("Error: Cannot retrieve note with ID:", id)
-			c.JSON(http.StatusNotFound, gin.H{"error": "note not found"})
+			respondError(c, http.StatusNotFound, "note not found")
 			return
 		}
 
-		log.Printf("Error deleting note with ID(%d): %v", id, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete note. Please try again later."})
+		handler.Logger.Error("error retrieving related notes", "operation", "get_related", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve related notes. Please try again later.")
 		return
 	}
 
-	log.Println("Successfully deleted note")
-	c.JSON(http.StatusOK, gin.H{"message": "Note deleted"})
+	respondOK(c, http.StatusOK, listNotes(notes, fields), nil)
 }
 
-func (handler *NoteHandler) SearchNotesByKeywordApi(c *gin.Context) {
-	keyword := c.Query("keyword")
+// defaultWordStatsLimit and maxWordStatsLimit bound how many words
+// GetNoteWordStatsApi returns.
+const (
+	defaultWordStatsLimit = 20
+	maxWordStatsLimit     = 200
+)
 
-	if strings.TrimSpace(keyword) == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Search keyword is required"})
+// GetNoteWordStatsApi godoc
+// @Summary List the most frequent words in a note
+// @Description Counts the words of a note's content, lower-cased and without punctuation, leaving out common English stopwords. Returns the most frequent first; a note without countable words gives an empty list.
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param limit query int false "Number of words, at most 200" default(20)
+// @Success 200 {object} Response{data=[]textstats.WordCount}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/{id}/wordstats [get]
+func (handler *NoteHandler) GetNoteWordStatsApi(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handler.Logger.Warn("invalid note id", "operation", "word_stats", "note_id", c.Param("id"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid note ID")
 		return
 	}
 
-	searchResults, err := handler.Usecase.SearchNotesByKeyword(keyword)
-	if err != nil {
-		log.Printf("Error retrieving search results: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve search results. Please try again later.",
-		})
+	limitStr := c.DefaultQuery("limit", strconv.Itoa(defaultWordStatsLimit))
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit <= 0 {
+		handler.Logger.Warn("invalid limit query", "operation", "word_stats", "limit", limitStr, "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid limit")
 		return
 	}
+	if limit > maxWordStatsLimit {
+		limit = maxWordStatsLimit
+	}
 
-	if len(searchResults) == 0 {
-		c.JSON(http.StatusOK, gin.H{
-			"message": "No notes match search criteria",
-			"notes":   searchResults,
-		})
+	note, err := handler.Usecase.GetNoteByID(uint(id), middleware.CurrentUserID(c))
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoteNotFound) {
+			respondError(c, http.StatusNotFound, "note not found")
+			return
+		}
+
+		handler.Logger.Error("error retrieving note for word stats", "operation", "word_stats", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to count words. Please try again later.")
 		return
 	}
 
-	log.Println("Successfully retrieved search results")
-	c.JSON(http.StatusOK, searchResults)
+	respondOK(c, http.StatusOK, textstats.TopWords(note.Content, limit), nil)
 }
 
-func (handler *NoteHandler) FilterNotesApi(c *gin.Context) {
-	keyword := c.Query("keyword")
-	category := c.Query("category")
-	fromDateStr := c.Query("fromDate")
-	toDateStr := c.Query("toDate")
+// GetNoteByIDApi godoc
+// @Summary Get a note
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param If-None-Match header string false "ETag from an earlier response"
+// @Param fields query string false "Comma separated note fields to return, such as id,title,meeting_date"
+// @Success 200 {object} Response{data=domain.Note}
+// @Success 304
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/{id} [get]
+func (handler *NoteHandler) GetNoteByIDApi(c *gin.Context) {
+	fields, ok := parseFields(c)
+	if !ok {
+		return
+	}
 
-	var fromDatePtr, toDatePtr *time.Time
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handler.Logger.Warn("invalid note id", "operation", "get_by_id", "note_id", c.Param("id"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
 
-	if fromDateStr != "" {
-		fromDate, err := time.Parse("2006-01-02", fromDateStr)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid fromDate format. Use YYYY-MM-DD."})
+	note, err := handler.Usecase.GetNoteByID(uint(id), middleware.CurrentUserID(c))
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoteNotFound) {
+			handler.Logger.Warn("note not found", "operation", "get_by_id", "note_id", id)
+			respondError(c, http.StatusNotFound, "Note not found")
 			return
 		}
-		fromDatePtr = &fromDate
+
+		handler.Logger.Error("error retrieving note", "operation", "get_by_id", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve note. Please try again later.")
+		return
 	}
 
-	if toDateStr != "" {
-		toDate, err := time.Parse("2006-01-02", toDateStr)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid toDate format. Use YYYY-MM-DD."})
-			return
-		}
-		toDatePtr = &toDate
+	etag := noteETag(note)
+	c.Header("ETag", etag)
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
 	}
 
-	filter := domain.NoteFilter{
-		Keyword:  keyword,
-		Category: category,
-		FromDate: fromDatePtr,
-		ToDate:   toDatePtr,
+	handler.Logger.Info("note retrieved", "operation", "get_by_id", "note_id", id)
+	respondOK(c, http.StatusOK, fields.note(note), nil)
+}
+
+// GetNoteBySlugApi godoc
+// @Summary Get a note by its slug
+// @Tags notes
+// @Produce json
+// @Param slug path string true "Note slug"
+// @Param fields query string false "Comma separated note fields to return, such as id,title,meeting_date"
+// @Success 200 {object} Response{data=domain.Note}
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/slug/{slug} [get]
+func (handler *NoteHandler) GetNoteBySlugApi(c *gin.Context) {
+	fields, ok := parseFields(c)
+	if !ok {
+		return
 	}
 
-	filterResults, err := handler.Usecase.FilterNotes(filter)
+	slug := c.Param("slug")
+
+	note, err := handler.Usecase.GetNoteBySlug(slug, middleware.CurrentUserID(c))
 	if err != nil {
-		log.Printf("Error filtering search results: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to filter search results. Please try again later.",
-		})
+		if errors.Is(err, usecase.ErrNoteNotFound) {
+			handler.Logger.Warn("note not found", "operation", "get_by_slug", "slug", slug)
+			respondError(c, http.StatusNotFound, "Note not found")
+			return
+		}
+
+		handler.Logger.Error("error retrieving note", "operation", "get_by_slug", "slug", slug, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve note. Please try again later.")
 		return
 	}
 
-	if len(filterResults) == 0 {
-		c.JSON(http.StatusOK, gin.H{
-			"message": "No notes match filter criteria",
-			"notes":   filterResults,
-		})
+	handler.Logger.Info("note retrieved", "operation", "get_by_slug", "note_id", note.ID)
+	respondOK(c, http.StatusOK, fields.note(note), nil)
+}
+
+// GetNotesByIDsApi godoc
+// @Summary Get several notes by ID
+// @Tags notes
+// @Produce json
+// @Param ids query string true "Comma separated note IDs"
+// @Param fields query string false "Comma separated note fields to return, such as id,title,meeting_date"
+// @Success 200 {object} Response{data=[]domain.Note}
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/batch [get]
+func (handler *NoteHandler) GetNotesByIDsApi(c *gin.Context) {
+	fields, ok := parseFields(c)
+	if !ok {
 		return
 	}
 
-	log.Println("Successfully filtered search results")
-	c.JSON(http.StatusOK, filterResults)
+	var ids []uint
+	for _, value := range splitCommaList(c.Query("ids")) {
+		id, err := strconv.ParseUint(value, 10, 0)
+		if err != nil {
+			handler.Logger.Warn("invalid note id", "operation", "get_by_ids", "note_id", value, "error", err)
+			respondError(c, http.StatusBadRequest, "Invalid note ID")
+			return
+		}
+		ids = append(ids, uint(id))
+	}
+
+	notes, err := handler.Usecase.GetNotesByIDs(ids, middleware.CurrentUserID(c))
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoIDs) {
+			respondError(c, http.StatusBadRequest, "at least one note ID is required")
+			return
+		}
+
+		handler.Logger.Error("error retrieving notes by id", "operation", "get_by_ids", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve notes. Please try again later.")
+		return
+	}
+
+	handler.Logger.Info("notes retrieved by id", "operation", "get_by_ids", "count", len(notes))
+	respondOK(c, http.StatusOK, fields.notes(notes), nil)
+}
+
+// UpdateNoteApi godoc
+// @Summary Update a note
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param note body domain.Note true "Updated note"
+// @Param tz query string false "IANA timezone a YYYY-MM-DD meeting_date is read in. Defaults to the server's timezone."
+// @Success 200 {object} Response{data=domain.Note}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 409 {object} Response
+// @Failure 413 {object} Response
+// @Failure 422 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/{id} [put]
+func (handler *NoteHandler) UpdateNoteApi(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handler.Logger.Warn("invalid note id", "operation", "update", "note_id", c.Param("id"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+
+	var note domain.Note
+	if !handler.bindNote(c, "update", &note, "Invalid input to update note") {
+		return
+	}
+
+	note.ID = uint(id)
+	note.OwnerID = middleware.CurrentUserID(c)
+	err = handler.Usecase.UpdateNote(&note)
+	if err != nil {
+		if body, ok := blockedContentError(err); ok {
+			handler.Logger.Warn("note has blocked content", "operation", "update", "note_id", id)
+			respondFieldErrors(c, http.StatusUnprocessableEntity, body.Message, body.Fields)
+			return
+		}
+
+		if fields, ok := validationErrorFields(err); ok {
+			handler.Logger.Warn("note failed validation", "operation", "update", "note_id", id, "error", err)
+			respondFieldErrors(c, http.StatusBadRequest, "note failed validation", fields)
+			return
+		}
+
+		if errors.Is(err, usecase.ErrEmptyTitle) {
+			handler.Logger.Warn("cannot update note without title", "operation", "update", "note_id", id)
+			respondError(c, http.StatusBadRequest, "note title cannot be empty")
+			return
+		} else if errors.Is(err, usecase.ErrEmptyContent) {
+			handler.Logger.Warn("cannot update note without content", "operation", "update", "note_id", id)
+			respondError(c, http.StatusBadRequest, "note content cannot be empty")
+			return
+		} else if errors.Is(err, usecase.ErrWeekendMeetingDate) {
+			handler.Logger.Warn("meeting date falls on a weekend", "operation", "update", "note_id", id)
+			respondError(c, http.StatusBadRequest, "meeting date cannot fall on a weekend")
+			return
+		} else if errors.Is(err, usecase.ErrInvalidMeetingDate) {
+			handler.Logger.Warn("cannot update note without meeting date", "operation", "update", "note_id", id)
+			respondError(c, http.StatusBadRequest, "meeting date is required")
+			return
+		} else if errors.Is(err, usecase.ErrNoteNotFound) {
+			handler.Logger.Warn("note to update not found", "operation", "update", "note_id", id)
+			respondError(c, http.StatusNotFound, "note not found")
+			return
+		} else if errors.Is(err, usecase.ErrStaleUpdate) {
+			handler.Logger.Warn("stale note update", "operation", "update", "note_id", id, "version", note.Version)
+			respondError(c, http.StatusConflict, "note has been modified since it was read, reload and try again")
+			return
+		} else if errors.Is(err, usecase.ErrDuplicateNote) {
+			handler.Logger.Warn("duplicate note", "operation", "update", "note_id", id)
+			respondError(c, http.StatusConflict, "a note with this title and meeting date already exists")
+			return
+		} else if errors.Is(err, usecase.ErrCategoryQuotaExceeded) {
+			handler.Logger.Warn("category quota reached", "operation", "update", "note_id", id)
+			respondError(c, http.StatusUnprocessableEntity, "category has reached its note quota")
+			return
+		}
+
+		handler.Logger.Error("error updating note", "operation", "update", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to update note. Please try again later.")
+		return
+	}
+
+	handler.Logger.Info("note updated", "operation", "update", "note_id", id)
+	respondOK(c, http.StatusOK, note, nil)
+}
+
+type archiveNoteRequest struct {
+	Archived *bool `json:"archived"`
+}
+
+// ArchiveNoteApi archives a note, or unarchives it when the body is
+// {"archived": false}. An empty body archives.
+//
+// @Summary Archive or unarchive a note
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param request body archiveNoteRequest false "Archived state, defaults to true"
+// @Success 200 {object} Response{data=map[string]any}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/{id}/archive [post]
+func (handler *NoteHandler) ArchiveNoteApi(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handler.Logger.Warn("invalid note id", "operation", "archive", "note_id", c.Param("id"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+
+	var req archiveNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		handler.Logger.Warn("invalid request body", "operation", "archive", "note_id", id, "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid input to archive note")
+		return
+	}
+
+	archived := true
+	if req.Archived != nil {
+		archived = *req.Archived
+	}
+
+	err = handler.Usecase.ArchiveNote(uint(id), middleware.CurrentUserID(c), archived)
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoteNotFound) {
+			respondError(c, http.StatusNotFound, "note not found")
+			return
+		}
+
+		handler.Logger.Error("error archiving note", "operation", "archive", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to archive note. Please try again later.")
+		return
+	}
+
+	respondOK(c, http.StatusOK, gin.H{"id": id, "archived": archived}, nil)
+}
+
+// ListRevisionsApi godoc
+// @Summary List a note's revisions
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Success 200 {object} Response{data=[]domain.NoteRevision}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/{id}/revisions [get]
+func (handler *NoteHandler) ListRevisionsApi(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handler.Logger.Warn("invalid note id", "operation", "list_revisions", "note_id", c.Param("id"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+
+	revisions, err := handler.Usecase.ListRevisions(uint(id), middleware.CurrentUserID(c))
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoteNotFound) {
+			respondError(c, http.StatusNotFound, "note not found")
+			return
+		}
+
+		handler.Logger.Error("error listing revisions", "operation", "list_revisions", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve revisions. Please try again later.")
+		return
+	}
+
+	respondOK(c, http.StatusOK, revisions, nil)
+}
+
+// ListAuditLogApi godoc
+// @Summary List a note's audit trail
+// @Description Returns who created, updated or deleted the note and which fields changed, oldest first.
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Success 200 {object} Response{data=[]domain.AuditLog}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/{id}/audit [get]
+func (handler *NoteHandler) ListAuditLogApi(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handler.Logger.Warn("invalid note id", "operation", "list_audit", "note_id", c.Param("id"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+
+	entries, err := handler.Usecase.ListAuditLog(uint(id), middleware.CurrentUserID(c))
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoteNotFound) {
+			respondError(c, http.StatusNotFound, "note not found")
+			return
+		}
+
+		handler.Logger.Error("error listing audit log", "operation", "list_audit", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve audit log. Please try again later.")
+		return
+	}
+
+	respondOK(c, http.StatusOK, entries, nil)
+}
+
+// AddAttachmentApi godoc
+// @Summary Attach a file to a note
+// @Description Records the metadata and URL of a file; the file itself is not uploaded. Validation failures return a map of field to message.
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param attachment body domain.Attachment true "Attachment metadata"
+// @Success 201 {object} Response{data=domain.Attachment}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 413 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/{id}/attachments [post]
+func (handler *NoteHandler) AddAttachmentApi(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handler.Logger.Warn("invalid note id", "operation", "add_attachment", "note_id", c.Param("id"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+
+	var attachment domain.Attachment
+	if !handler.bindJSON(c, "add_attachment", &attachment, "Invalid input to add attachment") {
+		return
+	}
+
+	err = handler.Usecase.AddAttachment(uint(id), middleware.CurrentUserID(c), &attachment)
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoteNotFound) {
+			respondError(c, http.StatusNotFound, "note not found")
+			return
+		}
+
+		if fields, ok := validationErrorFields(err); ok {
+			handler.Logger.Warn("attachment failed validation", "operation", "add_attachment", "note_id", id, "error", err)
+			respondFieldErrors(c, http.StatusBadRequest, "note failed validation", fields)
+			return
+		}
+
+		handler.Logger.Error("error adding attachment", "operation", "add_attachment", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to add attachment. Please try again later.")
+		return
+	}
+
+	respondOK(c, http.StatusCreated, attachment, nil)
+}
+
+// ListAttachmentsApi godoc
+// @Summary List a note's attachments
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Success 200 {object} Response{data=[]domain.Attachment}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/{id}/attachments [get]
+func (handler *NoteHandler) ListAttachmentsApi(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handler.Logger.Warn("invalid note id", "operation", "list_attachments", "note_id", c.Param("id"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+
+	attachments, err := handler.Usecase.ListAttachments(uint(id), middleware.CurrentUserID(c))
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoteNotFound) {
+			respondError(c, http.StatusNotFound, "note not found")
+			return
+		}
+
+		handler.Logger.Error("error listing attachments", "operation", "list_attachments", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve attachments. Please try again later.")
+		return
+	}
+
+	respondOK(c, http.StatusOK, attachments, nil)
+}
+
+// AddNoteLinkApi godoc
+// @Summary Link a note to another note
+// @Description Links the note to to_note_id, which the caller must be able to read. Relation is followup or related, and defaults to related. The response carries the linked note with its summary instead of its content. Validation failures return a map of field to message.
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param link body domain.NoteLink true "Link to add"
+// @Success 201 {object} Response{data=domain.LinkedNote}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 409 {object} Response
+// @Failure 413 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/{id}/links [post]
+func (handler *NoteHandler) AddNoteLinkApi(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handler.Logger.Warn("invalid note id", "operation", "add_link", "note_id", c.Param("id"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+
+	var link domain.NoteLink
+	if !handler.bindJSON(c, "add_link", &link, "Invalid input to add link") {
+		return
+	}
+
+	linked, err := handler.Usecase.AddNoteLink(uint(id), middleware.CurrentUserID(c), &link)
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoteNotFound) {
+			respondError(c, http.StatusNotFound, "note not found")
+			return
+		}
+
+		if errors.Is(err, usecase.ErrDuplicateLink) {
+			handler.Logger.Warn("duplicate link", "operation", "add_link", "note_id", id, "to_note_id", link.ToNoteID)
+			respondError(c, http.StatusConflict, "note already links to this note")
+			return
+		}
+
+		if fields, ok := validationErrorFields(err); ok {
+			handler.Logger.Warn("link failed validation", "operation", "add_link", "note_id", id, "error", err)
+			respondFieldErrors(c, http.StatusBadRequest, "link failed validation", fields)
+			return
+		}
+
+		handler.Logger.Error("error adding link", "operation", "add_link", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to add link. Please try again later.")
+		return
+	}
+
+	respondOK(c, http.StatusCreated, listLinks([]domain.LinkedNote{linked})[0], nil)
+}
+
+// ListNoteLinksApi godoc
+// @Summary List a note's links
+// @Description Lists the links from and to the note, oldest first, each with the note at its other end. Linked notes carry their summary instead of their content, and notes the caller can't read are left out.
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Success 200 {object} Response{data=[]domain.LinkedNote}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/{id}/links [get]
+func (handler *NoteHandler) ListNoteLinksApi(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handler.Logger.Warn("invalid note id", "operation", "list_links", "note_id", c.Param("id"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+
+	links, err := handler.Usecase.ListNoteLinks(uint(id), middleware.CurrentUserID(c))
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoteNotFound) {
+			respondError(c, http.StatusNotFound, "note not found")
+			return
+		}
+
+		handler.Logger.Error("error listing links", "operation", "list_links", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve links. Please try again later.")
+		return
+	}
+
+	respondOK(c, http.StatusOK, listLinks(links), nil)
+}
+
+// DiffRevisionsApi returns a line-based diff of a note's content between the
+// from and to revision versions.
+//
+// @Summary Diff two revisions of a note
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Param from query int true "Revision version to diff from"
+// @Param to query int true "Revision version to diff to"
+// @Success 200 {object} Response{data=map[string]any}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/{id}/diff [get]
+func (handler *NoteHandler) DiffRevisionsApi(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handler.Logger.Warn("invalid note id", "operation", "diff", "note_id", c.Param("id"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+
+	from, fromErr := strconv.Atoi(c.Query("from"))
+	to, toErr := strconv.Atoi(c.Query("to"))
+	if fromErr != nil || toErr != nil {
+		handler.Logger.Warn("invalid revision versions", "operation", "diff", "note_id", id, "from", c.Query("from"), "to", c.Query("to"))
+		respondError(c, http.StatusBadRequest, "from and to must be revision version numbers")
+		return
+	}
+
+	text, err := handler.Usecase.DiffRevisions(uint(id), middleware.CurrentUserID(c), from, to)
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoteNotFound) {
+			respondError(c, http.StatusNotFound, "note not found")
+			return
+		} else if errors.Is(err, usecase.ErrRevisionNotFound) {
+			respondError(c, http.StatusNotFound, "revision not found")
+			return
+		}
+
+		handler.Logger.Error("error diffing revisions", "operation", "diff", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to diff revisions. Please try again later.")
+		return
+	}
+
+	respondOK(c, http.StatusOK, gin.H{"note_id": id, "from": from, "to": to, "diff": text}, nil)
+}
+
+// ExportNoteApi godoc
+// @Summary Export a note as a file
+// @Description Renders a note as a downloadable PDF, Markdown or plain text file. Markdown notes are exported as written; plain text notes are escaped so they read the same. The txt format is the title, a blank line and the raw content. When export redaction is enabled, the title and content are redacted first.
+// @Tags notes
+// @Produce application/pdf,text/markdown,text/plain
+// @Param id path int true "Note ID"
+// @Param format query string false "Export format" Enums(pdf, markdown, txt) default(pdf)
+// @Param line_endings query string false "Line endings of a txt export" Enums(lf, crlf) default(lf)
+// @Success 200 {file} file
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/{id}/export [get]
+func (handler *NoteHandler) ExportNoteApi(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handler.Logger.Warn("invalid note id", "operation", "export", "note_id", c.Param("id"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+
+	format := c.DefaultQuery("format", "pdf")
+	if format != "pdf" && format != "markdown" && format != "txt" {
+		respondError(c, http.StatusBadRequest, "Unsupported export format. Use pdf, markdown or txt.")
+		return
+	}
+
+	lineEndings := c.DefaultQuery("line_endings", "lf")
+	if lineEndings != "lf" && lineEndings != "crlf" {
+		respondError(c, http.StatusBadRequest, "Unsupported line endings. Use lf or crlf.")
+		return
+	}
+
+	note, err := handler.Usecase.GetNoteByID(uint(id), middleware.CurrentUserID(c))
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoteNotFound) {
+			respondError(c, http.StatusNotFound, "note not found")
+			return
+		}
+
+		handler.Logger.Error("error retrieving note to export", "operation", "export", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to export note. Please try again later.")
+		return
+	}
+
+	note.Title = handler.Redactor.Redact(note.Title)
+	note.Content = handler.Redactor.Redact(note.Content)
+
+	switch format {
+	case "markdown":
+		handler.Logger.Info("note exported", "operation", "export", "note_id", id, "format", format)
+		setAttachment(c, export.Filename(note, "md"))
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", export.NoteToMarkdown(note))
+		return
+	case "txt":
+		handler.Logger.Info("note exported", "operation", "export", "note_id", id, "format", format)
+		setAttachment(c, export.Filename(note, "txt"))
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", export.NoteToText(note, lineEndings == "crlf"))
+		return
+	}
+
+	pdf, err := export.NoteToPDF(note)
+	if err != nil {
+		handler.Logger.Error("error rendering note pdf", "operation", "export", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to export note. Please try again later.")
+		return
+	}
+
+	handler.Logger.Info("note exported", "operation", "export", "note_id", id, "format", format)
+	setAttachment(c, export.Filename(note, "pdf"))
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// setAttachment marks the response as a download saved as filename.
+func setAttachment(c *gin.Context, filename string) {
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+}
+
+// RenderNoteApi godoc
+// @Summary Render a note as HTML
+// @Description Returns the note content as an HTML fragment. Markdown notes are rendered and sanitized; plain text notes are escaped and wrapped in a pre element.
+// @Tags notes
+// @Produce html
+// @Param id path int true "Note ID"
+// @Success 200 {string} string
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/{id}/render [get]
+func (handler *NoteHandler) RenderNoteApi(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handler.Logger.Warn("invalid note id", "operation", "render", "note_id", c.Param("id"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+
+	note, err := handler.Usecase.GetNoteByID(uint(id), middleware.CurrentUserID(c))
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoteNotFound) {
+			respondError(c, http.StatusNotFound, "note not found")
+			return
+		}
+
+		handler.Logger.Error("error retrieving note to render", "operation", "render", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to render note. Please try again later.")
+		return
+	}
+
+	body, err := render.NoteToHTML(note)
+	if err != nil {
+		handler.Logger.Error("error rendering note html", "operation", "render", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to render note. Please try again later.")
+		return
+	}
+
+	handler.Logger.Info("note rendered", "operation", "render", "note_id", id, "format", note.Format)
+	c.Data(http.StatusOK, "text/html; charset=utf-8", body)
+}
+
+// DeleteNoteApi godoc
+// @Summary Move a note to the trash
+// @Tags notes
+// @Produce json
+// @Param id path int true "Note ID"
+// @Success 200 {object} Response{data=map[string]string}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/{id} [delete]
+func (handler *NoteHandler) DeleteNoteApi(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		handler.Logger.Warn("invalid note id", "operation", "delete", "note_id", c.Param("id"), "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid note ID")
+		return
+	}
+
+	err = handler.Usecase.DeleteNote(uint(id), middleware.CurrentUserID(c))
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoteNotFound) {
+			handler.Logger.Warn("note to delete not found", "operation", "delete", "note_id", id)
+			respondError(c, http.StatusNotFound, "note not found")
+			return
+		}
+
+		handler.Logger.Error("error deleting note", "operation", "delete", "note_id", id, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to delete note. Please try again later.")
+		return
+	}
+
+	handler.Logger.Info("note deleted", "operation", "delete", "note_id", id)
+	respondOK(c, http.StatusOK, gin.H{"message": "Note deleted"}, nil)
+}
+
+// SearchNotesByKeywordApi godoc
+// @Summary Search notes by keyword
+// @Tags notes
+// @Produce json
+// @Param keyword query string true "Search keyword"
+// @Param fromDate query string false "Earliest meeting date (YYYY-MM-DD)"
+// @Param toDate query string false "Latest meeting date (YYYY-MM-DD)"
+// @Param tz query string false "IANA timezone fromDate and toDate are read in. Defaults to the server's timezone."
+// @Param limit query int false "Page size, 0 for all" default(10)
+// @Param offset query int false "Number of results to skip" default(0)
+// @Param fields query string false "Comma separated note fields to return, such as id,title,meeting_date"
+// @Success 200 {object} Response{data=[]domain.SearchResult}
+// @Header 200 {int} X-Total-Count "Matches across all pages"
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/search [get]
+func (handler *NoteHandler) SearchNotesByKeywordApi(c *gin.Context) {
+	keyword := c.Query("keyword")
+
+	if strings.TrimSpace(keyword) == "" {
+		respondError(c, http.StatusBadRequest, "Search keyword is required")
+		return
+	}
+
+	loc, ok := handler.requestLocation(c, "search")
+	if !ok {
+		return
+	}
+	fromDate, ok := parseDateQuery(c, "fromDate", loc)
+	if !ok {
+		return
+	}
+	toDate, ok := parseDateQuery(c, "toDate", loc)
+	if !ok {
+		return
+	}
+
+	fields, ok := parseFields(c)
+	if !ok {
+		return
+	}
+
+	page, ok := handler.bindPage(c, "search")
+	if !ok {
+		return
+	}
+
+	searchResults, total, err := handler.Usecase.SearchNotesByKeyword(middleware.CurrentUserID(c), keyword, domain.DateRange{From: fromDate, To: toDate}, page)
+	if err != nil {
+		if message, ok := filterErrorMessage(err); ok {
+			respondError(c, http.StatusBadRequest, message)
+			return
+		}
+
+		handler.Logger.Error("error retrieving search results", "operation", "search", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve search results. Please try again later.")
+		return
+	}
+
+	c.Header(totalCountHeader, strconv.FormatInt(total, 10))
+
+	if len(searchResults) == 0 {
+		respondOK(c, http.StatusOK, searchResults, gin.H{"message": "No notes match search criteria", "total": total})
+		return
+	}
+
+	handler.Logger.Info("search results retrieved", "operation", "search")
+	respondOK(c, http.StatusOK, listSearchResults(searchResults, fields), gin.H{"total": total})
+}
+
+// FilterNotesApi godoc
+// @Summary Filter notes
+// @Description Query params build a domain.NoteFilter. When preset is set, the saved filter is used and any other params override it.
+// @Tags notes
+// @Produce json
+// @Param keyword query []string false "Keywords, repeated or comma separated" collectionFormat(multi)
+// @Param match query string false "all to require every keyword, any to require one" Enums(any, all) default(any)
+// @Param category query string false "Category"
+// @Param categories query string false "Comma separated categories"
+// @Param fromDate query string false "Earliest meeting date, YYYY-MM-DD"
+// @Param toDate query string false "Latest meeting date, YYYY-MM-DD"
+// @Param year query int false "Only meetings in this year; not combined with fromDate or toDate"
+// @Param month query int false "Only meetings in this month, 1-12, of year"
+// @Param tz query string false "IANA timezone YYYY-MM-DD dates, year and month are read in. Defaults to the server's timezone."
+// @Param createdFrom query string false "Earliest creation date, YYYY-MM-DD"
+// @Param createdTo query string false "Latest creation date, YYYY-MM-DD"
+// @Param includeArchived query bool false "Include archived notes"
+// @Param uncategorized query bool false "Only notes without a category"
+// @Param maxContentLength query int false "Only notes with content of at most this many characters"
+// @Param mode query string false "remote for notes with a meeting URL, in_person for notes without" Enums(remote, in_person)
+// @Param priority query string false "Only notes of this priority" Enums(low, normal, high, critical)
+// @Param sort query string false "Field to order by" Enums(meeting_date, created_at, updated_at, title, priority) default(meeting_date)
+// @Param order query string false "Sort direction; defaults to asc for title and desc otherwise, so the highest priority comes first" Enums(asc, desc)
+// @Param preset query string false "Name of a saved filter preset"
+// @Param limit query int false "Page size, 0 for all" default(10)
+// @Param offset query int false "Number of results to skip" default(0)
+// @Param fields query string false "Comma separated note fields to return, such as id,title,meeting_date"
+// @Success 200 {object} Response{data=[]domain.Note}
+// @Header 200 {int} X-Total-Count "Matches across all pages"
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/filter [get]
+func (handler *NoteHandler) FilterNotesApi(c *gin.Context) {
+	filter, ok := handler.bindFilter(c)
+	if !ok {
+		return
+	}
+
+	fields, ok := parseFields(c)
+	if !ok {
+		return
+	}
+
+	page, ok := handler.bindPage(c, "filter")
+	if !ok {
+		return
+	}
+
+	filterResults, total, err := handler.Usecase.FilterNotes(filter, page)
+	if err != nil {
+		if message, ok := filterErrorMessage(err); ok {
+			respondError(c, http.StatusBadRequest, message)
+			return
+		}
+
+		handler.Logger.Error("error filtering notes", "operation", "filter", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to filter search results. Please try again later.")
+		return
+	}
+
+	c.Header(totalCountHeader, strconv.FormatInt(total, 10))
+
+	if len(filterResults) == 0 {
+		respondOK(c, http.StatusOK, filterResults, gin.H{"message": "No notes match filter criteria", "total": total})
+		return
+	}
+
+	handler.Logger.Info("filter results retrieved", "operation", "filter")
+	respondOK(c, http.StatusOK, listNotes(filterResults, fields), gin.H{"total": total})
+}
+
+// CountFilteredNotesApi godoc
+// @Summary Count the notes matching a filter
+// @Description Takes the same query params as GET /notes/filter, without paging, and returns how many notes match.
+// @Tags notes
+// @Produce json
+// @Param keyword query []string false "Keywords, repeated or comma separated" collectionFormat(multi)
+// @Param match query string false "all to require every keyword, any to require one" Enums(any, all) default(any)
+// @Param category query string false "Category"
+// @Param categories query string false "Comma separated categories"
+// @Param fromDate query string false "Earliest meeting date, YYYY-MM-DD"
+// @Param toDate query string false "Latest meeting date, YYYY-MM-DD"
+// @Param year query int false "Only meetings in this year; not combined with fromDate or toDate"
+// @Param month query int false "Only meetings in this month, 1-12, of year"
+// @Param tz query string false "IANA timezone YYYY-MM-DD dates, year and month are read in. Defaults to the server's timezone."
+// @Param createdFrom query string false "Earliest creation date, YYYY-MM-DD"
+// @Param createdTo query string false "Latest creation date, YYYY-MM-DD"
+// @Param includeArchived query bool false "Include archived notes"
+// @Param uncategorized query bool false "Only notes without a category"
+// @Param maxContentLength query int false "Only notes with content of at most this many characters"
+// @Param mode query string false "remote for notes with a meeting URL, in_person for notes without" Enums(remote, in_person)
+// @Param priority query string false "Only notes of this priority" Enums(low, normal, high, critical)
+// @Param preset query string false "Name of a saved filter preset"
+// @Success 200 {object} Response{data=map[string]int64}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/filter/count [get]
+func (handler *NoteHandler) CountFilteredNotesApi(c *gin.Context) {
+	filter, ok := handler.bindFilter(c)
+	if !ok {
+		return
+	}
+
+	count, err := handler.Usecase.CountFilteredNotes(filter)
+	if err != nil {
+		if message, ok := filterErrorMessage(err); ok {
+			respondError(c, http.StatusBadRequest, message)
+			return
+		}
+
+		handler.Logger.Error("error counting filtered notes", "operation", "filter_count", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to count notes. Please try again later.")
+		return
+	}
+
+	respondOK(c, http.StatusOK, gin.H{"count": count}, nil)
+}
+
+// bindFilter builds a domain.NoteFilter from the query params shared by the
+// filter endpoints, starting from the named preset if there is one. It writes
+// an error response and returns false when a param is malformed or the preset
+// can't be loaded.
+func (handler *NoteHandler) bindFilter(c *gin.Context) (domain.NoteFilter, bool) {
+	var keywords []string
+	for _, value := range c.QueryArray("keyword") {
+		keywords = append(keywords, splitCommaList(value)...)
+	}
+	categories := splitCommaList(c.Query("categories"))
+	if category := strings.TrimSpace(c.Query("category")); category != "" {
+		categories = append(categories, category)
+	}
+	loc, ok := handler.requestLocation(c, "filter")
+	if !ok {
+		return domain.NoteFilter{}, false
+	}
+	fromDate, ok := parseDateQuery(c, "fromDate", loc)
+	if !ok {
+		return domain.NoteFilter{}, false
+	}
+	toDate, ok := parseDateQuery(c, "toDate", loc)
+	if !ok {
+		return domain.NoteFilter{}, false
+	}
+	createdFrom, ok := parseDateQuery(c, "createdFrom", loc)
+	if !ok {
+		return domain.NoteFilter{}, false
+	}
+	createdTo, ok := parseDateQuery(c, "createdTo", loc)
+	if !ok {
+		return domain.NoteFilter{}, false
+	}
+	year, ok := parseIntQuery(c, "year")
+	if !ok {
+		return domain.NoteFilter{}, false
+	}
+	month, ok := parseIntQuery(c, "month")
+	if !ok {
+		return domain.NoteFilter{}, false
+	}
+	maxContentLength := 0
+	if value := c.Query("maxContentLength"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			respondError(c, http.StatusBadRequest, "maxContentLength must be a positive number")
+			return domain.NoteFilter{}, false
+		}
+		maxContentLength = n
+	}
+
+	filter := domain.NoteFilter{
+		Keywords:          keywords,
+		KeywordMatch:      c.Query("match"),
+		Categories:        categories,
+		FromDate:          fromDate,
+		ToDate:            toDate,
+		Year:              year,
+		Month:             month,
+		Timezone:          strings.TrimSpace(c.Query("tz")),
+		CreatedFrom:       createdFrom,
+		CreatedTo:         createdTo,
+		IncludeArchived:   c.Query("includeArchived") == "true",
+		UncategorizedOnly: c.Query("uncategorized") == "true",
+		MaxContentLength:  maxContentLength,
+		MeetingMode:       c.Query("mode"),
+		Priority:          c.Query("priority"),
+		Sort:              c.Query("sort"),
+		Order:             c.Query("order"),
+	}
+
+	if presetName := c.Query("preset"); presetName != "" {
+		if handler.Presets == nil {
+			respondError(c, http.StatusNotFound, "preset not found")
+			return domain.NoteFilter{}, false
+		}
+
+		preset, err := handler.Presets.GetPreset(middleware.CurrentUserID(c), presetName)
+		if err != nil {
+			if errors.Is(err, usecase.ErrPresetNotFound) {
+				handler.Logger.Warn("unknown filter preset", "operation", "filter", "preset", presetName)
+				respondError(c, http.StatusNotFound, "preset not found")
+				return domain.NoteFilter{}, false
+			}
+			handler.Logger.Error("error loading filter preset", "operation", "filter", "preset", presetName, "error", err)
+			respondError(c, http.StatusInternalServerError, "Failed to load preset. Please try again later.")
+			return domain.NoteFilter{}, false
+		}
+
+		filter = mergeFilter(preset.Filter, filter)
+	}
+
+	filter.ViewerID = middleware.CurrentUserID(c)
+	return filter, true
+}
+
+// filterErrorMessage maps the usecase errors for a bad filter or page to
+// the message of a 400 response.
+func filterErrorMessage(err error) (string, bool) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidDateRange):
+		return "fromDate must be before toDate", true
+	case errors.Is(err, usecase.ErrInvalidCreatedRange):
+		return "createdFrom must be before createdTo", true
+	case errors.Is(err, usecase.ErrInvalidPage):
+		return "limit and offset cannot be negative", true
+	case errors.Is(err, usecase.ErrInvalidYear), errors.Is(err, usecase.ErrInvalidMonth),
+		errors.Is(err, usecase.ErrMonthWithoutYear), errors.Is(err, usecase.ErrYearWithDateRange),
+		errors.Is(err, usecase.ErrInvalidKeywordMatch), errors.Is(err, usecase.ErrInvalidMeetingMode),
+		errors.Is(err, usecase.ErrInvalidPriority),
+		errors.Is(err, usecase.ErrInvalidSort), errors.Is(err, usecase.ErrInvalidSortOrder):
+		return err.Error(), true
+	case errors.Is(err, usecase.ErrInvalidTimezone):
+		return invalidTimezoneMessage, true
+	}
+	return "", false
+}
+
+// RestoreNotesApi godoc
+// @Summary Restore the caller's notes from the trash
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param request body restoreNotesRequest true "IDs to restore"
+// @Success 200 {object} Response{data=map[string]int}
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/trash/restore [post]
+func (handler *NoteHandler) RestoreNotesApi(c *gin.Context) {
+	var req restoreNotesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.Logger.Warn("invalid request body", "operation", "restore", "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid input to restore notes")
+		return
+	}
+
+	restored, err := handler.Usecase.RestoreNotes(middleware.CurrentUserID(c), req.IDs)
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoIDs) {
+			respondError(c, http.StatusBadRequest, "at least one note ID is required")
+			return
+		}
+
+		handler.Logger.Error("error restoring notes", "operation", "restore", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to restore notes. Please try again later.")
+		return
+	}
+
+	handler.Logger.Info("notes restored", "operation", "restore", "restored", restored)
+	respondOK(c, http.StatusOK, gin.H{"restored": restored}, nil)
+}
+
+// RestoreDeletedNotesApi godoc
+// @Summary Restore every note of the caller deleted after a time
+// @Description Takes every note of the caller moved to the trash after deletedAfter back out, to undo an accidental bulk delete. Notes deleted earlier stay in the trash.
+// @Tags notes
+// @Produce json
+// @Param deletedAfter query string true "RFC 3339 timestamp, e.g. 2025-06-01T09:30:00Z"
+// @Success 200 {object} Response{data=map[string]int}
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/restore [post]
+func (handler *NoteHandler) RestoreDeletedNotesApi(c *gin.Context) {
+	value := c.Query("deletedAfter")
+	if value == "" {
+		respondError(c, http.StatusBadRequest, "deletedAfter is required")
+		return
+	}
+	since, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		handler.Logger.Warn("invalid deletedAfter query", "operation", "restore_since", "deleted_after", value)
+		respondError(c, http.StatusBadRequest, "Invalid deletedAfter format, use RFC 3339 such as 2025-06-01T09:30:00Z")
+		return
+	}
+
+	restored, err := handler.Usecase.RestoreDeletedSince(middleware.CurrentUserID(c), since)
+	if err != nil {
+		if errors.Is(err, usecase.ErrFutureWindow) {
+			respondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		handler.Logger.Error("error restoring deleted notes", "operation", "restore_since", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to restore notes. Please try again later.")
+		return
+	}
+
+	handler.Logger.Info("deleted notes restored", "operation", "restore_since", "restored", restored)
+	respondOK(c, http.StatusOK, gin.H{"restored": restored}, nil)
+}
+
+// RenameCategoryApi godoc
+// @Summary Rename a category on the caller's notes
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Param request body renameCategoryRequest true "Old and new category names"
+// @Success 200 {object} Response{data=map[string]int}
+// @Failure 400 {object} Response
+// @Failure 422 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/category/rename [post]
+func (handler *NoteHandler) RenameCategoryApi(c *gin.Context) {
+	var req renameCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.Logger.Warn("invalid request body", "operation", "rename_category", "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid input to rename category")
+		return
+	}
+
+	renamed, err := handler.Usecase.RenameCategory(middleware.CurrentUserID(c), req.From, req.To)
+	if err != nil {
+		if errors.Is(err, usecase.ErrEmptyCategory) || errors.Is(err, usecase.ErrSameCategory) || errors.Is(err, usecase.ErrInvalidCategory) {
+			respondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, usecase.ErrCategoryQuotaExceeded) {
+			handler.Logger.Warn("category quota reached", "operation", "rename_category", "to", req.To)
+			respondError(c, http.StatusUnprocessableEntity, "category has reached its note quota")
+			return
+		}
+
+		handler.Logger.Error("error renaming category", "operation", "rename_category", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to rename category. Please try again later.")
+		return
+	}
+
+	handler.Logger.Info("category renamed", "operation", "rename_category", "renamed", renamed)
+	respondOK(c, http.StatusOK, gin.H{"renamed": renamed}, nil)
+}
+
+// GetNoteExtremesApi godoc
+// @Summary Get the longest and shortest notes
+// @Tags notes
+// @Produce json
+// @Success 200 {object} Response{data=domain.NoteExtremes}
+// @Failure 500 {object} Response
+// @Router /notes/extremes [get]
+func (handler *NoteHandler) GetNoteExtremesApi(c *gin.Context) {
+	extremes, err := handler.Usecase.GetNoteExtremes(middleware.CurrentUserID(c))
+	if err != nil {
+		handler.Logger.Error("error retrieving note extremes", "operation", "extremes", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve longest and shortest notes. Please try again later.")
+		return
+	}
+
+	handler.Logger.Info("note extremes retrieved", "operation", "extremes")
+	respondOK(c, http.StatusOK, extremes, nil)
+}
+
+// GetCategoriesApi godoc
+// @Summary List categories with note counts
+// @Description When notes are limited to a set of categories, meta.allowed lists them in their canonical spelling.
+// @Tags notes
+// @Produce json
+// @Success 200 {object} Response{data=[]domain.CategoryCount}
+// @Failure 500 {object} Response
+// @Router /notes/categories [get]
+func (handler *NoteHandler) GetCategoriesApi(c *gin.Context) {
+	categories, err := handler.Usecase.GetCategories(middleware.CurrentUserID(c))
+	if err != nil {
+		handler.Logger.Error("error retrieving categories", "operation", "categories", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve categories. Please try again later.")
+		return
+	}
+
+	if categories == nil {
+		categories = []domain.CategoryCount{}
+	}
+
+	var meta gin.H
+	if allowed := handler.Usecase.AllowedCategories(); len(allowed) > 0 {
+		meta = gin.H{"allowed": allowed}
+	}
+
+	handler.Logger.Info("categories retrieved", "operation", "categories")
+	respondOK(c, http.StatusOK, categories, meta)
+}
+
+// PurgeDeletedNotesApi godoc
+// @Summary Permanently delete old trashed notes
+// @Description Admin only: empties every user's trash.
+// @Tags notes
+// @Produce json
+// @Param olderThanDays query int false "Minimum days in the trash" default(30)
+// @Success 200 {object} Response{data=map[string]int}
+// @Failure 400 {object} Response
+// @Failure 403 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/purge [post]
+func (handler *NoteHandler) PurgeDeletedNotesApi(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("olderThanDays", "30"))
+	if err != nil || days < 0 {
+		handler.Logger.Warn("invalid olderThanDays query", "operation", "purge", "older_than_days", c.Query("olderThanDays"))
+		respondError(c, http.StatusBadRequest, "Invalid olderThanDays")
+		return
+	}
+
+	purged, err := handler.Usecase.PurgeDeletedNotes(time.Duration(days) * 24 * time.Hour)
+	if err != nil {
+		handler.Logger.Error("error purging deleted notes", "operation", "purge", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to purge deleted notes. Please try again later.")
+		return
+	}
+
+	handler.Logger.Info("deleted notes purged", "operation", "purge", "purged", purged)
+	respondOK(c, http.StatusOK, gin.H{"purged": purged}, nil)
+}
+
+// AutoArchiveNotesApi godoc
+// @Summary Archive notes with old meetings
+// @Description Admin only: archives every user's old notes.
+// @Tags notes
+// @Produce json
+// @Param olderThanDays query int false "Minimum days since the meeting" default(180)
+// @Success 200 {object} Response{data=map[string]int}
+// @Failure 400 {object} Response
+// @Failure 403 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/auto-archive [post]
+func (handler *NoteHandler) AutoArchiveNotesApi(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("olderThanDays", "180"))
+	if err != nil || days < 0 {
+		handler.Logger.Warn("invalid olderThanDays query", "operation", "auto_archive", "older_than_days", c.Query("olderThanDays"))
+		respondError(c, http.StatusBadRequest, "Invalid olderThanDays")
+		return
+	}
+
+	archived, err := handler.Usecase.AutoArchiveNotes(time.Duration(days) * 24 * time.Hour)
+	if err != nil {
+		handler.Logger.Error("error auto-archiving notes", "operation", "auto_archive", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to archive old notes. Please try again later.")
+		return
+	}
+
+	handler.Logger.Info("old notes archived", "operation", "auto_archive", "archived", archived)
+	respondOK(c, http.StatusOK, gin.H{"archived": archived}, nil)
+}
+
+// GetNoteStatsApi godoc
+// @Summary Get note statistics
+// @Description Meetings are counted per calendar month in the given timezone.
+// @Tags notes
+// @Produce json
+// @Param tz query string false "IANA timezone name, such as America/New_York. Defaults to the server's timezone."
+// @Success 200 {object} Response{data=domain.NoteStats}
+// @Failure 400 {object} Response
+// @Failure 500 {object} Response
+// @Router /notes/stats [get]
+func (handler *NoteHandler) GetNoteStatsApi(c *gin.Context) {
+	tz := c.Query("tz")
+
+	stats, err := handler.Usecase.GetNoteStats(middleware.CurrentUserID(c), tz)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidTimezone) {
+			handler.Logger.Warn("invalid tz query", "operation", "stats", "tz", tz)
+			respondError(c, http.StatusBadRequest, invalidTimezoneMessage)
+			return
+		}
+
+		handler.Logger.Error("error retrieving note stats", "operation", "stats", "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to retrieve note statistics. Please try again later.")
+		return
+	}
+
+	handler.Logger.Info("note stats retrieved", "operation", "stats")
+	respondOK(c, http.StatusOK, stats, nil)
+}
+
+// mergeFilter applies any explicitly requested filter fields on top of a
+// preset's saved filter. Requested dates replace a saved year and month, and
+// a requested year or month replaces saved dates, so the two never clash.
+func mergeFilter(base, override domain.NoteFilter) domain.NoteFilter {
+	if override.FromDate != nil || override.ToDate != nil {
+		base.Year, base.Month = 0, 0
+	}
+	if override.Year != 0 || override.Month != 0 {
+		base.FromDate, base.ToDate = nil, nil
+	}
+	if len(override.Keywords) > 0 {
+		base.Keyword = ""
+		base.Keywords = override.Keywords
+	}
+	if override.KeywordMatch != "" {
+		base.KeywordMatch = override.KeywordMatch
+	}
+	if len(override.Categories) > 0 {
+		base.Categories = override.Categories
+	}
+	if override.FromDate != nil {
+		base.FromDate = override.FromDate
+	}
+	if override.ToDate != nil {
+		base.ToDate = override.ToDate
+	}
+	if override.Year != 0 || override.Month != 0 {
+		base.Year = override.Year
+		base.Month = override.Month
+	}
+	base.Timezone = override.Timezone
+	if override.CreatedFrom != nil {
+		base.CreatedFrom = override.CreatedFrom
+	}
+	if override.CreatedTo != nil {
+		base.CreatedTo = override.CreatedTo
+	}
+	if override.IncludeArchived {
+		base.IncludeArchived = true
+	}
+	if override.UncategorizedOnly {
+		base.UncategorizedOnly = true
+	}
+	if override.MaxContentLength > 0 {
+		base.MaxContentLength = override.MaxContentLength
+	}
+	if override.MeetingMode != "" {
+		base.MeetingMode = override.MeetingMode
+	}
+	if override.Priority != "" {
+		base.Priority = override.Priority
+	}
+	if override.Sort != "" {
+		// A requested field takes the default direction for it rather than
+		// the direction saved for another field.
+		base.Sort = override.Sort
+		base.Order = override.Order
+	}
+	if override.Order != "" {
+		base.Order = override.Order
+	}
+	return base
+}
+
+// parseDateQuery reads an optional YYYY-MM-DD query param as the start of
+// that day in loc. It writes a 400 and returns false when the value is
+// malformed.
+func parseDateQuery(c *gin.Context, name string, loc *time.Location) (*time.Time, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+
+	date, err := timeutil.ParseDate(value, loc)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid "+name+" format. Use YYYY-MM-DD.")
+		return nil, false
+	}
+	return &date, true
+}
+
+// parseIntQuery reads an optional whole number query param. It writes a 400
+// and returns false when the value is not a number.
+func parseIntQuery(c *gin.Context, name string) (int, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, true
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, name+" must be a number")
+		return 0, false
+	}
+	return n, true
+}
+
+// totalCountHeader carries the number of matches across all pages on
+// paginated search and filter responses.
+const totalCountHeader = "X-Total-Count"
+
+// truncatedHeader is set on GET /notes when the list was cut off at the
+// configured maximum. The rest can be fetched with afterID paging.
+const truncatedHeader = "X-Truncated"
+
+// parsePageParams reads the limit and offset query params shared by every
+// paginated endpoint. A missing limit is the handler's PageLimit and a
+// missing offset 0. Both must be whole numbers, 0 or more; a limit of 0
+// means no limit.
+func (handler *NoteHandler) parsePageParams(c *gin.Context) (limit, offset int, err error) {
+	limit = handler.PageLimit
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+
+	if value := c.Query("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit < 0 {
+			return 0, 0, errInvalidLimit
+		}
+	}
+	if value := c.Query("offset"); value != "" {
+		if offset, err = strconv.Atoi(value); err != nil || offset < 0 {
+			return 0, 0, errInvalidOffset
+		}
+	}
+	return limit, offset, nil
+}
+
+var (
+	errInvalidLimit  = errors.New("limit must be a whole number, 0 or more")
+	errInvalidOffset = errors.New("offset must be a whole number, 0 or more")
+)
+
+// bindPage reads the page with parsePageParams. It writes a 400 and returns
+// false when limit or offset is malformed.
+func (handler *NoteHandler) bindPage(c *gin.Context, operation string) (domain.Page, bool) {
+	limit, offset, err := handler.parsePageParams(c)
+	if err != nil {
+		handler.Logger.Warn("invalid page query", "operation", operation, "limit", c.Query("limit"), "offset", c.Query("offset"), "error", err)
+		respondError(c, http.StatusBadRequest, err.Error())
+		return domain.Page{}, false
+	}
+	return domain.Page{Limit: limit, Offset: offset}, true
+}
+
+// bindLimit reads the limit of an endpoint that has a maximum page size the
+// same way bindPage does, then caps it at max. A limit of 0 asks for every
+// note, so it gets max too.
+func (handler *NoteHandler) bindLimit(c *gin.Context, operation string, max int) (int, bool) {
+	page, ok := handler.bindPage(c, operation)
+	if !ok {
+		return 0, false
+	}
+	if page.Limit == 0 || page.Limit > max {
+		return max, true
+	}
+	return page.Limit, true
+}
+
+// splitCommaList splits a comma separated query value, dropping empty entries.
+func splitCommaList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
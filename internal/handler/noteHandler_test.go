@@ -1,25 +1,64 @@
 package handler
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/assert/v2"
 	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/middleware"
 	"github.com/jt00721/meeting-notes-manager/internal/usecase"
 )
 
 type mockNoteUsecase struct {
 	mockCreateNote  func(n *domain.Note) error
-	mockGetAllNotes func() ([]domain.Note, error)
-	mockGetNoteByID func(id uint) (domain.Note, error)
+	mockGetAllNotes func(ownerID uint, includeArchived bool) ([]domain.Note, bool, error)
+	mockGetGrouped  func(userID uint, includeArchived bool, limit int) (map[string][]domain.Note, bool, error)
+	mockGetNoteByID func(id, ownerID uint) (domain.Note, error)
 	mockUpdateNote  func(n *domain.Note) error
-	mockDeleteNote  func(id uint) error
-	mockFilterNotes func(filter domain.NoteFilter) ([]domain.Note, error)
+	mockDeleteNote  func(id, ownerID uint) error
+	mockFilterNotes func(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error)
+	mockRestore     func(ownerID uint, ids []uint) (int64, error)
+	mockRestoreFrom func(ownerID uint, since time.Time) (int64, error)
+	mockExtremes    func() (domain.NoteExtremes, error)
+	mockCategories  func() ([]domain.CategoryCount, error)
+	mockPurge       func(olderThan time.Duration) (int64, error)
+	mockAutoArchive func(olderThan time.Duration) (int64, error)
+	mockStats       func(tz string) (domain.NoteStats, error)
+	mockSearch      func(viewerID uint, keyword string, dates domain.DateRange, page domain.Page) ([]domain.SearchResult, int64, error)
+	mockGetByIDs    func(ids []uint, ownerID uint) ([]domain.Note, error)
+	mockRename      func(ownerID uint, from, to string) (int64, error)
+	mockRecent      func(limit int) ([]domain.Note, error)
+	mockUpsert      func(n *domain.Note) error
+	mockValidate    func(n *domain.Note, opts usecase.CreateOptions) error
+	mockArchive     func(id, ownerID uint, archived bool) error
+	mockRevisions   func(noteID, ownerID uint) ([]domain.NoteRevision, error)
+	mockDiff        func(noteID, ownerID uint, from, to int) (string, error)
+	mockRelated     func(id, ownerID uint, limit int) ([]domain.Note, error)
+	mockUpcoming    func(within time.Duration) ([]domain.Note, error)
+	mockDuplicate   func(id, ownerID uint) (domain.Note, error)
+	mockAfterID     func(ownerID, afterID uint, limit int, includeArchived bool) ([]domain.Note, error)
+	mockPaginated   func(viewerID uint, limit, offset int, includeArchived bool) ([]domain.Note, error)
+
+	mockAddAttachment   func(noteID, ownerID uint, a *domain.Attachment) error
+	mockListAttachments func(noteID, ownerID uint) ([]domain.Attachment, error)
+	mockAddLink         func(noteID, ownerID uint, link *domain.NoteLink) (domain.LinkedNote, error)
+	mockListLinks       func(noteID, ownerID uint) ([]domain.LinkedNote, error)
+	mockToday           func(tz string) ([]domain.Note, error)
+	mockAuditLog        func(noteID, ownerID uint) ([]domain.AuditLog, error)
+	mockGetBySlug       func(slug string, ownerID uint) (domain.Note, error)
+	mockCountFiltered   func(filter domain.NoteFilter) (int64, error)
+	mockStreamNotes     func(ownerID uint, includeArchived bool, fn func(domain.Note) error) error
+	mockImportNotes     func(ownerID uint, next func() (usecase.ImportRow, error), opts usecase.ImportOptions) (usecase.ImportSummary, error)
+	mockChanges         func(ownerID uint, cursor domain.ChangeCursor, limit int) ([]domain.NoteChange, bool, error)
+	mockAllowed         []string
 }
 
 func (m *mockNoteUsecase) CreateNote(n *domain.Note) error {
@@ -29,43 +68,278 @@ func (m *mockNoteUsecase) CreateNote(n *domain.Note) error {
 	return nil
 }
 
-func (m *mockNoteUsecase) GetAllNotes() ([]domain.Note, error) {
+func (m *mockNoteUsecase) CreateNoteWithOptions(n *domain.Note, opts usecase.CreateOptions) error {
+	return m.CreateNote(n)
+}
+
+func (m *mockNoteUsecase) UpsertNote(n *domain.Note) error {
+	if m.mockUpsert != nil {
+		return m.mockUpsert(n)
+	}
+	return nil
+}
+
+func (m *mockNoteUsecase) ListRevisions(noteID, ownerID uint) ([]domain.NoteRevision, error) {
+	if m.mockRevisions != nil {
+		return m.mockRevisions(noteID, ownerID)
+	}
+	return nil, nil
+}
+
+func (m *mockNoteUsecase) ListAuditLog(noteID, ownerID uint) ([]domain.AuditLog, error) {
+	if m.mockAuditLog != nil {
+		return m.mockAuditLog(noteID, ownerID)
+	}
+	return nil, nil
+}
+
+func (m *mockNoteUsecase) GetTodaysMeetings(viewerID uint, tz string) ([]domain.Note, error) {
+	if m.mockToday != nil {
+		return m.mockToday(tz)
+	}
+	return nil, nil
+}
+
+func (m *mockNoteUsecase) GetMeetingsOnDay(viewerID uint, day time.Time) ([]domain.Note, error) {
+	return nil, nil
+}
+
+func (m *mockNoteUsecase) AddAttachment(noteID, ownerID uint, a *domain.Attachment) error {
+	if m.mockAddAttachment != nil {
+		return m.mockAddAttachment(noteID, ownerID, a)
+	}
+	return nil
+}
+
+func (m *mockNoteUsecase) ListAttachments(noteID, ownerID uint) ([]domain.Attachment, error) {
+	if m.mockListAttachments != nil {
+		return m.mockListAttachments(noteID, ownerID)
+	}
+	return nil, nil
+}
+
+func (m *mockNoteUsecase) AddNoteLink(noteID, ownerID uint, link *domain.NoteLink) (domain.LinkedNote, error) {
+	if m.mockAddLink != nil {
+		return m.mockAddLink(noteID, ownerID, link)
+	}
+	return domain.LinkedNote{}, nil
+}
+
+func (m *mockNoteUsecase) ListNoteLinks(noteID, ownerID uint) ([]domain.LinkedNote, error) {
+	if m.mockListLinks != nil {
+		return m.mockListLinks(noteID, ownerID)
+	}
+	return nil, nil
+}
+
+func (m *mockNoteUsecase) DiffRevisions(noteID, ownerID uint, from, to int) (string, error) {
+	if m.mockDiff != nil {
+		return m.mockDiff(noteID, ownerID, from, to)
+	}
+	return "", nil
+}
+
+func (m *mockNoteUsecase) GetRelatedNotes(id, ownerID uint, limit int) ([]domain.Note, error) {
+	if m.mockRelated != nil {
+		return m.mockRelated(id, ownerID, limit)
+	}
+	return nil, nil
+}
+
+func (m *mockNoteUsecase) GetUpcomingMeetings(viewerID uint, within time.Duration) ([]domain.Note, error) {
+	if m.mockUpcoming != nil {
+		return m.mockUpcoming(within)
+	}
+	return nil, nil
+}
+
+func (m *mockNoteUsecase) SendMeetingReminders(lead, interval time.Duration) (int, error) {
+	return 0, nil
+}
+
+func (m *mockNoteUsecase) DuplicateNote(id, ownerID uint) (domain.Note, error) {
+	if m.mockDuplicate != nil {
+		return m.mockDuplicate(id, ownerID)
+	}
+	return domain.Note{}, nil
+}
+
+func (m *mockNoteUsecase) ArchiveNote(id, ownerID uint, archived bool) error {
+	if m.mockArchive != nil {
+		return m.mockArchive(id, ownerID, archived)
+	}
+	return nil
+}
+
+func (m *mockNoteUsecase) ValidateNote(n *domain.Note, opts usecase.CreateOptions) error {
+	if m.mockValidate != nil {
+		return m.mockValidate(n, opts)
+	}
+	return nil
+}
+
+func (m *mockNoteUsecase) GetAllNotes(ownerID uint, includeArchived bool) ([]domain.Note, bool, error) {
 	if m.mockGetAllNotes != nil {
-		return m.mockGetAllNotes()
+		return m.mockGetAllNotes(ownerID, includeArchived)
 	}
-	return []domain.Note{}, nil
+	return []domain.Note{}, false, nil
+}
+func (m *mockNoteUsecase) GetNotesByCategory(userID uint, includeArchived bool, limit int) (map[string][]domain.Note, bool, error) {
+	if m.mockGetGrouped != nil {
+		return m.mockGetGrouped(userID, includeArchived, limit)
+	}
+	return map[string][]domain.Note{}, false, nil
+}
+func (m *mockNoteUsecase) ImportNotes(ownerID uint, next func() (usecase.ImportRow, error), opts usecase.ImportOptions) (usecase.ImportSummary, error) {
+	if m.mockImportNotes != nil {
+		return m.mockImportNotes(ownerID, next, opts)
+	}
+	return usecase.ImportSummary{}, nil
+}
+func (m *mockNoteUsecase) StreamNotes(ownerID uint, includeArchived bool, fn func(domain.Note) error) error {
+	if m.mockStreamNotes != nil {
+		return m.mockStreamNotes(ownerID, includeArchived, fn)
+	}
+	return nil
+}
+func (m *mockNoteUsecase) GetNoteChanges(ownerID uint, cursor domain.ChangeCursor, limit int) ([]domain.NoteChange, bool, error) {
+	if m.mockChanges != nil {
+		return m.mockChanges(ownerID, cursor, limit)
+	}
+	return []domain.NoteChange{}, false, nil
 }
-func (m *mockNoteUsecase) GetPaginatedNotes(limit, offset int) ([]domain.Note, error) {
+func (m *mockNoteUsecase) GetPaginatedNotes(viewerID uint, limit, offset int, includeArchived bool) ([]domain.Note, error) {
+	if m.mockPaginated != nil {
+		return m.mockPaginated(viewerID, limit, offset, includeArchived)
+	}
 	return nil, nil
 }
 
-func (m *mockNoteUsecase) GetNoteByID(id uint) (domain.Note, error) {
+func (m *mockNoteUsecase) GetNotesAfterID(viewerID, afterID uint, limit int, includeArchived bool) ([]domain.Note, error) {
+	if m.mockAfterID != nil {
+		return m.mockAfterID(viewerID, afterID, limit, includeArchived)
+	}
+	return []domain.Note{}, nil
+}
+
+func (m *mockNoteUsecase) GetRecentNotes(viewerID uint, limit int) ([]domain.Note, error) {
+	if m.mockRecent != nil {
+		return m.mockRecent(limit)
+	}
+	return []domain.Note{}, nil
+}
+
+func (m *mockNoteUsecase) GetNoteByID(id, ownerID uint) (domain.Note, error) {
 	if m.mockGetNoteByID != nil {
-		return m.mockGetNoteByID(id)
+		return m.mockGetNoteByID(id, ownerID)
+	}
+	return domain.Note{}, nil
+}
+
+func (m *mockNoteUsecase) GetNoteBySlug(slug string, ownerID uint) (domain.Note, error) {
+	if m.mockGetBySlug != nil {
+		return m.mockGetBySlug(slug, ownerID)
 	}
 	return domain.Note{}, nil
 }
 
+func (m *mockNoteUsecase) GetNotesByIDs(ids []uint, ownerID uint) ([]domain.Note, error) {
+	if m.mockGetByIDs != nil {
+		return m.mockGetByIDs(ids, ownerID)
+	}
+	return []domain.Note{}, nil
+}
+
 func (m *mockNoteUsecase) UpdateNote(n *domain.Note) error {
 	if m.mockUpdateNote != nil {
 		return m.mockUpdateNote(n)
 	}
 	return nil
 }
-func (m *mockNoteUsecase) DeleteNote(id uint) error {
+func (m *mockNoteUsecase) DeleteNote(id, ownerID uint) error {
 	if m.mockDeleteNote != nil {
-		return m.mockDeleteNote(id)
+		return m.mockDeleteNote(id, ownerID)
 	}
 	return nil
 }
-func (m *mockNoteUsecase) SearchNotesByKeyword(keyword string) ([]domain.Note, error) {
-	return nil, nil
+func (m *mockNoteUsecase) SearchNotesByKeyword(viewerID uint, keyword string, dates domain.DateRange, page domain.Page) ([]domain.SearchResult, int64, error) {
+	if m.mockSearch != nil {
+		return m.mockSearch(viewerID, keyword, dates, page)
+	}
+	return nil, 0, nil
+}
+func (m *mockNoteUsecase) CountFilteredNotes(filter domain.NoteFilter) (int64, error) {
+	if m.mockCountFiltered != nil {
+		return m.mockCountFiltered(filter)
+	}
+	return 0, nil
 }
-func (m *mockNoteUsecase) FilterNotes(filter domain.NoteFilter) ([]domain.Note, error) {
+
+func (m *mockNoteUsecase) FilterNotes(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error) {
 	if m.mockFilterNotes != nil {
-		return m.mockFilterNotes(filter)
+		return m.mockFilterNotes(filter, page)
 	}
-	return []domain.Note{}, nil
+	return []domain.Note{}, 0, nil
+}
+
+func (m *mockNoteUsecase) RenameCategory(ownerID uint, from, to string) (int64, error) {
+	if m.mockRename != nil {
+		return m.mockRename(ownerID, from, to)
+	}
+	return 0, nil
+}
+
+func (m *mockNoteUsecase) RestoreNotes(ownerID uint, ids []uint) (int64, error) {
+	if m.mockRestore != nil {
+		return m.mockRestore(ownerID, ids)
+	}
+	return 0, nil
+}
+
+func (m *mockNoteUsecase) RestoreDeletedSince(ownerID uint, since time.Time) (int64, error) {
+	if m.mockRestoreFrom != nil {
+		return m.mockRestoreFrom(ownerID, since)
+	}
+	return 0, nil
+}
+
+func (m *mockNoteUsecase) GetNoteExtremes(viewerID uint) (domain.NoteExtremes, error) {
+	if m.mockExtremes != nil {
+		return m.mockExtremes()
+	}
+	return domain.NoteExtremes{}, nil
+}
+
+func (m *mockNoteUsecase) GetCategories(viewerID uint) ([]domain.CategoryCount, error) {
+	if m.mockCategories != nil {
+		return m.mockCategories()
+	}
+	return []domain.CategoryCount{}, nil
+}
+
+func (m *mockNoteUsecase) AllowedCategories() []string {
+	return m.mockAllowed
+}
+
+func (m *mockNoteUsecase) PurgeDeletedNotes(olderThan time.Duration) (int64, error) {
+	if m.mockPurge != nil {
+		return m.mockPurge(olderThan)
+	}
+	return 0, nil
+}
+
+func (m *mockNoteUsecase) AutoArchiveNotes(olderThan time.Duration) (int64, error) {
+	if m.mockAutoArchive != nil {
+		return m.mockAutoArchive(olderThan)
+	}
+	return 0, nil
+}
+
+func (m *mockNoteUsecase) GetNoteStats(viewerID uint, tz string) (domain.NoteStats, error) {
+	if m.mockStats != nil {
+		return m.mockStats(tz)
+	}
+	return domain.NoteStats{}, nil
 }
 
 func TestCreateNoteApi(t *testing.T) {
@@ -100,6 +374,30 @@ func TestCreateNoteApi(t *testing.T) {
 			mockReturn: usecase.ErrEmptyContent,
 			wantCode:   http.StatusBadRequest,
 		},
+		{
+			name:       "Missing meeting date",
+			body:       `{"title": "Test meeting", "content": "Some content", "category": "Standup"}`,
+			mockReturn: usecase.ErrInvalidMeetingDate,
+			wantCode:   http.StatusBadRequest,
+		},
+		{
+			name:       "Meeting date outside window",
+			body:       `{"title": "Test meeting", "content": "Some content", "category": "Standup", "meeting_date": "2020-06-15T10:30:00Z"}`,
+			mockReturn: &usecase.MeetingDateWindowError{},
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Category quota reached",
+			body:       `{"title": "Test meeting", "content": "Some content", "category": "Standup", "meeting_date": "2025-06-15T10:30:00Z"}`,
+			mockReturn: usecase.ErrCategoryQuotaExceeded,
+			wantCode:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Duplicate title and meeting date",
+			body:       `{"title": "Test meeting", "content": "Some content", "category": "Standup", "meeting_date": "2025-06-15T10:30:00Z"}`,
+			mockReturn: usecase.ErrDuplicateNote,
+			wantCode:   http.StatusConflict,
+		},
 	}
 
 	for _, tt := range tests {
@@ -153,18 +451,18 @@ func TestGetAllNotesApi(t *testing.T) {
 		{
 			name:         "Repo error",
 			mockError:    errors.New("db error"),
-			expectedCode: http.StatusInternalServerError, // This is what your handler currently returns
+			expectedCode: http.StatusInternalServerError,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockUC := &mockNoteUsecase{
-				mockGetAllNotes: func() ([]domain.Note, error) {
+				mockGetAllNotes: func(ownerID uint, includeArchived bool) ([]domain.Note, bool, error) {
 					if tt.mockError != nil {
-						return []domain.Note{}, tt.mockError
+						return []domain.Note{}, false, tt.mockError
 					}
-					return tt.mockReturn, nil
+					return tt.mockReturn, false, nil
 				},
 			}
 
@@ -214,14 +512,14 @@ func TestGetNoteByIDApi(t *testing.T) {
 			name:         "Repo error",
 			idParam:      "5",
 			mockError:    errors.New("db error"),
-			expectedCode: http.StatusNotFound, // This is what your handler currently returns
+			expectedCode: http.StatusInternalServerError,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockUC := &mockNoteUsecase{
-				mockGetNoteByID: func(id uint) (domain.Note, error) {
+				mockGetNoteByID: func(id, ownerID uint) (domain.Note, error) {
 					if tt.mockError != nil {
 						return domain.Note{}, tt.mockError
 					}
@@ -293,6 +591,34 @@ func TestUpdateNoteApi(t *testing.T) {
 			mockReturn: errors.New("db error"),
 			wantCode:   http.StatusInternalServerError,
 		},
+		{
+			name:       "Note not found",
+			idParam:    "99",
+			body:       `{"title": "Test meeting", "content": "Some content", "category": "Standup", "meeting_date": "2025-06-15T10:30:00Z"}`,
+			mockReturn: usecase.ErrNoteNotFound,
+			wantCode:   http.StatusNotFound,
+		},
+		{
+			name:       "Stale version",
+			idParam:    "1",
+			body:       `{"title": "Test meeting", "content": "Some content", "category": "Standup", "version": 1}`,
+			mockReturn: usecase.ErrStaleUpdate,
+			wantCode:   http.StatusConflict,
+		},
+		{
+			name:       "Duplicate title and meeting date",
+			idParam:    "1",
+			body:       `{"title": "Test meeting", "content": "Some content", "category": "Standup", "meeting_date": "2025-06-15T10:30:00Z"}`,
+			mockReturn: usecase.ErrDuplicateNote,
+			wantCode:   http.StatusConflict,
+		},
+		{
+			name:       "Category quota reached",
+			idParam:    "1",
+			body:       `{"title": "Test meeting", "content": "Some content", "category": "Standup", "meeting_date": "2025-06-15T10:30:00Z"}`,
+			mockReturn: usecase.ErrCategoryQuotaExceeded,
+			wantCode:   http.StatusUnprocessableEntity,
+		},
 	}
 
 	for _, tt := range tests {
@@ -348,14 +674,14 @@ func TestDeleteNoteApi(t *testing.T) {
 			name:         "Repo error",
 			idParam:      "5",
 			mockError:    errors.New("db error"),
-			expectedCode: http.StatusInternalServerError, // This is what your handler currently returns
+			expectedCode: http.StatusInternalServerError,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockUC := &mockNoteUsecase{
-				mockDeleteNote: func(id uint) error {
+				mockDeleteNote: func(id, ownerID uint) error {
 					return tt.mockError
 				},
 			}
@@ -429,11 +755,153 @@ func TestFilterNotesApi(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockUC := &mockNoteUsecase{
-				mockFilterNotes: func(filter domain.NoteFilter) ([]domain.Note, error) {
+				mockFilterNotes: func(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error) {
 					if tt.mockError != nil {
-						return nil, tt.mockError
+						return nil, 0, tt.mockError
 					}
-					return tt.mockReturn, nil
+					return tt.mockReturn, int64(len(tt.mockReturn)), nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/filter", handler.FilterNotesApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/filter"+tt.queryParams, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+		})
+	}
+}
+
+func TestFilterNotesApiYearMonth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		queryParams  string
+		mockError    error
+		expectedCode int
+		wantYear     int
+		wantMonth    int
+	}{
+		{name: "Year and month", queryParams: "?year=2025&month=6", expectedCode: http.StatusOK, wantYear: 2025, wantMonth: 6},
+		{name: "Year only", queryParams: "?year=2025", expectedCode: http.StatusOK, wantYear: 2025},
+		{name: "Year not a number", queryParams: "?year=last", expectedCode: http.StatusBadRequest},
+		{name: "Month not a number", queryParams: "?year=2025&month=june", expectedCode: http.StatusBadRequest},
+		{name: "Invalid month", queryParams: "?year=2025&month=13", mockError: usecase.ErrInvalidMonth, expectedCode: http.StatusBadRequest, wantYear: 2025, wantMonth: 13},
+		{name: "Combined with dates", queryParams: "?year=2025&fromDate=2025-01-01", mockError: usecase.ErrYearWithDateRange, expectedCode: http.StatusBadRequest, wantYear: 2025},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilter domain.NoteFilter
+			mockUC := &mockNoteUsecase{
+				mockFilterNotes: func(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error) {
+					gotFilter = filter
+					return nil, 0, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/filter", handler.FilterNotesApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/filter"+tt.queryParams, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, tt.wantYear, gotFilter.Year)
+			assert.Equal(t, tt.wantMonth, gotFilter.Month)
+			if tt.mockError != nil {
+				assert.Equal(t, true, strings.Contains(resp.Body.String(), tt.mockError.Error()))
+			}
+		})
+	}
+}
+
+func TestFilterNotesApiTimezone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.Equal(t, nil, err)
+
+	tests := []struct {
+		name         string
+		queryParams  string
+		location     *time.Location
+		mockError    error
+		expectedCode int
+		wantTimezone string
+		wantFrom     time.Time
+	}{
+		{name: "Dates in UTC by default", queryParams: "?fromDate=2025-06-01", expectedCode: http.StatusOK, wantFrom: time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "Dates in the requested timezone", queryParams: "?fromDate=2025-06-01&tz=Asia/Tokyo", expectedCode: http.StatusOK, wantTimezone: "Asia/Tokyo", wantFrom: time.Date(2025, time.May, 31, 15, 0, 0, 0, time.UTC)},
+		{name: "Dates in the server's timezone", queryParams: "?fromDate=2025-06-01", location: tokyo, expectedCode: http.StatusOK, wantFrom: time.Date(2025, time.May, 31, 15, 0, 0, 0, time.UTC)},
+		{name: "Year and month keep the timezone", queryParams: "?year=2025&month=6&tz=Asia/Tokyo", expectedCode: http.StatusOK, wantTimezone: "Asia/Tokyo"},
+		{name: "Unknown timezone", queryParams: "?fromDate=2025-06-01&tz=Nowhere", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilter domain.NoteFilter
+			mockUC := &mockNoteUsecase{
+				mockFilterNotes: func(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error) {
+					gotFilter = filter
+					return nil, 0, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			handler.Location = tt.location
+			router := gin.Default()
+			router.GET("/notes/filter", handler.FilterNotesApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/filter"+tt.queryParams, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, tt.wantTimezone, gotFilter.Timezone)
+			if !tt.wantFrom.IsZero() {
+				assert.Equal(t, true, gotFilter.FromDate.Equal(tt.wantFrom))
+			}
+		})
+	}
+}
+
+func TestFilterNotesApiSort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		queryParams  string
+		mockError    error
+		expectedCode int
+		wantSort     string
+		wantOrder    string
+	}{
+		{name: "Default", queryParams: "", expectedCode: http.StatusOK},
+		{name: "Sort and order", queryParams: "?sort=title&order=asc&keyword=sync", expectedCode: http.StatusOK, wantSort: "title", wantOrder: "asc"},
+		{name: "Invalid sort", queryParams: "?sort=content", mockError: usecase.ErrInvalidSort, expectedCode: http.StatusBadRequest, wantSort: "content"},
+		{name: "Invalid order", queryParams: "?sort=title&order=up", mockError: usecase.ErrInvalidSortOrder, expectedCode: http.StatusBadRequest, wantSort: "title", wantOrder: "up"},
+		{name: "Sort by priority", queryParams: "?sort=priority&priority=high", expectedCode: http.StatusOK, wantSort: "priority"},
+		{name: "Invalid priority", queryParams: "?priority=urgent", mockError: usecase.ErrInvalidPriority, expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilter domain.NoteFilter
+			mockUC := &mockNoteUsecase{
+				mockFilterNotes: func(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error) {
+					gotFilter = filter
+					return nil, 0, tt.mockError
 				},
 			}
 
@@ -447,6 +915,3104 @@ func TestFilterNotesApi(t *testing.T) {
 			router.ServeHTTP(resp, req)
 
 			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, tt.wantSort, gotFilter.Sort)
+			assert.Equal(t, tt.wantOrder, gotFilter.Order)
+			if tt.mockError != nil {
+				assert.Equal(t, true, strings.Contains(resp.Body.String(), tt.mockError.Error()))
+			}
 		})
 	}
 }
+
+func TestCountFilteredNotesApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		queryParams  string
+		mockError    error
+		expectedCode int
+		expectedBody string
+	}{
+		{name: "Count", queryParams: "?keyword=team&categories=Standup,Planning&year=2025", expectedCode: http.StatusOK, expectedBody: `{"count":42}`},
+		{name: "Malformed date", queryParams: "?fromDate=01-01-2025", expectedCode: http.StatusBadRequest, expectedBody: "Invalid fromDate format"},
+		{name: "Invalid range", queryParams: "?fromDate=2025-02-01&toDate=2025-01-01", mockError: usecase.ErrInvalidDateRange, expectedCode: http.StatusBadRequest, expectedBody: "fromDate must be before toDate"},
+		{name: "Unknown preset", queryParams: "?preset=missing", expectedCode: http.StatusNotFound, expectedBody: "preset not found"},
+		{name: "Usecase error", mockError: errors.New("boom"), expectedCode: http.StatusInternalServerError, expectedBody: "Failed to count notes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilter domain.NoteFilter
+			mockUC := &mockNoteUsecase{
+				mockCountFiltered: func(filter domain.NoteFilter) (int64, error) {
+					gotFilter = filter
+					if tt.mockError != nil {
+						return 0, tt.mockError
+					}
+					return 42, nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/filter/count", handler.CountFilteredNotesApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/filter/count"+tt.queryParams, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, true, strings.Contains(resp.Body.String(), tt.expectedBody))
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, []string{"team"}, gotFilter.Keywords)
+				assert.Equal(t, []string{"Standup", "Planning"}, gotFilter.Categories)
+				assert.Equal(t, 2025, gotFilter.Year)
+			}
+		})
+	}
+}
+
+func TestRestoreNotesApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		body         string
+		mockReturn   int64
+		mockError    error
+		expectedCode int
+	}{
+		{
+			name:         "Valid restore",
+			body:         `{"ids": [1, 2, 3]}`,
+			mockReturn:   2,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Invalid JSON",
+			body:         `{"ids": [1, 2`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "No IDs",
+			body:         `{"ids": []}`,
+			mockError:    usecase.ErrNoIDs,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Repo error",
+			body:         `{"ids": [1]}`,
+			mockError:    errors.New("db error"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOwner uint
+			mockUC := &mockNoteUsecase{
+				mockRestore: func(ownerID uint, ids []uint) (int64, error) {
+					gotOwner = ownerID
+					return tt.mockReturn, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.Use(middleware.UserID())
+			router.POST("/notes/trash/restore", handler.RestoreNotesApi)
+
+			req := httptest.NewRequest(http.MethodPost, "/notes/trash/restore", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(middleware.UserIDHeader, "7")
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, uint(7), gotOwner)
+			}
+		})
+	}
+}
+
+func TestRestoreDeletedNotesApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		query        string
+		mockError    error
+		expectedCode int
+		wantSince    time.Time
+	}{
+		{
+			name:         "Valid timestamp",
+			query:        "?deletedAfter=2025-06-01T09:30:00Z",
+			expectedCode: http.StatusOK,
+			wantSince:    time.Date(2025, time.June, 1, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:         "Missing timestamp",
+			query:        "",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Date without time",
+			query:        "?deletedAfter=2025-06-01",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Future timestamp",
+			query:        "?deletedAfter=2999-01-01T00:00:00Z",
+			mockError:    usecase.ErrFutureWindow,
+			expectedCode: http.StatusBadRequest,
+			wantSince:    time.Date(2999, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "Repo error",
+			query:        "?deletedAfter=2025-06-01T09:30:00Z",
+			mockError:    errors.New("db error"),
+			expectedCode: http.StatusInternalServerError,
+			wantSince:    time.Date(2025, time.June, 1, 9, 30, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSince time.Time
+			var gotOwner uint
+			mockUC := &mockNoteUsecase{
+				mockRestoreFrom: func(ownerID uint, since time.Time) (int64, error) {
+					gotSince = since
+					gotOwner = ownerID
+					return 3, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.Use(middleware.UserID())
+			router.POST("/notes/restore", handler.RestoreDeletedNotesApi)
+
+			req := httptest.NewRequest(http.MethodPost, "/notes/restore"+tt.query, nil)
+			req.Header.Set(middleware.UserIDHeader, "7")
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, true, gotSince.Equal(tt.wantSince))
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, true, strings.Contains(resp.Body.String(), `"restored":3`))
+				assert.Equal(t, uint(7), gotOwner)
+			}
+		})
+	}
+}
+
+func TestGetNoteExtremesApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		mockReturn   domain.NoteExtremes
+		mockError    error
+		expectedCode int
+	}{
+		{
+			name: "Valid extremes",
+			mockReturn: domain.NoteExtremes{
+				Longest:  &domain.NoteLength{ID: 1, Title: "Long", Length: 120},
+				Shortest: &domain.NoteLength{ID: 2, Title: "Short", Length: 4},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "No notes",
+			mockReturn:   domain.NoteExtremes{},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Repo error",
+			mockError:    errors.New("db error"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockExtremes: func() (domain.NoteExtremes, error) {
+					return tt.mockReturn, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/extremes", handler.GetNoteExtremesApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/extremes", nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+		})
+	}
+}
+
+func TestFilterNotesApiCategories(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		queryParams    string
+		wantCategories []string
+	}{
+		{
+			name:           "Single category param",
+			queryParams:    "?category=Standup",
+			wantCategories: []string{"Standup"},
+		},
+		{
+			name:           "Multiple categories",
+			queryParams:    "?categories=Standup,1:1",
+			wantCategories: []string{"Standup", "1:1"},
+		},
+		{
+			name:           "Empty entries ignored",
+			queryParams:    "?categories=,Standup,,1:1,",
+			wantCategories: []string{"Standup", "1:1"},
+		},
+		{
+			name:           "Both params combined",
+			queryParams:    "?categories=Standup&category=Planning",
+			wantCategories: []string{"Standup", "Planning"},
+		},
+		{
+			name:           "No categories",
+			queryParams:    "?categories=,,",
+			wantCategories: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilter domain.NoteFilter
+			mockUC := &mockNoteUsecase{
+				mockFilterNotes: func(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error) {
+					gotFilter = filter
+					return []domain.Note{}, 0, nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/filter", handler.FilterNotesApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/filter"+tt.queryParams, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			assert.Equal(t, tt.wantCategories, gotFilter.Categories)
+		})
+	}
+}
+
+func TestGetCategoriesApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		mockReturn   []domain.CategoryCount
+		mockAllowed  []string
+		mockError    error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "Valid categories",
+			mockReturn: []domain.CategoryCount{
+				{Category: "1:1", Count: 1},
+				{Category: "Standup", Count: 2},
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":[{"category":"1:1","count":1},{"category":"Standup","count":2}],"meta":{},"error":null}`,
+		},
+		{
+			name:         "No categories",
+			mockReturn:   nil,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":[],"meta":{},"error":null}`,
+		},
+		{
+			name:         "Allowed categories",
+			mockReturn:   []domain.CategoryCount{{Category: "Standup", Count: 2}},
+			mockAllowed:  []string{"Standup", "Planning"},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":[{"category":"Standup","count":2}],"meta":{"allowed":["Standup","Planning"]},"error":null}`,
+		},
+		{
+			name:         "Repo error",
+			mockError:    errors.New("db error"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockCategories: func() ([]domain.CategoryCount, error) {
+					return tt.mockReturn, tt.mockError
+				},
+				mockAllowed: tt.mockAllowed,
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/categories", handler.GetCategoriesApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/categories", nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, resp.Body.String())
+			}
+		})
+	}
+}
+
+func TestPurgeDeletedNotesApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		queryParams   string
+		mockError     error
+		wantOlderThan time.Duration
+		expectedCode  int
+	}{
+		{
+			name:          "Default age",
+			queryParams:   "",
+			wantOlderThan: 30 * 24 * time.Hour,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "Custom age",
+			queryParams:   "?olderThanDays=7",
+			wantOlderThan: 7 * 24 * time.Hour,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:         "Invalid age",
+			queryParams:  "?olderThanDays=abc",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Negative age",
+			queryParams:  "?olderThanDays=-1",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:          "Repo error",
+			queryParams:   "?olderThanDays=1",
+			mockError:     errors.New("db error"),
+			wantOlderThan: 24 * time.Hour,
+			expectedCode:  http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOlderThan time.Duration
+			mockUC := &mockNoteUsecase{
+				mockPurge: func(olderThan time.Duration) (int64, error) {
+					gotOlderThan = olderThan
+					return 3, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.POST("/notes/purge", handler.PurgeDeletedNotesApi)
+
+			req := httptest.NewRequest(http.MethodPost, "/notes/purge"+tt.queryParams, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, tt.wantOlderThan, gotOlderThan)
+		})
+	}
+}
+
+func TestAutoArchiveNotesApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		queryParams   string
+		mockError     error
+		wantOlderThan time.Duration
+		expectedCode  int
+		expectedBody  string
+	}{
+		{
+			name:          "Default age",
+			queryParams:   "",
+			wantOlderThan: 180 * 24 * time.Hour,
+			expectedCode:  http.StatusOK,
+			expectedBody:  `"archived":4`,
+		},
+		{
+			name:          "Custom age",
+			queryParams:   "?olderThanDays=90",
+			wantOlderThan: 90 * 24 * time.Hour,
+			expectedCode:  http.StatusOK,
+			expectedBody:  `"archived":4`,
+		},
+		{
+			name:         "Invalid age",
+			queryParams:  "?olderThanDays=soon",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "Invalid olderThanDays",
+		},
+		{
+			name:         "Negative age",
+			queryParams:  "?olderThanDays=-5",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "Invalid olderThanDays",
+		},
+		{
+			name:          "Repo error",
+			queryParams:   "?olderThanDays=1",
+			mockError:     errors.New("db error"),
+			wantOlderThan: 24 * time.Hour,
+			expectedCode:  http.StatusInternalServerError,
+			expectedBody:  "Failed to archive old notes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOlderThan time.Duration
+			mockUC := &mockNoteUsecase{
+				mockAutoArchive: func(olderThan time.Duration) (int64, error) {
+					gotOlderThan = olderThan
+					return 4, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.POST("/notes/auto-archive", handler.AutoArchiveNotesApi)
+
+			req := httptest.NewRequest(http.MethodPost, "/notes/auto-archive"+tt.queryParams, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, tt.wantOlderThan, gotOlderThan)
+			assert.Equal(t, true, strings.Contains(resp.Body.String(), tt.expectedBody))
+		})
+	}
+}
+
+func TestGetNoteStatsApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		query        string
+		expectedTZ   string
+		mockError    error
+		expectedCode int
+	}{
+		{
+			name:         "Valid stats",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Timezone",
+			query:        "?tz=America/New_York",
+			expectedTZ:   "America/New_York",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Invalid timezone",
+			query:        "?tz=Nowhere",
+			expectedTZ:   "Nowhere",
+			mockError:    usecase.ErrInvalidTimezone,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Repo error",
+			mockError:    errors.New("db error"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotTZ string
+			mockUC := &mockNoteUsecase{
+				mockStats: func(tz string) (domain.NoteStats, error) {
+					gotTZ = tz
+					return domain.NoteStats{Total: 3}, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/stats", handler.GetNoteStatsApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/stats"+tt.query, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, tt.expectedTZ, gotTZ)
+		})
+	}
+}
+
+func TestSearchNotesByKeywordApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		queryParams  string
+		mockReturn   []domain.SearchResult
+		mockError    error
+		expectedCode int
+	}{
+		{
+			name:        "Valid search",
+			queryParams: "?keyword=budget",
+			mockReturn: []domain.SearchResult{
+				{Note: domain.Note{ID: 1, Title: "Planning", Content: "Discussed the budget"}, Snippet: "Discussed the **budget**"},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Missing keyword",
+			queryParams:  "?keyword=%20",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "No results",
+			queryParams:  "?keyword=xyz",
+			mockReturn:   []domain.SearchResult{},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Repo error",
+			queryParams:  "?keyword=budget",
+			mockError:    errors.New("db error"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotViewer uint
+			mockUC := &mockNoteUsecase{
+				mockSearch: func(viewerID uint, keyword string, dates domain.DateRange, page domain.Page) ([]domain.SearchResult, int64, error) {
+					gotViewer = viewerID
+					return tt.mockReturn, int64(len(tt.mockReturn)), tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.Use(middleware.UserID())
+			router.GET("/notes/search", handler.SearchNotesByKeywordApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/search"+tt.queryParams, nil)
+			req.Header.Set(middleware.UserIDHeader, "7")
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, uint(7), gotViewer)
+			}
+		})
+	}
+}
+
+func TestGetNoteByIDApiOwnerScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		userHeader   string
+		expectedCode int
+	}{
+		{
+			name:         "Owner can read note",
+			userHeader:   "7",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Other user gets not found",
+			userHeader:   "8",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "Anonymous user gets not found",
+			userHeader:   "",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockGetNoteByID: func(id, ownerID uint) (domain.Note, error) {
+					if ownerID != 7 {
+						return domain.Note{}, usecase.ErrNoteNotFound
+					}
+					return domain.Note{ID: id, OwnerID: ownerID, Title: "Mine"}, nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.Use(middleware.UserID())
+			router.GET("/notes/:id", handler.GetNoteByIDApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/1", nil)
+			if tt.userHeader != "" {
+				req.Header.Set(middleware.UserIDHeader, tt.userHeader)
+			}
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+		})
+	}
+}
+
+func TestGetNoteByIDApiETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	note := domain.Note{ID: 1, Title: "Test Meeting", Version: 2, UpdatedAt: time.Date(2025, time.June, 15, 10, 30, 0, 0, time.UTC)}
+	currentETag := noteETag(note)
+
+	tests := []struct {
+		name         string
+		ifNoneMatch  string
+		expectedCode int
+	}{
+		{
+			name:         "No conditional header",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Matching ETag",
+			ifNoneMatch:  currentETag,
+			expectedCode: http.StatusNotModified,
+		},
+		{
+			name:         "Stale ETag",
+			ifNoneMatch:  noteETag(domain.Note{ID: 1, Version: 1, UpdatedAt: note.UpdatedAt.Add(-time.Hour)}),
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockGetNoteByID: func(id, ownerID uint) (domain.Note, error) {
+					return note, nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/:id", handler.GetNoteByIDApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/1", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, currentETag, resp.Header().Get("ETag"))
+			if tt.expectedCode == http.StatusNotModified {
+				assert.Equal(t, 0, resp.Body.Len())
+			}
+		})
+	}
+}
+
+func TestFilterNotesApiPreset(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	presets := &mockPresetUsecase{
+		mockGetPreset: func(ownerID uint, name string) (domain.FilterPreset, error) {
+			if ownerID != 7 {
+				return domain.FilterPreset{}, usecase.ErrPresetNotFound
+			}
+			switch name {
+			case "standups":
+				return domain.FilterPreset{Name: name, Filter: domain.NoteFilter{Keyword: "blocker", Categories: []string{"Standup"}}}, nil
+			case "z-to-a":
+				return domain.FilterPreset{Name: name, Filter: domain.NoteFilter{Sort: domain.SortTitle, Order: domain.SortDesc}}, nil
+			}
+			return domain.FilterPreset{}, usecase.ErrPresetNotFound
+		},
+	}
+
+	tests := []struct {
+		name         string
+		queryParams  string
+		userID       string
+		expectedCode int
+		wantFilter   domain.NoteFilter
+	}{
+		{
+			name:         "Known preset is applied",
+			queryParams:  "?preset=standups",
+			expectedCode: http.StatusOK,
+			wantFilter:   domain.NoteFilter{Keyword: "blocker", Categories: []string{"Standup"}},
+		},
+		{
+			name:         "Another user's preset",
+			queryParams:  "?preset=standups",
+			userID:       "8",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "Explicit params override preset",
+			queryParams:  "?preset=standups&keyword=release",
+			expectedCode: http.StatusOK,
+			wantFilter:   domain.NoteFilter{Keywords: []string{"release"}, Categories: []string{"Standup"}},
+		},
+		{
+			name:         "Preset sort is applied",
+			queryParams:  "?preset=z-to-a",
+			expectedCode: http.StatusOK,
+			wantFilter:   domain.NoteFilter{Sort: domain.SortTitle, Order: domain.SortDesc},
+		},
+		{
+			name:         "Requested sort drops the preset's order",
+			queryParams:  "?preset=z-to-a&sort=created_at",
+			expectedCode: http.StatusOK,
+			wantFilter:   domain.NoteFilter{Sort: domain.SortCreatedAt},
+		},
+		{
+			name:         "Requested order keeps the preset's sort",
+			queryParams:  "?preset=z-to-a&order=asc",
+			expectedCode: http.StatusOK,
+			wantFilter:   domain.NoteFilter{Sort: domain.SortTitle, Order: domain.SortAsc},
+		},
+		{
+			name:         "Unknown preset",
+			queryParams:  "?preset=missing",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilter domain.NoteFilter
+			mockUC := &mockNoteUsecase{
+				mockFilterNotes: func(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error) {
+					gotFilter = filter
+					return []domain.Note{}, 0, nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			handler.Presets = presets
+			router := gin.Default()
+			router.Use(middleware.UserID())
+			router.GET("/notes/filter", handler.FilterNotesApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/filter"+tt.queryParams, nil)
+			userID := tt.userID
+			if userID == "" {
+				userID = "7"
+			}
+			req.Header.Set(middleware.UserIDHeader, userID)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedCode == http.StatusOK {
+				tt.wantFilter.ViewerID = 7
+			}
+			assert.Equal(t, tt.wantFilter, gotFilter)
+		})
+	}
+}
+
+func TestGetNotesByIDsApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		queryParams  string
+		mockError    error
+		expectedCode int
+		wantIDs      []uint
+	}{
+		{
+			name:         "Valid ids",
+			queryParams:  "?ids=3,1,2",
+			expectedCode: http.StatusOK,
+			wantIDs:      []uint{3, 1, 2},
+		},
+		{
+			name:         "Missing ids",
+			queryParams:  "",
+			mockError:    usecase.ErrNoIDs,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Invalid id",
+			queryParams:  "?ids=1,abc",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Repo error",
+			queryParams:  "?ids=1",
+			mockError:    errors.New("db error"),
+			expectedCode: http.StatusInternalServerError,
+			wantIDs:      []uint{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotIDs []uint
+			mockUC := &mockNoteUsecase{
+				mockGetByIDs: func(ids []uint, ownerID uint) ([]domain.Note, error) {
+					gotIDs = ids
+					return []domain.Note{}, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/batch", handler.GetNotesByIDsApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/batch"+tt.queryParams, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
+
+func TestSearchAndFilterPagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		path         string
+		pageLimit    int
+		expectedCode int
+		wantPage     domain.Page
+		wantTotal    string
+	}{
+		{
+			name:         "Search uses the default page size",
+			path:         "/notes/search?keyword=budget",
+			expectedCode: http.StatusOK,
+			wantPage:     domain.Page{Limit: DefaultPageLimit},
+			wantTotal:    "42",
+		},
+		{
+			name:         "Filter uses the configured page size",
+			path:         "/notes/filter?category=Standup",
+			pageLimit:    25,
+			expectedCode: http.StatusOK,
+			wantPage:     domain.Page{Limit: 25},
+			wantTotal:    "42",
+		},
+		{
+			name:         "Limit of zero returns everything",
+			path:         "/notes/search?keyword=budget&limit=0",
+			pageLimit:    25,
+			expectedCode: http.StatusOK,
+			wantPage:     domain.Page{},
+			wantTotal:    "42",
+		},
+		{
+			name:         "Search with limit and offset",
+			path:         "/notes/search?keyword=budget&limit=5&offset=10",
+			expectedCode: http.StatusOK,
+			wantPage:     domain.Page{Limit: 5, Offset: 10},
+			wantTotal:    "42",
+		},
+		{
+			name:         "Filter with limit and offset",
+			path:         "/notes/filter?category=Standup&limit=5&offset=10",
+			expectedCode: http.StatusOK,
+			wantPage:     domain.Page{Limit: 5, Offset: 10},
+			wantTotal:    "42",
+		},
+		{
+			name:         "Invalid limit",
+			path:         "/notes/search?keyword=budget&limit=abc",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Invalid offset",
+			path:         "/notes/filter?offset=abc",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Negative limit",
+			path:         "/notes/filter?limit=-1",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPage domain.Page
+			mockUC := &mockNoteUsecase{
+				mockSearch: func(viewerID uint, keyword string, dates domain.DateRange, page domain.Page) ([]domain.SearchResult, int64, error) {
+					gotPage = page
+					return []domain.SearchResult{{Note: domain.Note{ID: 1}}}, 42, nil
+				},
+				mockFilterNotes: func(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error) {
+					gotPage = page
+					return []domain.Note{{ID: 1}}, 42, nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			handler.PageLimit = tt.pageLimit
+			router := gin.Default()
+			router.GET("/notes/search", handler.SearchNotesByKeywordApi)
+			router.GET("/notes/filter", handler.FilterNotesApi)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, tt.wantPage, gotPage)
+			assert.Equal(t, tt.wantTotal, resp.Header().Get("X-Total-Count"))
+		})
+	}
+}
+
+func TestSearchNotesDateRangeApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		path         string
+		mockError    error
+		wantDates    string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "No dates",
+			path:         "/notes/search?keyword=budget",
+			wantDates:    "-",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "From date only",
+			path:         "/notes/search?keyword=budget&fromDate=2025-01-01",
+			wantDates:    "2025-01-01-",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Both dates",
+			path:         "/notes/search?keyword=budget&fromDate=2025-01-01&toDate=2025-03-31",
+			wantDates:    "2025-01-01-2025-03-31",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Malformed date",
+			path:         "/notes/search?keyword=budget&toDate=31-03-2025",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "Invalid toDate format. Use YYYY-MM-DD.",
+		},
+		{
+			name:         "From after to",
+			path:         "/notes/search?keyword=budget&fromDate=2025-03-31&toDate=2025-01-01",
+			mockError:    usecase.ErrInvalidDateRange,
+			wantDates:    "2025-03-31-2025-01-01",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "fromDate must be before toDate",
+		},
+	}
+
+	format := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format("2006-01-02")
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotDates string
+			mockUC := &mockNoteUsecase{
+				mockSearch: func(viewerID uint, keyword string, dates domain.DateRange, page domain.Page) ([]domain.SearchResult, int64, error) {
+					gotDates = format(dates.From) + "-" + format(dates.To)
+					return []domain.SearchResult{{Note: domain.Note{ID: 1}}}, 1, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/search", handler.SearchNotesByKeywordApi)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, tt.wantDates, gotDates)
+			assert.Equal(t, true, strings.Contains(resp.Body.String(), tt.expectedBody))
+		})
+	}
+}
+
+func TestRenameCategoryApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		body         string
+		mockReturn   int64
+		mockError    error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "Valid rename",
+			body:         `{"from": "OldTeam", "to": "NewTeam"}`,
+			mockReturn:   3,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":{"renamed":3},"meta":{},"error":null}`,
+		},
+		{
+			name:         "Invalid JSON",
+			body:         `{"from": "OldTeam"`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Empty category",
+			body:         `{"from": "OldTeam"}`,
+			mockError:    usecase.ErrEmptyCategory,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Category quota reached",
+			body:         `{"from": "OldTeam", "to": "NewTeam"}`,
+			mockError:    usecase.ErrCategoryQuotaExceeded,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"data":null,"meta":{},"error":{"message":"category has reached its note quota"}}`,
+		},
+		{
+			name:         "Same category",
+			body:         `{"from": "OldTeam", "to": "OldTeam"}`,
+			mockError:    usecase.ErrSameCategory,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Repo error",
+			body:         `{"from": "OldTeam", "to": "NewTeam"}`,
+			mockError:    errors.New("db error"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOwner uint
+			mockUC := &mockNoteUsecase{
+				mockRename: func(ownerID uint, from, to string) (int64, error) {
+					gotOwner = ownerID
+					return tt.mockReturn, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.Use(middleware.UserID())
+			router.POST("/notes/category/rename", handler.RenameCategoryApi)
+
+			req := httptest.NewRequest(http.MethodPost, "/notes/category/rename", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(middleware.UserIDHeader, "7")
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if resp.Code == http.StatusOK {
+				assert.Equal(t, uint(7), gotOwner)
+			}
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, resp.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetRecentNotesApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		queryParams  string
+		mockReturn   []domain.Note
+		mockError    error
+		expectedCode int
+		wantLimit    int
+		expectedBody string
+	}{
+		{
+			name:         "Default limit",
+			mockReturn:   []domain.Note{},
+			expectedCode: http.StatusOK,
+			wantLimit:    DefaultPageLimit,
+			expectedBody: `{"data":[],"meta":{},"error":null}`,
+		},
+		{
+			name:         "Explicit limit",
+			queryParams:  "?limit=2",
+			mockReturn:   []domain.Note{{ID: 2}, {ID: 1}},
+			expectedCode: http.StatusOK,
+			wantLimit:    2,
+		},
+		{
+			name:         "Invalid limit",
+			queryParams:  "?limit=abc",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Negative limit",
+			queryParams:  "?limit=-1",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Zero limit gets the most allowed",
+			queryParams:  "?limit=0",
+			mockReturn:   []domain.Note{},
+			expectedCode: http.StatusOK,
+			wantLimit:    usecase.MaxRecentLimit,
+		},
+		{
+			name:         "Limit is capped",
+			queryParams:  "?limit=500",
+			mockReturn:   []domain.Note{},
+			expectedCode: http.StatusOK,
+			wantLimit:    usecase.MaxRecentLimit,
+		},
+		{
+			name:         "Repo error",
+			mockError:    errors.New("db error"),
+			expectedCode: http.StatusInternalServerError,
+			wantLimit:    DefaultPageLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotLimit int
+			mockUC := &mockNoteUsecase{
+				mockRecent: func(limit int) ([]domain.Note, error) {
+					gotLimit = limit
+					return tt.mockReturn, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/recent", handler.GetRecentNotesApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/recent"+tt.queryParams, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, tt.wantLimit, gotLimit)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, resp.Body.String())
+			}
+		})
+	}
+}
+
+func TestUpsertNoteApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		body         string
+		mockReturn   error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "Valid import",
+			body:         `{"title": "Imported", "content": "Some content", "meetingdate": "2025-06-16T10:30:00Z"}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Invalid JSON",
+			body:         `{"title": "Imported"`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Empty title",
+			body:         `{"title": "", "content": "Some content"}`,
+			mockReturn:   usecase.ErrEmptyTitle,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid priority",
+			body: `{"title": "Imported", "content": "Some content", "priority": "urgent"}`,
+			mockReturn: &usecase.ValidationError{Fields: []usecase.FieldError{
+				{Field: "priority", Err: usecase.ErrInvalidPriority},
+			}},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"data":null,"meta":{},"error":{"message":"note failed validation","fields":{"priority":"priority must be low, normal, high or critical"}}}`,
+		},
+		{
+			name:         "Duplicate title and date",
+			body:         `{"title": "Imported", "content": "Some content"}`,
+			mockReturn:   usecase.ErrDuplicateNote,
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "Repo error",
+			body:         `{"title": "Imported", "content": "Some content"}`,
+			mockReturn:   errors.New("db error"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotExternalID string
+			mockUC := &mockNoteUsecase{
+				mockUpsert: func(n *domain.Note) error {
+					gotExternalID = n.ExternalID
+					return tt.mockReturn
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.PUT("/notes/external/:externalID", handler.UpsertNoteApi)
+
+			req := httptest.NewRequest(http.MethodPut, "/notes/external/crm-42", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, resp.Body.String())
+			}
+			if tt.expectedCode != http.StatusBadRequest || tt.mockReturn != nil {
+				assert.Equal(t, "crm-42", gotExternalID)
+			}
+		})
+	}
+}
+
+func TestFilterNotesApiCreatedRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	createdFrom := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)
+	fromDate := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		queryParams  string
+		mockError    error
+		expectedCode int
+		wantFilter   domain.NoteFilter
+	}{
+		{
+			name:         "Created range",
+			queryParams:  "?createdFrom=2025-03-01&createdTo=2025-03-31",
+			expectedCode: http.StatusOK,
+			wantFilter:   domain.NoteFilter{CreatedFrom: &createdFrom, CreatedTo: &createdTo},
+		},
+		{
+			name:         "Combined with meeting date range",
+			queryParams:  "?fromDate=2025-01-01&createdFrom=2025-03-01",
+			expectedCode: http.StatusOK,
+			wantFilter:   domain.NoteFilter{FromDate: &fromDate, CreatedFrom: &createdFrom},
+		},
+		{
+			name:         "Invalid createdFrom format",
+			queryParams:  "?createdFrom=01-03-2025",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Illogical created range",
+			queryParams:  "?createdFrom=2025-03-31&createdTo=2025-03-01",
+			mockError:    usecase.ErrInvalidCreatedRange,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilter domain.NoteFilter
+			mockUC := &mockNoteUsecase{
+				mockFilterNotes: func(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error) {
+					gotFilter = filter
+					return []domain.Note{}, 0, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/filter", handler.FilterNotesApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/filter"+tt.queryParams, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.mockError == nil {
+				assert.Equal(t, tt.wantFilter, gotFilter)
+			}
+		})
+	}
+}
+
+func TestValidateNoteApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		query        string
+		body         string
+		mockReturn   error
+		expectedCode int
+		expectedBody string
+		wantOpts     usecase.CreateOptions
+	}{
+		{
+			name:         "Valid note",
+			body:         `{"title": "Test meeting", "content": "Some content", "meetingdate": "2025-06-16T10:30:00Z"}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":{"valid":true},"meta":{},"error":null}`,
+		},
+		{
+			name:         "Options as on create",
+			query:        "?useTemplate=true&force=true",
+			body:         `{"title": "Test meeting", "category": "Standup"}`,
+			expectedCode: http.StatusOK,
+			wantOpts:     usecase.CreateOptions{Force: true, UseTemplate: true},
+		},
+		{
+			name: "All failing fields listed",
+			body: `{"title": "", "content": ""}`,
+			mockReturn: &usecase.ValidationError{Fields: []usecase.FieldError{
+				{Field: "title", Err: usecase.ErrEmptyTitle},
+				{Field: "content", Err: usecase.ErrEmptyContent},
+				{Field: "meeting_date", Err: usecase.ErrInvalidMeetingDate},
+			}},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedBody: `{"data":null,"meta":{},"error":{"message":"note failed validation","fields":{"content":"note content cannot be empty","meeting_date":"meeting date is required","title":"note title cannot be empty"}}}`,
+		},
+		{
+			name:         "Invalid JSON",
+			body:         `{"title": "Test meeting"`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Unexpected error",
+			body:         `{"title": "Test meeting", "content": "Some content"}`,
+			mockReturn:   errors.New("boom"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOpts usecase.CreateOptions
+			mockUC := &mockNoteUsecase{
+				mockValidate: func(n *domain.Note, opts usecase.CreateOptions) error {
+					gotOpts = opts
+					return tt.mockReturn
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.POST("/notes/validate", handler.ValidateNoteApi)
+
+			req := httptest.NewRequest(http.MethodPost, "/notes/validate"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, resp.Body.String())
+			}
+			assert.Equal(t, tt.wantOpts, gotOpts)
+		})
+	}
+}
+
+func TestCreateNoteApiReportsAllFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUC := &mockNoteUsecase{
+		mockCreateNote: func(n *domain.Note) error {
+			return &usecase.ValidationError{Fields: []usecase.FieldError{
+				{Field: "title", Err: usecase.ErrEmptyTitle},
+				{Field: "content", Err: usecase.ErrEmptyContent},
+			}}
+		},
+	}
+
+	handler := NewNoteHandler(mockUC)
+	router := gin.Default()
+	router.POST("/notes", handler.CreateNoteApi)
+
+	req := httptest.NewRequest(http.MethodPost, "/notes", strings.NewReader(`{"title": "", "content": "", "meeting_date": "2025-06-16T10:30:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Equal(t, `{"data":null,"meta":{},"error":{"message":"note failed validation","fields":{"content":"note content cannot be empty","title":"note title cannot be empty"}}}`, resp.Body.String())
+}
+
+func TestCreateNoteApiBlockedContent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUC := &mockNoteUsecase{
+		mockCreateNote: func(n *domain.Note) error {
+			return &usecase.ValidationError{Fields: []usecase.FieldError{
+				{Field: "content", Err: &usecase.BlockedContentError{Category: "profanity"}},
+			}}
+		},
+	}
+
+	handler := NewNoteHandler(mockUC)
+	router := gin.Default()
+	router.POST("/notes", handler.CreateNoteApi)
+
+	req := httptest.NewRequest(http.MethodPost, "/notes", strings.NewReader(`{"title": "Retro", "content": "darn", "meeting_date": "2025-06-16T10:30:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	assert.Equal(t, `{"data":null,"meta":{},"error":{"message":"note content contains a blocked term","fields":{"content":"content contains a blocked term (profanity)"}}}`, resp.Body.String())
+}
+
+func TestGetNotesGroupedApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	groups := map[string][]domain.Note{
+		"Standup": {{ID: 2, Title: "Sync", Content: "Long notes"}},
+		"":        {{ID: 1, Title: "Loose"}},
+	}
+
+	tests := []struct {
+		name          string
+		query         string
+		truncated     bool
+		mockErr       error
+		wantCode      int
+		wantLimit     int
+		wantBody      string
+		wantTruncated string
+	}{
+		{
+			name:     "Grouped notes",
+			query:    "?fields=id,title",
+			wantCode: http.StatusOK,
+			wantBody: `{"data":{"":[{"ID":1,"Title":"Loose"}],"Standup":[{"ID":2,"Title":"Sync"}]},"meta":{},"error":null}`,
+		},
+		{
+			name:          "Limit per group and truncated",
+			query:         "?limit=3&fields=id,title",
+			truncated:     true,
+			wantCode:      http.StatusOK,
+			wantLimit:     3,
+			wantBody:      `{"data":{"":[{"ID":1,"Title":"Loose"}],"Standup":[{"ID":2,"Title":"Sync"}]},"meta":{"truncated":true},"error":null}`,
+			wantTruncated: "true",
+		},
+		{name: "Zero limit", query: "?limit=0", wantCode: http.StatusBadRequest},
+		{name: "Invalid limit", query: "?limit=abc", wantCode: http.StatusBadRequest},
+		{name: "Usecase error", mockErr: errors.New("db error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotLimit int
+			mockUC := &mockNoteUsecase{
+				mockGetGrouped: func(userID uint, includeArchived bool, limit int) (map[string][]domain.Note, bool, error) {
+					gotLimit = limit
+					if tt.mockErr != nil {
+						return nil, false, tt.mockErr
+					}
+					copied := map[string][]domain.Note{}
+					for category, notes := range groups {
+						copied[category] = append([]domain.Note(nil), notes...)
+					}
+					return copied, tt.truncated, nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/grouped", handler.GetNotesGroupedApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/grouped"+tt.query, nil)
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, resp.Body.String())
+				assert.Equal(t, tt.wantLimit, gotLimit)
+				assert.Equal(t, tt.wantTruncated, resp.Header().Get("X-Truncated"))
+			}
+		})
+	}
+}
+
+func TestArchiveNoteApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		id           string
+		body         string
+		mockReturn   error
+		wantCode     int
+		wantArchived bool
+	}{
+		{name: "Empty body archives", id: "1", wantCode: http.StatusOK, wantArchived: true},
+		{name: "Unarchive", id: "1", body: `{"archived": false}`, wantCode: http.StatusOK, wantArchived: false},
+		{name: "Invalid ID", id: "abc", wantCode: http.StatusBadRequest},
+		{name: "Invalid JSON", id: "1", body: `{"archived": `, wantCode: http.StatusBadRequest},
+		{name: "Not found", id: "1", mockReturn: usecase.ErrNoteNotFound, wantCode: http.StatusNotFound},
+		{name: "Usecase error", id: "1", mockReturn: errors.New("boom"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArchived bool
+			mockUC := &mockNoteUsecase{
+				mockArchive: func(id, ownerID uint, archived bool) error {
+					gotArchived = archived
+					return tt.mockReturn
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.POST("/notes/:id/archive", handler.ArchiveNoteApi)
+
+			req := httptest.NewRequest(http.MethodPost, "/notes/"+tt.id+"/archive", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			if tt.wantCode == http.StatusOK {
+				assert.Equal(t, tt.wantArchived, gotArchived)
+			}
+		})
+	}
+}
+
+func TestGetAllNotesApiIncludeArchived(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for query, want := range map[string]bool{"": false, "?includeArchived=true": true} {
+		var got bool
+		mockUC := &mockNoteUsecase{
+			mockGetAllNotes: func(ownerID uint, includeArchived bool) ([]domain.Note, bool, error) {
+				got = includeArchived
+				return nil, false, nil
+			},
+		}
+
+		handler := NewNoteHandler(mockUC)
+		router := gin.Default()
+		router.GET("/notes", handler.GetAllNotesApi)
+
+		req := httptest.NewRequest(http.MethodGet, "/notes"+query, nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestGetPaginatedNotesApiIncludeArchived(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for query, want := range map[string]bool{"": false, "?includeArchived=true": true} {
+		var got bool
+		mockUC := &mockNoteUsecase{
+			mockPaginated: func(viewerID uint, limit, offset int, includeArchived bool) ([]domain.Note, error) {
+				got = includeArchived
+				return nil, nil
+			},
+		}
+
+		handler := NewNoteHandler(mockUC)
+		router := gin.Default()
+		router.GET("/notes/paginated", handler.GetPaginatedNotesApi)
+
+		req := httptest.NewRequest(http.MethodGet, "/notes/paginated"+query, nil)
+		resp := httptest.NewRecorder()
+
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestDiffRevisionsApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		query        string
+		mockReturn   error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "Valid diff",
+			query:        "?from=2&to=4",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"data":{"diff":"- old\n+ new\n","from":2,"note_id":1,"to":4},"meta":{},"error":null}`,
+		},
+		{name: "Missing versions", query: "?from=2", expectedCode: http.StatusBadRequest},
+		{name: "Non-numeric version", query: "?from=a&to=4", expectedCode: http.StatusBadRequest},
+		{name: "Note not found", query: "?from=2&to=4", mockReturn: usecase.ErrNoteNotFound, expectedCode: http.StatusNotFound},
+		{name: "Revision not found", query: "?from=2&to=9", mockReturn: usecase.ErrRevisionNotFound, expectedCode: http.StatusNotFound},
+		{name: "Usecase error", query: "?from=2&to=4", mockReturn: errors.New("boom"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockDiff: func(noteID, ownerID uint, from, to int) (string, error) {
+					if tt.mockReturn != nil {
+						return "", tt.mockReturn
+					}
+					return "- old\n+ new\n", nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/:id/diff", handler.DiffRevisionsApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/1/diff"+tt.query, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, resp.Body.String())
+			}
+		})
+	}
+}
+
+func TestListRevisionsApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		id           string
+		mockReturn   error
+		expectedCode int
+	}{
+		{name: "Valid", id: "1", expectedCode: http.StatusOK},
+		{name: "Invalid ID", id: "abc", expectedCode: http.StatusBadRequest},
+		{name: "Not found", id: "1", mockReturn: usecase.ErrNoteNotFound, expectedCode: http.StatusNotFound},
+		{name: "Usecase error", id: "1", mockReturn: errors.New("boom"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockRevisions: func(noteID, ownerID uint) ([]domain.NoteRevision, error) {
+					return []domain.NoteRevision{{NoteID: noteID, Version: 1}}, tt.mockReturn
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/:id/revisions", handler.ListRevisionsApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/"+tt.id+"/revisions", nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+		})
+	}
+}
+
+func TestAddAttachmentApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	validBody := `{"filename": "slides.pdf", "url": "https://files.example.com/slides.pdf", "size_bytes": 2048}`
+
+	tests := []struct {
+		name         string
+		id           string
+		body         string
+		mockReturn   error
+		expectedCode int
+		expectedBody string
+	}{
+		{name: "Valid", id: "1", body: validBody, expectedCode: http.StatusCreated, expectedBody: `"note_id":1`},
+		{name: "Invalid ID", id: "abc", body: validBody, expectedCode: http.StatusBadRequest},
+		{name: "Malformed body", id: "1", body: `{"filename":`, expectedCode: http.StatusBadRequest, expectedBody: `"error":{"message":"Invalid input to add attachment"}`},
+		{
+			name:         "Validation error",
+			id:           "1",
+			body:         `{"filename": "slides.pdf", "url": "slides"}`,
+			mockReturn:   &usecase.ValidationError{Fields: []usecase.FieldError{{Field: "url", Err: usecase.ErrInvalidAttachmentURL}}},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `"error":{"message":"note failed validation","fields":{"url":"attachment url must be an absolute http or https url"}}`,
+		},
+		{name: "Not found", id: "1", body: validBody, mockReturn: usecase.ErrNoteNotFound, expectedCode: http.StatusNotFound},
+		{name: "Usecase error", id: "1", body: validBody, mockReturn: errors.New("boom"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockAddAttachment: func(noteID, ownerID uint, a *domain.Attachment) error {
+					a.ID = 1
+					a.NoteID = noteID
+					return tt.mockReturn
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.POST("/notes/:id/attachments", handler.AddAttachmentApi)
+
+			req := httptest.NewRequest(http.MethodPost, "/notes/"+tt.id+"/attachments", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, true, strings.Contains(resp.Body.String(), tt.expectedBody))
+			}
+		})
+	}
+}
+
+func TestListAttachmentsApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		id           string
+		mockReturn   error
+		expectedCode int
+	}{
+		{name: "Valid", id: "1", expectedCode: http.StatusOK},
+		{name: "Invalid ID", id: "abc", expectedCode: http.StatusBadRequest},
+		{name: "Not found", id: "1", mockReturn: usecase.ErrNoteNotFound, expectedCode: http.StatusNotFound},
+		{name: "Usecase error", id: "1", mockReturn: errors.New("boom"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockListAttachments: func(noteID, ownerID uint) ([]domain.Attachment, error) {
+					return []domain.Attachment{{ID: 1, NoteID: noteID, Filename: "slides.pdf"}}, tt.mockReturn
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/:id/attachments", handler.ListAttachmentsApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/"+tt.id+"/attachments", nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+		})
+	}
+}
+
+func TestAddNoteLinkApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	validBody := `{"to_note_id": 2, "relation": "followup"}`
+
+	tests := []struct {
+		name         string
+		id           string
+		body         string
+		mockReturn   error
+		expectedCode int
+		expectedBody string
+	}{
+		{name: "Valid", id: "1", body: validBody, expectedCode: http.StatusCreated, expectedBody: `"summary":"Actions"`},
+		{name: "Invalid ID", id: "abc", body: validBody, expectedCode: http.StatusBadRequest},
+		{name: "Malformed body", id: "1", body: `{"to_note_id":`, expectedCode: http.StatusBadRequest, expectedBody: `"error":{"message":"Invalid input to add link"}`},
+		{
+			name:         "Self-link",
+			id:           "1",
+			body:         `{"to_note_id": 1}`,
+			mockReturn:   &usecase.ValidationError{Fields: []usecase.FieldError{{Field: "to_note_id", Err: usecase.ErrSelfLink}}},
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `"fields":{"to_note_id":"a note cannot link to itself"}`,
+		},
+		{name: "Duplicate", id: "1", body: validBody, mockReturn: usecase.ErrDuplicateLink, expectedCode: http.StatusConflict},
+		{name: "Not found", id: "1", body: validBody, mockReturn: usecase.ErrNoteNotFound, expectedCode: http.StatusNotFound},
+		{name: "Usecase error", id: "1", body: validBody, mockReturn: errors.New("boom"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockAddLink: func(noteID, ownerID uint, link *domain.NoteLink) (domain.LinkedNote, error) {
+					link.ID = 1
+					link.FromNoteID = noteID
+					target := domain.Note{ID: link.ToNoteID, Title: "Follow-up", Content: "Actions in full", Summary: "Actions"}
+					return domain.LinkedNote{Link: *link, Note: target}, tt.mockReturn
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.POST("/notes/:id/links", handler.AddNoteLinkApi)
+
+			req := httptest.NewRequest(http.MethodPost, "/notes/"+tt.id+"/links", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, true, strings.Contains(resp.Body.String(), tt.expectedBody))
+			}
+			assert.Equal(t, false, strings.Contains(resp.Body.String(), "Actions in full"))
+		})
+	}
+}
+
+func TestListNoteLinksApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		id           string
+		mockReturn   error
+		expectedCode int
+	}{
+		{name: "Valid", id: "1", expectedCode: http.StatusOK},
+		{name: "Invalid ID", id: "abc", expectedCode: http.StatusBadRequest},
+		{name: "Not found", id: "1", mockReturn: usecase.ErrNoteNotFound, expectedCode: http.StatusNotFound},
+		{name: "Usecase error", id: "1", mockReturn: errors.New("boom"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockListLinks: func(noteID, ownerID uint) ([]domain.LinkedNote, error) {
+					return []domain.LinkedNote{{
+						Link: domain.NoteLink{ID: 1, FromNoteID: noteID, ToNoteID: 2, Relation: domain.LinkRelated},
+						Note: domain.Note{ID: 2, Title: "Kickoff", Content: "Kickoff in full", Summary: "Kickoff"},
+					}}, tt.mockReturn
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/:id/links", handler.ListNoteLinksApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/"+tt.id+"/links", nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, true, strings.Contains(resp.Body.String(), `"relation":"related"`))
+				assert.Equal(t, false, strings.Contains(resp.Body.String(), "Kickoff in full"))
+			}
+		})
+	}
+}
+
+func TestCreateNoteApiBodyLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	body := `{"title": "Test meeting", "content": "Some content", "meeting_date": "2025-06-16T10:30:00Z"}`
+	limit := int64(len(body))
+
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{name: "Exactly at the limit", body: body, wantCode: http.StatusCreated},
+		{name: "One byte over", body: strings.Replace(body, "Some content", "Some content!", 1), wantCode: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewNoteHandler(&mockNoteUsecase{})
+			router := gin.Default()
+			router.POST("/notes", middleware.MaxBodySize(limit), handler.CreateNoteApi)
+
+			req := httptest.NewRequest(http.MethodPost, "/notes", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			// Hide the length so the limit is hit while binding rather than
+			// up front.
+			req.ContentLength = -1
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+		})
+	}
+}
+
+func TestGetRelatedNotesApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		path         string
+		mockReturn   error
+		expectedCode int
+		wantLimit    int
+	}{
+		{name: "Default limit", path: "/notes/1/related", expectedCode: http.StatusOK, wantLimit: usecase.DefaultRelatedLimit},
+		{name: "Explicit limit", path: "/notes/1/related?limit=2", expectedCode: http.StatusOK, wantLimit: 2},
+		{name: "Invalid limit", path: "/notes/1/related?limit=0", expectedCode: http.StatusBadRequest},
+		{name: "Invalid ID", path: "/notes/abc/related", expectedCode: http.StatusBadRequest},
+		{name: "Not found", path: "/notes/1/related", mockReturn: usecase.ErrNoteNotFound, expectedCode: http.StatusNotFound},
+		{name: "Usecase error", path: "/notes/1/related", mockReturn: errors.New("boom"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotLimit int
+			mockUC := &mockNoteUsecase{
+				mockRelated: func(id, ownerID uint, limit int) ([]domain.Note, error) {
+					gotLimit = limit
+					return []domain.Note{}, tt.mockReturn
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/:id/related", handler.GetRelatedNotesApi)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, tt.wantLimit, gotLimit)
+			}
+		})
+	}
+}
+
+func TestGetNoteWordStatsApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		path         string
+		content      string
+		mockError    error
+		expectedCode int
+		wantBody     string
+	}{
+		{name: "Top words", path: "/notes/1/wordstats", content: "Budget, budget and hiring.", expectedCode: http.StatusOK, wantBody: `"data":[{"word":"budget","count":2},{"word":"hiring","count":1}]`},
+		{name: "Limited", path: "/notes/1/wordstats?limit=1", content: "Budget, budget and hiring.", expectedCode: http.StatusOK, wantBody: `"data":[{"word":"budget","count":2}]`},
+		{name: "Limit above the cap", path: "/notes/1/wordstats?limit=1000", content: "hiring", expectedCode: http.StatusOK, wantBody: `"data":[{"word":"hiring","count":1}]`},
+		{name: "No words", path: "/notes/1/wordstats", content: "", expectedCode: http.StatusOK, wantBody: `"data":[]`},
+		{name: "Invalid limit", path: "/notes/1/wordstats?limit=0", expectedCode: http.StatusBadRequest},
+		{name: "Invalid ID", path: "/notes/abc/wordstats", expectedCode: http.StatusBadRequest},
+		{name: "Not found", path: "/notes/1/wordstats", mockError: usecase.ErrNoteNotFound, expectedCode: http.StatusNotFound},
+		{name: "Usecase error", path: "/notes/1/wordstats", mockError: errors.New("boom"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockGetNoteByID: func(id, ownerID uint) (domain.Note, error) {
+					return domain.Note{ID: id, Content: tt.content}, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/:id/wordstats", handler.GetNoteWordStatsApi)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, true, strings.Contains(resp.Body.String(), tt.wantBody))
+			}
+		})
+	}
+}
+
+func TestGetUpcomingMeetingsApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		query        string
+		mockReturn   error
+		expectedCode int
+		wantWithin   time.Duration
+	}{
+		{name: "Default window", expectedCode: http.StatusOK, wantWithin: 24 * time.Hour},
+		{name: "Explicit window", query: "?withinHours=2", expectedCode: http.StatusOK, wantWithin: 2 * time.Hour},
+		{name: "Zero hours", query: "?withinHours=0", expectedCode: http.StatusBadRequest},
+		{name: "Not a number", query: "?withinHours=soon", expectedCode: http.StatusBadRequest},
+		{name: "Usecase error", mockReturn: errors.New("boom"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotWithin time.Duration
+			mockUC := &mockNoteUsecase{
+				mockUpcoming: func(within time.Duration) ([]domain.Note, error) {
+					gotWithin = within
+					return []domain.Note{}, tt.mockReturn
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/upcoming", handler.GetUpcomingMeetingsApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/upcoming"+tt.query, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, tt.wantWithin, gotWithin)
+			}
+		})
+	}
+}
+
+func TestDuplicateNoteApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		id           string
+		mockReturn   error
+		expectedCode int
+	}{
+		{name: "Duplicated", id: "1", expectedCode: http.StatusCreated},
+		{name: "Invalid ID", id: "abc", expectedCode: http.StatusBadRequest},
+		{name: "Not found", id: "1", mockReturn: usecase.ErrNoteNotFound, expectedCode: http.StatusNotFound},
+		{name: "Category quota reached", id: "1", mockReturn: usecase.ErrCategoryQuotaExceeded, expectedCode: http.StatusUnprocessableEntity},
+		{
+			name:         "Copy fails validation",
+			id:           "1",
+			mockReturn:   &usecase.ValidationError{Fields: []usecase.FieldError{{Field: "meeting_date", Err: usecase.ErrWeekendMeetingDate}}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{name: "Usecase error", id: "1", mockReturn: errors.New("boom"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockDuplicate: func(id, ownerID uint) (domain.Note, error) {
+					if tt.mockReturn != nil {
+						return domain.Note{}, tt.mockReturn
+					}
+					return domain.Note{ID: 2, Title: "Copy of Planning"}, nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.POST("/notes/:id/duplicate", handler.DuplicateNoteApi)
+
+			req := httptest.NewRequest(http.MethodPost, "/notes/"+tt.id+"/duplicate", nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+		})
+	}
+}
+
+func TestFilterNotesApiCleanupParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		query        string
+		expectedCode int
+		wantFilter   domain.NoteFilter
+	}{
+		{
+			name:         "Uncategorized with keyword",
+			query:        "?uncategorized=true&keyword=sync",
+			expectedCode: http.StatusOK,
+			wantFilter:   domain.NoteFilter{Keywords: []string{"sync"}, UncategorizedOnly: true},
+		},
+		{
+			name:         "Short content",
+			query:        "?maxContentLength=20",
+			expectedCode: http.StatusOK,
+			wantFilter:   domain.NoteFilter{MaxContentLength: 20},
+		},
+		{name: "Invalid content length", query: "?maxContentLength=-1", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilter domain.NoteFilter
+			mockUC := &mockNoteUsecase{
+				mockFilterNotes: func(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error) {
+					gotFilter = filter
+					return nil, 0, nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/filter", handler.FilterNotesApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/filter"+tt.query, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, tt.wantFilter, gotFilter)
+			}
+		})
+	}
+}
+
+func TestExportNotesNDJSONApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	redactor, err := NewRedactor(nil)
+	assert.Equal(t, nil, err)
+
+	notes := []domain.Note{{ID: 1, Title: "Planning", Content: "Mail jo@example.com"}, {ID: 2, Title: "Retro"}}
+
+	tests := []struct {
+		name            string
+		query           string
+		failAfter       int
+		wantCode        int
+		wantContentType string
+		wantLines       []string
+		wantArchived    bool
+	}{
+		{
+			name:            "Streams one note per line",
+			query:           "?includeArchived=true",
+			failAfter:       -1,
+			wantCode:        http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantLines:       []string{`"Content":"Mail [REDACTED]"`, `"ID":2,`},
+			wantArchived:    true,
+		},
+		{
+			name:            "Failure before the first note",
+			failAfter:       0,
+			wantCode:        http.StatusInternalServerError,
+			wantContentType: "application/json; charset=utf-8",
+			wantLines:       []string{`"error":{"message":"Failed to export notes. Please try again later."}`},
+		},
+		{
+			name:            "Failure mid-stream keeps complete lines",
+			failAfter:       1,
+			wantCode:        http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantLines:       []string{`"ID":1,`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArchived bool
+			mockUC := &mockNoteUsecase{
+				mockStreamNotes: func(ownerID uint, includeArchived bool, fn func(domain.Note) error) error {
+					gotArchived = includeArchived
+					for i, n := range notes {
+						if i == tt.failAfter {
+							return errors.New("failed to stream notes")
+						}
+						if err := fn(n); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			handler.Redactor = redactor
+			router := gin.Default()
+			router.GET("/notes/export.ndjson", handler.ExportNotesNDJSONApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/export.ndjson"+tt.query, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			assert.Equal(t, tt.wantContentType, resp.Header().Get("Content-Type"))
+			assert.Equal(t, tt.wantArchived, gotArchived)
+
+			lines := strings.Split(strings.TrimSuffix(resp.Body.String(), "\n"), "\n")
+			assert.Equal(t, len(tt.wantLines), len(lines))
+			for i, want := range tt.wantLines {
+				assert.Equal(t, true, strings.Contains(lines[i], want))
+			}
+		})
+	}
+}
+
+func TestFilterNotesApiKeywords(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		query        string
+		mockError    error
+		expectedCode int
+		wantFilter   domain.NoteFilter
+	}{
+		{
+			name:         "Repeated keyword",
+			query:        "?keyword=budget&keyword=q3",
+			expectedCode: http.StatusOK,
+			wantFilter:   domain.NoteFilter{Keywords: []string{"budget", "q3"}},
+		},
+		{
+			name:         "Comma list with match all",
+			query:        "?keyword=budget,%20,q3&match=all",
+			expectedCode: http.StatusOK,
+			wantFilter:   domain.NoteFilter{Keywords: []string{"budget", "q3"}, KeywordMatch: domain.KeywordMatchAll},
+		},
+		{
+			name:         "Invalid match",
+			query:        "?keyword=budget&match=some",
+			mockError:    usecase.ErrInvalidKeywordMatch,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotFilter domain.NoteFilter
+			mockUC := &mockNoteUsecase{
+				mockFilterNotes: func(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error) {
+					gotFilter = filter
+					return nil, 0, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/filter", handler.FilterNotesApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/filter"+tt.query, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, tt.wantFilter, gotFilter)
+			}
+		})
+	}
+}
+
+func TestExportNoteApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	redactor, err := NewRedactor(nil)
+	assert.Equal(t, nil, err)
+
+	tests := []struct {
+		name         string
+		path         string
+		mockError    error
+		expectedCode int
+	}{
+		{name: "Exported as pdf", path: "/notes/1/export?format=pdf", expectedCode: http.StatusOK},
+		{name: "Exported as markdown", path: "/notes/1/export?format=markdown", expectedCode: http.StatusOK},
+		{name: "Format defaults to pdf", path: "/notes/1/export", expectedCode: http.StatusOK},
+		{name: "Exported as txt", path: "/notes/1/export?format=txt", expectedCode: http.StatusOK},
+		{name: "Exported as txt with crlf", path: "/notes/1/export?format=txt&line_endings=crlf", expectedCode: http.StatusOK},
+		{name: "Unsupported line endings", path: "/notes/1/export?format=txt&line_endings=cr", expectedCode: http.StatusBadRequest},
+		{name: "Unsupported format", path: "/notes/1/export?format=docx", expectedCode: http.StatusBadRequest},
+		{name: "Invalid ID", path: "/notes/abc/export?format=pdf", expectedCode: http.StatusBadRequest},
+		{name: "Not found", path: "/notes/1/export?format=pdf", mockError: usecase.ErrNoteNotFound, expectedCode: http.StatusNotFound},
+		{name: "Usecase error", path: "/notes/1/export?format=pdf", mockError: errors.New("boom"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockGetNoteByID: func(id, ownerID uint) (domain.Note, error) {
+					if tt.mockError != nil {
+						return domain.Note{}, tt.mockError
+					}
+					return domain.Note{ID: id, Title: "Planning", Content: "Mail jo@example.com", MeetingDate: time.Now()}, nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			handler.Redactor = redactor
+			router := gin.Default()
+			router.GET("/notes/:id/export", handler.ExportNoteApi)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedCode == http.StatusOK && strings.Contains(tt.path, "txt") {
+				lineEnding := "\n"
+				if strings.Contains(tt.path, "crlf") {
+					lineEnding = "\r\n"
+				}
+				assert.Equal(t, "text/plain; charset=utf-8", resp.Header().Get("Content-Type"))
+				assert.Equal(t, `attachment; filename="note-1.txt"`, resp.Header().Get("Content-Disposition"))
+				assert.Equal(t, "Planning"+lineEnding+lineEnding, resp.Body.String()[:len("Planning")+2*len(lineEnding)])
+			} else if tt.expectedCode == http.StatusOK && strings.Contains(tt.path, "markdown") {
+				assert.Equal(t, "text/markdown; charset=utf-8", resp.Header().Get("Content-Type"))
+				assert.Equal(t, `attachment; filename="note-1.md"`, resp.Header().Get("Content-Disposition"))
+				assert.Equal(t, true, strings.HasPrefix(resp.Body.String(), "# Planning\n"))
+			} else if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, "application/pdf", resp.Header().Get("Content-Type"))
+				assert.Equal(t, `attachment; filename="note-1.pdf"`, resp.Header().Get("Content-Disposition"))
+				assert.Equal(t, true, strings.HasPrefix(resp.Body.String(), "%PDF-"))
+			}
+		})
+	}
+}
+
+func TestGetNoteBySlugApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		mockError    error
+		expectedCode int
+		expectedBody string
+	}{
+		{name: "Found", expectedCode: http.StatusOK, expectedBody: `"Slug":"q3-planning-kickoff"`},
+		{name: "Not found", mockError: usecase.ErrNoteNotFound, expectedCode: http.StatusNotFound, expectedBody: "Note not found"},
+		{name: "Usecase error", mockError: errors.New("boom"), expectedCode: http.StatusInternalServerError, expectedBody: "Failed to retrieve note"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSlug string
+			mockUC := &mockNoteUsecase{
+				mockGetBySlug: func(slug string, ownerID uint) (domain.Note, error) {
+					gotSlug = slug
+					if tt.mockError != nil {
+						return domain.Note{}, tt.mockError
+					}
+					return domain.Note{ID: 1, Title: "Q3 planning kickoff", Slug: slug}, nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/slug/:slug", handler.GetNoteBySlugApi)
+			router.GET("/notes/:id", handler.GetNoteByIDApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/slug/q3-planning-kickoff", nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, "q3-planning-kickoff", gotSlug)
+			assert.Equal(t, true, strings.Contains(resp.Body.String(), tt.expectedBody))
+		})
+	}
+}
+
+func TestRenderNoteApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		path         string
+		mockNote     domain.Note
+		mockError    error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "Markdown note",
+			path:         "/notes/1/render",
+			mockNote:     domain.Note{ID: 1, Content: "**Agenda** <script>alert(1)</script>", Format: domain.NoteFormatMarkdown},
+			expectedCode: http.StatusOK,
+			expectedBody: "<p><strong>Agenda</strong> alert(1)</p>\n",
+		},
+		{
+			name:         "Plain text note",
+			path:         "/notes/1/render",
+			mockNote:     domain.Note{ID: 1, Content: "**Agenda** <b>", Format: domain.NoteFormatPlaintext},
+			expectedCode: http.StatusOK,
+			expectedBody: "<pre>**Agenda** &lt;b&gt;</pre>",
+		},
+		{name: "Invalid ID", path: "/notes/abc/render", expectedCode: http.StatusBadRequest},
+		{name: "Not found", path: "/notes/1/render", mockError: usecase.ErrNoteNotFound, expectedCode: http.StatusNotFound},
+		{name: "Usecase error", path: "/notes/1/render", mockError: errors.New("boom"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockGetNoteByID: func(id, ownerID uint) (domain.Note, error) {
+					return tt.mockNote, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/:id/render", handler.RenderNoteApi)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, "text/html; charset=utf-8", resp.Header().Get("Content-Type"))
+				assert.Equal(t, tt.expectedBody, resp.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetAllNotesApiAfterID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		query        string
+		mockNotes    []domain.Note
+		mockError    error
+		expectedCode int
+		wantAfterID  uint
+		wantLimit    int
+		wantLastID   string
+	}{
+		{
+			name:         "Next page",
+			query:        "?afterID=100&limit=2",
+			mockNotes:    []domain.Note{{ID: 101}, {ID: 104}},
+			expectedCode: http.StatusOK,
+			wantAfterID:  100,
+			wantLimit:    2,
+			wantLastID:   `"last_id":104`,
+		},
+		{
+			name:         "Default limit",
+			query:        "?afterID=0",
+			mockNotes:    []domain.Note{{ID: 1}},
+			expectedCode: http.StatusOK,
+			wantLimit:    DefaultPageLimit,
+			wantLastID:   `"last_id":1}`,
+		},
+		{
+			name:         "No more notes keeps the cursor",
+			query:        "?afterID=104",
+			mockNotes:    []domain.Note{},
+			expectedCode: http.StatusOK,
+			wantAfterID:  104,
+			wantLimit:    DefaultPageLimit,
+			wantLastID:   `"last_id":104`,
+		},
+		{name: "Invalid afterID", query: "?afterID=abc", expectedCode: http.StatusBadRequest},
+		{name: "Negative afterID", query: "?afterID=-1", expectedCode: http.StatusBadRequest},
+		{
+			name:         "Zero limit gets the most allowed",
+			query:        "?afterID=1&limit=0",
+			mockNotes:    []domain.Note{},
+			expectedCode: http.StatusOK,
+			wantAfterID:  1,
+			wantLimit:    usecase.MaxCursorLimit,
+			wantLastID:   `"last_id":1`,
+		},
+		{
+			name:         "Limit is capped",
+			query:        "?afterID=1&limit=500",
+			mockNotes:    []domain.Note{},
+			expectedCode: http.StatusOK,
+			wantAfterID:  1,
+			wantLimit:    usecase.MaxCursorLimit,
+			wantLastID:   `"last_id":1`,
+		},
+		{name: "Invalid limit", query: "?afterID=1&limit=abc", expectedCode: http.StatusBadRequest},
+		{name: "Negative limit", query: "?afterID=1&limit=-1", expectedCode: http.StatusBadRequest},
+		{name: "Usecase error", query: "?afterID=1", mockError: errors.New("boom"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotAfterID uint
+			var gotLimit int
+			mockUC := &mockNoteUsecase{
+				mockAfterID: func(ownerID, afterID uint, limit int, includeArchived bool) ([]domain.Note, error) {
+					gotAfterID, gotLimit = afterID, limit
+					return tt.mockNotes, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes", handler.GetAllNotesApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes"+tt.query, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedCode != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, tt.wantAfterID, gotAfterID)
+			assert.Equal(t, tt.wantLimit, gotLimit)
+			assert.Equal(t, true, strings.Contains(resp.Body.String(), tt.wantLastID))
+		})
+	}
+}
+
+func TestGetNoteChangesApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	since := time.Date(2025, time.June, 16, 9, 0, 0, 0, time.UTC)
+	changed := since.Add(90 * time.Second)
+
+	tests := []struct {
+		name          string
+		query         string
+		mockChanges   []domain.NoteChange
+		mockMore      bool
+		mockError     error
+		expectedCode  int
+		wantCursor    domain.ChangeCursor
+		wantLimit     int
+		wantNextSince string
+		wantNextID    string
+	}{
+		{
+			name:  "Changes since",
+			query: "?since=2025-06-16T10:00:00%2B01:00",
+			mockChanges: []domain.NoteChange{
+				{Note: domain.Note{ID: 1, UpdatedAt: since.Add(time.Second)}},
+				{Note: domain.Note{ID: 2, UpdatedAt: changed}, Deleted: true},
+			},
+			expectedCode:  http.StatusOK,
+			wantCursor:    domain.ChangeCursor{Since: since},
+			wantLimit:     DefaultPageLimit,
+			wantNextSince: `"next_since":"2025-06-16T09:01:30Z"`,
+			wantNextID:    `"next_after_id":2`,
+		},
+		{
+			name:          "Full sync",
+			mockChanges:   []domain.NoteChange{{Note: domain.Note{ID: 1, UpdatedAt: changed}}},
+			expectedCode:  http.StatusOK,
+			wantLimit:     DefaultPageLimit,
+			wantNextSince: `"next_since":"2025-06-16T09:01:30Z"`,
+			wantNextID:    `"next_after_id":1`,
+		},
+		{
+			name:          "Next page",
+			query:         "?since=2025-06-16T09:00:00Z&after_id=4&limit=1",
+			mockChanges:   []domain.NoteChange{{Note: domain.Note{ID: 5, UpdatedAt: since}}},
+			mockMore:      true,
+			expectedCode:  http.StatusOK,
+			wantCursor:    domain.ChangeCursor{Since: since, AfterID: 4},
+			wantLimit:     1,
+			wantNextSince: `"next_since":"2025-06-16T09:00:00Z"`,
+			wantNextID:    `"next_after_id":5`,
+		},
+		{
+			name:          "No changes keeps since",
+			query:         "?since=2025-06-16T09:00:00.5Z&after_id=3",
+			mockChanges:   []domain.NoteChange{},
+			expectedCode:  http.StatusOK,
+			wantCursor:    domain.ChangeCursor{Since: since.Add(500 * time.Millisecond), AfterID: 3},
+			wantLimit:     DefaultPageLimit,
+			wantNextSince: `"next_since":"2025-06-16T09:00:00.5Z"`,
+			wantNextID:    `"next_after_id":3`,
+		},
+		{name: "Invalid since", query: "?since=2025-06-16", expectedCode: http.StatusBadRequest},
+		{name: "Invalid after_id", query: "?after_id=x", expectedCode: http.StatusBadRequest},
+		{name: "Offset", query: "?offset=10", expectedCode: http.StatusBadRequest},
+		{name: "Usecase error", query: "?since=2025-06-16T09:00:00Z", mockError: errors.New("boom"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotCursor domain.ChangeCursor
+			var gotLimit int
+			mockUC := &mockNoteUsecase{
+				mockChanges: func(ownerID uint, cursor domain.ChangeCursor, limit int) ([]domain.NoteChange, bool, error) {
+					gotCursor, gotLimit = cursor, limit
+					return tt.mockChanges, tt.mockMore, tt.mockError
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/changes", handler.GetNoteChangesApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/changes"+tt.query, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedCode != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, true, gotCursor.Since.Equal(tt.wantCursor.Since))
+			assert.Equal(t, tt.wantCursor.AfterID, gotCursor.AfterID)
+			assert.Equal(t, tt.wantLimit, gotLimit)
+			assert.Equal(t, true, strings.Contains(resp.Body.String(), tt.wantNextSince))
+			assert.Equal(t, true, strings.Contains(resp.Body.String(), tt.wantNextID))
+			assert.Equal(t, true, strings.Contains(resp.Body.String(), fmt.Sprintf(`"has_more":%t`, tt.mockMore)))
+			if len(tt.mockChanges) > 1 {
+				assert.Equal(t, true, strings.Contains(resp.Body.String(), `"deleted":true`))
+			}
+		})
+	}
+}
+
+func TestStrictJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		strict       bool
+		body         string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "Lenient ignores a misspelled field",
+			method:       http.MethodPost,
+			path:         "/notes",
+			body:         `{"titel":"Sprint planning","title":"Sprint planning","content":"Notes"}`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "Strict rejects a misspelled field on create",
+			method:       http.MethodPost,
+			path:         "/notes",
+			strict:       true,
+			body:         `{"titel":"Sprint planning","content":"Notes"}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"data":null,"meta":{},"error":{"message":"unknown field in request body","fields":{"titel":"unknown field"}}}`,
+		},
+		{
+			name:         "Strict rejects an extra nested field",
+			method:       http.MethodPost,
+			path:         "/notes",
+			strict:       true,
+			body:         `{"title":"Sprint planning","content":"Notes","meta":{"author":"jo"}}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"data":null,"meta":{},"error":{"message":"unknown field in request body","fields":{"meta":"unknown field"}}}`,
+		},
+		{
+			name:         "Strict rejects a misspelled field on update",
+			method:       http.MethodPut,
+			path:         "/notes/1",
+			strict:       true,
+			body:         `{"title":"Sprint planning","contnet":"Notes","version":1}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"data":null,"meta":{},"error":{"message":"unknown field in request body","fields":{"contnet":"unknown field"}}}`,
+		},
+		{
+			name:         "Strict accepts known fields in any case",
+			method:       http.MethodPost,
+			path:         "/notes",
+			strict:       true,
+			body:         `{"Title":"Sprint planning","content":"Notes","category":"Planning"}`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "Strict still reports malformed JSON",
+			method:       http.MethodPost,
+			path:         "/notes",
+			strict:       true,
+			body:         `{"title":`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"data":null,"meta":{},"error":{"message":"Invalid input to create note"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{}
+
+			handler := NewNoteHandler(mockUC)
+			handler.StrictJSON = tt.strict
+			router := gin.Default()
+			router.POST("/notes", handler.CreateNoteApi)
+			router.PUT("/notes/:id", handler.UpdateNoteApi)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, resp.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetTodaysMeetingsApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		query        string
+		mockReturn   error
+		expectedTZ   string
+		expectedCode int
+	}{
+		{name: "Default timezone", query: "", expectedTZ: "", expectedCode: http.StatusOK},
+		{name: "Named timezone", query: "?tz=America/New_York", expectedTZ: "America/New_York", expectedCode: http.StatusOK},
+		{name: "Invalid timezone", query: "?tz=Nowhere", expectedTZ: "Nowhere", mockReturn: usecase.ErrInvalidTimezone, expectedCode: http.StatusBadRequest},
+		{name: "Usecase error", query: "", mockReturn: errors.New("boom"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotTZ string
+			mockUC := &mockNoteUsecase{
+				mockToday: func(tz string) ([]domain.Note, error) {
+					gotTZ = tz
+					return []domain.Note{{ID: 1, Title: "Standup"}}, tt.mockReturn
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/today", handler.GetTodaysMeetingsApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/today"+tt.query, nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, tt.expectedTZ, gotTZ)
+		})
+	}
+}
+
+func TestListAuditLogApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		id           string
+		mockReturn   error
+		expectedCode int
+	}{
+		{name: "Valid", id: "1", expectedCode: http.StatusOK},
+		{name: "Invalid ID", id: "abc", expectedCode: http.StatusBadRequest},
+		{name: "Not found", id: "1", mockReturn: usecase.ErrNoteNotFound, expectedCode: http.StatusNotFound},
+		{name: "Usecase error", id: "1", mockReturn: errors.New("boom"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockAuditLog: func(noteID, ownerID uint) ([]domain.AuditLog, error) {
+					return []domain.AuditLog{{ID: 1, NoteID: noteID, Operation: domain.AuditCreate}}, tt.mockReturn
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes/:id/audit", handler.ListAuditLogApi)
+
+			req := httptest.NewRequest(http.MethodGet, "/notes/"+tt.id+"/audit", nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+		})
+	}
+}
+
+func TestListResponsesCarrySummaries(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	note := domain.Note{ID: 1, Title: "Planning", Content: "Agreed on the roadmap for next quarter", Summary: "Agreed on the..."}
+	mockUC := &mockNoteUsecase{
+		mockGetAllNotes: func(ownerID uint, includeArchived bool) ([]domain.Note, bool, error) {
+			return []domain.Note{note}, false, nil
+		},
+		mockFilterNotes: func(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error) {
+			return []domain.Note{note}, 1, nil
+		},
+		mockSearch: func(viewerID uint, keyword string, dates domain.DateRange, page domain.Page) ([]domain.SearchResult, int64, error) {
+			return []domain.SearchResult{{Note: note, Snippet: "the **roadmap**"}}, 1, nil
+		},
+		mockGetNoteByID: func(id, ownerID uint) (domain.Note, error) {
+			return note, nil
+		},
+	}
+
+	handler := NewNoteHandler(mockUC)
+	router := gin.Default()
+	router.GET("/notes", handler.GetAllNotesApi)
+	router.GET("/notes/filter", handler.FilterNotesApi)
+	router.GET("/notes/search", handler.SearchNotesByKeywordApi)
+	router.GET("/notes/:id", handler.GetNoteByIDApi)
+
+	for _, path := range []string{"/notes", "/notes/filter", "/notes/search?keyword=roadmap"} {
+		t.Run(path, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, path, nil))
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			assert.Equal(t, true, strings.Contains(resp.Body.String(), `"summary":"Agreed on the..."`))
+			assert.Equal(t, false, strings.Contains(resp.Body.String(), `"Content"`))
+		})
+	}
+
+	t.Run("single note keeps its content", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/notes/1", nil))
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, true, strings.Contains(resp.Body.String(), `"Content":"Agreed on the roadmap for next quarter"`))
+	})
+}
+
+func TestGetAllNotesApiTruncated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, truncated := range []bool{false, true} {
+		t.Run(fmt.Sprintf("truncated=%v", truncated), func(t *testing.T) {
+			mockUC := &mockNoteUsecase{
+				mockGetAllNotes: func(ownerID uint, includeArchived bool) ([]domain.Note, bool, error) {
+					return []domain.Note{{ID: 1, Title: "Planning"}}, truncated, nil
+				},
+			}
+
+			handler := NewNoteHandler(mockUC)
+			router := gin.Default()
+			router.GET("/notes", handler.GetAllNotesApi)
+
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/notes", nil))
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			assert.Equal(t, truncated, strings.Contains(resp.Body.String(), `"meta":{"truncated":true}`))
+			assert.Equal(t, truncated, resp.Header().Get("X-Truncated") == "true")
+		})
+	}
+}
+
+func TestSparseFieldsets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	note := domain.Note{ID: 1, Title: "Planning", Content: "Agreed on the roadmap", Category: "Team", MeetingDate: time.Date(2025, time.June, 10, 9, 0, 0, 0, time.UTC)}
+	mockUC := &mockNoteUsecase{
+		mockGetAllNotes: func(ownerID uint, includeArchived bool) ([]domain.Note, bool, error) {
+			return []domain.Note{note}, false, nil
+		},
+		mockSearch: func(viewerID uint, keyword string, dates domain.DateRange, page domain.Page) ([]domain.SearchResult, int64, error) {
+			return []domain.SearchResult{{Note: note, Snippet: "the **roadmap**"}}, 1, nil
+		},
+		mockGetNoteByID: func(id, ownerID uint) (domain.Note, error) {
+			return note, nil
+		},
+	}
+
+	handler := NewNoteHandler(mockUC)
+	router := gin.Default()
+	router.GET("/notes", handler.GetAllNotesApi)
+	router.GET("/notes/search", handler.SearchNotesByKeywordApi)
+	router.GET("/notes/:id", handler.GetNoteByIDApi)
+
+	tests := []struct {
+		name         string
+		path         string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "List",
+			path:         "/notes?fields=id,title,meeting_date",
+			expectedCode: http.StatusOK,
+			expectedBody: `"data":[{"ID":1,"MeetingDate":"2025-06-10T09:00:00Z","Title":"Planning"}]`,
+		},
+		{
+			name:         "List asking for content",
+			path:         "/notes?fields=id,content",
+			expectedCode: http.StatusOK,
+			expectedBody: `"data":[{"Content":"Agreed on the roadmap","ID":1}]`,
+		},
+		{
+			name:         "Search projects the note",
+			path:         "/notes/search?keyword=roadmap&fields=title",
+			expectedCode: http.StatusOK,
+			expectedBody: `"data":[{"note":{"Title":"Planning"},"snippet":"the **roadmap**"}]`,
+		},
+		{
+			name:         "Single note",
+			path:         "/notes/1?fields=title,category",
+			expectedCode: http.StatusOK,
+			expectedBody: `"data":{"Category":"Team","Title":"Planning"}`,
+		},
+		{
+			name:         "Unknown field",
+			path:         "/notes?fields=id,owner_id",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `Invalid field \"owner_id\"`,
+		},
+		{
+			name:         "Unknown field on a single note",
+			path:         "/notes/1?fields=secret",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `Invalid field \"secret\"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			assert.Equal(t, true, strings.Contains(resp.Body.String(), tt.expectedBody))
+		})
+	}
+}
+
+func TestSparseFieldsetKeysMatchNoteJSON(t *testing.T) {
+	now := time.Date(2025, time.June, 10, 9, 0, 0, 0, time.UTC)
+	note := domain.Note{
+		ID: 1, Title: "Planning", Content: "Agreed on the roadmap", Summary: "Agreed",
+		Category: "Team", MeetingDate: now, EndTime: now, DurationMinutes: 30,
+		Location: "Room 1", MeetingURL: "https://meet.example.com/1", Slug: "planning",
+		Format: domain.NoteFormatPlaintext, Priority: domain.PriorityNormal,
+		Visibility: domain.VisibilityPrivate, Archived: true, Version: 1,
+		CreatedAt: now, UpdatedAt: now,
+	}
+
+	whole, err := json.Marshal(note)
+	assert.Equal(t, nil, err)
+	var keys map[string]interface{}
+	assert.Equal(t, nil, json.Unmarshal(whole, &keys))
+
+	for name, field := range noteFields {
+		if _, ok := keys[field.key]; !ok {
+			t.Errorf("field %q is projected as %q, which a whole note doesn't have", name, field.key)
+		}
+	}
+}
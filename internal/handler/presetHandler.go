@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+	"github.com/jt00721/meeting-notes-manager/internal/middleware"
+	"github.com/jt00721/meeting-notes-manager/internal/usecase"
+)
+
+type PresetHandler struct {
+	Usecase usecase.PresetUsecase
+	Logger  logger.Logger
+}
+
+func NewPresetHandler(u usecase.PresetUsecase) *PresetHandler {
+	return NewPresetHandlerWithLogger(u, logger.Default())
+}
+
+func NewPresetHandlerWithLogger(u usecase.PresetUsecase, l logger.Logger) *PresetHandler {
+	return &PresetHandler{Usecase: u, Logger: l}
+}
+
+// CreatePresetApi godoc
+// @Summary Save a filter preset
+// @Description Saves the preset for the caller. Preset names only need to be unique among the caller's own presets.
+// @Tags presets
+// @Accept json
+// @Produce json
+// @Param preset body domain.FilterPreset true "Preset to save"
+// @Success 201 {object} Response{data=domain.FilterPreset}
+// @Failure 400 {object} Response
+// @Failure 409 {object} Response
+// @Failure 500 {object} Response
+// @Router /presets [post]
+func (handler *PresetHandler) CreatePresetApi(c *gin.Context) {
+	var preset domain.FilterPreset
+	if err := c.ShouldBindJSON(&preset); err != nil {
+		handler.Logger.Warn("invalid request body", "operation", "create_preset", "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid input to create preset")
+		return
+	}
+
+	preset.OwnerID = middleware.CurrentUserID(c)
+	if err := handler.Usecase.CreatePreset(&preset); err != nil {
+		handler.writePresetError(c, "create_preset", err)
+		return
+	}
+
+	respondOK(c, http.StatusCreated, preset, nil)
+}
+
+// GetAllPresetsApi godoc
+// @Summary List the caller's filter presets
+// @Tags presets
+// @Produce json
+// @Success 200 {object} Response{data=[]domain.FilterPreset}
+// @Failure 500 {object} Response
+// @Router /presets [get]
+func (handler *PresetHandler) GetAllPresetsApi(c *gin.Context) {
+	presets, err := handler.Usecase.GetAllPresets(middleware.CurrentUserID(c))
+	if err != nil {
+		handler.writePresetError(c, "get_presets", err)
+		return
+	}
+
+	if presets == nil {
+		presets = []domain.FilterPreset{}
+	}
+	respondOK(c, http.StatusOK, presets, nil)
+}
+
+// GetPresetApi godoc
+// @Summary Get one of the caller's filter presets
+// @Tags presets
+// @Produce json
+// @Param name path string true "Preset name"
+// @Success 200 {object} Response{data=domain.FilterPreset}
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /presets/{name} [get]
+func (handler *PresetHandler) GetPresetApi(c *gin.Context) {
+	preset, err := handler.Usecase.GetPreset(middleware.CurrentUserID(c), c.Param("name"))
+	if err != nil {
+		handler.writePresetError(c, "get_preset", err)
+		return
+	}
+
+	respondOK(c, http.StatusOK, preset, nil)
+}
+
+// UpdatePresetApi godoc
+// @Summary Replace one of the caller's filter presets
+// @Tags presets
+// @Accept json
+// @Produce json
+// @Param name path string true "Preset name"
+// @Param preset body domain.FilterPreset true "New preset filter"
+// @Success 200 {object} Response{data=domain.FilterPreset}
+// @Failure 400 {object} Response
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /presets/{name} [put]
+func (handler *PresetHandler) UpdatePresetApi(c *gin.Context) {
+	var preset domain.FilterPreset
+	if err := c.ShouldBindJSON(&preset); err != nil {
+		handler.Logger.Warn("invalid request body", "operation", "update_preset", "error", err)
+		respondError(c, http.StatusBadRequest, "Invalid input to update preset")
+		return
+	}
+
+	preset.Name = c.Param("name")
+	preset.OwnerID = middleware.CurrentUserID(c)
+	if err := handler.Usecase.UpdatePreset(&preset); err != nil {
+		handler.writePresetError(c, "update_preset", err)
+		return
+	}
+
+	respondOK(c, http.StatusOK, preset, nil)
+}
+
+// DeletePresetApi godoc
+// @Summary Delete one of the caller's filter presets
+// @Tags presets
+// @Produce json
+// @Param name path string true "Preset name"
+// @Success 200 {object} Response{data=map[string]string}
+// @Failure 404 {object} Response
+// @Failure 500 {object} Response
+// @Router /presets/{name} [delete]
+func (handler *PresetHandler) DeletePresetApi(c *gin.Context) {
+	if err := handler.Usecase.DeletePreset(middleware.CurrentUserID(c), c.Param("name")); err != nil {
+		handler.writePresetError(c, "delete_preset", err)
+		return
+	}
+
+	respondOK(c, http.StatusOK, gin.H{"message": "Preset deleted"}, nil)
+}
+
+func (handler *PresetHandler) writePresetError(c *gin.Context, operation string, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrPresetNotFound):
+		respondError(c, http.StatusNotFound, "preset not found")
+	case errors.Is(err, usecase.ErrPresetExists):
+		respondError(c, http.StatusConflict, "preset name already exists")
+	case errors.Is(err, usecase.ErrEmptyPresetName):
+		respondError(c, http.StatusBadRequest, "preset name cannot be empty")
+	case errors.Is(err, usecase.ErrInvalidDateRange):
+		respondError(c, http.StatusBadRequest, "fromDate must be before toDate")
+	case errors.Is(err, usecase.ErrInvalidCreatedRange):
+		respondError(c, http.StatusBadRequest, "createdFrom must be before createdTo")
+	default:
+		handler.Logger.Error("preset request failed", "operation", operation, "error", err)
+		respondError(c, http.StatusInternalServerError, "Failed to process preset. Please try again later.")
+	}
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/middleware"
+	"github.com/jt00721/meeting-notes-manager/internal/usecase"
+)
+
+type mockPresetUsecase struct {
+	mockCreatePreset func(p *domain.FilterPreset) error
+	mockGetPreset    func(ownerID uint, name string) (domain.FilterPreset, error)
+	mockUpdatePreset func(p *domain.FilterPreset) error
+	mockDeletePreset func(ownerID uint, name string) error
+}
+
+func (m *mockPresetUsecase) CreatePreset(p *domain.FilterPreset) error {
+	if m.mockCreatePreset != nil {
+		return m.mockCreatePreset(p)
+	}
+	return nil
+}
+
+func (m *mockPresetUsecase) GetAllPresets(ownerID uint) ([]domain.FilterPreset, error) {
+	return []domain.FilterPreset{}, nil
+}
+
+func (m *mockPresetUsecase) GetPreset(ownerID uint, name string) (domain.FilterPreset, error) {
+	if m.mockGetPreset != nil {
+		return m.mockGetPreset(ownerID, name)
+	}
+	return domain.FilterPreset{}, usecase.ErrPresetNotFound
+}
+
+func (m *mockPresetUsecase) UpdatePreset(p *domain.FilterPreset) error {
+	if m.mockUpdatePreset != nil {
+		return m.mockUpdatePreset(p)
+	}
+	return nil
+}
+
+func (m *mockPresetUsecase) DeletePreset(ownerID uint, name string) error {
+	if m.mockDeletePreset != nil {
+		return m.mockDeletePreset(ownerID, name)
+	}
+	return nil
+}
+
+func TestCreatePresetApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockReturn error
+		wantCode   int
+	}{
+		{
+			name:     "Valid preset",
+			body:     `{"name": "standups", "filter": {"categories": ["Standup"]}}`,
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:     "Invalid JSON",
+			body:     `{"name": "standups"`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Duplicate name",
+			body:       `{"name": "standups"}`,
+			mockReturn: usecase.ErrPresetExists,
+			wantCode:   http.StatusConflict,
+		},
+		{
+			name:       "Illogical date range",
+			body:       `{"name": "backwards", "filter": {"from_date": "2025-06-01T00:00:00Z", "to_date": "2025-01-01T00:00:00Z"}}`,
+			mockReturn: usecase.ErrInvalidDateRange,
+			wantCode:   http.StatusBadRequest,
+		},
+		{
+			name:       "Repo error",
+			body:       `{"name": "standups"}`,
+			mockReturn: errors.New("db error"),
+			wantCode:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOwner uint
+			mockUC := &mockPresetUsecase{
+				mockCreatePreset: func(p *domain.FilterPreset) error {
+					gotOwner = p.OwnerID
+					return tt.mockReturn
+				},
+			}
+
+			handler := NewPresetHandler(mockUC)
+			router := gin.Default()
+			router.Use(middleware.UserID())
+			router.POST("/presets", handler.CreatePresetApi)
+
+			req := httptest.NewRequest(http.MethodPost, "/presets", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(middleware.UserIDHeader, "7")
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			if tt.wantCode == http.StatusCreated {
+				assert.Equal(t, uint(7), gotOwner)
+			}
+		})
+	}
+}
+
+func TestGetAndDeletePresetApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUC := &mockPresetUsecase{
+		mockGetPreset: func(ownerID uint, name string) (domain.FilterPreset, error) {
+			if ownerID == 7 && name == "standups" {
+				return domain.FilterPreset{ID: 1, OwnerID: ownerID, Name: name}, nil
+			}
+			return domain.FilterPreset{}, usecase.ErrPresetNotFound
+		},
+		mockDeletePreset: func(ownerID uint, name string) error {
+			if ownerID == 7 && name == "standups" {
+				return nil
+			}
+			return usecase.ErrPresetNotFound
+		},
+	}
+
+	handler := NewPresetHandler(mockUC)
+	router := gin.Default()
+	router.Use(middleware.UserID())
+	router.GET("/presets/:name", handler.GetPresetApi)
+	router.DELETE("/presets/:name", handler.DeletePresetApi)
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		userID       string
+		expectedCode int
+	}{
+		{name: "Get known preset", method: http.MethodGet, path: "/presets/standups", userID: "7", expectedCode: http.StatusOK},
+		{name: "Get unknown preset", method: http.MethodGet, path: "/presets/missing", userID: "7", expectedCode: http.StatusNotFound},
+		{name: "Get another user's preset", method: http.MethodGet, path: "/presets/standups", userID: "8", expectedCode: http.StatusNotFound},
+		{name: "Delete another user's preset", method: http.MethodDelete, path: "/presets/standups", userID: "8", expectedCode: http.StatusNotFound},
+		{name: "Delete known preset", method: http.MethodDelete, path: "/presets/standups", userID: "7", expectedCode: http.StatusOK},
+		{name: "Delete unknown preset", method: http.MethodDelete, path: "/presets/missing", userID: "7", expectedCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set(middleware.UserIDHeader, tt.userID)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const redactedPlaceholder = "[REDACTED]"
+
+// EmailPattern matches email addresses and is always applied when redaction
+// is enabled.
+const EmailPattern = `[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`
+
+// Redactor replaces sensitive content in exported notes. It is only used by
+// the export handlers; stored notes and regular API responses are untouched.
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+func NewRedactor(patterns []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, p := range append([]string{EmailPattern}, patterns...) {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// Redact returns s with every pattern match replaced by [REDACTED]. A nil
+// Redactor returns s unchanged.
+func (r *Redactor) Redact(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, re := range r.patterns {
+		s = re.ReplaceAllString(s, redactedPlaceholder)
+	}
+	return s
+}
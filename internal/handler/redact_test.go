@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/go-playground/assert/v2"
+)
+
+func TestRedactor(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		input    string
+		want     string
+	}{
+		{
+			name:  "No matching patterns",
+			input: "Went over items in the current sprint",
+			want:  "Went over items in the current sprint",
+		},
+		{
+			name:  "Email redacted by default",
+			input: "Follow up with jane.doe@example.com about budget",
+			want:  "Follow up with [REDACTED] about budget",
+		},
+		{
+			name:     "Configured pattern redacted",
+			patterns: []string{`ACC-\d{6}`},
+			input:    "Client ACC-123456 escalated, cc bob@corp.io",
+			want:     "Client [REDACTED] escalated, cc [REDACTED]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redactor, err := NewRedactor(tt.patterns)
+			assert.Equal(t, nil, err)
+
+			assert.Equal(t, tt.want, redactor.Redact(tt.input))
+		})
+	}
+}
+
+func TestRedactorInvalidPattern(t *testing.T) {
+	_, err := NewRedactor([]string{"("})
+	assert.NotEqual(t, nil, err)
+}
+
+func TestNilRedactor(t *testing.T) {
+	var redactor *Redactor
+	assert.Equal(t, "jane@example.com", redactor.Redact("jane@example.com"))
+}
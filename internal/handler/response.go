@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+// Response is the body of every JSON response. A successful request has its
+// result in Data and a null Error; a failed one has a null Data and an Error.
+// Meta holds anything about the result that isn't part of it, such as the
+// number of matches across all pages, and is an empty object otherwise.
+type Response struct {
+	Data  interface{}    `json:"data"`
+	Meta  gin.H          `json:"meta" swaggertype:"object"`
+	Error *ResponseError `json:"error"`
+}
+
+// ResponseError describes why a request failed. Fields maps each invalid
+// input to what is wrong with it, when there is more than one thing to
+// report.
+type ResponseError struct {
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// respondOK writes data with the given status. meta may be nil.
+func respondOK(c *gin.Context, status int, data interface{}, meta gin.H) {
+	if meta == nil {
+		meta = gin.H{}
+	}
+	c.JSON(status, Response{Data: data, Meta: meta})
+}
+
+// respondError writes a failed response with the given status and message.
+func respondError(c *gin.Context, status int, message string) {
+	c.JSON(status, Response{Meta: gin.H{}, Error: &ResponseError{Message: message}})
+}
+
+// respondFieldErrors writes a failed response listing the invalid fields.
+func respondFieldErrors(c *gin.Context, status int, message string, fields map[string]string) {
+	c.JSON(status, Response{Meta: gin.H{}, Error: &ResponseError{Message: message, Fields: fields}})
+}
+
+// NotFoundApi answers a request that matches no route.
+func NotFoundApi(c *gin.Context) {
+	respondError(c, http.StatusNotFound, "route not found")
+}
+
+// listNotes leaves Content out of notes for list responses, which carry each
+// note's Summary instead, then applies fields. Single-note responses keep the
+// full content, as do lists whose fields name it.
+func listNotes(notes []domain.Note, fields projection) interface{} {
+	if fields != nil {
+		return fields.notes(notes)
+	}
+	for i := range notes {
+		notes[i].Content = ""
+	}
+	return notes
+}
+
+// listLinks is listNotes for the notes at the other end of links.
+func listLinks(links []domain.LinkedNote) []domain.LinkedNote {
+	for i := range links {
+		links[i].Note.Content = ""
+	}
+	return links
+}
+
+// listSearchResults is listNotes for search results.
+func listSearchResults(results []domain.SearchResult, fields projection) interface{} {
+	if fields != nil {
+		return fields.searchResults(results)
+	}
+	for i := range results {
+		results[i].Note.Content = ""
+	}
+	return results
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+	"github.com/jt00721/meeting-notes-manager/internal/template"
+)
+
+type TemplateHandler struct {
+	Templates *template.Registry
+	Logger    logger.Logger
+}
+
+func NewTemplateHandler(r *template.Registry) *TemplateHandler {
+	return NewTemplateHandlerWithLogger(r, logger.Default())
+}
+
+func NewTemplateHandlerWithLogger(r *template.Registry, l logger.Logger) *TemplateHandler {
+	return &TemplateHandler{Templates: r, Logger: l}
+}
+
+func (handler *TemplateHandler) GetTemplateApi(c *gin.Context) {
+	category := c.Param("category")
+
+	body, ok := handler.Templates.Get(category)
+	if !ok {
+		handler.Logger.Warn("template not found", "operation", "get_template", "category", category)
+		respondError(c, http.StatusNotFound, "no template for category")
+		return
+	}
+
+	respondOK(c, http.StatusOK, gin.H{"category": category, "template": body}, nil)
+}
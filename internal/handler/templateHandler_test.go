@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"github.com/jt00721/meeting-notes-manager/internal/template"
+)
+
+func TestGetTemplateApi(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	templates := template.NewRegistry()
+	templates.Register("1:1", "## Agenda\n")
+	templates.Register("Standup", "## Blockers\n")
+
+	handler := NewTemplateHandler(templates)
+	router := gin.Default()
+	router.GET("/templates/:category", handler.GetTemplateApi)
+
+	tests := []struct {
+		name         string
+		category     string
+		expectedCode int
+	}{
+		{name: "Known category", category: "1:1", expectedCode: http.StatusOK},
+		{name: "Case insensitive", category: "standup", expectedCode: http.StatusOK},
+		{name: "Unknown category", category: "Retro", expectedCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/templates/"+url.PathEscape(tt.category), nil)
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+		})
+	}
+}
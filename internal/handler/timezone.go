@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jt00721/meeting-notes-manager/internal/timeutil"
+)
+
+// invalidTimezoneMessage is the 400 message for a tz query param that isn't
+// an IANA timezone name.
+const invalidTimezoneMessage = "tz must be an IANA timezone name, such as America/New_York"
+
+// requestLocation returns the timezone named by the optional tz query param,
+// or NoteHandler.Location when there is none. Date-only values in the
+// request are read in it. It writes a 400 and returns false for an unknown
+// name.
+func (handler *NoteHandler) requestLocation(c *gin.Context, operation string) (*time.Location, bool) {
+	tz := c.Query("tz")
+	loc, err := timeutil.LoadLocation(tz, handler.Location)
+	if err != nil {
+		handler.Logger.Warn("invalid tz query", "operation", operation, "tz", tz)
+		respondError(c, http.StatusBadRequest, invalidTimezoneMessage)
+		return nil, false
+	}
+	return loc, true
+}
@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// Logger writes structured log lines. Fields are passed as alternating
+// key/value pairs, e.g. Info("note created", "note_id", 1).
+type Logger interface {
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// Level is the least severe kind of entry a Logger writes.
+type Level int
+
+const (
+	LevelInfo Level = iota
+	LevelWarn
+	LevelError
+)
+
+// ParseLevel reads "info", "warn" or "error", in any case.
+func ParseLevel(value string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %q", value)
+}
+
+type jsonLogger struct {
+	out   *log.Logger
+	level Level
+}
+
+// New returns a Logger that writes one JSON object per line to w using the
+// standard library logger.
+func New(w io.Writer) Logger {
+	return NewWithLevel(w, LevelInfo)
+}
+
+// NewWithLevel returns a Logger like New that drops entries less severe than
+// level.
+func NewWithLevel(w io.Writer, level Level) Logger {
+	return &jsonLogger{out: log.New(w, "", 0), level: level}
+}
+
+var defaultLogger = New(os.Stderr)
+
+// Default returns the process wide JSON logger writing to stderr.
+func Default() Logger {
+	return defaultLogger
+}
+
+func (l *jsonLogger) Info(msg string, keysAndValues ...interface{}) {
+	if l.level <= LevelInfo {
+		l.write("info", msg, keysAndValues)
+	}
+}
+
+func (l *jsonLogger) Warn(msg string, keysAndValues ...interface{}) {
+	if l.level <= LevelWarn {
+		l.write("warn", msg, keysAndValues)
+	}
+}
+
+func (l *jsonLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.write("error", msg, keysAndValues)
+}
+
+func (l *jsonLogger) write(level, msg string, keysAndValues []interface{}) {
+	entry := map[string]interface{}{
+		"time":  time.Now().UTC().Format(time.RFC3339),
+		"level": level,
+		"msg":   msg,
+	}
+
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key := fmt.Sprint(keysAndValues[i])
+		if i+1 >= len(keysAndValues) {
+			entry[key] = nil
+			break
+		}
+
+		value := keysAndValues[i+1]
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		entry[key] = value
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		l.out.Printf(`{"level":"error","msg":"failed to encode log entry","error":%q}`, err.Error())
+		return
+	}
+	l.out.Println(string(line))
+}
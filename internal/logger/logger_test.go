@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONLogger(t *testing.T) {
+	tests := []struct {
+		name      string
+		log       func(l Logger)
+		wantLevel string
+		wantMsg   string
+		wantField map[string]interface{}
+	}{
+		{
+			name: "Info with fields",
+			log: func(l Logger) {
+				l.Info("note created", "note_id", 1, "operation", "create")
+			},
+			wantLevel: "info",
+			wantMsg:   "note created",
+			wantField: map[string]interface{}{"note_id": float64(1), "operation": "create"},
+		},
+		{
+			name: "Error value is stringified",
+			log: func(l Logger) {
+				l.Error("update failed", "error", errors.New("db error"))
+			},
+			wantLevel: "error",
+			wantMsg:   "update failed",
+			wantField: map[string]interface{}{"error": "db error"},
+		},
+		{
+			name: "Dangling key",
+			log: func(l Logger) {
+				l.Warn("odd fields", "note_id")
+			},
+			wantLevel: "warn",
+			wantMsg:   "odd fields",
+			wantField: map[string]interface{}{"note_id": nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.log(New(&buf))
+
+			assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+
+			var entry map[string]interface{}
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, tt.wantLevel, entry["level"])
+			assert.Equal(t, tt.wantMsg, entry["msg"])
+			for k, v := range tt.wantField {
+				assert.Equal(t, v, entry[k])
+			}
+		})
+	}
+}
+
+func TestLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewWithLevel(&buf, LevelWarn)
+
+	l.Info("dropped")
+	l.Warn("kept")
+	l.Error("kept too")
+
+	assert.Equal(t, 2, strings.Count(buf.String(), "\n"))
+	assert.NotContains(t, buf.String(), "dropped")
+}
+
+func TestParseLevel(t *testing.T) {
+	for value, want := range map[string]Level{"info": LevelInfo, " WARN ": LevelWarn, "warning": LevelWarn, "Error": LevelError} {
+		level, err := ParseLevel(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, want, level, value)
+	}
+
+	_, err := ParseLevel("verbose")
+	assert.Error(t, err)
+}
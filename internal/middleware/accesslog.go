@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+)
+
+// AccessLog writes one structured entry per request to l, after the request
+// has been handled. Server errors are logged as errors and client errors as
+// warnings, so a logger at LevelWarn only reports failed requests.
+func AccessLog(l logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+
+		c.Next()
+
+		status := c.Writer.Status()
+		fields := []interface{}{
+			"method", c.Request.Method,
+			"path", path,
+			"route", c.FullPath(),
+			"status", status,
+			"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+			"bytes", c.Writer.Size(),
+			"client_ip", c.ClientIP(),
+		}
+		if userID := CurrentUserID(c); userID != 0 {
+			fields = append(fields, "user_id", userID)
+		}
+		if len(c.Errors) > 0 {
+			fields = append(fields, "error", c.Errors.String())
+		}
+
+		switch {
+		case status >= 500:
+			l.Error("request", fields...)
+		case status >= 400:
+			l.Warn("request", fields...)
+		default:
+			l.Info("request", fields...)
+		}
+	}
+}
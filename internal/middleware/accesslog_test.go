@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		path      string
+		level     logger.Level
+		wantLevel string
+	}{
+		{name: "Success", path: "/notes/7", level: logger.LevelInfo, wantLevel: "info"},
+		{name: "Client error", path: "/notes/missing", level: logger.LevelInfo, wantLevel: "warn"},
+		{name: "Server error", path: "/notes/broken", level: logger.LevelInfo, wantLevel: "error"},
+		{name: "Success below level", path: "/notes/7", level: logger.LevelWarn},
+		{name: "Client error at level", path: "/notes/missing", level: logger.LevelWarn, wantLevel: "warn"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			router := gin.New()
+			router.Use(AccessLog(logger.NewWithLevel(&buf, tt.level)))
+			router.GET("/notes/:id", func(c *gin.Context) {
+				switch c.Param("id") {
+				case "missing":
+					c.Status(http.StatusNotFound)
+				case "broken":
+					c.Status(http.StatusInternalServerError)
+				default:
+					c.String(http.StatusOK, "ok")
+				}
+			})
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if tt.wantLevel == "" {
+				assert.Empty(t, buf.String())
+				return
+			}
+			assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+
+			var entry map[string]interface{}
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, tt.wantLevel, entry["level"])
+			assert.Equal(t, "request", entry["msg"])
+			assert.Equal(t, "GET", entry["method"])
+			assert.Equal(t, tt.path, entry["path"])
+			assert.Equal(t, "/notes/:id", entry["route"])
+			assert.Contains(t, entry, "latency_ms")
+		})
+	}
+}
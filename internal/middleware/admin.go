@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Admin only lets through requests from the given user ids, as set by
+// UserID, and rejects everyone else, anonymous users included, with 403.
+// With no ids every request is rejected.
+func Admin(ids []uint) gin.HandlerFunc {
+	admins := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		admins[id] = true
+	}
+
+	return func(c *gin.Context) {
+		if id := CurrentUserID(c); id == 0 || !admins[id] {
+			c.AbortWithStatusJSON(http.StatusForbidden, errorBody("admin access required"))
+			return
+		}
+		c.Next()
+	}
+}
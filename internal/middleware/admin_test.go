@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		admins       []uint
+		header       string
+		expectedCode int
+	}{
+		{
+			name:         "Admin",
+			admins:       []uint{1, 7},
+			header:       "7",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Other user",
+			admins:       []uint{1, 7},
+			header:       "8",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "Anonymous",
+			admins:       []uint{1, 7},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "No admins configured",
+			header:       "7",
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(UserID())
+			router.POST("/purge", Admin(tt.admins), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/purge", nil)
+			if tt.header != "" {
+				req.Header.Set(UserIDHeader, tt.header)
+			}
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedCode == http.StatusForbidden {
+				assert.JSONEq(t, `{"data":null,"meta":{},"error":{"message":"admin access required"}}`, resp.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultMaxBodyBytes is the largest request body accepted when no other
+// limit is configured.
+const DefaultMaxBodyBytes int64 = 1 << 20
+
+// MaxBodySize rejects request bodies larger than limit bytes with 413. A
+// declared Content-Length over the limit is rejected up front; otherwise the
+// body is wrapped so reading past the limit fails with an error that
+// IsBodyTooLarge recognises.
+func MaxBodySize(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, errorBody("request body too large"))
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
+
+// errorBody is a failed response in the shape every handler uses, for
+// middleware that rejects a request before it reaches one.
+func errorBody(message string) gin.H {
+	return gin.H{"data": nil, "meta": gin.H{}, "error": gin.H{"message": message}}
+}
+
+// IsBodyTooLarge reports whether err came from reading past a MaxBodySize
+// limit.
+func IsBodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
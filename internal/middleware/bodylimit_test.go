@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxBodySize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const limit = 16
+
+	tests := []struct {
+		name          string
+		body          string
+		unknownLength bool
+		wantStatus    int
+	}{
+		{name: "Under the limit", body: strings.Repeat("a", limit-1), wantStatus: http.StatusOK},
+		{name: "Exactly at the limit", body: strings.Repeat("a", limit), wantStatus: http.StatusOK},
+		{name: "One byte over", body: strings.Repeat("a", limit+1), wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "Exactly at the limit without Content-Length", body: strings.Repeat("a", limit), unknownLength: true, wantStatus: http.StatusOK},
+		{name: "Over the limit without Content-Length", body: strings.Repeat("a", limit+1), unknownLength: true, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/notes", MaxBodySize(limit), func(c *gin.Context) {
+				body, err := io.ReadAll(c.Request.Body)
+				if IsBodyTooLarge(err) {
+					c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body too large"})
+					return
+				}
+				c.String(http.StatusOK, string(body))
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/notes", strings.NewReader(tt.body))
+			if tt.unknownLength {
+				req.ContentLength = -1
+			}
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantStatus, resp.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, tt.body, resp.Body.String())
+			}
+		})
+	}
+}
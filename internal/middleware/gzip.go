@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultGzipMinSize is the smallest response body, in bytes, that gets
+// compressed. Smaller bodies are cheaper to send as is.
+const DefaultGzipMinSize = 1024
+
+// Compress gzips response bodies of at least minSize bytes when the client
+// accepts gzip. The body is buffered so Content-Length can be set to the size
+// actually sent; a handler that flushes switches to an uncompressed stream.
+func Compress(minSize int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !acceptsGzip(c.Request) {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		writer := &gzipWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = original
+		writer.finish(minSize)
+	}
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		if strings.TrimSpace(strings.SplitN(encoding, ";", 2)[0]) == "gzip" {
+			return true
+		}
+	}
+	return false
+}
+
+type gzipWriter struct {
+	gin.ResponseWriter
+	buf         bytes.Buffer
+	status      int
+	passthrough bool
+}
+
+func (w *gzipWriter) WriteHeader(code int) {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+}
+
+func (w *gzipWriter) WriteHeaderNow() {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *gzipWriter) Write(data []byte) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.buf.Write(data)
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.WriteString(s)
+	}
+	return w.buf.WriteString(s)
+}
+
+func (w *gzipWriter) Status() int {
+	if w.passthrough {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+func (w *gzipWriter) Size() int {
+	if w.passthrough {
+		return w.ResponseWriter.Size()
+	}
+	return w.buf.Len()
+}
+
+func (w *gzipWriter) Written() bool {
+	return w.passthrough || w.buf.Len() > 0
+}
+
+// Flush sends anything buffered uncompressed and stops buffering, so
+// streaming handlers keep working.
+func (w *gzipWriter) Flush() {
+	if !w.passthrough {
+		w.passthrough = true
+		w.ResponseWriter.WriteHeader(w.status)
+		if w.buf.Len() > 0 {
+			_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+			w.buf.Reset()
+		}
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *gzipWriter) finish(minSize int) {
+	if w.passthrough {
+		return
+	}
+
+	header := w.ResponseWriter.Header()
+	body := w.buf.Bytes()
+
+	if len(body) < minSize || header.Get("Content-Encoding") != "" || !bodyAllowed(w.status) {
+		if len(body) > 0 {
+			header.Set("Content-Length", strconv.Itoa(len(body)))
+		}
+		w.ResponseWriter.WriteHeader(w.status)
+		w.ResponseWriter.WriteHeaderNow()
+		_, _ = w.ResponseWriter.Write(body)
+		return
+	}
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, _ = gz.Write(body)
+	_ = gz.Close()
+
+	header.Set("Content-Encoding", "gzip")
+	header.Add("Vary", "Accept-Encoding")
+	header.Set("Content-Length", strconv.Itoa(compressed.Len()))
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(compressed.Bytes())
+}
+
+func bodyAllowed(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompress(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	largeContent := strings.Repeat("Discussed sprint planning. ", 100)
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+		wantGzip       bool
+		wantBody       string
+		wantStatus     int
+	}{
+		{
+			name:           "Large JSON is compressed",
+			path:           "/large",
+			acceptEncoding: "gzip, deflate",
+			wantGzip:       true,
+			wantBody:       `{"content":"` + largeContent + `"}`,
+			wantStatus:     http.StatusOK,
+		},
+		{
+			name:           "Small JSON is not compressed",
+			path:           "/small",
+			acceptEncoding: "gzip",
+			wantBody:       `{"content":"short"}`,
+			wantStatus:     http.StatusOK,
+		},
+		{
+			name:       "Client without gzip support",
+			path:       "/large",
+			wantBody:   `{"content":"` + largeContent + `"}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:           "Large CSV export is compressed",
+			path:           "/export.csv",
+			acceptEncoding: "gzip",
+			wantGzip:       true,
+			wantBody:       "title,content\nPlanning," + largeContent + "\n",
+			wantStatus:     http.StatusOK,
+		},
+		{
+			name:           "Not modified has no body",
+			path:           "/not-modified",
+			acceptEncoding: "gzip",
+			wantBody:       "",
+			wantStatus:     http.StatusNotModified,
+		},
+	}
+
+	router := gin.New()
+	router.Use(Compress(DefaultGzipMinSize))
+	router.GET("/large", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"content": largeContent}) })
+	router.GET("/small", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"content": "short"}) })
+	router.GET("/export.csv", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/csv", []byte("title,content\nPlanning,"+largeContent+"\n"))
+	})
+	router.GET("/not-modified", func(c *gin.Context) { c.Status(http.StatusNotModified) })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.wantStatus, resp.Code)
+
+			body := resp.Body.Bytes()
+			if tt.wantGzip {
+				assert.Equal(t, "gzip", resp.Header().Get("Content-Encoding"))
+				assert.Equal(t, strconv.Itoa(len(body)), resp.Header().Get("Content-Length"))
+
+				gz, err := gzip.NewReader(resp.Body)
+				assert.NoError(t, err)
+				body, err = io.ReadAll(gz)
+				assert.NoError(t, err)
+			} else {
+				assert.Empty(t, resp.Header().Get("Content-Encoding"))
+			}
+			assert.Equal(t, tt.wantBody, string(body))
+		})
+	}
+}
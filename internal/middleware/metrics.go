@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// unmatchedRoute labels requests that didn't match any route, so arbitrary
+// paths can't blow up the label cardinality.
+const unmatchedRoute = "unmatched"
+
+// PrometheusMetrics holds the collectors exposed at /metrics. They are
+// registered on the Registerer passed to NewPrometheusMetrics rather than the
+// global default, so tests can each use a fresh registry.
+type PrometheusMetrics struct {
+	requests   *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+	NotesTotal prometheus.Gauge
+}
+
+func NewPrometheusMetrics(reg prometheus.Registerer) *PrometheusMetrics {
+	labels := []string{"method", "route", "status"}
+
+	m := &PrometheusMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests handled, by method, route and status code.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency, by method, route and status code.",
+			Buckets: prometheus.DefBuckets,
+		}, labels),
+		NotesTotal: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "notes_total",
+			Help: "Notes currently stored, excluding soft-deleted ones.",
+		}),
+	}
+
+	reg.MustRegister(m.requests, m.duration, m.NotesTotal)
+	return m
+}
+
+// Middleware records the count and latency of every request. Routes are
+// labelled by their pattern (/notes/:id), not the raw path.
+func (m *PrometheusMetrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		m.requests.WithLabelValues(c.Request.Method, route, status).Inc()
+		m.duration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
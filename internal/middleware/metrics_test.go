@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	reg := prometheus.NewRegistry()
+	metrics := NewPrometheusMetrics(reg)
+
+	router := gin.New()
+	router.Use(metrics.Middleware())
+	router.GET("/notes/:id", func(c *gin.Context) {
+		if c.Param("id") == "missing" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	for _, path := range []string{"/notes/1", "/notes/2", "/notes/missing", "/nowhere"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	tests := []struct {
+		name   string
+		route  string
+		status string
+		want   float64
+	}{
+		{name: "Successful requests grouped by route", route: "/notes/:id", status: "200", want: 2},
+		{name: "Errors labelled by status", route: "/notes/:id", status: "404", want: 1},
+		{name: "Unmatched paths share a label", route: "unmatched", status: "404", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testutil.ToFloat64(metrics.requests.WithLabelValues(http.MethodGet, tt.route, tt.status))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Equal(t, 3, testutil.CollectAndCount(metrics.duration))
+}
+
+func TestPrometheusMetricsSeparateRegistries(t *testing.T) {
+	assert.NotPanics(t, func() {
+		NewPrometheusMetrics(prometheus.NewRegistry())
+		NewPrometheusMetrics(prometheus.NewRegistry())
+	})
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func recordingMiddleware(name string, calls *[]string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		*calls = append(*calls, name)
+		c.Next()
+	}
+}
+
+func TestRegistryChainOrder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		disabled  []string
+		wantNames []string
+	}{
+		{
+			name:      "All enabled run in documented order",
+			wantNames: []string{Recovery, RequestLogging, Auth, Gzip},
+		},
+		{
+			name:      "Disabling auth removes it",
+			disabled:  []string{Auth},
+			wantNames: []string{Recovery, RequestLogging, Gzip},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			registry := NewRegistry()
+			// Register out of order to check the registry sorts them.
+			for _, name := range []string{Gzip, Auth, Recovery, RequestLogging} {
+				assert.NoError(t, registry.Register(name, recordingMiddleware(name, &calls)))
+			}
+			for _, name := range tt.disabled {
+				registry.SetEnabled(name, false)
+			}
+
+			assert.Equal(t, tt.wantNames, registry.Names())
+
+			router := gin.New()
+			registry.Apply(router)
+			router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+			assert.Equal(t, tt.wantNames, calls)
+		})
+	}
+}
+
+func TestRegistryUnknownMiddleware(t *testing.T) {
+	registry := NewRegistry()
+
+	err := registry.Register("telemetry", func(c *gin.Context) {})
+	assert.Error(t, err)
+	assert.Empty(t, registry.Names())
+}
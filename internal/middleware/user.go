@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserIDKey is the gin context key holding the authenticated user id.
+const UserIDKey = "userID"
+
+// UserIDHeader is the request header the user id is read from.
+const UserIDHeader = "X-User-ID"
+
+// UserID stores the user id from the X-User-ID header in the gin context.
+// Requests without the header are treated as the anonymous user (id 0);
+// a malformed header is rejected.
+func UserID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader(UserIDHeader)
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		id, err := strconv.ParseUint(header, 10, 64)
+		if err != nil || id == 0 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, errorBody("Invalid "+UserIDHeader+" header"))
+			return
+		}
+
+		c.Set(UserIDKey, uint(id))
+		c.Next()
+	}
+}
+
+// CurrentUserID returns the user id set by UserID, or 0 for anonymous
+// requests.
+func CurrentUserID(c *gin.Context) uint {
+	if id, ok := c.Get(UserIDKey); ok {
+		if userID, ok := id.(uint); ok {
+			return userID
+		}
+	}
+	return 0
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		header       string
+		expectedCode int
+		wantUserID   uint
+	}{
+		{
+			name:         "Valid header",
+			header:       "42",
+			expectedCode: http.StatusOK,
+			wantUserID:   42,
+		},
+		{
+			name:         "Missing header is anonymous",
+			header:       "",
+			expectedCode: http.StatusOK,
+			wantUserID:   0,
+		},
+		{
+			name:         "Non-numeric header",
+			header:       "abc",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "Zero header",
+			header:       "0",
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(UserID())
+			router.GET("/whoami", func(c *gin.Context) {
+				c.String(http.StatusOK, strconv.FormatUint(uint64(CurrentUserID(c)), 10))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+			if tt.header != "" {
+				req.Header.Set(UserIDHeader, tt.header)
+			}
+			resp := httptest.NewRecorder()
+
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, strconv.FormatUint(uint64(tt.wantUserID), 10), resp.Body.String())
+			}
+		})
+	}
+}
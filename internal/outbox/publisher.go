@@ -0,0 +1,29 @@
+package outbox
+
+import (
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+	"github.com/jt00721/meeting-notes-manager/internal/repository"
+)
+
+// Publisher implements usecase.NoteEventPublisher by writing events to the
+// outbox for the Relay to deliver. It covers events that aren't tied to a note
+// write, such as reminders; note changes are enqueued by the note repository.
+type Publisher struct {
+	Repo   repository.OutboxRepository
+	Logger logger.Logger
+}
+
+func NewPublisher(r repository.OutboxRepository) *Publisher {
+	return NewPublisherWithLogger(r, logger.Default())
+}
+
+func NewPublisherWithLogger(r repository.OutboxRepository, l logger.Logger) *Publisher {
+	return &Publisher{Repo: r, Logger: l}
+}
+
+func (p *Publisher) Publish(event domain.NoteEvent) {
+	if err := p.Repo.Enqueue(event); err != nil {
+		p.Logger.Error("error enqueuing outbox event", "operation", "outbox_enqueue", "event", event.Type, "note_id", event.Note.ID, "error", err)
+	}
+}
@@ -0,0 +1,114 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+	"github.com/jt00721/meeting-notes-manager/internal/repository"
+)
+
+const (
+	DefaultBatchSize  = 50
+	DefaultBackoff    = 5 * time.Second
+	DefaultMaxBackoff = time.Hour
+)
+
+// Deliverer sends one event and reports whether it arrived.
+// webhook.Dispatcher implements it.
+type Deliverer interface {
+	Deliver(event domain.NoteEvent) error
+}
+
+// Relay delivers outbox events until each one succeeds. An event that fails is
+// retried after Backoff, doubling on every further failure up to MaxBackoff.
+// Delivery is at least once: an event can be sent again if the relay stops
+// between delivering it and marking it sent.
+type Relay struct {
+	Repo       repository.OutboxRepository
+	Deliverer  Deliverer
+	BatchSize  int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	Logger     logger.Logger
+
+	now func() time.Time
+}
+
+func NewRelay(r repository.OutboxRepository, d Deliverer) *Relay {
+	return NewRelayWithLogger(r, d, logger.Default())
+}
+
+func NewRelayWithLogger(r repository.OutboxRepository, d Deliverer, l logger.Logger) *Relay {
+	return &Relay{
+		Repo:       r,
+		Deliverer:  d,
+		BatchSize:  DefaultBatchSize,
+		Backoff:    DefaultBackoff,
+		MaxBackoff: DefaultMaxBackoff,
+		Logger:     l,
+		now:        time.Now,
+	}
+}
+
+// RelayOnce attempts every event that is due, up to BatchSize, and returns how
+// many were delivered.
+func (r *Relay) RelayOnce() (int, error) {
+	now := r.now()
+	events, err := r.Repo.PollDue(now, r.BatchSize)
+	if err != nil {
+		r.Logger.Error("error polling outbox", "operation", "outbox_relay", "error", err)
+		return 0, err
+	}
+
+	sent := 0
+	for _, event := range events {
+		if err := r.deliver(event); err != nil {
+			r.fail(event, now, err)
+			continue
+		}
+
+		if err := r.Repo.MarkSent(event.ID, r.now()); err != nil {
+			r.Logger.Error("error marking outbox event sent", "operation", "outbox_relay", "event_id", event.ID, "error", err)
+			return sent, err
+		}
+		sent++
+	}
+
+	if len(events) > 0 {
+		r.Logger.Info("outbox relayed", "operation", "outbox_relay", "due", len(events), "sent", sent)
+	}
+	return sent, nil
+}
+
+func (r *Relay) deliver(event domain.OutboxEvent) error {
+	var noteEvent domain.NoteEvent
+	if err := json.Unmarshal([]byte(event.Payload), &noteEvent); err != nil {
+		return err
+	}
+	return r.Deliverer.Deliver(noteEvent)
+}
+
+// fail pushes event back by the backoff for its attempt count.
+func (r *Relay) fail(event domain.OutboxEvent, now time.Time, deliveryErr error) {
+	next := now.Add(r.backoff(event.Attempts + 1))
+	r.Logger.Warn("outbox delivery failed", "operation", "outbox_relay", "event_id", event.ID, "event", event.Type, "attempt", event.Attempts+1, "next_attempt_at", next, "error", deliveryErr)
+
+	if err := r.Repo.MarkFailed(event.ID, next, deliveryErr.Error()); err != nil {
+		r.Logger.Error("error recording outbox failure", "operation", "outbox_relay", "event_id", event.ID, "error", err)
+	}
+}
+
+// backoff returns Backoff doubled for each failure after the first, capped at
+// MaxBackoff.
+func (r *Relay) backoff(failures int) time.Duration {
+	wait := r.Backoff
+	for i := 1; i < failures && wait < r.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > r.MaxBackoff {
+		wait = r.MaxBackoff
+	}
+	return wait
+}
@@ -0,0 +1,140 @@
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockOutboxRepository struct {
+	events      []domain.OutboxEvent
+	forceDBFail bool
+}
+
+func (m *mockOutboxRepository) Enqueue(event domain.NoteEvent) error {
+	if m.forceDBFail {
+		return errors.New("db error")
+	}
+
+	payload, _ := json.Marshal(event)
+	m.events = append(m.events, domain.OutboxEvent{
+		ID:            uint(len(m.events) + 1),
+		Type:          event.Type,
+		NoteID:        event.Note.ID,
+		Payload:       string(payload),
+		NextAttemptAt: event.OccurredAt,
+	})
+	return nil
+}
+
+func (m *mockOutboxRepository) PollDue(now time.Time, limit int) ([]domain.OutboxEvent, error) {
+	if m.forceDBFail {
+		return nil, errors.New("db error")
+	}
+
+	var due []domain.OutboxEvent
+	for _, event := range m.events {
+		if event.SentAt == nil && !event.NextAttemptAt.After(now) && len(due) < limit {
+			due = append(due, event)
+		}
+	}
+	return due, nil
+}
+
+func (m *mockOutboxRepository) MarkSent(id uint, sentAt time.Time) error {
+	m.events[id-1].SentAt = &sentAt
+	return nil
+}
+
+func (m *mockOutboxRepository) MarkFailed(id uint, nextAttemptAt time.Time, lastErr string) error {
+	m.events[id-1].Attempts++
+	m.events[id-1].NextAttemptAt = nextAttemptAt
+	m.events[id-1].LastError = lastErr
+	return nil
+}
+
+type mockDeliverer struct {
+	failures  int
+	delivered []domain.NoteEvent
+}
+
+func (d *mockDeliverer) Deliver(event domain.NoteEvent) error {
+	if d.failures > 0 {
+		d.failures--
+		return errors.New("webhook down")
+	}
+	d.delivered = append(d.delivered, event)
+	return nil
+}
+
+func TestRelayOnce(t *testing.T) {
+	start := time.Date(2025, time.June, 16, 10, 0, 0, 0, time.UTC)
+	now := start
+
+	repo := &mockOutboxRepository{}
+	deliverer := &mockDeliverer{failures: 3}
+	relay := NewRelayWithLogger(repo, deliverer, logger.New(io.Discard))
+	relay.Backoff = time.Minute
+	relay.MaxBackoff = 3 * time.Minute
+	relay.now = func() time.Time { return now }
+
+	assert.NoError(t, repo.Enqueue(domain.NoteEvent{Type: domain.NoteCreated, Note: domain.Note{ID: 7}, OccurredAt: start}))
+
+	// Each failure pushes the next attempt back further: 1m, 2m, then capped
+	// at 3m.
+	for _, wantWait := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute} {
+		sent, err := relay.RelayOnce()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, sent)
+		assert.Equal(t, now.Add(wantWait), repo.events[0].NextAttemptAt)
+		assert.Equal(t, "webhook down", repo.events[0].LastError)
+
+		// Nothing is due until the backoff has passed.
+		now = now.Add(wantWait - time.Second)
+		sent, err = relay.RelayOnce()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, sent)
+		now = now.Add(time.Second)
+	}
+	assert.Equal(t, 3, repo.events[0].Attempts)
+
+	sent, err := relay.RelayOnce()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.NotNil(t, repo.events[0].SentAt)
+	if assert.Len(t, deliverer.delivered, 1) {
+		assert.Equal(t, domain.NoteCreated, deliverer.delivered[0].Type)
+		assert.Equal(t, uint(7), deliverer.delivered[0].Note.ID)
+	}
+
+	// A sent event is never delivered again.
+	sent, err = relay.RelayOnce()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Len(t, deliverer.delivered, 1)
+}
+
+func TestRelayOncePollError(t *testing.T) {
+	relay := NewRelayWithLogger(&mockOutboxRepository{forceDBFail: true}, &mockDeliverer{}, logger.New(io.Discard))
+
+	_, err := relay.RelayOnce()
+	assert.Error(t, err)
+}
+
+func TestPublisherEnqueues(t *testing.T) {
+	repo := &mockOutboxRepository{}
+	publisher := NewPublisherWithLogger(repo, logger.New(io.Discard))
+
+	publisher.Publish(domain.NoteEvent{Type: domain.NoteReminder, Note: domain.Note{ID: 3}, OccurredAt: time.Now()})
+
+	if assert.Len(t, repo.events, 1) {
+		assert.Equal(t, domain.NoteReminder, repo.events[0].Type)
+		assert.Equal(t, uint(3), repo.events[0].NoteID)
+	}
+}
@@ -0,0 +1,34 @@
+// Package render turns a note's content into HTML for display.
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var (
+	markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+	policy   = bluemonday.UGCPolicy()
+)
+
+// NoteToHTML renders the content of n as an HTML fragment. Markdown notes are
+// converted and then sanitized, so a script written into the markdown, or
+// produced from it, never reaches the page. Any other note is escaped and
+// wrapped in <pre> to keep its line breaks.
+func NoteToHTML(n domain.Note) ([]byte, error) {
+	if n.Format != domain.NoteFormatMarkdown {
+		return []byte("<pre>" + html.EscapeString(n.Content) + "</pre>"), nil
+	}
+
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(n.Content), &buf); err != nil {
+		return nil, fmt.Errorf("failed to render note %d markdown: %w", n.ID, err)
+	}
+	return policy.SanitizeBytes(buf.Bytes()), nil
+}
@@ -0,0 +1,58 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoteToHTML(t *testing.T) {
+	tests := []struct {
+		name        string
+		note        domain.Note
+		want        string
+		wantMissing []string
+	}{
+		{
+			name: "Markdown is rendered",
+			note: domain.Note{Content: "## Actions\n\n- **ship** it\n- ~~drop~~ it", Format: domain.NoteFormatMarkdown},
+			want: "<h2>Actions</h2>\n<ul>\n<li><strong>ship</strong> it</li>\n<li><del>drop</del> it</li>\n</ul>\n",
+		},
+		{
+			name:        "Scripts in markdown are stripped",
+			note:        domain.Note{Content: "Hi <script>alert(1)</script>\n\n<img src=x onerror=alert(1)>", Format: domain.NoteFormatMarkdown},
+			wantMissing: []string{"<script", "alert(1)</script>", "onerror"},
+		},
+		{
+			name:        "Script links in markdown are stripped",
+			note:        domain.Note{Content: "[click](javascript:alert(1))", Format: domain.NoteFormatMarkdown},
+			wantMissing: []string{"javascript:"},
+		},
+		{
+			name: "Plain text is escaped",
+			note: domain.Note{Content: "a <b>tag</b>\n**not bold**", Format: domain.NoteFormatPlaintext},
+			want: "<pre>a &lt;b&gt;tag&lt;/b&gt;\n**not bold**</pre>",
+		},
+		{
+			name: "Missing format is plain text",
+			note: domain.Note{Content: "# not a heading"},
+			want: "<pre># not a heading</pre>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := NoteToHTML(tt.note)
+
+			require.NoError(t, err)
+			if tt.want != "" {
+				assert.Equal(t, tt.want, string(out))
+			}
+			for _, missing := range tt.wantMissing {
+				assert.NotContains(t, string(out), missing)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"gorm.io/gorm"
+)
+
+type AuditRepository interface {
+	Create(entry *domain.AuditLog) error
+	ListByNote(noteID uint) ([]domain.AuditLog, error)
+}
+
+type auditRepository struct {
+	DB *gorm.DB
+}
+
+func NewAuditRepository(DB *gorm.DB) *auditRepository {
+	return &auditRepository{DB: DB}
+}
+
+func (r *auditRepository) Create(entry *domain.AuditLog) error {
+	return r.DB.Create(entry).Error
+}
+
+// ListByNote returns a note's audit entries, oldest first.
+func (r *auditRepository) ListByNote(noteID uint) ([]domain.AuditLog, error) {
+	var entries []domain.AuditLog
+	err := r.DB.Where("note_id = ?", noteID).Order("id").Find(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditRepository(t *testing.T) {
+	cleanDB(t)
+	repo := NewAuditRepository(DB)
+
+	actor := uint(7)
+	meetingDate := time.Date(2025, time.June, 10, 9, 0, 0, 0, time.UTC).Format(time.RFC3339Nano)
+	for _, entry := range []*domain.AuditLog{
+		{NoteID: 1, Operation: domain.AuditCreate, Changes: domain.FieldChanges{"title": {To: "Planning"}, "meeting_date": {To: meetingDate}}},
+		{NoteID: 2, Operation: domain.AuditCreate, Changes: domain.FieldChanges{"title": {To: "Other"}}},
+		{NoteID: 1, Operation: domain.AuditUpdate, ActorID: &actor, Changes: domain.FieldChanges{"title": {From: "Planning", To: "Planning v2"}}},
+	} {
+		assert.NoError(t, repo.Create(entry))
+	}
+
+	entries, err := repo.ListByNote(1)
+	assert.NoError(t, err)
+	if !assert.Len(t, entries, 2) {
+		return
+	}
+
+	assert.Equal(t, domain.AuditCreate, entries[0].Operation)
+	assert.Nil(t, entries[0].ActorID)
+	assert.Equal(t, domain.FieldChanges{"title": {To: "Planning"}, "meeting_date": {To: meetingDate}}, entries[0].Changes)
+
+	assert.Equal(t, domain.AuditUpdate, entries[1].Operation)
+	if assert.NotNil(t, entries[1].ActorID) {
+		assert.Equal(t, actor, *entries[1].ActorID)
+	}
+	assert.Equal(t, domain.FieldChange{From: "Planning", To: "Planning v2"}, entries[1].Changes["title"])
+}
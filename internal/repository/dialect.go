@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// dialect covers the few queries whose SQL differs between Postgres, the
+// production database, and SQLite, which is used for local development and
+// fast tests. Postgres gets exactly the SQL it always has.
+type dialect struct {
+	sqlite bool
+}
+
+func dialectOf(db *gorm.DB) dialect {
+	return dialect{sqlite: db.Dialector.Name() == "sqlite"}
+}
+
+// ilike returns a case-insensitive pattern match on column with one bound
+// argument, which should come from containsPattern. SQLite's LIKE already
+// ignores case, but only for ASCII letters. Backslash is the escape
+// character on both; SQLite has none unless told.
+func (d dialect) ilike(column string) string {
+	if d.sqlite {
+		return column + ` LIKE ? ESCAPE '\'`
+	}
+	return column + ` ILIKE ? ESCAPE '\'`
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// containsPattern returns a LIKE pattern matching text anywhere in a value.
+// Wildcards in text are escaped, so a search for "100%" only matches
+// "100%" and not every value containing "100".
+func containsPattern(text string) string {
+	return "%" + likeEscaper.Replace(text) + "%"
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"gorm.io/gorm"
+)
+
+// BackfillMeetingDurations gives every note without a duration, such as
+// those stored before notes had one, a duration of minutes. It returns how
+// many notes were changed, and leaves their versions and UpdatedAt alone.
+func BackfillMeetingDurations(db *gorm.DB, minutes int) (int64, error) {
+	result := db.Unscoped().
+		Model(&domain.Note{}).
+		Where("duration_minutes <= 0").
+		UpdateColumn("duration_minutes", minutes)
+	return result.RowsAffected, result.Error
+}
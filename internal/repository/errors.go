@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ErrVersionConflict is returned by Update when the stored version no longer
+// matches the version the caller read.
+var ErrVersionConflict = errors.New("note version conflict")
+
+// ErrDuplicateNote is returned by a write that would leave an owner with two
+// live notes with the same title and meeting date while
+// EnsureTitleDateIndex's index is in place.
+var ErrDuplicateNote = errors.New("note with this title and meeting date already exists")
+
+// ErrDuplicateSlug is returned by a write whose slug another note took
+// first, as happens when two notes with the same title are created at once.
+var ErrDuplicateSlug = fmt.Errorf("%w: note slug already taken", gorm.ErrDuplicatedKey)
+
+// ErrSelfLink is returned by AddLink for a link from a note to itself.
+var ErrSelfLink = errors.New("note cannot link to itself")
+
+// ErrDuplicateLink is returned by AddLink when the note already links to the
+// target.
+var ErrDuplicateLink = errors.New("note already links to this note")
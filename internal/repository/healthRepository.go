@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+}
+
+type healthRepository struct {
+	DB *gorm.DB
+}
+
+func NewHealthRepository(DB *gorm.DB) *healthRepository {
+	return &healthRepository{DB: DB}
+}
+
+func (r *healthRepository) Ping(ctx context.Context) error {
+	sqlDB, err := r.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPing(t *testing.T) {
+	healthRepo := NewHealthRepository(DB)
+
+	err := healthRepo.Ping(context.Background())
+	assert.NoError(t, err)
+}
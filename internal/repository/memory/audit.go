@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+type auditRepository struct {
+	mu      sync.RWMutex
+	entries []domain.AuditLog
+	nextID  uint
+}
+
+func NewAuditRepository() *auditRepository {
+	return &auditRepository{}
+}
+
+func (r *auditRepository) Create(entry *domain.AuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	entry.ID = r.nextID
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = now()
+	}
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+// ListByNote returns a note's audit entries, oldest first.
+func (r *auditRepository) ListByNote(noteID uint) ([]domain.AuditLog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entries []domain.AuditLog
+	for _, entry := range r.entries {
+		if entry.NoteID == noteID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// deleteNote removes every entry about a note, for a hard delete.
+func (r *auditRepository) deleteNote(noteID uint) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.entries[:0]
+	for _, entry := range r.entries {
+		if entry.NoteID != noteID {
+			kept = append(kept, entry)
+		}
+	}
+	r.entries = kept
+}
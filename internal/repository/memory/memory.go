@@ -0,0 +1,34 @@
+// Package memory implements the repository interfaces on plain Go maps, for
+// tests and demos that shouldn't need a database. Every repository is safe
+// for concurrent use and hands out copies, so callers can't change stored
+// values behind its back.
+//
+// The repositories follow the gorm ones closely: missing rows are reported
+// as gorm.ErrRecordNotFound, duplicate keys as gorm.ErrDuplicatedKey, and
+// notes are soft-deleted unless hard deletes are enabled. Nothing is kept
+// once the process exits.
+package memory
+
+import (
+	"context"
+	"time"
+)
+
+// now is the timestamp stored for a write. Dropping the monotonic clock
+// reading makes stored times compare the way they would after a round trip
+// through a database.
+func now() time.Time {
+	return time.Now().Round(0)
+}
+
+type healthRepository struct{}
+
+// NewHealthRepository returns a health check for the in-memory store, which
+// is always reachable.
+func NewHealthRepository() *healthRepository {
+	return &healthRepository{}
+}
+
+func (r *healthRepository) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
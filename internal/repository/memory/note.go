@@ -0,0 +1,1087 @@
+package memory
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/repository"
+	"github.com/jt00721/meeting-notes-manager/internal/timeutil"
+	"gorm.io/gorm"
+)
+
+type noteRepository struct {
+	mu          sync.RWMutex
+	notes       map[uint]domain.Note
+	revisions   []domain.NoteRevision
+	attachments []domain.Attachment
+	links       []domain.NoteLink
+
+	nextNoteID       uint
+	nextRevisionID   uint
+	nextAttachmentID uint
+	nextLinkID       uint
+
+	hardDelete      bool
+	uniqueTitleDate bool
+	audit           repository.AuditRepository
+}
+
+// NoteRepositoryOptions adjusts a repository from NewNoteRepositoryWithOptions.
+type NoteRepositoryOptions struct {
+	// HardDelete makes Delete remove a note, its attachments and revisions
+	// outright rather than soft-deleting it.
+	HardDelete bool
+	// AuditLog is the repository from NewAuditRepository holding the notes'
+	// audit history, which a hard delete clears as well.
+	AuditLog repository.AuditRepository
+	// UniqueTitleDate rejects a note with the same title and meeting date as
+	// a live note of the same owner, as repository.EnsureTitleDateIndex's
+	// index does.
+	UniqueTitleDate bool
+}
+
+func NewNoteRepository() *noteRepository {
+	return NewNoteRepositoryWithOptions(NoteRepositoryOptions{})
+}
+
+func NewNoteRepositoryWithOptions(opts NoteRepositoryOptions) *noteRepository {
+	return &noteRepository{
+		notes:           map[uint]domain.Note{},
+		hardDelete:      opts.HardDelete,
+		uniqueTitleDate: opts.UniqueTitleDate,
+		audit:           opts.AuditLog,
+	}
+}
+
+// Create stores n, filling in its ID, timestamps and the column defaults.
+func (r *noteRepository) Create(n *domain.Note) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkUnique(*n); err != nil {
+		return err
+	}
+	if r.titleDateTaken(*n, 0) {
+		return repository.ErrDuplicateNote
+	}
+	r.insert(n)
+	return nil
+}
+
+// CreateBatch stores every note or, if any of them clashes with a stored
+// note or another in the batch, none of them.
+func (r *noteRepository) CreateBatch(notes []domain.Note) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := map[uint]bool{}
+	slugs := map[string]bool{}
+	externalIDs := map[externalIDKey]bool{}
+	titleDates := map[string]bool{}
+	for _, n := range notes {
+		if err := r.checkUnique(n); err != nil {
+			return err
+		}
+		if (n.ID != 0 && ids[n.ID]) || (n.Slug != "" && slugs[n.Slug]) || (n.ExternalID != "" && externalIDs[externalIDKey{n.OwnerID, n.ExternalID}]) {
+			return gorm.ErrDuplicatedKey
+		}
+		if r.titleDateTaken(n, 0) || (r.uniqueTitleDate && titleDates[titleDateKey(n)]) {
+			return repository.ErrDuplicateNote
+		}
+		ids[n.ID] = true
+		slugs[n.Slug] = true
+		externalIDs[externalIDKey{n.OwnerID, n.ExternalID}] = true
+		titleDates[titleDateKey(n)] = true
+	}
+
+	for i := range notes {
+		r.insert(&notes[i])
+	}
+	return nil
+}
+
+// Upsert inserts n, or if n's owner already has a note with the same
+// ExternalID, trashed ones included, overwrites the fields the gorm
+// repository does and bumps its version. n is refreshed with the stored note
+// either way. Another owner's note with the same ExternalID is never touched.
+func (r *noteRepository) Upsert(n *domain.Note) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if n.ExternalID != "" {
+		for id, stored := range r.notes {
+			if stored.ExternalID != n.ExternalID || stored.OwnerID != n.OwnerID {
+				continue
+			}
+			if r.titleDateTaken(*n, id) {
+				return repository.ErrDuplicateNote
+			}
+			stored.Title = n.Title
+			stored.Content = n.Content
+			stored.Category = n.Category
+			stored.MeetingDate = n.MeetingDate
+			stored.DurationMinutes = n.DurationMinutes
+			stored.Location = n.Location
+			stored.MeetingURL = n.MeetingURL
+			stored.Format = n.Format
+			stored.Priority = n.Priority
+			stored.Visibility = n.Visibility
+			stored.UpdatedAt = now()
+			stored.Version++
+			r.notes[id] = stored
+			r.saveRevision(stored)
+
+			*n = r.load(stored)
+			return nil
+		}
+	}
+
+	if err := r.checkUnique(*n); err != nil {
+		return err
+	}
+	if r.titleDateTaken(*n, 0) {
+		return repository.ErrDuplicateNote
+	}
+	r.insert(n)
+	return nil
+}
+
+// checkUnique returns gorm.ErrDuplicatedKey if n's ID or slug, or its
+// external ID among its owner's notes, is already taken, wrapped as
+// repository.ErrDuplicateSlug for the slug. Like the database's unique
+// indexes it counts trashed notes and ignores empty slugs and external IDs.
+func (r *noteRepository) checkUnique(n domain.Note) error {
+	if _, ok := r.notes[n.ID]; ok && n.ID != 0 {
+		return gorm.ErrDuplicatedKey
+	}
+	for _, stored := range r.notes {
+		if n.Slug != "" && stored.Slug == n.Slug {
+			return repository.ErrDuplicateSlug
+		}
+		if n.ExternalID != "" && stored.ExternalID == n.ExternalID && stored.OwnerID == n.OwnerID {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	return nil
+}
+
+// titleDateTaken reports whether, with the UniqueTitleDate option, a live
+// note of n's owner other than the one with ID except has n's title and
+// meeting date.
+func (r *noteRepository) titleDateTaken(n domain.Note, except uint) bool {
+	if !r.uniqueTitleDate {
+		return false
+	}
+	for id, stored := range r.notes {
+		if id != except && !stored.DeletedAt.Valid && stored.OwnerID == n.OwnerID && stored.Title == n.Title && stored.MeetingDate.Equal(n.MeetingDate) {
+			return true
+		}
+	}
+	return false
+}
+
+// externalIDKey is what an external ID is unique by: it belongs to an owner.
+type externalIDKey struct {
+	ownerID    uint
+	externalID string
+}
+
+// titleDateKey identifies n's owner, title and meeting date, the same instant
+// in any time zone giving the same key.
+func titleDateKey(n domain.Note) string {
+	return strconv.FormatUint(uint64(n.OwnerID), 10) + "\x00" + n.Title + "\x00" + n.MeetingDate.UTC().Format(time.RFC3339Nano)
+}
+
+// insert stores n with its first revision and fills in what the database
+// would: an ID, the version and format defaults, and the timestamps.
+func (r *noteRepository) insert(n *domain.Note) {
+	if n.ID == 0 {
+		r.nextNoteID++
+		n.ID = r.nextNoteID
+	} else if n.ID > r.nextNoteID {
+		r.nextNoteID = n.ID
+	}
+	if n.Version == 0 {
+		n.Version = 1
+	}
+	if n.Format == "" {
+		n.Format = domain.NoteFormatPlaintext
+	}
+	if n.Priority == "" {
+		n.Priority = domain.PriorityNormal
+	}
+	if n.Visibility == "" {
+		n.Visibility = domain.VisibilityPrivate
+	}
+	stamp := now()
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = stamp
+	}
+	if n.UpdatedAt.IsZero() {
+		n.UpdatedAt = stamp
+	}
+	n.SetEndTime()
+
+	stored := *n
+	stored.Attachments = nil
+	stored.Warnings = nil
+	r.notes[n.ID] = stored
+	r.saveRevision(stored)
+}
+
+// saveRevision snapshots n at its current version.
+func (r *noteRepository) saveRevision(n domain.Note) {
+	r.nextRevisionID++
+	r.revisions = append(r.revisions, domain.NoteRevision{
+		ID:        r.nextRevisionID,
+		NoteID:    n.ID,
+		Version:   n.Version,
+		Title:     n.Title,
+		Content:   n.Content,
+		Category:  n.Category,
+		CreatedAt: now(),
+	})
+}
+
+// load returns a copy of a stored note as a query would load it.
+func (r *noteRepository) load(n domain.Note) domain.Note {
+	n.SetEndTime()
+	return n
+}
+
+// live returns every note that hasn't been trashed and passes keep, in ID
+// order.
+func (r *noteRepository) live(keep func(domain.Note) bool) []domain.Note {
+	return r.collect(false, keep)
+}
+
+// collect returns the notes that pass keep in ID order, trashed ones too
+// when unscoped is set.
+func (r *noteRepository) collect(unscoped bool, keep func(domain.Note) bool) []domain.Note {
+	notes := []domain.Note{}
+	for _, n := range r.notes {
+		if !unscoped && n.DeletedAt.Valid {
+			continue
+		}
+		if keep == nil || keep(n) {
+			notes = append(notes, r.load(n))
+		}
+	}
+	sort.Slice(notes, func(i, j int) bool {
+		return notes[i].ID < notes[j].ID
+	})
+	return notes
+}
+
+// window returns up to limit notes starting at offset. A limit of zero or
+// less returns the rest.
+func window(notes []domain.Note, offset, limit int) []domain.Note {
+	if offset >= len(notes) {
+		return []domain.Note{}
+	}
+	if offset > 0 {
+		notes = notes[offset:]
+	}
+	if limit > 0 && limit < len(notes) {
+		notes = notes[:limit]
+	}
+	return notes
+}
+
+// visibleTo keeps the notes userID may read, following
+// domain.Note.VisibleTo.
+func visibleTo(userID uint) func(domain.Note) bool {
+	return func(n domain.Note) bool {
+		return n.VisibleTo(userID)
+	}
+}
+
+// unarchived keeps archived notes out unless include is set.
+func unarchived(include bool) func(domain.Note) bool {
+	return func(n domain.Note) bool {
+		return include || !n.Archived
+	}
+}
+
+func (r *noteRepository) GetAll() ([]domain.Note, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.live(nil), nil
+}
+
+func (r *noteRepository) GetAllByOwner(ownerID uint, includeArchived bool) ([]domain.Note, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.live(func(n domain.Note) bool {
+		return n.OwnerID == ownerID && unarchived(includeArchived)(n)
+	}), nil
+}
+
+// GetAllVisible returns up to limit of the notes userID may read, newest
+// meeting first: their own, and others' that are shared or public. A limit
+// of zero or less returns them all.
+func (r *noteRepository) GetAllVisible(userID uint, includeArchived bool, limit int) ([]domain.Note, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	notes, _, err := findPage(r.live(func(n domain.Note) bool {
+		return n.VisibleTo(userID) && unarchived(includeArchived)(n)
+	}), domain.Page{Limit: limit}, domain.NoteFilter{})
+	return notes, err
+}
+
+// GetPaginated returns limit of the notes viewerID may read, skipping the
+// first offset.
+func (r *noteRepository) GetPaginated(viewerID uint, limit, offset int, includeArchived bool) ([]domain.Note, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return window(r.live(func(n domain.Note) bool {
+		return n.VisibleTo(viewerID) && unarchived(includeArchived)(n)
+	}), offset, limit), nil
+}
+
+// GetAfterID returns up to limit of the notes viewerID may read with an ID
+// above afterID, in ID order.
+func (r *noteRepository) GetAfterID(viewerID, afterID uint, limit int, includeArchived bool) ([]domain.Note, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return window(r.live(func(n domain.Note) bool {
+		return n.VisibleTo(viewerID) && n.ID > afterID && unarchived(includeArchived)(n)
+	}), 0, limit), nil
+}
+
+// StreamByOwner calls fn with each of the owner's notes in ID order. The
+// notes are copied out first, so fn may use the repository itself.
+func (r *noteRepository) StreamByOwner(ownerID uint, includeArchived bool, fn func(domain.Note) error) error {
+	notes, _ := r.GetAllByOwner(ownerID, includeArchived)
+	for _, n := range notes {
+		if err := fn(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetModifiedSince returns up to limit of the owner's notes changed after
+// cursor, archived and trashed ones included, oldest change first. A limit
+// of zero returns them all.
+func (r *noteRepository) GetModifiedSince(ownerID uint, cursor domain.ChangeCursor, limit int) ([]domain.Note, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	notes := r.collect(true, func(n domain.Note) bool {
+		if n.OwnerID != ownerID {
+			return false
+		}
+		if cursor.AfterID == 0 {
+			return n.UpdatedAt.After(cursor.Since)
+		}
+		return n.UpdatedAt.After(cursor.Since) || (n.UpdatedAt.Equal(cursor.Since) && n.ID > cursor.AfterID)
+	})
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].UpdatedAt.Before(notes[j].UpdatedAt)
+	})
+	return window(notes, 0, limit), nil
+}
+
+// GetRecent returns the limit most recently created notes viewerID may read.
+func (r *noteRepository) GetRecent(viewerID uint, limit int) ([]domain.Note, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	notes := r.live(visibleTo(viewerID))
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].CreatedAt.After(notes[j].CreatedAt)
+	})
+	return window(notes, 0, limit), nil
+}
+
+// GetUpcoming returns the unarchived notes viewerID may read whose meeting
+// falls in [from, to), soonest first.
+func (r *noteRepository) GetUpcoming(viewerID uint, from, to time.Time) ([]domain.Note, error) {
+	return r.meetingsBetween(visibleTo(viewerID), from, to), nil
+}
+
+// GetStartingBetween returns every user's unarchived notes whose meeting
+// falls in [from, to), soonest first, for meeting reminders.
+func (r *noteRepository) GetStartingBetween(from, to time.Time) ([]domain.Note, error) {
+	return r.meetingsBetween(nil, from, to), nil
+}
+
+// meetingsBetween returns the unarchived notes that pass keep and whose
+// meeting falls in [from, to), soonest first.
+func (r *noteRepository) meetingsBetween(keep func(domain.Note) bool, from, to time.Time) []domain.Note {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	notes := r.live(func(n domain.Note) bool {
+		return (keep == nil || keep(n)) && !n.Archived && !n.MeetingDate.Before(from) && n.MeetingDate.Before(to)
+	})
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].MeetingDate.Before(notes[j].MeetingDate)
+	})
+	return notes
+}
+
+func (r *noteRepository) GetByID(id uint) (domain.Note, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	n, ok := r.notes[id]
+	if !ok || n.DeletedAt.Valid {
+		return domain.Note{}, gorm.ErrRecordNotFound
+	}
+	return r.load(n), nil
+}
+
+// Exists reports whether ownerID has a live note with the given id.
+func (r *noteRepository) Exists(id, ownerID uint) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	n, ok := r.notes[id]
+	return ok && !n.DeletedAt.Valid && n.OwnerID == ownerID, nil
+}
+
+// GetByIDs returns every note whose ID is in ids, in ID order. Unknown IDs
+// are skipped.
+func (r *noteRepository) GetByIDs(ids []uint) ([]domain.Note, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	return r.live(func(n domain.Note) bool {
+		return wanted[n.ID]
+	}), nil
+}
+
+func (r *noteRepository) GetBySlug(slug string) (domain.Note, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	notes := r.live(func(n domain.Note) bool {
+		return n.Slug == slug
+	})
+	if len(notes) == 0 {
+		return domain.Note{}, gorm.ErrRecordNotFound
+	}
+	return notes[0], nil
+}
+
+// GetByExternalID loads the owner's note with the given ExternalID, trashed
+// or not: the note Upsert would overwrite.
+func (r *noteRepository) GetByExternalID(ownerID uint, externalID string) (domain.Note, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, n := range r.notes {
+		if n.OwnerID == ownerID && n.ExternalID == externalID {
+			return r.load(n), nil
+		}
+	}
+	return domain.Note{}, gorm.ErrRecordNotFound
+}
+
+// SlugsWithPrefix returns every stored slug that is base itself or starts
+// with base followed by "-", trashed notes included.
+func (r *noteRepository) SlugsWithPrefix(base string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var slugs []string
+	for _, n := range r.collect(true, nil) {
+		if n.Slug == base || strings.HasPrefix(n.Slug, base+"-") {
+			slugs = append(slugs, n.Slug)
+		}
+	}
+	return slugs, nil
+}
+
+// Update writes the editable fields of n only if the stored note has the
+// same owner and version, then bumps the version and records a revision.
+// Otherwise it returns repository.ErrVersionConflict.
+func (r *noteRepository) Update(n *domain.Note) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.notes[n.ID]
+	if !ok || stored.DeletedAt.Valid || stored.OwnerID != n.OwnerID || stored.Version != n.Version {
+		return repository.ErrVersionConflict
+	}
+	if n.Slug != "" && n.Slug != stored.Slug {
+		if err := r.checkUnique(domain.Note{Slug: n.Slug}); err != nil {
+			return err
+		}
+	}
+	if r.titleDateTaken(*n, n.ID) {
+		return repository.ErrDuplicateNote
+	}
+
+	stored.Title = n.Title
+	stored.Content = n.Content
+	stored.Category = n.Category
+	stored.MeetingDate = n.MeetingDate
+	stored.DurationMinutes = n.DurationMinutes
+	stored.Location = n.Location
+	stored.MeetingURL = n.MeetingURL
+	stored.Format = n.Format
+	stored.Priority = n.Priority
+	stored.Visibility = n.Visibility
+	stored.Slug = n.Slug
+	stored.UpdatedAt = now()
+	stored.Version++
+	r.notes[n.ID] = stored
+	r.saveRevision(stored)
+
+	n.Version = stored.Version
+	return nil
+}
+
+// Delete moves the note and its attachments to the trash or, with the
+// HardDelete option, removes them along with the note's revisions and audit
+// history. Either way the links from and to the note are removed for good.
+// An unknown ID is not an error.
+func (r *noteRepository) Delete(id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.removeLinks(id)
+	if r.hardDelete {
+		r.remove(id)
+		if audit, ok := r.audit.(interface{ deleteNote(uint) }); ok {
+			audit.deleteNote(id)
+		}
+		return nil
+	}
+
+	n, ok := r.notes[id]
+	if !ok || n.DeletedAt.Valid {
+		return nil
+	}
+	stamp := now()
+	n.DeletedAt = gorm.DeletedAt{Time: stamp, Valid: true}
+	// Touch UpdatedAt so the deletion shows up in GetModifiedSince.
+	n.UpdatedAt = stamp
+	r.notes[id] = n
+
+	for i, a := range r.attachments {
+		if a.NoteID == id && !a.DeletedAt.Valid {
+			r.attachments[i].DeletedAt = gorm.DeletedAt{Time: stamp, Valid: true}
+		}
+	}
+	return nil
+}
+
+// removeLinks drops every link from or to the note.
+func (r *noteRepository) removeLinks(id uint) {
+	links := r.links[:0]
+	for _, l := range r.links {
+		if l.FromNoteID != id && l.ToNoteID != id {
+			links = append(links, l)
+		}
+	}
+	r.links = links
+}
+
+// remove drops a note with its attachments and revisions.
+func (r *noteRepository) remove(id uint) {
+	delete(r.notes, id)
+
+	attachments := r.attachments[:0]
+	for _, a := range r.attachments {
+		if a.NoteID != id {
+			attachments = append(attachments, a)
+		}
+	}
+	r.attachments = attachments
+
+	revisions := r.revisions[:0]
+	for _, rev := range r.revisions {
+		if rev.NoteID != id {
+			revisions = append(revisions, rev)
+		}
+	}
+	r.revisions = revisions
+}
+
+// SetArchived marks the note archived or unarchived. It leaves the version
+// alone since the note's content is unchanged.
+func (r *noteRepository) SetArchived(id uint, archived bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n, ok := r.notes[id]
+	if !ok || n.DeletedAt.Valid {
+		return nil
+	}
+	n.Archived = archived
+	n.UpdatedAt = now()
+	r.notes[id] = n
+	return nil
+}
+
+// ArchiveOlderThan archives every live, unarchived note whose meeting was
+// before cutoff.
+func (r *noteRepository) ArchiveOlderThan(cutoff time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var archived int64
+	stamp := now()
+	for id, n := range r.notes {
+		if n.DeletedAt.Valid || n.Archived || !n.MeetingDate.Before(cutoff) {
+			continue
+		}
+		n.Archived = true
+		n.UpdatedAt = stamp
+		r.notes[id] = n
+		archived++
+	}
+	return archived, nil
+}
+
+// ListRevisions returns every stored revision of a note, oldest first.
+func (r *noteRepository) ListRevisions(noteID uint) ([]domain.NoteRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var revisions []domain.NoteRevision
+	for _, rev := range r.revisions {
+		if rev.NoteID == noteID {
+			revisions = append(revisions, rev)
+		}
+	}
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return revisions[i].Version < revisions[j].Version
+	})
+	return revisions, nil
+}
+
+// AddAttachment stores the metadata in a. It does not check that the note
+// exists; callers do that first.
+func (r *noteRepository) AddAttachment(a *domain.Attachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextAttachmentID++
+	a.ID = r.nextAttachmentID
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = now()
+	}
+	r.attachments = append(r.attachments, *a)
+	return nil
+}
+
+// ListAttachments returns a note's attachments in the order they were added.
+func (r *noteRepository) ListAttachments(noteID uint) ([]domain.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var attachments []domain.Attachment
+	for _, a := range r.attachments {
+		if a.NoteID == noteID && !a.DeletedAt.Valid {
+			attachments = append(attachments, a)
+		}
+	}
+	return attachments, nil
+}
+
+// AddLink stores l, returning repository.ErrSelfLink if it links a note to
+// itself and repository.ErrDuplicateLink if the note already links to the
+// target. It does not check that the notes exist; callers do that first.
+func (r *noteRepository) AddLink(l *domain.NoteLink) error {
+	if l.FromNoteID == l.ToNoteID {
+		return repository.ErrSelfLink
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.links {
+		if existing.FromNoteID == l.FromNoteID && existing.ToNoteID == l.ToNoteID {
+			return repository.ErrDuplicateLink
+		}
+	}
+
+	r.nextLinkID++
+	l.ID = r.nextLinkID
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = now()
+	}
+	r.links = append(r.links, *l)
+	return nil
+}
+
+// ListLinks returns the links from and to a note in the order they were
+// added.
+func (r *noteRepository) ListLinks(noteID uint) ([]domain.NoteLink, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var links []domain.NoteLink
+	for _, l := range r.links {
+		if l.FromNoteID == noteID || l.ToNoteID == noteID {
+			links = append(links, l)
+		}
+	}
+	return links, nil
+}
+
+// containsFold reports whether text contains keyword, ignoring case.
+func containsFold(text, keyword string) bool {
+	return strings.Contains(strings.ToLower(text), strings.ToLower(keyword))
+}
+
+// Search returns one page of the notes viewerID may read whose title or
+// content contains keyword and whose meeting falls within dates, newest
+// meeting first, along with the total number of matches.
+func (r *noteRepository) Search(viewerID uint, keyword string, dates domain.DateRange, page domain.Page) ([]domain.Note, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return findPage(r.live(func(n domain.Note) bool {
+		return n.VisibleTo(viewerID) && dates.Contains(n.MeetingDate) && (containsFold(n.Title, keyword) || containsFold(n.Content, keyword))
+	}), page, domain.NoteFilter{})
+}
+
+// FuzzySearch returns one page of the notes viewerID may read whose title or
+// content has a trigram similarity of at least threshold to keyword and whose
+// meeting falls within dates, most similar first, along with the total number
+// of matches.
+func (r *noteRepository) FuzzySearch(viewerID uint, keyword string, threshold float64, dates domain.DateRange, page domain.Page) ([]domain.Note, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	scores := map[uint]float64{}
+	notes := r.live(func(n domain.Note) bool {
+		if !n.VisibleTo(viewerID) || !dates.Contains(n.MeetingDate) {
+			return false
+		}
+		score := math.Max(repository.TrigramSimilarity(n.Title, keyword), repository.TrigramSimilarity(n.Content, keyword))
+		scores[n.ID] = score
+		return score >= threshold
+	})
+	sort.SliceStable(notes, func(i, j int) bool {
+		return scores[notes[i].ID] > scores[notes[j].ID]
+	})
+	return window(notes, page.Offset, page.Limit), int64(len(notes)), nil
+}
+
+// Filter returns one page of notes matching filter in the filter's sort
+// order, newest meeting first by default, along with the total number of
+// matches.
+func (r *noteRepository) Filter(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return findPage(r.live(matches(filter)), page, filter)
+}
+
+// CountFiltered returns how many notes Filter would match across all pages.
+func (r *noteRepository) CountFiltered(filter domain.NoteFilter) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return int64(len(r.live(matches(filter)))), nil
+}
+
+// matches returns whether a note passes filter, with the same conditions as
+// the gorm repository's filter query.
+func matches(filter domain.NoteFilter) func(domain.Note) bool {
+	keywords := filter.AllKeywords()
+	categories := map[string]bool{}
+	for _, category := range filter.Categories {
+		categories[strings.ToLower(strings.TrimSpace(category))] = true
+	}
+
+	return func(n domain.Note) bool {
+		if n.Archived && !filter.IncludeArchived {
+			return false
+		}
+
+		if len(keywords) > 0 {
+			all := filter.KeywordMatch == domain.KeywordMatchAll
+			found := all
+			for _, keyword := range keywords {
+				match := containsFold(n.Title, keyword) || containsFold(n.Content, keyword)
+				if all {
+					found = found && match
+				} else {
+					found = found || match
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+
+		if len(categories) > 0 && !categories[strings.ToLower(n.Category)] {
+			return false
+		}
+		if filter.FromDate != nil && n.MeetingDate.Before(*filter.FromDate) {
+			return false
+		}
+		if filter.ToDate != nil && n.MeetingDate.After(*filter.ToDate) {
+			return false
+		}
+		if filter.CreatedFrom != nil && n.CreatedAt.Before(*filter.CreatedFrom) {
+			return false
+		}
+		if filter.CreatedTo != nil && n.CreatedAt.After(*filter.CreatedTo) {
+			return false
+		}
+		if filter.UncategorizedOnly && n.Category != "" {
+			return false
+		}
+		if filter.MaxContentLength > 0 && utf8.RuneCountInString(n.Content) > filter.MaxContentLength {
+			return false
+		}
+		if filter.Priority != "" && n.Priority != filter.Priority {
+			return false
+		}
+		if !n.VisibleTo(filter.ViewerID) {
+			return false
+		}
+
+		switch filter.MeetingMode {
+		case domain.MeetingModeRemote:
+			return n.MeetingURL != ""
+		case domain.MeetingModeInPerson:
+			return n.MeetingURL == ""
+		}
+		return true
+	}
+}
+
+// findPage orders notes, which are in ID order, by the sort field and
+// direction of filter, newest meeting first by default. It returns the
+// requested page of them along with their total.
+func findPage(notes []domain.Note, page domain.Page, filter domain.NoteFilter) ([]domain.Note, int64, error) {
+	less := func(a, b domain.Note) bool {
+		return a.MeetingDate.Before(b.MeetingDate)
+	}
+	switch filter.Sort {
+	case domain.SortCreatedAt:
+		less = func(a, b domain.Note) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	case domain.SortUpdatedAt:
+		less = func(a, b domain.Note) bool { return a.UpdatedAt.Before(b.UpdatedAt) }
+	case domain.SortTitle:
+		less = func(a, b domain.Note) bool { return a.Title < b.Title }
+	case domain.SortPriority:
+		less = func(a, b domain.Note) bool { return domain.PriorityRank(a.Priority) < domain.PriorityRank(b.Priority) }
+	}
+
+	desc := filter.Sort == "" || filter.Order != domain.SortAsc
+	sort.SliceStable(notes, func(i, j int) bool {
+		if desc {
+			return less(notes[j], notes[i])
+		}
+		return less(notes[i], notes[j])
+	})
+	return window(notes, page.Offset, page.Limit), int64(len(notes)), nil
+}
+
+// RestoreNotes takes the owner's notes with the given IDs, and their
+// attachments, out of the trash. It returns how many notes were restored.
+func (r *noteRepository) RestoreNotes(ownerID uint, ids []uint) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var restored int64
+	stamp := now()
+	for _, id := range ids {
+		n, ok := r.notes[id]
+		if !ok || n.OwnerID != ownerID || !n.DeletedAt.Valid {
+			continue
+		}
+		n.DeletedAt = gorm.DeletedAt{}
+		n.UpdatedAt = stamp
+		r.notes[id] = n
+		restored++
+
+		for i, a := range r.attachments {
+			if a.NoteID == id {
+				r.attachments[i].DeletedAt = gorm.DeletedAt{}
+			}
+		}
+	}
+	return restored, nil
+}
+
+// RestoreDeletedSince takes every note of the owner trashed after since out
+// of the trash, along with the attachments trashed with it, and returns how
+// many notes were restored.
+func (r *noteRepository) RestoreDeletedSince(ownerID uint, since time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var restored int64
+	stamp := now()
+	for id, n := range r.notes {
+		if n.OwnerID != ownerID || !n.DeletedAt.Valid || !n.DeletedAt.Time.After(since) {
+			continue
+		}
+		n.DeletedAt = gorm.DeletedAt{}
+		n.UpdatedAt = stamp
+		r.notes[id] = n
+		restored++
+
+		for i, a := range r.attachments {
+			if a.NoteID == id && a.DeletedAt.Valid && a.DeletedAt.Time.After(since) {
+				r.attachments[i].DeletedAt = gorm.DeletedAt{}
+			}
+		}
+	}
+	return restored, nil
+}
+
+// RenameCategory moves every live note of the owner in category from to
+// category to and bumps their versions. Trashed notes keep their old
+// category.
+func (r *noteRepository) RenameCategory(ownerID uint, from, to string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	renamed := r.live(func(n domain.Note) bool {
+		return n.OwnerID == ownerID && n.Category == from
+	})
+	stamp := now()
+	for _, n := range renamed {
+		n.Category = to
+		n.Version++
+		n.UpdatedAt = stamp
+		r.notes[n.ID] = n
+		r.saveRevision(n)
+	}
+	return int64(len(renamed)), nil
+}
+
+// Extremes finds the longest and shortest of the notes viewerID may read.
+func (r *noteRepository) Extremes(viewerID uint) (domain.NoteExtremes, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var extremes domain.NoteExtremes
+	for _, n := range r.live(visibleTo(viewerID)) {
+		length := utf8.RuneCountInString(n.Content)
+		if extremes.Longest == nil || length > extremes.Longest.Length {
+			extremes.Longest = &domain.NoteLength{ID: n.ID, Title: n.Title, Length: length}
+		}
+		if length > 0 && (extremes.Shortest == nil || length < extremes.Shortest.Length) {
+			extremes.Shortest = &domain.NoteLength{ID: n.ID, Title: n.Title, Length: length}
+		}
+	}
+	return extremes, nil
+}
+
+// DistinctCategories counts the notes viewerID may read per category,
+// leaving out notes without one.
+func (r *noteRepository) DistinctCategories(viewerID uint) ([]domain.CategoryCount, error) {
+	return r.categoryCounts(visibleTo(viewerID)), nil
+}
+
+// CountByCategory counts the owner's live notes per category, leaving out
+// notes without one, for category quotas.
+func (r *noteRepository) CountByCategory(ownerID uint) ([]domain.CategoryCount, error) {
+	return r.categoryCounts(func(n domain.Note) bool { return n.OwnerID == ownerID }), nil
+}
+
+// categoryCounts counts the live notes that pass keep per category, leaving
+// out notes without one.
+func (r *noteRepository) categoryCounts(keep func(domain.Note) bool) []domain.CategoryCount {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var categories []domain.CategoryCount
+	for _, count := range countByCategory(r.live(keep)) {
+		if count.Category != "" {
+			categories = append(categories, count)
+		}
+	}
+	return categories
+}
+
+// countByCategory counts notes per category, in category order.
+func countByCategory(notes []domain.Note) []domain.CategoryCount {
+	counts := map[string]int64{}
+	for _, n := range notes {
+		counts[n.Category]++
+	}
+
+	categories := make([]domain.CategoryCount, 0, len(counts))
+	for category, count := range counts {
+		categories = append(categories, domain.CategoryCount{Category: category, Count: count})
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Category < categories[j].Category
+	})
+	return categories
+}
+
+// PurgeDeleted permanently removes notes trashed before cutoff, along with
+// their revisions and attachments.
+func (r *noteRepository) PurgeDeleted(cutoff time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int64
+	for id, n := range r.notes {
+		if n.DeletedAt.Valid && n.DeletedAt.Time.Before(cutoff) {
+			r.remove(id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// Stats summarises the live notes viewerID may read. Meetings are grouped by
+// calendar month in loc.
+func (r *noteRepository) Stats(viewerID uint, loc *time.Location) (domain.NoteStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	notes := r.live(visibleTo(viewerID))
+	stats := domain.NoteStats{
+		Total:      int64(len(notes)),
+		ByCategory: countByCategory(notes),
+		ByMonth:    []domain.MonthCount{},
+	}
+
+	months := map[time.Time]int64{}
+	for _, n := range notes {
+		months[timeutil.StartOfMonth(n.MeetingDate, loc)]++
+
+		if stats.LatestMeeting == nil || n.MeetingDate.After(*stats.LatestMeeting) {
+			latest := n.MeetingDate
+			stats.LatestMeeting = &latest
+		}
+	}
+	for month, count := range months {
+		stats.ByMonth = append(stats.ByMonth, domain.MonthCount{Month: month, Count: count})
+	}
+	sort.Slice(stats.ByMonth, func(i, j int) bool {
+		return stats.ByMonth[i].Month.Before(stats.ByMonth[j].Month)
+	})
+	return stats, nil
+}
+
+// CountNotes returns the number of notes that haven't been trashed.
+func (r *noteRepository) CountNotes() (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return int64(len(r.live(nil))), nil
+}
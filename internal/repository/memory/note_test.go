@@ -0,0 +1,516 @@
+package memory
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var (
+	_ repository.NoteRepository   = (*noteRepository)(nil)
+	_ repository.PresetRepository = (*presetRepository)(nil)
+	_ repository.AuditRepository  = (*auditRepository)(nil)
+	_ repository.HealthRepository = (*healthRepository)(nil)
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 3, d, 10, 0, 0, 0, time.UTC)
+}
+
+func TestCreateAndGet(t *testing.T) {
+	repo := NewNoteRepository()
+
+	note := domain.Note{Title: "Kickoff", Content: "Agenda", MeetingDate: day(1), DurationMinutes: 30, Slug: "kickoff"}
+	assert.NoError(t, repo.Create(&note))
+	assert.Equal(t, uint(1), note.ID)
+	assert.Equal(t, 1, note.Version)
+	assert.Equal(t, domain.NoteFormatPlaintext, note.Format)
+	assert.False(t, note.CreatedAt.IsZero())
+	assert.Equal(t, day(1).Add(30*time.Minute), note.EndTime)
+
+	found, err := repo.GetByID(note.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Kickoff", found.Title)
+	assert.Equal(t, note.EndTime, found.EndTime)
+
+	bySlug, err := repo.GetBySlug("kickoff")
+	assert.NoError(t, err)
+	assert.Equal(t, note.ID, bySlug.ID)
+
+	_, err = repo.GetByID(99)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	duplicate := domain.Note{Title: "Again", Slug: "kickoff"}
+	assert.ErrorIs(t, repo.Create(&duplicate), gorm.ErrDuplicatedKey)
+	assert.ErrorIs(t, repo.Create(&duplicate), repository.ErrDuplicateSlug)
+
+	revisions, err := repo.ListRevisions(note.ID)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 1)
+}
+
+func TestUniqueTitleDate(t *testing.T) {
+	repo := NewNoteRepositoryWithOptions(NoteRepositoryOptions{UniqueTitleDate: true})
+
+	first := domain.Note{Title: "Kickoff", MeetingDate: day(1)}
+	assert.NoError(t, repo.Create(&first))
+
+	sameInstant := domain.Note{Title: "Kickoff", MeetingDate: day(1).In(time.FixedZone("CET", 3600))}
+	assert.ErrorIs(t, repo.Create(&sameInstant), repository.ErrDuplicateNote)
+	assert.ErrorIs(t, repo.CreateBatch([]domain.Note{{Title: "Kickoff", MeetingDate: day(1)}}), repository.ErrDuplicateNote)
+	assert.ErrorIs(t, repo.CreateBatch([]domain.Note{
+		{Title: "Retro", MeetingDate: day(2)},
+		{Title: "Retro", MeetingDate: day(2)},
+	}), repository.ErrDuplicateNote)
+
+	theirs := domain.Note{OwnerID: 2, Title: "Kickoff", MeetingDate: day(1)}
+	assert.NoError(t, repo.Create(&theirs), "other owners' notes don't count")
+	assert.NoError(t, repo.CreateBatch([]domain.Note{
+		{OwnerID: 3, Title: "Retro", MeetingDate: day(2)},
+		{OwnerID: 4, Title: "Retro", MeetingDate: day(2)},
+	}))
+
+	other := domain.Note{Title: "Kickoff", MeetingDate: day(2)}
+	assert.NoError(t, repo.Create(&other))
+	other.MeetingDate = day(1)
+	assert.ErrorIs(t, repo.Update(&other), repository.ErrDuplicateNote)
+
+	assert.NoError(t, repo.Delete(first.ID))
+	again := domain.Note{Title: "Kickoff", MeetingDate: day(1)}
+	assert.NoError(t, repo.Create(&again), "trashed notes don't count")
+
+	plain := NewNoteRepository()
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, plain.Create(&domain.Note{Title: "Kickoff", MeetingDate: day(1)}))
+	}
+}
+
+func TestReturnsCopies(t *testing.T) {
+	repo := NewNoteRepository()
+
+	note := domain.Note{Title: "Original"}
+	assert.NoError(t, repo.Create(&note))
+	note.Title = "Changed by caller"
+
+	found, _ := repo.GetByID(note.ID)
+	found.Title = "Changed again"
+
+	stored, _ := repo.GetByID(note.ID)
+	assert.Equal(t, "Original", stored.Title)
+}
+
+func TestUpdate(t *testing.T) {
+	repo := NewNoteRepository()
+
+	note := domain.Note{OwnerID: 1, Title: "Draft", ExternalID: "ext-1"}
+	assert.NoError(t, repo.Create(&note))
+
+	edit := note
+	edit.Title = "Final"
+	edit.ExternalID = "ignored"
+	assert.NoError(t, repo.Update(&edit))
+	assert.Equal(t, 2, edit.Version)
+
+	stored, _ := repo.GetByID(note.ID)
+	assert.Equal(t, "Final", stored.Title)
+	assert.Equal(t, "ext-1", stored.ExternalID)
+
+	stale := note
+	stale.Title = "Stale"
+	assert.ErrorIs(t, repo.Update(&stale), repository.ErrVersionConflict)
+
+	otherOwner := stored
+	otherOwner.OwnerID = 2
+	assert.ErrorIs(t, repo.Update(&otherOwner), repository.ErrVersionConflict)
+
+	revisions, _ := repo.ListRevisions(note.ID)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, "Final", revisions[1].Title)
+}
+
+func TestUpsert(t *testing.T) {
+	repo := NewNoteRepository()
+
+	first := domain.Note{Title: "Imported", ExternalID: "ext-1"}
+	assert.NoError(t, repo.Upsert(&first))
+	assert.Equal(t, 1, first.Version)
+
+	again := domain.Note{Title: "Imported again", ExternalID: "ext-1"}
+	assert.NoError(t, repo.Upsert(&again))
+	assert.Equal(t, first.ID, again.ID)
+	assert.Equal(t, 2, again.Version)
+
+	all, _ := repo.GetAll()
+	assert.Len(t, all, 1)
+	assert.Equal(t, "Imported again", all[0].Title)
+}
+
+func TestUpsertOtherOwner(t *testing.T) {
+	repo := NewNoteRepository()
+
+	mine := domain.Note{OwnerID: 1, ExternalID: "ext-1", Title: "Mine", Content: "Private"}
+	assert.NoError(t, repo.Upsert(&mine))
+	theirs := domain.Note{OwnerID: 2, ExternalID: "ext-1", Title: "Theirs", Content: "Other"}
+	assert.NoError(t, repo.Upsert(&theirs))
+	assert.NotEqual(t, mine.ID, theirs.ID)
+
+	found, _ := repo.GetByID(mine.ID)
+	assert.Equal(t, "Mine", found.Title)
+	assert.Equal(t, 1, found.Version)
+}
+
+func TestCreateBatchIsAtomic(t *testing.T) {
+	repo := NewNoteRepository()
+
+	notes := []domain.Note{{Title: "A", Slug: "same"}, {Title: "B", Slug: "same"}}
+	assert.ErrorIs(t, repo.CreateBatch(notes), gorm.ErrDuplicatedKey)
+
+	count, _ := repo.CountNotes()
+	assert.Equal(t, int64(0), count)
+
+	notes = []domain.Note{{Title: "A"}, {Title: "B"}}
+	assert.NoError(t, repo.CreateBatch(notes))
+	assert.Equal(t, uint(1), notes[0].ID)
+	assert.Equal(t, uint(2), notes[1].ID)
+}
+
+func TestSoftDeleteAndRestore(t *testing.T) {
+	repo := NewNoteRepository()
+
+	note := domain.Note{OwnerID: 1, Title: "Retro", Slug: "retro"}
+	assert.NoError(t, repo.Create(&note))
+	assert.NoError(t, repo.AddAttachment(&domain.Attachment{NoteID: note.ID, Filename: "a.pdf"}))
+	before := time.Now().Add(-time.Second)
+
+	exists, _ := repo.Exists(note.ID, 1)
+	assert.True(t, exists)
+	exists, _ = repo.Exists(note.ID, 2)
+	assert.False(t, exists)
+
+	assert.NoError(t, repo.Delete(note.ID))
+
+	_, err := repo.GetByID(note.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	exists, _ = repo.Exists(note.ID, 1)
+	assert.False(t, exists)
+	attachments, _ := repo.ListAttachments(note.ID)
+	assert.Empty(t, attachments)
+
+	// Trashed notes keep their slug and show up in the changes feed.
+	slugs, _ := repo.SlugsWithPrefix("retro")
+	assert.Equal(t, []string{"retro"}, slugs)
+	changes, _ := repo.GetModifiedSince(1, domain.ChangeCursor{Since: before}, 0)
+	assert.Len(t, changes, 1)
+	assert.True(t, changes[0].DeletedAt.Valid)
+
+	restored, err := repo.RestoreNotes(1, []uint{note.ID, 99})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), restored)
+	_, err = repo.GetByID(note.ID)
+	assert.NoError(t, err)
+	attachments, _ = repo.ListAttachments(note.ID)
+	assert.Len(t, attachments, 1)
+}
+
+func TestLinks(t *testing.T) {
+	repo := NewNoteRepository()
+
+	var notes [3]domain.Note
+	for i := range notes {
+		notes[i] = domain.Note{OwnerID: 1, Title: "Meeting"}
+		assert.NoError(t, repo.Create(&notes[i]))
+	}
+	first, second, third := notes[0].ID, notes[1].ID, notes[2].ID
+
+	assert.NoError(t, repo.AddLink(&domain.NoteLink{FromNoteID: first, ToNoteID: second, Relation: domain.LinkFollowUp}))
+	assert.NoError(t, repo.AddLink(&domain.NoteLink{FromNoteID: third, ToNoteID: first, Relation: domain.LinkRelated}))
+	assert.ErrorIs(t, repo.AddLink(&domain.NoteLink{FromNoteID: first, ToNoteID: second, Relation: domain.LinkRelated}), repository.ErrDuplicateLink)
+	assert.ErrorIs(t, repo.AddLink(&domain.NoteLink{FromNoteID: first, ToNoteID: first}), repository.ErrSelfLink)
+
+	links, _ := repo.ListLinks(first)
+	assert.Len(t, links, 2)
+	links, _ = repo.ListLinks(third)
+	assert.Len(t, links, 1)
+
+	// Deleting a note removes its links both ways, even if it is restored.
+	assert.NoError(t, repo.Delete(first))
+	_, err := repo.RestoreNotes(1, []uint{first})
+	assert.NoError(t, err)
+	links, _ = repo.ListLinks(first)
+	assert.Empty(t, links)
+	links, _ = repo.ListLinks(third)
+	assert.Empty(t, links)
+}
+
+func TestRestoreDeletedSince(t *testing.T) {
+	repo := NewNoteRepository()
+
+	old := domain.Note{OwnerID: 1, Title: "Old"}
+	recent := domain.Note{OwnerID: 1, Title: "Recent"}
+	theirs := domain.Note{OwnerID: 2, Title: "Theirs"}
+	assert.NoError(t, repo.Create(&old))
+	assert.NoError(t, repo.Create(&recent))
+	assert.NoError(t, repo.Create(&theirs))
+	assert.NoError(t, repo.Delete(old.ID))
+	since := time.Now()
+	time.Sleep(time.Millisecond)
+	assert.NoError(t, repo.Delete(recent.ID))
+	assert.NoError(t, repo.Delete(theirs.ID))
+
+	restored, err := repo.RestoreDeletedSince(1, since)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), restored)
+
+	_, err = repo.GetByID(recent.ID)
+	assert.NoError(t, err)
+	_, err = repo.GetByID(old.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = repo.GetByID(theirs.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestHardDelete(t *testing.T) {
+	audit := NewAuditRepository()
+	repo := NewNoteRepositoryWithOptions(NoteRepositoryOptions{HardDelete: true, AuditLog: audit})
+
+	note := domain.Note{Title: "Secret"}
+	assert.NoError(t, repo.Create(&note))
+	assert.NoError(t, audit.Create(&domain.AuditLog{NoteID: note.ID, Operation: domain.AuditCreate}))
+
+	assert.NoError(t, repo.Delete(note.ID))
+
+	restored, _ := repo.RestoreNotes(0, []uint{note.ID})
+	assert.Equal(t, int64(0), restored)
+	revisions, _ := repo.ListRevisions(note.ID)
+	assert.Empty(t, revisions)
+	entries, _ := audit.ListByNote(note.ID)
+	assert.Empty(t, entries)
+}
+
+func TestPurgeDeleted(t *testing.T) {
+	repo := NewNoteRepository()
+
+	trashed := domain.Note{Title: "Old"}
+	kept := domain.Note{Title: "Live"}
+	assert.NoError(t, repo.Create(&trashed))
+	assert.NoError(t, repo.Create(&kept))
+	assert.NoError(t, repo.Delete(trashed.ID))
+
+	purged, err := repo.PurgeDeleted(time.Now().Add(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	restored, _ := repo.RestoreNotes(0, []uint{trashed.ID})
+	assert.Equal(t, int64(0), restored)
+	count, _ := repo.CountNotes()
+	assert.Equal(t, int64(1), count)
+}
+
+func TestArchiveOlderThan(t *testing.T) {
+	repo := NewNoteRepository()
+
+	old := domain.Note{Title: "Old", MeetingDate: day(1)}
+	recent := domain.Note{Title: "Recent", MeetingDate: day(20)}
+	trashed := domain.Note{Title: "Trashed", MeetingDate: day(1)}
+	for _, n := range []*domain.Note{&old, &recent, &trashed} {
+		assert.NoError(t, repo.Create(n))
+	}
+	assert.NoError(t, repo.Delete(trashed.ID))
+
+	archived, err := repo.ArchiveOlderThan(day(10))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), archived)
+
+	note, _ := repo.GetByID(old.ID)
+	assert.True(t, note.Archived)
+	note, _ = repo.GetByID(recent.ID)
+	assert.False(t, note.Archived)
+
+	archived, err = repo.ArchiveOlderThan(day(10))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), archived)
+}
+
+func TestSearchAndFilter(t *testing.T) {
+	repo := NewNoteRepository()
+
+	notes := []domain.Note{
+		{Title: "Budget review", Content: "Q3 numbers", Category: "Finance", MeetingDate: day(1)},
+		{Title: "Standup", Content: "budget blockers", Category: "Engineering", MeetingDate: day(3), MeetingURL: "https://meet.example.com/x"},
+		{Title: "Planning", Content: "roadmap", MeetingDate: day(2), Archived: true, Priority: domain.PriorityHigh},
+		{Title: "Trashed budget", MeetingDate: day(4)},
+	}
+	assert.NoError(t, repo.CreateBatch(notes))
+	assert.NoError(t, repo.Delete(notes[3].ID))
+
+	found, total, err := repo.Search(0, "BUDGET", domain.DateRange{}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, "Standup", found[0].Title, "newest meeting first")
+
+	found, total, _ = repo.Search(0, "budget", domain.DateRange{}, domain.Page{Limit: 1, Offset: 1})
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, found, 1)
+	assert.Equal(t, "Budget review", found[0].Title)
+
+	to := day(2)
+	found, total, _ = repo.Search(0, "budget", domain.DateRange{To: &to}, domain.Page{})
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "Budget review", found[0].Title)
+
+	tests := []struct {
+		name   string
+		filter domain.NoteFilter
+		titles []string
+	}{
+		{"no conditions skips archived", domain.NoteFilter{}, []string{"Standup", "Budget review"}},
+		{"include archived", domain.NoteFilter{IncludeArchived: true}, []string{"Standup", "Planning", "Budget review"}},
+		{"category ignores case", domain.NoteFilter{Categories: []string{" finance "}}, []string{"Budget review"}},
+		{"uncategorized", domain.NoteFilter{UncategorizedOnly: true, IncludeArchived: true}, []string{"Planning"}},
+		{"all keywords", domain.NoteFilter{Keywords: []string{"budget", "blockers"}, KeywordMatch: domain.KeywordMatchAll}, []string{"Standup"}},
+		{"any keyword", domain.NoteFilter{Keywords: []string{"numbers", "blockers"}}, []string{"Standup", "Budget review"}},
+		{"remote", domain.NoteFilter{MeetingMode: domain.MeetingModeRemote}, []string{"Standup"}},
+		{"date range", domain.NoteFilter{FromDate: ptr(day(2)), ToDate: ptr(day(3)), IncludeArchived: true}, []string{"Standup", "Planning"}},
+		{"max content length", domain.NoteFilter{MaxContentLength: 10}, []string{"Budget review"}},
+		{"priority", domain.NoteFilter{Priority: domain.PriorityHigh, IncludeArchived: true}, []string{"Planning"}},
+		{"highest priority first", domain.NoteFilter{Sort: domain.SortPriority, IncludeArchived: true}, []string{"Planning", "Budget review", "Standup"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, total, err := repo.Filter(tt.filter, domain.Page{})
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(tt.titles)), total)
+
+			var titles []string
+			for _, n := range found {
+				titles = append(titles, n.Title)
+			}
+			assert.Equal(t, tt.titles, titles)
+
+			count, _ := repo.CountFiltered(tt.filter)
+			assert.Equal(t, total, count)
+		})
+	}
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestFuzzySearch(t *testing.T) {
+	repo := NewNoteRepository()
+
+	assert.NoError(t, repo.Create(&domain.Note{Title: "Retrospective", Content: "what went well"}))
+	assert.NoError(t, repo.Create(&domain.Note{Title: "Roadmap", Content: "next quarter"}))
+	assert.NoError(t, repo.Create(&domain.Note{OwnerID: 2, Title: "Retrospective", Content: "private"}))
+
+	found, total, err := repo.FuzzySearch(0, "retrospectve", 0.3, domain.DateRange{}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "Retrospective", found[0].Title)
+
+	// Another user's private note only turns up for them.
+	_, total, _ = repo.Search(0, "retrospective", domain.DateRange{}, domain.Page{})
+	assert.Equal(t, int64(1), total)
+	_, total, _ = repo.Search(2, "retrospective", domain.DateRange{}, domain.Page{})
+	assert.Equal(t, int64(1), total)
+}
+
+func TestStatsAndCategories(t *testing.T) {
+	repo := NewNoteRepository()
+
+	assert.NoError(t, repo.CreateBatch([]domain.Note{
+		{Title: "A", Content: "short", Category: "Sales", MeetingDate: day(1)},
+		{Title: "B", Content: "a bit longer", Category: "Sales", MeetingDate: time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)},
+		{Title: "C", Content: "", MeetingDate: day(5)},
+		{OwnerID: 2, Title: "D", Content: "someone else's private notes", Category: "Secret", MeetingDate: day(6)},
+	}))
+
+	categories, err := repo.DistinctCategories(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.CategoryCount{{Category: "Sales", Count: 2}}, categories)
+
+	stats, err := repo.Stats(0, time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), stats.Total)
+	assert.Equal(t, []domain.CategoryCount{{Category: "", Count: 1}, {Category: "Sales", Count: 2}}, stats.ByCategory)
+	assert.Equal(t, []domain.MonthCount{
+		{Month: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Count: 2},
+		{Month: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), Count: 1},
+	}, stats.ByMonth)
+	assert.Equal(t, time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC), *stats.LatestMeeting)
+
+	extremes, err := repo.Extremes(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "B", extremes.Longest.Title)
+	assert.Equal(t, "A", extremes.Shortest.Title)
+
+	// Category quotas count each owner's notes, private or not.
+	counts, err := repo.CountByCategory(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.CategoryCount{{Category: "Sales", Count: 2}}, counts)
+	counts, err = repo.CountByCategory(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.CategoryCount{{Category: "Secret", Count: 1}}, counts)
+
+	renamed, err := repo.RenameCategory(0, "Sales", "Revenue")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), renamed)
+	categories, _ = repo.DistinctCategories(0)
+	assert.Equal(t, []domain.CategoryCount{{Category: "Revenue", Count: 2}}, categories)
+}
+
+func TestConcurrentWrites(t *testing.T) {
+	repo := NewNoteRepository()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			note := domain.Note{Title: fmt.Sprintf("Note %d", i)}
+			assert.NoError(t, repo.Create(&note))
+			_, _, err := repo.Search(0, "note", domain.DateRange{}, domain.Page{Limit: 5})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	count, _ := repo.CountNotes()
+	assert.Equal(t, int64(50), count)
+}
+
+func TestPresets(t *testing.T) {
+	repo := NewPresetRepository()
+
+	preset := domain.FilterPreset{OwnerID: 1, Name: "finance", Filter: domain.NoteFilter{Categories: []string{"Finance"}}}
+	assert.NoError(t, repo.Create(&preset))
+	assert.ErrorIs(t, repo.Create(&domain.FilterPreset{OwnerID: 1, Name: "finance"}), gorm.ErrDuplicatedKey)
+	assert.NoError(t, repo.Create(&domain.FilterPreset{OwnerID: 2, Name: "finance"}))
+
+	preset.Filter.Keyword = "budget"
+	assert.NoError(t, repo.Update(&preset))
+	found, err := repo.GetByName(1, "finance")
+	assert.NoError(t, err)
+	assert.Equal(t, "budget", found.Filter.Keyword)
+
+	presets, err := repo.GetAll(2)
+	assert.NoError(t, err)
+	assert.Len(t, presets, 1)
+	assert.Empty(t, presets[0].Filter.Keyword)
+
+	deleted, err := repo.Delete(1, "finance")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	_, err = repo.GetByName(1, "finance")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = repo.GetByName(2, "finance")
+	assert.NoError(t, err)
+}
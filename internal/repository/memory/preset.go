@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"gorm.io/gorm"
+)
+
+type presetRepository struct {
+	mu      sync.RWMutex
+	presets map[uint]domain.FilterPreset
+	nextID  uint
+}
+
+func NewPresetRepository() *presetRepository {
+	return &presetRepository{presets: map[uint]domain.FilterPreset{}}
+}
+
+func (r *presetRepository) Create(p *domain.FilterPreset) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nameTaken(p.OwnerID, p.Name, 0) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	r.nextID++
+	p.ID = r.nextID
+	stamp := now()
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = stamp
+	}
+	if p.UpdatedAt.IsZero() {
+		p.UpdatedAt = stamp
+	}
+	r.presets[p.ID] = *p
+	return nil
+}
+
+func (r *presetRepository) GetAll(ownerID uint) ([]domain.FilterPreset, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	presets := make([]domain.FilterPreset, 0, len(r.presets))
+	for _, p := range r.presets {
+		if p.OwnerID == ownerID {
+			presets = append(presets, p)
+		}
+	}
+	sort.Slice(presets, func(i, j int) bool {
+		return presets[i].Name < presets[j].Name
+	})
+	return presets, nil
+}
+
+func (r *presetRepository) GetByName(ownerID uint, name string) (domain.FilterPreset, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, p := range r.presets {
+		if p.OwnerID == ownerID && p.Name == name {
+			return p, nil
+		}
+	}
+	return domain.FilterPreset{}, gorm.ErrRecordNotFound
+}
+
+// Update saves p over the preset with its ID, or creates it when it has
+// none, as gorm's Save does.
+func (r *presetRepository) Update(p *domain.FilterPreset) error {
+	if p.ID == 0 {
+		return r.Create(p)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nameTaken(p.OwnerID, p.Name, p.ID) {
+		return gorm.ErrDuplicatedKey
+	}
+	p.UpdatedAt = now()
+	r.presets[p.ID] = *p
+	return nil
+}
+
+func (r *presetRepository) Delete(ownerID uint, name string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for id, p := range r.presets {
+		if p.OwnerID == ownerID && p.Name == name {
+			delete(r.presets, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// nameTaken reports whether the owner has a preset other than the one with
+// ID except called name.
+func (r *presetRepository) nameTaken(ownerID uint, name string, except uint) bool {
+	for id, p := range r.presets {
+		if id != except && p.OwnerID == ownerID && p.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -1,89 +1,1164 @@
 package repository
 
 import (
+	"database/sql"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/timeutil"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// NoteRepository stores notes.
+//
+// GetAll, StreamByOwner, GetByID, Exists, GetBySlug, Search, Filter and
+// CountFiltered may be served by a read replica, which can lag the primary:
+// a note written moments ago may be missing or show its previous version.
+// Callers that need their own writes should use what the write returned
+// rather than reading it back. Update always checks the version on the
+// primary, so an update based on a stale read fails with ErrVersionConflict
+// instead of overwriting newer data.
 type NoteRepository interface {
 	Create(n *domain.Note) error
+	CreateBatch(notes []domain.Note) error
+	Upsert(n *domain.Note) error
 	GetAll() ([]domain.Note, error)
-	GetPaginated(limit, offset int) ([]domain.Note, error)
+	GetAllByOwner(ownerID uint, includeArchived bool) ([]domain.Note, error)
+	GetAllVisible(userID uint, includeArchived bool, limit int) ([]domain.Note, error)
+	GetPaginated(viewerID uint, limit, offset int, includeArchived bool) ([]domain.Note, error)
+	GetAfterID(viewerID, afterID uint, limit int, includeArchived bool) ([]domain.Note, error)
+	StreamByOwner(ownerID uint, includeArchived bool, fn func(domain.Note) error) error
+	GetModifiedSince(ownerID uint, cursor domain.ChangeCursor, limit int) ([]domain.Note, error)
+	GetRecent(viewerID uint, limit int) ([]domain.Note, error)
+	GetUpcoming(viewerID uint, from, to time.Time) ([]domain.Note, error)
+	GetStartingBetween(from, to time.Time) ([]domain.Note, error)
 	GetByID(id uint) (domain.Note, error)
+	GetByIDs(ids []uint) ([]domain.Note, error)
+	Exists(id, ownerID uint) (bool, error)
+	GetBySlug(slug string) (domain.Note, error)
+	GetByExternalID(ownerID uint, externalID string) (domain.Note, error)
+	SlugsWithPrefix(base string) ([]string, error)
 	Update(n *domain.Note) error
 	Delete(id uint) error
-	Search(keyword string) ([]domain.Note, error)
-	Filter(filter domain.NoteFilter) ([]domain.Note, error)
+	SetArchived(id uint, archived bool) error
+	ArchiveOlderThan(cutoff time.Time) (int64, error)
+	ListRevisions(noteID uint) ([]domain.NoteRevision, error)
+	AddAttachment(a *domain.Attachment) error
+	ListAttachments(noteID uint) ([]domain.Attachment, error)
+	AddLink(l *domain.NoteLink) error
+	ListLinks(noteID uint) ([]domain.NoteLink, error)
+	Search(viewerID uint, keyword string, dates domain.DateRange, page domain.Page) ([]domain.Note, int64, error)
+	FuzzySearch(viewerID uint, keyword string, threshold float64, dates domain.DateRange, page domain.Page) ([]domain.Note, int64, error)
+	Filter(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error)
+	CountFiltered(filter domain.NoteFilter) (int64, error)
+	RestoreNotes(ownerID uint, ids []uint) (int64, error)
+	RestoreDeletedSince(ownerID uint, since time.Time) (int64, error)
+	RenameCategory(ownerID uint, from, to string) (int64, error)
+	Extremes(viewerID uint) (domain.NoteExtremes, error)
+	DistinctCategories(viewerID uint) ([]domain.CategoryCount, error)
+	CountByCategory(ownerID uint) ([]domain.CategoryCount, error)
+	PurgeDeleted(cutoff time.Time) (int64, error)
+	Stats(viewerID uint, loc *time.Location) (domain.NoteStats, error)
+	CountNotes() (int64, error)
 }
 
 type noteRepository struct {
 	DB *gorm.DB
+	// outbox records a note.created, note.updated or note.deleted event in
+	// the transaction of each create, upsert, update and delete.
+	outbox bool
+	// hardDelete makes Delete remove notes for good instead of trashing them.
+	hardDelete bool
+	// writeRetries is how many times a write is retried after a transient
+	// database error.
+	writeRetries int
+}
+
+// NoteRepositoryOptions adjusts a repository from NewNoteRepositoryWithOptions.
+type NoteRepositoryOptions struct {
+	// Outbox writes an outbox event for every note change, for an outbox
+	// relay to deliver.
+	Outbox bool
+	// HardDelete makes Delete remove a note, its attachments, revisions and
+	// audit history outright rather than soft-deleting it. Deleted notes then
+	// can't be restored, and the changes feed has no tombstones for them.
+	HardDelete bool
+	// WriteRetries is how many times a write that failed with a transient
+	// error, such as a serialization failure or a dropped connection, is
+	// retried. Zero disables retries.
+	WriteRetries int
 }
 
 func NewNoteRepository(DB *gorm.DB) *noteRepository {
-	return &noteRepository{DB: DB}
+	return &noteRepository{DB: DB, writeRetries: DefaultWriteRetries}
+}
+
+// NewNoteRepositoryWithOutbox returns a repository that writes an outbox
+// event for every note change, for an outbox relay to deliver.
+func NewNoteRepositoryWithOutbox(DB *gorm.DB) *noteRepository {
+	return NewNoteRepositoryWithOptions(DB, NoteRepositoryOptions{Outbox: true, WriteRetries: DefaultWriteRetries})
+}
+
+func NewNoteRepositoryWithOptions(DB *gorm.DB, opts NoteRepositoryOptions) *noteRepository {
+	return &noteRepository{DB: DB, outbox: opts.Outbox, hardDelete: opts.HardDelete, writeRetries: opts.WriteRetries}
+}
+
+// transaction runs fn in a transaction, retrying the whole transaction after
+// a transient error.
+func (r *noteRepository) transaction(fn func(tx *gorm.DB) error) error {
+	return r.write(func() error {
+		return r.DB.Transaction(fn)
+	})
 }
 
 func (r *noteRepository) Create(n *domain.Note) error {
-	return r.DB.Create(n).Error
+	original := *n
+	return r.transaction(func(tx *gorm.DB) error {
+		// Start from the caller's note again if a failed attempt filled it in.
+		*n = original
+		if err := tx.Omit(clause.Associations).Create(n).Error; err != nil {
+			return err
+		}
+		if err := saveRevisions(tx, *n); err != nil {
+			return err
+		}
+		return r.recordEvent(tx, domain.NoteCreated, *n)
+	})
+}
+
+// createBatchSize is how many notes CreateBatch sends per INSERT.
+const createBatchSize = 100
+
+// CreateBatch inserts notes in a single transaction, along with a revision
+// and outbox event for each, so either all of them are stored or none are.
+// The elements of notes are filled in with their IDs and timestamps.
+func (r *noteRepository) CreateBatch(notes []domain.Note) error {
+	if len(notes) == 0 {
+		return nil
+	}
+
+	original := append([]domain.Note(nil), notes...)
+	return r.transaction(func(tx *gorm.DB) error {
+		copy(notes, original)
+		if err := tx.Omit(clause.Associations).CreateInBatches(notes, createBatchSize).Error; err != nil {
+			return err
+		}
+		if err := saveRevisions(tx, notes...); err != nil {
+			return err
+		}
+		for _, n := range notes {
+			if err := r.recordEvent(tx, domain.NoteCreated, n); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// recordEvent enqueues an outbox event for n when the outbox is enabled.
+func (r *noteRepository) recordEvent(tx *gorm.DB, eventType domain.NoteEventType, n domain.Note) error {
+	if !r.outbox {
+		return nil
+	}
+	return enqueueEvent(tx, domain.NoteEvent{Type: eventType, Note: n, OccurredAt: time.Now()})
+}
+
+// Upsert inserts n, or if n's owner already has a note with the same
+// ExternalID overwrites its title, content, category, meeting date,
+// duration, location, meeting URL, format, priority and visibility and bumps
+// its version. n is refreshed with the stored row either way. External IDs
+// are unique per owner, so another owner's note with the same ExternalID is
+// a different note and is never touched.
+func (r *noteRepository) Upsert(n *domain.Note) error {
+	original := *n
+	return r.transaction(func(tx *gorm.DB) error {
+		*n = original
+		result := tx.Clauses(
+			clause.OnConflict{
+				Columns: []clause.Column{{Name: "owner_id"}, {Name: "external_id"}},
+				// Written as a literal, matching the index definition, so the
+				// planner can tell it implies the partial index's predicate.
+				TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "external_id <> ''"}}},
+				DoUpdates: clause.Set{
+					{Column: clause.Column{Name: "title"}, Value: n.Title},
+					{Column: clause.Column{Name: "content"}, Value: n.Content},
+					{Column: clause.Column{Name: "category"}, Value: n.Category},
+					{Column: clause.Column{Name: "meeting_date"}, Value: n.MeetingDate},
+					{Column: clause.Column{Name: "duration_minutes"}, Value: n.DurationMinutes},
+					{Column: clause.Column{Name: "location"}, Value: n.Location},
+					{Column: clause.Column{Name: "meeting_url"}, Value: n.MeetingURL},
+					{Column: clause.Column{Name: "format"}, Value: n.Format},
+					{Column: clause.Column{Name: "priority"}, Value: n.Priority},
+					{Column: clause.Column{Name: "visibility"}, Value: n.Visibility},
+					{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("CURRENT_TIMESTAMP")},
+					{Column: clause.Column{Name: "version"}, Value: gorm.Expr("notes.version + 1")},
+				},
+			},
+			clause.Returning{},
+		).Omit(clause.Associations).Create(n)
+		if result.Error != nil {
+			return result.Error
+		}
+		if err := saveRevisions(tx, *n); err != nil {
+			return err
+		}
+
+		eventType := domain.NoteCreated
+		if n.Version > 1 {
+			eventType = domain.NoteUpdated
+		}
+		return r.recordEvent(tx, eventType, *n)
+	})
 }
 
 func (r *noteRepository) GetAll() ([]domain.Note, error) {
 	var notes []domain.Note
-	err := r.DB.Find(&notes).Error
+	err := r.replica().Find(&notes).Error
+	return notes, err
+}
+
+func (r *noteRepository) GetAllByOwner(ownerID uint, includeArchived bool) ([]domain.Note, error) {
+	var notes []domain.Note
+	err := r.DB.Scopes(archivedScope(includeArchived)).Where("owner_id = ?", ownerID).Find(&notes).Error
+	return notes, err
+}
+
+// GetAllVisible returns up to limit of the notes userID may read, newest
+// meeting first: their own, and others' that are shared or public. A limit
+// of zero or less returns them all.
+func (r *noteRepository) GetAllVisible(userID uint, includeArchived bool, limit int) ([]domain.Note, error) {
+	tx := r.DB.Scopes(archivedScope(includeArchived), visibleTo(userID)).Order(byMeetingDate).Order("id")
+	if limit > 0 {
+		tx = tx.Limit(limit)
+	}
+
+	var notes []domain.Note
+	err := tx.Find(&notes).Error
+	return notes, err
+}
+
+// GetPaginated returns limit of the notes viewerID may read, skipping the
+// first offset. A limit of zero returns them all. Archived notes are left
+// out unless includeArchived is set.
+func (r *noteRepository) GetPaginated(viewerID uint, limit, offset int, includeArchived bool) ([]domain.Note, error) {
+	tx := r.DB.Scopes(archivedScope(includeArchived), visibleTo(viewerID)).Offset(offset)
+	if limit > 0 {
+		tx = tx.Limit(limit)
+	}
+
+	var notes []domain.Note
+	err := tx.Find(&notes).Error
+	return notes, err
+}
+
+// GetAfterID returns up to limit of the notes viewerID may read with an ID
+// above afterID, in ID order. These are the same notes as GetAllVisible's,
+// so paging through them picks up where a truncated list stopped. Seeking on
+// the primary key keeps later pages as cheap as the first, unlike
+// GetPaginated's OFFSET.
+func (r *noteRepository) GetAfterID(viewerID, afterID uint, limit int, includeArchived bool) ([]domain.Note, error) {
+	var notes []domain.Note
+	err := r.DB.Scopes(archivedScope(includeArchived), visibleTo(viewerID)).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&notes).Error
 	return notes, err
 }
 
-func (r *noteRepository) GetPaginated(limit, offset int) ([]domain.Note, error) {
+// StreamByOwner calls fn with each of the owner's notes in ID order, scanning
+// one row at a time so the whole set is never held in memory. Archived notes
+// are left out unless includeArchived is set. It stops at the first error
+// from the database or from fn and returns it.
+func (r *noteRepository) StreamByOwner(ownerID uint, includeArchived bool, fn func(domain.Note) error) error {
+	tx := r.replica().Model(&domain.Note{}).
+		Scopes(archivedScope(includeArchived)).
+		Where("owner_id = ?", ownerID).
+		Order("id ASC")
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var note domain.Note
+		if err := tx.ScanRows(rows, &note); err != nil {
+			return err
+		}
+		// ScanRows doesn't run the AfterFind hook.
+		note.SetEndTime()
+		if err := fn(note); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// GetModifiedSince returns up to limit of the owner's notes changed after
+// cursor, archived and soft-deleted ones included, oldest change first. A
+// limit of zero returns them all. A soft-deleted note's UpdatedAt is when it
+// was deleted. Notes purged by PurgeDeleted are gone and can't be reported.
+//
+// It always reads the primary: a lagging replica could leave out a change
+// older than ones it returns, and a client checkpointing on the newest
+// UpdatedAt would never see it.
+func (r *noteRepository) GetModifiedSince(ownerID uint, cursor domain.ChangeCursor, limit int) ([]domain.Note, error) {
+	tx := r.DB.Unscoped().Where("owner_id = ?", ownerID)
+	if cursor.AfterID == 0 {
+		tx = tx.Where("updated_at > ?", cursor.Since)
+	} else {
+		tx = tx.Where("updated_at > ? OR (updated_at = ? AND id > ?)", cursor.Since, cursor.Since, cursor.AfterID)
+	}
+	if limit > 0 {
+		tx = tx.Limit(limit)
+	}
+
+	var notes []domain.Note
+	err := tx.Order("updated_at ASC, id ASC").Find(&notes).Error
+	return notes, err
+}
+
+// GetRecent returns the limit most recently created notes viewerID may read.
+func (r *noteRepository) GetRecent(viewerID uint, limit int) ([]domain.Note, error) {
+	var notes []domain.Note
+	err := r.DB.Scopes(visibleTo(viewerID)).Order("created_at DESC").Limit(limit).Find(&notes).Error
+	return notes, err
+}
+
+// GetUpcoming returns the unarchived notes viewerID may read whose meeting
+// falls in [from, to), soonest first.
+func (r *noteRepository) GetUpcoming(viewerID uint, from, to time.Time) ([]domain.Note, error) {
+	return meetingsBetween(r.DB.Scopes(visibleTo(viewerID)), from, to)
+}
+
+// GetStartingBetween returns every user's unarchived notes whose meeting
+// falls in [from, to), soonest first, for meeting reminders.
+func (r *noteRepository) GetStartingBetween(from, to time.Time) ([]domain.Note, error) {
+	return meetingsBetween(r.DB, from, to)
+}
+
+// meetingsBetween runs the query shared by GetUpcoming and
+// GetStartingBetween.
+func meetingsBetween(tx *gorm.DB, from, to time.Time) ([]domain.Note, error) {
 	var notes []domain.Note
-	err := r.DB.Limit(limit).Offset(offset).Find(&notes).Error
+	err := tx.Scopes(archivedScope(false)).
+		Where("meeting_date >= ? AND meeting_date < ?", from, to).
+		Order("meeting_date ASC").
+		Find(&notes).Error
 	return notes, err
 }
 
 func (r *noteRepository) GetByID(id uint) (domain.Note, error) {
 	var note domain.Note
-	err := r.DB.First(&note, id).Error
+	err := r.replica().First(&note, id).Error
+	return note, err
+}
+
+// Exists reports whether ownerID has a note with the given id that is not in
+// the trash. It reads only the ID, for callers that need to know the note is
+// there but not what is in it.
+func (r *noteRepository) Exists(id, ownerID uint) (bool, error) {
+	var ids []uint
+	err := r.replica().Model(&domain.Note{}).
+		Where("id = ? AND owner_id = ?", id, ownerID).
+		Limit(1).
+		Pluck("id", &ids).Error
+	return len(ids) > 0, err
+}
+
+// GetByIDs loads every note whose ID is in ids with a single query. Unknown
+// IDs are skipped and the result is in database order.
+func (r *noteRepository) GetByIDs(ids []uint) ([]domain.Note, error) {
+	var notes []domain.Note
+	err := r.DB.Where("id IN ?", ids).Find(&notes).Error
+	return notes, err
+}
+
+// GetBySlug loads the note with the given slug.
+func (r *noteRepository) GetBySlug(slug string) (domain.Note, error) {
+	var note domain.Note
+	err := r.replica().Where("slug = ?", slug).First(&note).Error
 	return note, err
 }
 
+// GetByExternalID loads the owner's note with the given ExternalID, trashed
+// or not: the note Upsert would overwrite.
+func (r *noteRepository) GetByExternalID(ownerID uint, externalID string) (domain.Note, error) {
+	var note domain.Note
+	err := r.DB.Unscoped().Where("owner_id = ? AND external_id = ?", ownerID, externalID).First(&note).Error
+	return note, err
+}
+
+// SlugsWithPrefix returns every stored slug that is base itself or starts
+// with base followed by "-". Trashed notes keep their slug, so they are
+// included.
+func (r *noteRepository) SlugsWithPrefix(base string) ([]string, error) {
+	var slugs []string
+	err := r.DB.Unscoped().Model(&domain.Note{}).
+		Where("slug = ? OR slug LIKE ?", base, base+"-%").
+		Pluck("slug", &slugs).Error
+	return slugs, err
+}
+
+// Update writes n only if the stored version still equals n.Version, then
+// bumps the version and records a revision. A version mismatch returns
+// ErrVersionConflict.
+//
+// Only the editable fields are written: title, content, category, meeting
+// date, duration, location, meeting URL, format, priority, visibility and
+// slug. CreatedAt, OwnerID, ExternalID and Archived keep their stored values
+// whatever n holds.
 func (r *noteRepository) Update(n *domain.Note) error {
-	return r.DB.Save(n).Error
+	return r.transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Note{}).
+			Where("id = ? AND owner_id = ? AND version = ?", n.ID, n.OwnerID, n.Version).
+			Updates(map[string]interface{}{
+				"title":            n.Title,
+				"content":          n.Content,
+				"category":         n.Category,
+				"meeting_date":     n.MeetingDate,
+				"duration_minutes": n.DurationMinutes,
+				"location":         n.Location,
+				"meeting_url":      n.MeetingURL,
+				"format":           n.Format,
+				"priority":         n.Priority,
+				"visibility":       n.Visibility,
+				"slug":             n.Slug,
+				"version":          gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+
+		saved := *n
+		saved.Version++
+		if err := saveRevisions(tx, saved); err != nil {
+			return err
+		}
+		if err := r.recordEvent(tx, domain.NoteUpdated, saved); err != nil {
+			return err
+		}
+
+		n.Version = saved.Version
+		return nil
+	})
+}
+
+// ListRevisions returns every stored revision of a note, oldest first.
+func (r *noteRepository) ListRevisions(noteID uint) ([]domain.NoteRevision, error) {
+	var revisions []domain.NoteRevision
+	err := r.DB.Where("note_id = ?", noteID).Order("version").Find(&revisions).Error
+	return revisions, err
+}
+
+// AddAttachment stores the metadata in a. It does not check that the note
+// exists; callers do that first.
+func (r *noteRepository) AddAttachment(a *domain.Attachment) error {
+	return r.write(func() error {
+		return r.DB.Create(a).Error
+	})
+}
+
+// ListAttachments returns a note's attachments in the order they were added.
+func (r *noteRepository) ListAttachments(noteID uint) ([]domain.Attachment, error) {
+	var attachments []domain.Attachment
+	err := r.DB.Where("note_id = ?", noteID).Order("id").Find(&attachments).Error
+	return attachments, err
+}
+
+// AddLink stores l, returning ErrSelfLink if it links a note to itself and
+// ErrDuplicateLink if the note already links to the target. It does not
+// check that the notes exist; callers do that first.
+func (r *noteRepository) AddLink(l *domain.NoteLink) error {
+	if l.FromNoteID == l.ToNoteID {
+		return ErrSelfLink
+	}
+	return r.write(func() error {
+		return translateUniqueViolation(r.DB.Create(l).Error)
+	})
+}
+
+// ListLinks returns the links from and to a note in the order they were
+// added.
+func (r *noteRepository) ListLinks(noteID uint) ([]domain.NoteLink, error) {
+	var links []domain.NoteLink
+	err := r.DB.Where("from_note_id = ? OR to_note_id = ?", noteID, noteID).Order("id").Find(&links).Error
+	return links, err
+}
+
+// saveRevisions snapshots each note at its current version. It runs inside
+// the transaction that changed the notes so a revision is never missed.
+func saveRevisions(tx *gorm.DB, notes ...domain.Note) error {
+	if len(notes) == 0 {
+		return nil
+	}
+
+	revisions := make([]domain.NoteRevision, 0, len(notes))
+	for _, n := range notes {
+		revisions = append(revisions, domain.NoteRevision{
+			NoteID:   n.ID,
+			Version:  n.Version,
+			Title:    n.Title,
+			Content:  n.Content,
+			Category: n.Category,
+		})
+	}
+	return tx.Create(&revisions).Error
 }
 
+// Delete moves the note and its attachments to the trash or, with the
+// HardDelete option, removes them along with the note's revisions and audit
+// history. Either way the links from and to the note are removed for good.
 func (r *noteRepository) Delete(id uint) error {
-	return r.DB.Delete(&domain.Note{}, id).Error
+	return r.transaction(func(tx *gorm.DB) error {
+		note := domain.Note{ID: id}
+		if r.outbox {
+			if err := tx.First(&note, id).Error; err != nil {
+				return err
+			}
+		}
+
+		if r.hardDelete {
+			if err := hardDeleteNote(tx, id); err != nil {
+				return err
+			}
+			return r.recordEvent(tx, domain.NoteDeleted, note)
+		}
+
+		if err := tx.Delete(&domain.Note{}, id).Error; err != nil {
+			return err
+		}
+		// A soft delete only sets deleted_at. Touch updated_at too so the
+		// deletion shows up in GetModifiedSince.
+		if err := tx.Unscoped().Model(&domain.Note{}).Where("id = ?", id).UpdateColumn("updated_at", time.Now()).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("note_id = ?", id).Delete(&domain.Attachment{}).Error; err != nil {
+			return err
+		}
+		if err := deleteLinks(tx, id); err != nil {
+			return err
+		}
+		return r.recordEvent(tx, domain.NoteDeleted, note)
+	})
+}
+
+// hardDeleteNote removes the note and everything stored about it. Audit
+// entries go too, since they hold copies of its content.
+func hardDeleteNote(tx *gorm.DB, id uint) error {
+	for _, model := range []interface{}{&domain.Attachment{}, &domain.NoteRevision{}, &domain.AuditLog{}} {
+		if err := tx.Unscoped().Where("note_id = ?", id).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	if err := deleteLinks(tx, id); err != nil {
+		return err
+	}
+	return tx.Unscoped().Delete(&domain.Note{}, id).Error
+}
+
+// deleteLinks removes every link from or to the note.
+func deleteLinks(tx *gorm.DB, id uint) error {
+	return tx.Where("from_note_id = ? OR to_note_id = ?", id, id).Delete(&domain.NoteLink{}).Error
+}
+
+// SetArchived marks the note archived or unarchived. It leaves the version
+// alone since the note's content is unchanged.
+func (r *noteRepository) SetArchived(id uint, archived bool) error {
+	return r.write(func() error {
+		return r.DB.Model(&domain.Note{}).Where("id = ?", id).Update("archived", archived).Error
+	})
 }
 
-func (r *noteRepository) Search(keyword string) ([]domain.Note, error) {
+// ArchiveOlderThan archives every live, unarchived note whose meeting was
+// before cutoff and reports how many were archived.
+func (r *noteRepository) ArchiveOlderThan(cutoff time.Time) (int64, error) {
+	var archived int64
+	err := r.write(func() error {
+		result := r.DB.Model(&domain.Note{}).
+			Where("archived = ? AND meeting_date < ?", false, cutoff).
+			Update("archived", true)
+		archived = result.RowsAffected
+		return result.Error
+	})
+	return archived, err
+}
+
+// archivedScope leaves archived notes out of a query unless include is set.
+func archivedScope(include bool) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if include {
+			return db
+		}
+		return db.Where("archived = ?", false)
+	}
+}
+
+// visibleTo limits a query to the notes userID may read, following
+// domain.Note.VisibleTo.
+func visibleTo(userID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if userID == 0 {
+			return db.Where("owner_id = ? OR visibility = ?", userID, domain.VisibilityPublic)
+		}
+		return db.Where("owner_id = ? OR visibility IN ?", userID, []string{domain.VisibilityShared, domain.VisibilityPublic})
+	}
+}
+
+// Search returns one page of the notes viewerID may read whose title or
+// content contains keyword and whose meeting falls within dates, newest
+// meeting first, along with the total number of matches.
+func (r *noteRepository) Search(viewerID uint, keyword string, dates domain.DateRange, page domain.Page) ([]domain.Note, int64, error) {
+	like := containsPattern(keyword)
+	d := dialectOf(r.DB)
+	tx := r.replica().Model(&domain.Note{}).Scopes(visibleTo(viewerID)).Where(d.ilike("title")+" OR "+d.ilike("content"), like, like)
+	return findPage(meetingDateIn(tx, dates), page, byMeetingDate)
+}
+
+// meetingDateIn limits tx to notes whose meeting falls within dates.
+func meetingDateIn(tx *gorm.DB, dates domain.DateRange) *gorm.DB {
+	if dates.From != nil {
+		tx = tx.Where("meeting_date >= ?", *dates.From)
+	}
+	if dates.To != nil {
+		tx = tx.Where("meeting_date <= ?", *dates.To)
+	}
+	return tx
+}
+
+// FuzzySearch returns one page of the notes viewerID may read whose title or
+// content has a trigram similarity of at least threshold to keyword and whose
+// meeting falls within dates, most similar first, along with the total number
+// of matches. It tolerates typos that Search does not.
+func (r *noteRepository) FuzzySearch(viewerID uint, keyword string, threshold float64, dates domain.DateRange, page domain.Page) ([]domain.Note, int64, error) {
+	if dialectOf(r.DB).sqlite {
+		return r.fuzzySearchInMemory(viewerID, keyword, threshold, dates, page)
+	}
+
 	var notes []domain.Note
-	err := r.DB.
-		Where("title ILIKE ? OR content ILIKE ?", "%"+keyword+"%", "%"+keyword+"%").
-		Find(&notes).Error
-	return notes, err
+	var total int64
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		// The % operator is what the trigram indexes serve, and it compares
+		// against pg_trgm.similarity_threshold; set that for this
+		// transaction only.
+		err := tx.Exec("SELECT set_config('pg_trgm.similarity_threshold', ?, true)", strconv.FormatFloat(threshold, 'f', -1, 64)).Error
+		if err != nil {
+			return err
+		}
+
+		query := meetingDateIn(tx.Model(&domain.Note{}).Scopes(visibleTo(viewerID)).Where("title % ? OR content % ?", keyword, keyword), dates).Session(&gorm.Session{})
+		if err := query.Count(&total).Error; err != nil {
+			return err
+		}
+
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "GREATEST(similarity(title, ?), similarity(content, ?)) DESC, id",
+			Vars:               []interface{}{keyword, keyword},
+			WithoutParentheses: true,
+		}}).Offset(page.Offset)
+		if page.Limit > 0 {
+			query = query.Limit(page.Limit)
+		}
+		return query.Find(&notes).Error
+	})
+
+	return notes, total, err
+}
+
+// fuzzySearchInMemory scores every note in Go, for SQLite.
+func (r *noteRepository) fuzzySearchInMemory(viewerID uint, keyword string, threshold float64, dates domain.DateRange, page domain.Page) ([]domain.Note, int64, error) {
+	var all []domain.Note
+	if err := meetingDateIn(r.DB.Scopes(visibleTo(viewerID)), dates).Order("id").Find(&all).Error; err != nil {
+		return nil, 0, err
+	}
+
+	type scored struct {
+		note  domain.Note
+		score float64
+	}
+	var matches []scored
+	for _, n := range all {
+		score := math.Max(TrigramSimilarity(n.Title, keyword), TrigramSimilarity(n.Content, keyword))
+		if score >= threshold {
+			matches = append(matches, scored{note: n, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	total := int64(len(matches))
+	if page.Offset >= len(matches) {
+		return []domain.Note{}, total, nil
+	}
+	matches = matches[page.Offset:]
+	if page.Limit > 0 && page.Limit < len(matches) {
+		matches = matches[:page.Limit]
+	}
+
+	notes := make([]domain.Note, 0, len(matches))
+	for _, m := range matches {
+		notes = append(notes, m.note)
+	}
+	return notes, total, nil
+}
+
+// Filter returns one page of notes matching filter in the filter's sort
+// order, newest meeting first by default, along with the total number of
+// matches.
+func (r *noteRepository) Filter(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error) {
+	return findPage(r.filterQuery(filter), page, filterOrder(filter))
+}
+
+// byMeetingDate orders notes newest meeting first.
+var byMeetingDate = clause.OrderByColumn{Column: clause.Column{Name: "meeting_date"}, Desc: true}
+
+// sortColumns maps each field a NoteFilter can sort by to its column. Only
+// these columns ever reach ORDER BY.
+var sortColumns = map[string]string{
+	domain.SortMeetingDate: "meeting_date",
+	domain.SortCreatedAt:   "created_at",
+	domain.SortUpdatedAt:   "updated_at",
+	domain.SortTitle:       "title",
+}
+
+// byPriorityRank ranks the priority column in the order of
+// domain.Priorities, since sorting the names themselves would put critical
+// before high.
+var byPriorityRank = func() string {
+	var b strings.Builder
+	b.WriteString("CASE priority")
+	for rank, priority := range domain.Priorities {
+		b.WriteString(" WHEN '" + priority + "' THEN " + strconv.Itoa(rank))
+	}
+	b.WriteString(" END")
+	return b.String()
+}()
+
+// filterOrder returns the ordering filter asks for, falling back to
+// byMeetingDate for an unknown field.
+func filterOrder(filter domain.NoteFilter) clause.OrderByColumn {
+	if filter.Sort == domain.SortPriority {
+		return clause.OrderByColumn{Column: clause.Column{Name: byPriorityRank, Raw: true}, Desc: filter.Order != domain.SortAsc}
+	}
+	column, ok := sortColumns[filter.Sort]
+	if !ok {
+		return byMeetingDate
+	}
+	return clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: filter.Order != domain.SortAsc}
+}
+
+// CountFiltered returns how many notes Filter would match across all pages.
+func (r *noteRepository) CountFiltered(filter domain.NoteFilter) (int64, error) {
+	var count int64
+	err := r.filterQuery(filter).Count(&count).Error
+	return count, err
 }
 
-func (r *noteRepository) Filter(filter domain.NoteFilter) ([]domain.Note, error) {
+// filterQuery builds the conditions shared by Filter and CountFiltered.
+func (r *noteRepository) filterQuery(filter domain.NoteFilter) *gorm.DB {
+	tx := r.replica().Model(&domain.Note{}).Scopes(archivedScope(filter.IncludeArchived), visibleTo(filter.ViewerID)) // Start building the query
+
+	if keywords := filter.AllKeywords(); len(keywords) > 0 {
+		d := dialectOf(r.DB)
+		join := " OR "
+		if filter.KeywordMatch == domain.KeywordMatchAll {
+			join = " AND "
+		}
+
+		clauses := make([]string, 0, len(keywords))
+		args := make([]interface{}, 0, 2*len(keywords))
+		for _, keyword := range keywords {
+			like := containsPattern(keyword)
+			clauses = append(clauses, "("+d.ilike("title")+" OR "+d.ilike("content")+")")
+			args = append(args, like, like)
+		}
+		tx = tx.Where(strings.Join(clauses, join), args...)
+	}
+
+	if len(filter.Categories) > 0 {
+		// Matched case-insensitively; idx_notes_category_lower covers
+		// LOWER(category) so this still uses an index.
+		categories := make([]string, 0, len(filter.Categories))
+		for _, category := range filter.Categories {
+			categories = append(categories, strings.ToLower(strings.TrimSpace(category)))
+		}
+		tx = tx.Where("LOWER(category) IN ?", categories)
+	}
+
+	tx = meetingDateIn(tx, domain.DateRange{From: filter.FromDate, To: filter.ToDate})
+
+	if filter.CreatedFrom != nil {
+		tx = tx.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+
+	if filter.CreatedTo != nil {
+		tx = tx.Where("created_at <= ?", *filter.CreatedTo)
+	}
+
+	if filter.UncategorizedOnly {
+		tx = tx.Where("category = '' OR category IS NULL")
+	}
+
+	if filter.MaxContentLength > 0 {
+		tx = tx.Where("LENGTH(content) <= ?", filter.MaxContentLength)
+	}
+
+	switch filter.MeetingMode {
+	case domain.MeetingModeRemote:
+		tx = tx.Where("meeting_url <> ''")
+	case domain.MeetingModeInPerson:
+		tx = tx.Where("meeting_url = ''")
+	}
+
+	if filter.Priority != "" {
+		tx = tx.Where("priority = ?", filter.Priority)
+	}
+
+	return tx
+}
+
+// findPage counts every row matched by tx, then loads the requested page of
+// them in order, with ties broken by ID so pages don't overlap.
+func findPage(tx *gorm.DB, page domain.Page, order clause.OrderByColumn) ([]domain.Note, int64, error) {
+	tx = tx.Session(&gorm.Session{})
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := tx.Order(order).Order("id").Offset(page.Offset)
+	if page.Limit > 0 {
+		query = query.Limit(page.Limit)
+	}
+
 	var notes []domain.Note
+	err := query.Find(&notes).Error
+	return notes, total, err
+}
+
+// restoredColumns clears deleted_at and touches updated_at, so a restore
+// shows up in GetModifiedSince the same way a delete does.
+func restoredColumns() map[string]interface{} {
+	return map[string]interface{}{"deleted_at": nil, "updated_at": time.Now()}
+}
+
+// RestoreNotes takes the owner's notes with the given IDs, and their
+// attachments, out of the trash and returns how many notes were restored.
+// IDs of other owners' notes are skipped.
+func (r *noteRepository) RestoreNotes(ownerID uint, ids []uint) (int64, error) {
+	var restored int64
+
+	err := r.transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().
+			Model(&domain.Note{}).
+			Where("id IN ? AND owner_id = ? AND deleted_at IS NOT NULL", ids, ownerID).
+			UpdateColumns(restoredColumns())
+		if result.Error != nil {
+			return result.Error
+		}
+		restored = result.RowsAffected
 
-	tx := r.DB // Start building the query
+		return tx.Unscoped().
+			Model(&domain.Attachment{}).
+			Where("note_id IN (?) AND deleted_at IS NOT NULL",
+				tx.Unscoped().Model(&domain.Note{}).Select("id").Where("id IN ? AND owner_id = ?", ids, ownerID)).
+			Update("deleted_at", nil).Error
+	})
 
-	if filter.Keyword != "" {
-		like := "%" + filter.Keyword + "%"
-		tx = tx.Where("title ILIKE ? OR content ILIKE ?", like, like)
+	return restored, err
+}
+
+// RestoreDeletedSince takes every note of the owner soft-deleted after since
+// out of the trash, along with the attachments trashed with it, and returns
+// how many notes were restored. Notes deleted at or before since, and other
+// owners' notes, stay in the trash.
+func (r *noteRepository) RestoreDeletedSince(ownerID uint, since time.Time) (int64, error) {
+	var restored int64
+
+	err := r.transaction(func(tx *gorm.DB) error {
+		restored = 0
+
+		var ids []uint
+		err := tx.Unscoped().Model(&domain.Note{}).
+			Where("owner_id = ? AND deleted_at IS NOT NULL AND deleted_at > ?", ownerID, since).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		result := tx.Unscoped().
+			Model(&domain.Note{}).
+			Where("id IN ?", ids).
+			UpdateColumns(restoredColumns())
+		if result.Error != nil {
+			return result.Error
+		}
+		restored = result.RowsAffected
+
+		return tx.Unscoped().
+			Model(&domain.Attachment{}).
+			Where("note_id IN (?) AND deleted_at IS NOT NULL AND deleted_at > ?",
+				tx.Unscoped().Model(&domain.Note{}).Select("id").Where("id IN ? AND owner_id = ?", ids, ownerID), since).
+			Update("deleted_at", nil).Error
+	})
+
+	return restored, err
+}
+
+// RenameCategory moves every live note of the owner in category from to
+// category to and bumps their versions, recording a note.updated event for
+// each. Soft-deleted notes keep their old category.
+func (r *noteRepository) RenameCategory(ownerID uint, from, to string) (int64, error) {
+	var renamed int64
+
+	err := r.transaction(func(tx *gorm.DB) error {
+		var notes []domain.Note
+		result := tx.Model(&notes).
+			Clauses(clause.Returning{}).
+			Where("owner_id = ? AND category = ?", ownerID, from).
+			Updates(map[string]interface{}{
+				"category": to,
+				"version":  gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		renamed = result.RowsAffected
+		if err := saveRevisions(tx, notes...); err != nil {
+			return err
+		}
+		for _, n := range notes {
+			if err := r.recordEvent(tx, domain.NoteUpdated, n); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return renamed, err
+}
+
+// Extremes finds the longest and shortest of the notes viewerID may read.
+func (r *noteRepository) Extremes(viewerID uint) (domain.NoteExtremes, error) {
+	var extremes domain.NoteExtremes
+
+	longest, err := r.noteByContentLength(viewerID, "LENGTH(content) DESC", false)
+	if err != nil {
+		return extremes, err
 	}
 
-	if filter.Category != "" {
-		tx = tx.Where("category = ?", filter.Category)
+	shortest, err := r.noteByContentLength(viewerID, "LENGTH(content) ASC", true)
+	if err != nil {
+		return extremes, err
 	}
 
-	if filter.FromDate != nil {
-		tx = tx.Where("meeting_date >= ?", *filter.FromDate)
+	extremes.Longest = longest
+	extremes.Shortest = shortest
+	return extremes, nil
+}
+
+func (r *noteRepository) noteByContentLength(viewerID uint, order string, skipEmpty bool) (*domain.NoteLength, error) {
+	var result domain.NoteLength
+
+	tx := r.DB.Model(&domain.Note{}).
+		Scopes(visibleTo(viewerID)).
+		Select("id, title, LENGTH(content) AS length")
+
+	if skipEmpty {
+		tx = tx.Where("content <> ''")
 	}
 
-	if filter.ToDate != nil {
-		tx = tx.Where("meeting_date <= ?", *filter.ToDate)
+	res := tx.Order(order).Order("id").Limit(1).Scan(&result)
+	if res.Error != nil {
+		return nil, res.Error
 	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &result, nil
+}
 
-	err := tx.Find(&notes).Error
-	return notes, err
+// DistinctCategories counts the notes viewerID may read per category,
+// leaving out notes without one.
+func (r *noteRepository) DistinctCategories(viewerID uint) ([]domain.CategoryCount, error) {
+	return categoryCounts(r.DB.Scopes(visibleTo(viewerID)))
+}
+
+// CountByCategory counts the owner's live notes per category, leaving out
+// notes without one, for category quotas. Unlike DistinctCategories it
+// ignores visibility: other users' notes never count.
+func (r *noteRepository) CountByCategory(ownerID uint) ([]domain.CategoryCount, error) {
+	return categoryCounts(r.DB.Where("owner_id = ?", ownerID))
+}
+
+// categoryCounts runs the query shared by DistinctCategories and
+// CountByCategory.
+func categoryCounts(tx *gorm.DB) ([]domain.CategoryCount, error) {
+	var categories []domain.CategoryCount
+	err := tx.Model(&domain.Note{}).
+		Select("category, COUNT(*) AS count").
+		Where("category <> ''").
+		Group("category").
+		Order("category").
+		Scan(&categories).Error
+	return categories, err
+}
+
+// PurgeDeleted permanently removes notes soft-deleted before cutoff, along
+// with their revisions. Rows that are not soft-deleted are never touched.
+func (r *noteRepository) PurgeDeleted(cutoff time.Time) (int64, error) {
+	var purged int64
+
+	err := r.transaction(func(tx *gorm.DB) error {
+		expired := tx.Unscoped().
+			Model(&domain.Note{}).
+			Select("id").
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff)
+
+		if err := tx.Where("note_id IN (?)", expired).Delete(&domain.NoteRevision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("note_id IN (?)", expired).Delete(&domain.Attachment{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+			Delete(&domain.Note{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged = result.RowsAffected
+		return nil
+	})
+
+	return purged, err
+}
+
+// Stats summarises the live notes viewerID may read. Meetings are grouped by
+// calendar month in loc.
+func (r *noteRepository) Stats(viewerID uint, loc *time.Location) (domain.NoteStats, error) {
+	stats := domain.NoteStats{
+		ByCategory: []domain.CategoryCount{},
+		ByMonth:    []domain.MonthCount{},
+	}
+
+	if err := r.visibleNotes(viewerID).Count(&stats.Total).Error; err != nil {
+		return stats, err
+	}
+
+	err := r.visibleNotes(viewerID).
+		Select("category, COUNT(*) AS count").
+		Group("category").
+		Order("category").
+		Scan(&stats.ByCategory).Error
+	if err != nil {
+		return stats, err
+	}
+
+	stats.ByMonth, err = r.countByMonth(viewerID, loc)
+	if err != nil {
+		return stats, err
+	}
+
+	stats.LatestMeeting, err = r.latestMeeting(viewerID)
+	if err != nil {
+		return stats, err
+	}
+
+	return stats, nil
+}
+
+// visibleNotes starts a query on the notes viewerID may read.
+func (r *noteRepository) visibleNotes(viewerID uint) *gorm.DB {
+	return r.DB.Model(&domain.Note{}).Scopes(visibleTo(viewerID))
+}
+
+func (r *noteRepository) latestMeeting(viewerID uint) (*time.Time, error) {
+	if dialectOf(r.DB).sqlite {
+		// SQLite hands MAX() back as text; reading the column itself keeps
+		// its declared type so it scans as a time.
+		var dates []time.Time
+		err := r.visibleNotes(viewerID).Order("meeting_date DESC").Limit(1).Pluck("meeting_date", &dates).Error
+		if err != nil || len(dates) == 0 {
+			return nil, err
+		}
+		return &dates[0], nil
+	}
+
+	var latest sql.NullTime
+	if err := r.visibleNotes(viewerID).Select("MAX(meeting_date)").Row().Scan(&latest); err != nil {
+		return nil, err
+	}
+	if !latest.Valid {
+		return nil, nil
+	}
+	return &latest.Time, nil
+}
+
+// countByMonth counts the meetings viewerID may read per calendar month in
+// loc. Each month is given as its first day at midnight in loc.
+func (r *noteRepository) countByMonth(viewerID uint, loc *time.Location) ([]domain.MonthCount, error) {
+	byMonth := []domain.MonthCount{}
+
+	if dialectOf(r.DB).sqlite {
+		// SQLite has no timezone data, so the months are counted here.
+		var dates []time.Time
+		if err := r.visibleNotes(viewerID).Pluck("meeting_date", &dates).Error; err != nil {
+			return byMonth, err
+		}
+		counts := map[time.Time]int64{}
+		for _, date := range dates {
+			counts[timeutil.StartOfMonth(date, loc)]++
+		}
+		for month, count := range counts {
+			byMonth = append(byMonth, domain.MonthCount{Month: month, Count: count})
+		}
+		sort.Slice(byMonth, func(i, j int) bool {
+			return byMonth[i].Month.Before(byMonth[j].Month)
+		})
+		return byMonth, nil
+	}
+
+	// AT TIME ZONE turns each timestamptz into the wall clock time in loc,
+	// which comes back labelled UTC.
+	var rows []struct {
+		Month time.Time
+		Count int64
+	}
+	err := r.visibleNotes(viewerID).
+		Select("date_trunc('month', meeting_date AT TIME ZONE ?) AS month, COUNT(*) AS count", loc.String()).
+		Group("month").
+		Order("month").
+		Scan(&rows).Error
+	if err != nil {
+		return byMonth, err
+	}
+	for _, row := range rows {
+		byMonth = append(byMonth, domain.MonthCount{
+			Month: time.Date(row.Month.Year(), row.Month.Month(), 1, 0, 0, 0, 0, loc),
+			Count: row.Count,
+		})
+	}
+	return byMonth, nil
+}
+
+// CountNotes returns the number of notes that haven't been soft-deleted.
+func (r *noteRepository) CountNotes() (int64, error) {
+	var count int64
+	err := r.DB.Model(&domain.Note{}).Count(&count).Error
+	return count, err
 }
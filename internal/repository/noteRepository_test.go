@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/jt00721/meeting-notes-manager/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
@@ -32,16 +35,36 @@ func SetupTestDB(m *testing.M) {
 		os.Getenv("POSTGRES_PORT"),
 	)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// DB_DRIVER=sqlite runs the suite against an in-memory SQLite database
+	// instead of POSTGRES_TEST_DB.
+	dialector := postgres.Open(dsn)
+	if os.Getenv("DB_DRIVER") == "sqlite" {
+		dialector = sqlite.Open(":memory:")
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{})
 	if err != nil {
 		log.Fatal("Failed to connect to test DB:", err)
 	}
 
-	err = db.AutoMigrate(&domain.Note{})
+	if db.Dialector.Name() == "sqlite" {
+		// Every connection to :memory: is its own empty database.
+		sqlDB, err := db.DB()
+		if err != nil {
+			log.Fatal("Failed to configure test DB:", err)
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	err = db.AutoMigrate(&domain.Note{}, &domain.NoteRevision{}, &domain.FilterPreset{}, &domain.OutboxEvent{}, &domain.Attachment{}, &domain.AuditLog{}, &domain.NoteLink{})
 	if err != nil {
 		log.Fatal("Failed to migrate schema:", err)
 	}
 
+	if err := EnsureTrigramIndexes(db); err != nil {
+		log.Fatal("Failed to enable trigram search:", err)
+	}
+
 	DB = db
 
 	testRepo = NewNoteRepository(DB)
@@ -52,8 +75,21 @@ func SetupTestDB(m *testing.M) {
 }
 
 func cleanDB(t *testing.T) {
-	err := DB.Exec("TRUNCATE notes RESTART IDENTITY CASCADE").Error
-	assert.NoError(t, err)
+	truncate(t, "notes", "note_revisions", "outbox_events", "attachments", "audit_logs", "note_links")
+}
+
+// truncate empties tables and resets their ID sequences.
+func truncate(t *testing.T, tables ...string) {
+	if DB.Dialector.Name() != "sqlite" {
+		err := DB.Exec("TRUNCATE " + strings.Join(tables, ", ") + " RESTART IDENTITY CASCADE").Error
+		assert.NoError(t, err)
+		return
+	}
+
+	for _, table := range tables {
+		assert.NoError(t, DB.Exec("DELETE FROM "+table).Error)
+	}
+	assert.NoError(t, DB.Exec("DELETE FROM sqlite_sequence WHERE name IN ?", tables).Error)
 }
 
 func TestMain(m *testing.M) {
@@ -73,6 +109,108 @@ func TestCreate(t *testing.T) {
 	err := testRepo.Create(&note)
 	assert.NoError(t, err)
 	assert.NotZero(t, note.ID)
+
+	stored, err := testRepo.GetByID(note.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NoteFormatPlaintext, stored.Format, "format defaults to plaintext")
+}
+
+func TestCreateBatch(t *testing.T) {
+	cleanDB(t)
+
+	notes := []domain.Note{
+		{Title: "First", Content: "Some notes", Slug: "first", MeetingDate: time.Now()},
+		{Title: "Second", Content: "More notes", Slug: "second", MeetingDate: time.Now()},
+	}
+	assert.NoError(t, testRepo.CreateBatch(notes))
+
+	for _, n := range notes {
+		assert.NotZero(t, n.ID)
+		assert.Equal(t, 1, n.Version)
+
+		revisions, err := testRepo.ListRevisions(n.ID)
+		assert.NoError(t, err)
+		assert.Len(t, revisions, 1)
+	}
+
+	// A clashing slug fails the whole batch.
+	err := testRepo.CreateBatch([]domain.Note{
+		{Title: "Third", Content: "Some notes", Slug: "third", MeetingDate: time.Now()},
+		{Title: "First again", Content: "Some notes", Slug: "first", MeetingDate: time.Now()},
+	})
+	assert.Error(t, err)
+
+	count, err := testRepo.CountNotes()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestGetRecent(t *testing.T) {
+	cleanDB(t)
+
+	for _, title := range []string{"First", "Second", "Third"} {
+		assert.NoError(t, testRepo.Create(&domain.Note{
+			Title:       title,
+			Content:     "Some notes",
+			MeetingDate: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		}))
+	}
+
+	notes, err := testRepo.GetRecent(0, 2)
+	assert.NoError(t, err)
+	assert.Len(t, notes, 2)
+	assert.Equal(t, "Third", notes[0].Title)
+	assert.Equal(t, "Second", notes[1].Title)
+}
+
+func TestUpsert(t *testing.T) {
+	cleanDB(t)
+
+	// Native notes share an empty ExternalID without tripping the unique index.
+	assert.NoError(t, testRepo.Create(&domain.Note{Title: "Native 1", Content: "Some notes", MeetingDate: time.Now()}))
+	assert.NoError(t, testRepo.Create(&domain.Note{Title: "Native 2", Content: "Some notes", MeetingDate: time.Now()}))
+
+	first := domain.Note{ExternalID: "ext-1", Title: "Imported", Content: "Some notes", MeetingDate: time.Now()}
+	assert.NoError(t, testRepo.Upsert(&first))
+
+	retry := domain.Note{ExternalID: "ext-1", Title: "Imported again", Content: "Updated notes", MeetingDate: time.Now()}
+	assert.NoError(t, testRepo.Upsert(&retry))
+	assert.Equal(t, first.ID, retry.ID)
+	assert.Equal(t, 2, retry.Version)
+
+	notes, err := testRepo.GetAll()
+	assert.NoError(t, err)
+	assert.Len(t, notes, 3)
+
+	fetched, err := testRepo.GetByID(first.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Imported again", fetched.Title)
+}
+
+func TestUpsertOtherOwner(t *testing.T) {
+	cleanDB(t)
+
+	mine := domain.Note{OwnerID: 1, ExternalID: "ext-1", Title: "Mine", Content: "Private notes", MeetingDate: time.Now(), Visibility: domain.VisibilityPrivate}
+	assert.NoError(t, testRepo.Upsert(&mine))
+
+	// Another owner reusing the external ID gets a note of their own and
+	// never sees or changes the first one.
+	theirs := domain.Note{OwnerID: 2, ExternalID: "ext-1", Title: "Theirs", Content: "Other notes", MeetingDate: time.Now(), Visibility: domain.VisibilityPublic}
+	assert.NoError(t, testRepo.Upsert(&theirs))
+	assert.NotEqual(t, mine.ID, theirs.ID)
+	assert.Equal(t, 1, theirs.Version)
+	assert.Equal(t, "Theirs", theirs.Title)
+
+	fetched, err := testRepo.GetByID(mine.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Mine", fetched.Title)
+	assert.Equal(t, domain.VisibilityPrivate, fetched.Visibility)
+	assert.Equal(t, 1, fetched.Version)
+
+	retry := domain.Note{OwnerID: 2, ExternalID: "ext-1", Title: "Theirs again", Content: "Other notes", MeetingDate: time.Now()}
+	assert.NoError(t, testRepo.Upsert(&retry))
+	assert.Equal(t, theirs.ID, retry.ID)
+	assert.Equal(t, 2, retry.Version)
 }
 
 func TestGetByID(t *testing.T) {
@@ -93,6 +231,74 @@ func TestGetByID(t *testing.T) {
 	assert.Equal(t, "Test Meeting", fetchedNote.Title)
 }
 
+func TestSlugs(t *testing.T) {
+	cleanDB(t)
+
+	// Notes without a slug don't trip the unique index.
+	assert.NoError(t, testRepo.Create(&domain.Note{Title: "Legacy 1", Content: "Some notes", MeetingDate: time.Now()}))
+	assert.NoError(t, testRepo.Create(&domain.Note{Title: "Legacy 2", Content: "Some notes", MeetingDate: time.Now()}))
+
+	for _, slug := range []string{"standup", "standup-2", "standup-review", "standups"} {
+		note := domain.Note{Title: "Standup", Content: "Some notes", Slug: slug, MeetingDate: time.Now()}
+		assert.NoError(t, testRepo.Create(&note))
+		if slug == "standup-2" {
+			assert.NoError(t, testRepo.Delete(note.ID))
+		}
+	}
+
+	assert.Error(t, testRepo.Create(&domain.Note{Title: "Standup", Content: "Some notes", Slug: "standup", MeetingDate: time.Now()}))
+
+	slugs, err := testRepo.SlugsWithPrefix("standup")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"standup", "standup-2", "standup-review"}, slugs)
+
+	note, err := testRepo.GetBySlug("standups")
+	assert.NoError(t, err)
+	assert.Equal(t, "standups", note.Slug)
+
+	_, err = testRepo.GetBySlug("standup-2")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound, "trashed notes are not found")
+}
+
+func TestGetByIDs(t *testing.T) {
+	cleanDB(t)
+
+	first := domain.Note{Title: "First", Content: "Some notes", MeetingDate: time.Now()}
+	second := domain.Note{Title: "Second", Content: "Some notes", MeetingDate: time.Now()}
+	other := domain.Note{Title: "Other", Content: "Some notes", MeetingDate: time.Now()}
+	for _, n := range []*domain.Note{&first, &second, &other} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+
+	notes, err := testRepo.GetByIDs([]uint{first.ID, second.ID, 9999})
+	assert.NoError(t, err)
+	assert.Len(t, notes, 2)
+}
+
+func TestExists(t *testing.T) {
+	cleanDB(t)
+
+	note := domain.Note{Title: "Mine", Content: "Some notes", MeetingDate: time.Now(), OwnerID: 1}
+	assert.NoError(t, testRepo.Create(&note))
+
+	ok, err := testRepo.Exists(note.ID, 1)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = testRepo.Exists(note.ID, 2)
+	assert.NoError(t, err)
+	assert.False(t, ok, "another owner's note")
+
+	ok, err = testRepo.Exists(9999, 1)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.NoError(t, testRepo.Delete(note.ID))
+	ok, err = testRepo.Exists(note.ID, 1)
+	assert.NoError(t, err)
+	assert.False(t, ok, "trashed note")
+}
+
 func TestGetAll(t *testing.T) {
 	cleanDB(t)
 
@@ -141,6 +347,8 @@ func TestUpdate(t *testing.T) {
 		Content:     "Updated notes",
 		Category:    "Updated category",
 		MeetingDate: time.Date(2025, time.June, 15, 10, 30, 0, 0, time.UTC),
+		Format:      domain.NoteFormatMarkdown,
+		Version:     note.Version,
 	}
 
 	err = testRepo.Update(&createdNote)
@@ -149,6 +357,62 @@ func TestUpdate(t *testing.T) {
 	updatedNote, err := testRepo.GetByID(note.ID)
 	assert.NoError(t, err)
 	assert.Equal(t, "Updated Test Meeting", updatedNote.Title)
+	assert.Equal(t, domain.NoteFormatMarkdown, updatedNote.Format)
+	assert.Equal(t, note.Version+1, updatedNote.Version)
+}
+
+func TestUpdatePreservesCreatedAt(t *testing.T) {
+	cleanDB(t)
+
+	note := domain.Note{OwnerID: 7, Title: "Test Meeting", Content: "Some notes", ExternalID: "ext-1", MeetingDate: time.Now()}
+	assert.NoError(t, testRepo.Create(&note))
+	created, err := testRepo.GetByID(note.ID)
+	assert.NoError(t, err)
+
+	// Built from scratch, as a handler decoding a request body would.
+	edited := domain.Note{
+		ID:          note.ID,
+		OwnerID:     note.OwnerID,
+		Title:       "Edited",
+		Content:     "Edited notes",
+		MeetingDate: note.MeetingDate,
+		Version:     note.Version,
+		CreatedAt:   time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+	assert.NoError(t, testRepo.Update(&edited))
+
+	updated, err := testRepo.GetByID(note.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, note.ID, updated.ID)
+	assert.Equal(t, "Edited", updated.Title)
+	assert.True(t, created.CreatedAt.Equal(updated.CreatedAt), "created_at changed from %s to %s", created.CreatedAt, updated.CreatedAt)
+	assert.Equal(t, "ext-1", updated.ExternalID)
+}
+
+func TestUpdateStaleVersion(t *testing.T) {
+	cleanDB(t)
+
+	note := domain.Note{
+		Title:       "Test Meeting",
+		Content:     "Some notes",
+		Category:    "Planning",
+		MeetingDate: time.Now(),
+	}
+	assert.NoError(t, testRepo.Create(&note))
+	assert.Equal(t, 1, note.Version)
+
+	first := note
+	first.Title = "First edit"
+	assert.NoError(t, testRepo.Update(&first))
+
+	second := note
+	second.Title = "Second edit"
+	err := testRepo.Update(&second)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	storedNote, err := testRepo.GetByID(note.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "First edit", storedNote.Title)
 }
 
 func TestDelete(t *testing.T) {
@@ -172,6 +436,77 @@ func TestDelete(t *testing.T) {
 	assert.Len(t, notes, 0)
 }
 
+func TestDeleteHard(t *testing.T) {
+	cleanDB(t)
+	repo := NewNoteRepositoryWithOptions(DB, NoteRepositoryOptions{HardDelete: true})
+	audit := NewAuditRepository(DB)
+
+	note := domain.Note{Title: "Test Meeting", Content: "Some notes", MeetingDate: time.Now()}
+	kept := domain.Note{Title: "Kept", Content: "Some notes", MeetingDate: time.Now()}
+	for _, n := range []*domain.Note{&note, &kept} {
+		assert.NoError(t, repo.Create(n))
+		assert.NoError(t, repo.AddAttachment(&domain.Attachment{NoteID: n.ID, Filename: "agenda.pdf", URL: "https://example.com/agenda.pdf"}))
+		assert.NoError(t, audit.Create(&domain.AuditLog{NoteID: n.ID, Operation: domain.AuditCreate}))
+	}
+	assert.NoError(t, repo.AddLink(&domain.NoteLink{FromNoteID: kept.ID, ToNoteID: note.ID, Relation: domain.LinkRelated}))
+
+	assert.NoError(t, repo.Delete(note.ID))
+
+	links, err := repo.ListLinks(kept.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, links)
+
+	for _, model := range []interface{}{&domain.Attachment{}, &domain.NoteRevision{}, &domain.AuditLog{}} {
+		var count int64
+		assert.NoError(t, DB.Unscoped().Model(model).Where("note_id = ?", note.ID).Count(&count).Error)
+		assert.Equal(t, int64(0), count)
+		assert.NoError(t, DB.Unscoped().Model(model).Where("note_id = ?", kept.ID).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	}
+
+	var count int64
+	assert.NoError(t, DB.Unscoped().Model(&domain.Note{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	restored, err := repo.RestoreNotes(0, []uint{note.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), restored)
+}
+
+func TestFuzzySearch(t *testing.T) {
+	cleanDB(t)
+
+	for _, n := range []*domain.Note{
+		{Title: "Daily standup", Content: "Blockers and progress", MeetingDate: time.Now()},
+		{Title: "Standup", Content: "Sprint review", MeetingDate: time.Now()},
+		{Title: "Budget review", Content: "Quarterly numbers", MeetingDate: time.Now()},
+	} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+
+	notes, total, err := testRepo.FuzzySearch(0, "stanup", 0.3, domain.DateRange{}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	titles := make([]string, 0, len(notes))
+	for _, n := range notes {
+		titles = append(titles, n.Title)
+	}
+	// The closer match comes first.
+	assert.Equal(t, []string{"Standup", "Daily standup"}, titles)
+
+	notes, total, err = testRepo.FuzzySearch(0, "stanup", 0.3, domain.DateRange{}, domain.Page{Limit: 1, Offset: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	if assert.Len(t, notes, 1) {
+		assert.Equal(t, "Daily standup", notes[0].Title)
+	}
+
+	notes, total, err = testRepo.FuzzySearch(0, "stanup", 0.9, domain.DateRange{}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Empty(t, notes)
+}
+
 func TestFilter(t *testing.T) {
 	cleanDB(t)
 
@@ -207,50 +542,57 @@ func TestFilter(t *testing.T) {
 		{
 			name: "Keyword only",
 			input: domain.NoteFilter{
-				Keyword:  "Keyword",
-				Category: "",
-				FromDate: nil,
-				ToDate:   nil,
+				Keyword:    "Keyword",
+				Categories: nil,
+				FromDate:   nil,
+				ToDate:     nil,
 			},
 			wantLen: 1,
 		},
 		{
 			name: "Category only",
 			input: domain.NoteFilter{
-				Keyword:  "",
-				Category: "Standup",
-				FromDate: nil,
-				ToDate:   nil,
+				Keyword:    "",
+				Categories: []string{"Standup"},
+				FromDate:   nil,
+				ToDate:     nil,
 			},
 			wantLen: 1,
 		},
+		{
+			name: "Multiple categories",
+			input: domain.NoteFilter{
+				Categories: []string{"Standup", "1:1"},
+			},
+			wantLen: 2,
+		},
 		{
 			name: "Date range only",
 			input: domain.NoteFilter{
-				Keyword:  "",
-				Category: "",
-				FromDate: &validFromDate,
-				ToDate:   &validToDate,
+				Keyword:    "",
+				Categories: nil,
+				FromDate:   &validFromDate,
+				ToDate:     &validToDate,
 			},
 			wantLen: 1,
 		},
 		{
 			name: "Combined filters (keyword + category + date)",
 			input: domain.NoteFilter{
-				Keyword:  "Test",
-				Category: "1:1",
-				FromDate: &validFromDate,
-				ToDate:   &validToDate,
+				Keyword:    "Test",
+				Categories: []string{"1:1"},
+				FromDate:   &validFromDate,
+				ToDate:     &validToDate,
 			},
 			wantLen: 1,
 		},
 		{
 			name: "No match",
 			input: domain.NoteFilter{
-				Keyword:  "None",
-				Category: "N/A",
-				FromDate: &validFromDate,
-				ToDate:   &validToDate,
+				Keyword:    "None",
+				Categories: []string{"N/A"},
+				FromDate:   &validFromDate,
+				ToDate:     &validToDate,
 			},
 			wantLen: 0,
 		},
@@ -258,9 +600,1165 @@ func TestFilter(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			searchResults, err := testRepo.Filter(tt.input)
+			searchResults, total, err := testRepo.Filter(tt.input, domain.Page{})
 			assert.NoError(t, err)
 			assert.Len(t, searchResults, tt.wantLen)
+			assert.Equal(t, int64(tt.wantLen), total)
+		})
+	}
+}
+
+func TestFilterCategoryCaseInsensitive(t *testing.T) {
+	cleanDB(t)
+
+	for _, category := range []string{"Standup", "STANDUP", "1:1"} {
+		assert.NoError(t, testRepo.Create(&domain.Note{
+			Title:       "Meeting",
+			Content:     "Some notes",
+			Category:    category,
+			MeetingDate: time.Now(),
+		}))
+	}
+
+	notes, total, err := testRepo.Filter(domain.NoteFilter{Categories: []string{" standup "}}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Len(t, notes, 2)
+	assert.Equal(t, int64(2), total)
+}
+
+func TestSearchPage(t *testing.T) {
+	cleanDB(t)
+
+	for day := 1; day <= 5; day++ {
+		assert.NoError(t, testRepo.Create(&domain.Note{
+			Title:       "Budget review",
+			Content:     "Some notes",
+			MeetingDate: time.Date(2025, time.June, day, 10, 0, 0, 0, time.UTC),
+		}))
+	}
+	assert.NoError(t, testRepo.Create(&domain.Note{Title: "Standup", Content: "Some notes", MeetingDate: time.Now()}))
+
+	notes, total, err := testRepo.Search(0, "budget", domain.DateRange{}, domain.Page{Limit: 2, Offset: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.Len(t, notes, 2)
+	assert.Equal(t, 4, notes[0].MeetingDate.Day())
+	assert.Equal(t, 3, notes[1].MeetingDate.Day())
+}
+
+func TestSearchDateRange(t *testing.T) {
+	cleanDB(t)
+
+	for _, month := range []time.Month{time.January, time.March, time.May} {
+		assert.NoError(t, testRepo.Create(&domain.Note{
+			Title:       "Budget " + month.String(),
+			Content:     "Some notes",
+			MeetingDate: time.Date(2025, month, 1, 10, 0, 0, 0, time.UTC),
+		}))
+	}
+
+	from := time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	notes, total, err := testRepo.Search(0, "budget", domain.DateRange{From: &from}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, "Budget May", notes[0].Title)
+
+	notes, total, err = testRepo.Search(0, "budget", domain.DateRange{From: &from, To: &to}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "Budget March", notes[0].Title)
+
+	notes, total, err = testRepo.FuzzySearch(0, "budgt", 0.1, domain.DateRange{To: &to}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, notes, 2)
+}
+
+func TestSearchEscapesWildcards(t *testing.T) {
+	cleanDB(t)
+
+	for _, n := range []*domain.Note{
+		{Title: "Discount", Content: "Agreed on 50% off", MeetingDate: time.Now()},
+		{Title: "Budget", Content: "Spent 500 so far", MeetingDate: time.Now()},
+		{Title: "Naming", Content: "Use snake_case for columns", MeetingDate: time.Now()},
+		{Title: "Naming again", Content: "Use snakeXcase nowhere", MeetingDate: time.Now()},
+		{Title: "Paths", Content: `Saved to C:\notes`, MeetingDate: time.Now()},
+	} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+
+	tests := []struct {
+		keyword   string
+		wantTitle string
+	}{
+		{keyword: "50%", wantTitle: "Discount"},
+		{keyword: "snake_case", wantTitle: "Naming"},
+		{keyword: `C:\`, wantTitle: "Paths"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.keyword, func(t *testing.T) {
+			notes, total, err := testRepo.Search(0, tt.keyword, domain.DateRange{}, domain.Page{})
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), total)
+			if assert.Len(t, notes, 1) {
+				assert.Equal(t, tt.wantTitle, notes[0].Title)
+			}
+
+			notes, total, err = testRepo.Filter(domain.NoteFilter{Keywords: []string{tt.keyword}}, domain.Page{})
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), total)
+			if assert.Len(t, notes, 1) {
+				assert.Equal(t, tt.wantTitle, notes[0].Title)
+			}
 		})
 	}
 }
+
+func TestRenameCategory(t *testing.T) {
+	cleanDB(t)
+
+	live := domain.Note{OwnerID: 1, Title: "Live", Content: "Some notes", Category: "OldTeam", MeetingDate: time.Now()}
+	deleted := domain.Note{OwnerID: 1, Title: "Deleted", Content: "Some notes", Category: "OldTeam", MeetingDate: time.Now()}
+	other := domain.Note{OwnerID: 1, Title: "Other", Content: "Some notes", Category: "Standup", MeetingDate: time.Now()}
+	theirs := domain.Note{OwnerID: 2, Title: "Theirs", Content: "Some notes", Category: "OldTeam", MeetingDate: time.Now()}
+	for _, n := range []*domain.Note{&live, &deleted, &other, &theirs} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+	assert.NoError(t, testRepo.Delete(deleted.ID))
+
+	renamed, err := testRepo.RenameCategory(1, "OldTeam", "NewTeam")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), renamed)
+
+	fetched, err := testRepo.GetByID(live.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "NewTeam", fetched.Category)
+	assert.Equal(t, live.Version+1, fetched.Version)
+
+	var trashed domain.Note
+	assert.NoError(t, testRepo.DB.Unscoped().First(&trashed, deleted.ID).Error)
+	assert.Equal(t, "OldTeam", trashed.Category)
+
+	untouched, err := testRepo.GetByID(theirs.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "OldTeam", untouched.Category, "another owner's note")
+	assert.Equal(t, theirs.Version, untouched.Version)
+}
+
+func TestCountNotes(t *testing.T) {
+	cleanDB(t)
+
+	kept := domain.Note{Title: "Kept", Content: "Some notes", MeetingDate: time.Now()}
+	deleted := domain.Note{Title: "Deleted", Content: "Some notes", MeetingDate: time.Now()}
+	for _, n := range []*domain.Note{&kept, &deleted} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+	assert.NoError(t, testRepo.Delete(deleted.ID))
+
+	count, err := testRepo.CountNotes()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestRestoreNotes(t *testing.T) {
+	cleanDB(t)
+
+	deleted1 := domain.Note{OwnerID: 1, Title: "Deleted 1", Content: "Some notes", MeetingDate: time.Now()}
+	deleted2 := domain.Note{OwnerID: 1, Title: "Deleted 2", Content: "Some notes", MeetingDate: time.Now()}
+	active := domain.Note{OwnerID: 1, Title: "Active", Content: "Some notes", MeetingDate: time.Now()}
+	theirs := domain.Note{OwnerID: 2, Title: "Theirs", Content: "Some notes", MeetingDate: time.Now()}
+
+	for _, n := range []*domain.Note{&deleted1, &deleted2, &active, &theirs} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+	assert.NoError(t, testRepo.AddAttachment(&domain.Attachment{NoteID: theirs.ID, Filename: "theirs.pdf", URL: "https://files.example.com/theirs.pdf"}))
+	assert.NoError(t, testRepo.Delete(deleted1.ID))
+	assert.NoError(t, testRepo.Delete(deleted2.ID))
+	assert.NoError(t, testRepo.Delete(theirs.ID))
+
+	restored, err := testRepo.RestoreNotes(1, []uint{deleted1.ID, deleted2.ID, active.ID, theirs.ID, 9999})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), restored)
+
+	notes, err := testRepo.GetAll()
+	assert.NoError(t, err)
+	assert.Len(t, notes, 3)
+
+	// Another owner's note, and its attachments, stay in the trash.
+	_, err = testRepo.GetByID(theirs.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	attachments, err := testRepo.ListAttachments(theirs.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, attachments)
+}
+
+func TestRestoreDeletedSince(t *testing.T) {
+	cleanDB(t)
+
+	old := domain.Note{OwnerID: 1, Title: "Deleted last week", Content: "Some notes", MeetingDate: time.Now()}
+	recent1 := domain.Note{OwnerID: 1, Title: "Deleted 1", Content: "Some notes", MeetingDate: time.Now()}
+	recent2 := domain.Note{OwnerID: 1, Title: "Deleted 2", Content: "Some notes", MeetingDate: time.Now()}
+	active := domain.Note{OwnerID: 1, Title: "Active", Content: "Some notes", MeetingDate: time.Now()}
+	theirs := domain.Note{OwnerID: 2, Title: "Theirs", Content: "Some notes", MeetingDate: time.Now()}
+	for _, n := range []*domain.Note{&old, &recent1, &recent2, &active, &theirs} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+	assert.NoError(t, testRepo.AddAttachment(&domain.Attachment{NoteID: recent1.ID, Filename: "slides.pdf", URL: "https://files.example.com/slides.pdf"}))
+	assert.NoError(t, testRepo.AddAttachment(&domain.Attachment{NoteID: theirs.ID, Filename: "theirs.pdf", URL: "https://files.example.com/theirs.pdf"}))
+
+	since := time.Now().Add(-time.Hour)
+	assert.NoError(t, testRepo.Delete(old.ID))
+	assert.NoError(t, DB.Unscoped().Model(&domain.Note{}).Where("id = ?", old.ID).Update("deleted_at", since.Add(-24*time.Hour)).Error)
+	assert.NoError(t, testRepo.Delete(recent1.ID))
+	assert.NoError(t, testRepo.Delete(recent2.ID))
+	assert.NoError(t, testRepo.Delete(theirs.ID))
+
+	restored, err := testRepo.RestoreDeletedSince(1, since)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), restored)
+
+	notes, err := testRepo.GetAll()
+	assert.NoError(t, err)
+	assert.Len(t, notes, 3)
+
+	_, err = testRepo.GetByID(old.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// Another owner's trash is left alone, attachments included.
+	_, err = testRepo.GetByID(theirs.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	var trashedAttachments int64
+	assert.NoError(t, DB.Unscoped().Model(&domain.Attachment{}).Where("note_id = ? AND deleted_at IS NOT NULL", theirs.ID).Count(&trashedAttachments).Error)
+	assert.Equal(t, int64(1), trashedAttachments)
+
+	attachments, err := testRepo.ListAttachments(recent1.ID)
+	assert.NoError(t, err)
+	assert.Len(t, attachments, 1)
+
+	restored, err = testRepo.RestoreDeletedSince(1, since)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), restored)
+}
+
+func TestExtremes(t *testing.T) {
+	cleanDB(t)
+
+	extremes, err := testRepo.Extremes(0)
+	assert.NoError(t, err)
+	assert.Nil(t, extremes.Longest)
+	assert.Nil(t, extremes.Shortest)
+
+	short := domain.Note{Title: "Short", Content: "ok", MeetingDate: time.Now()}
+	medium := domain.Note{Title: "Medium", Content: "A few more words", MeetingDate: time.Now()}
+	long := domain.Note{Title: "Long", Content: "A much longer note covering every agenda item in detail", MeetingDate: time.Now()}
+	for _, n := range []*domain.Note{&short, &medium, &long} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+	// Empty content bypasses usecase validation here to check it is skipped.
+	assert.NoError(t, DB.Create(&domain.Note{Title: "Empty", MeetingDate: time.Now()}).Error)
+
+	extremes, err = testRepo.Extremes(0)
+	assert.NoError(t, err)
+	assert.Equal(t, long.ID, extremes.Longest.ID)
+	assert.Equal(t, len(long.Content), extremes.Longest.Length)
+	assert.Equal(t, short.ID, extremes.Shortest.ID)
+	assert.Equal(t, len(short.Content), extremes.Shortest.Length)
+}
+
+func TestDistinctCategories(t *testing.T) {
+	cleanDB(t)
+
+	deleted := domain.Note{Title: "Deleted", Content: "Some notes", Category: "Retro", MeetingDate: time.Now()}
+	for _, n := range []*domain.Note{
+		{Title: "Standup 1", Content: "Some notes", Category: "Standup", MeetingDate: time.Now()},
+		{Title: "Standup 2", Content: "Some notes", Category: "Standup", MeetingDate: time.Now()},
+		{Title: "Catch up", Content: "Some notes", Category: "1:1", MeetingDate: time.Now()},
+		{Title: "Uncategorised", Content: "Some notes", MeetingDate: time.Now()},
+		&deleted,
+	} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+	assert.NoError(t, testRepo.Delete(deleted.ID))
+
+	categories, err := testRepo.DistinctCategories(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.CategoryCount{
+		{Category: "1:1", Count: 1},
+		{Category: "Standup", Count: 2},
+	}, categories)
+}
+
+func TestPurgeDeleted(t *testing.T) {
+	cleanDB(t)
+
+	oldDeleted := domain.Note{Title: "Old", Content: "Some notes", MeetingDate: time.Now()}
+	recentDeleted := domain.Note{Title: "Recent", Content: "Some notes", MeetingDate: time.Now()}
+	active := domain.Note{Title: "Active", Content: "Some notes", MeetingDate: time.Now()}
+	for _, n := range []*domain.Note{&oldDeleted, &recentDeleted, &active} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+	assert.NoError(t, testRepo.Delete(oldDeleted.ID))
+	assert.NoError(t, testRepo.Delete(recentDeleted.ID))
+	assert.NoError(t, DB.Unscoped().Model(&domain.Note{}).
+		Where("id = ?", oldDeleted.ID).
+		Update("deleted_at", time.Now().AddDate(0, -2, 0)).Error)
+
+	purged, err := testRepo.PurgeDeleted(time.Now().AddDate(0, -1, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	var remaining int64
+	assert.NoError(t, DB.Unscoped().Model(&domain.Note{}).Count(&remaining).Error)
+	assert.Equal(t, int64(2), remaining)
+
+	_, err = testRepo.GetByID(active.ID)
+	assert.NoError(t, err)
+}
+
+func TestStats(t *testing.T) {
+	cleanDB(t)
+
+	stats, err := testRepo.Stats(0, time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), stats.Total)
+	assert.Empty(t, stats.ByCategory)
+	assert.Empty(t, stats.ByMonth)
+	assert.Nil(t, stats.LatestMeeting)
+
+	latest := time.Date(2025, time.June, 20, 9, 0, 0, 0, time.UTC)
+	for _, n := range []*domain.Note{
+		{Title: "Standup 1", Content: "Some notes", Category: "Standup", MeetingDate: time.Date(2025, time.May, 10, 9, 0, 0, 0, time.UTC)},
+		{Title: "Standup 2", Content: "Some notes", Category: "Standup", MeetingDate: time.Date(2025, time.June, 1, 9, 0, 0, 0, time.UTC)},
+		{Title: "Catch up", Content: "Some notes", Category: "1:1", MeetingDate: latest},
+	} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+
+	stats, err = testRepo.Stats(0, time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), stats.Total)
+	assert.Equal(t, []domain.CategoryCount{
+		{Category: "1:1", Count: 1},
+		{Category: "Standup", Count: 2},
+	}, stats.ByCategory)
+	assert.Len(t, stats.ByMonth, 2)
+	assert.Equal(t, int64(1), stats.ByMonth[0].Count)
+	assert.Equal(t, int64(2), stats.ByMonth[1].Count)
+	assert.True(t, latest.Equal(*stats.LatestMeeting))
+
+	// Months are those of the requested timezone. 1 June 09:00 UTC is still
+	// 31 May in Honolulu.
+	honolulu, err := time.LoadLocation("Pacific/Honolulu")
+	assert.NoError(t, err)
+	stats, err = testRepo.Stats(0, honolulu)
+	assert.NoError(t, err)
+	assert.Len(t, stats.ByMonth, 2)
+	assert.Equal(t, time.Date(2025, time.May, 1, 0, 0, 0, 0, honolulu), stats.ByMonth[0].Month)
+	assert.Equal(t, int64(2), stats.ByMonth[0].Count)
+	assert.Equal(t, int64(1), stats.ByMonth[1].Count)
+}
+
+func TestGetAllByOwner(t *testing.T) {
+	cleanDB(t)
+
+	for _, n := range []*domain.Note{
+		{OwnerID: 1, Title: "Owner 1 a", Content: "Some notes", MeetingDate: time.Now()},
+		{OwnerID: 1, Title: "Owner 1 b", Content: "Some notes", MeetingDate: time.Now()},
+		{OwnerID: 2, Title: "Owner 2", Content: "Some notes", MeetingDate: time.Now()},
+	} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+
+	notes, err := testRepo.GetAllByOwner(1, false)
+	assert.NoError(t, err)
+	assert.Len(t, notes, 2)
+
+	notes, err = testRepo.GetAllByOwner(3, false)
+	assert.NoError(t, err)
+	assert.Len(t, notes, 0)
+}
+
+func TestGetAllVisible(t *testing.T) {
+	cleanDB(t)
+
+	for _, n := range []*domain.Note{
+		{OwnerID: 1, Title: "Private", Content: "Some notes", MeetingDate: time.Now()},
+		{OwnerID: 1, Title: "Shared", Content: "Some notes", MeetingDate: time.Now(), Visibility: domain.VisibilityShared},
+		{OwnerID: 1, Title: "Public", Content: "Some notes", MeetingDate: time.Now(), Visibility: domain.VisibilityPublic},
+		{OwnerID: 2, Title: "Own", Content: "Some notes", MeetingDate: time.Now()},
+	} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+
+	titles := func(notes []domain.Note) []string {
+		names := []string{}
+		for _, n := range notes {
+			names = append(names, n.Title)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	notes, err := testRepo.GetAllVisible(1, false, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Private", "Public", "Shared"}, titles(notes))
+
+	notes, err = testRepo.GetAllVisible(2, false, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Own", "Public", "Shared"}, titles(notes), "visibility defaults to private")
+
+	notes, err = testRepo.GetAllVisible(0, false, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Public"}, titles(notes), "anonymous callers only see public notes")
+
+	notes, _, err = testRepo.Filter(domain.NoteFilter{ViewerID: 2, Keyword: "notes"}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Own", "Public", "Shared"}, titles(notes))
+
+	notes, err = testRepo.GetAllVisible(1, false, 2)
+	assert.NoError(t, err)
+	assert.Len(t, notes, 2)
+}
+
+func TestReadsHideOthersPrivateNotes(t *testing.T) {
+	cleanDB(t)
+
+	now := time.Now()
+	mine := domain.Note{OwnerID: 1, Title: "Budget review", Content: "Short", Category: "Finance", MeetingDate: now.Add(time.Hour)}
+	theirs := domain.Note{OwnerID: 2, Title: "Budget secrets", Content: "Much longer private notes", Category: "Secret", MeetingDate: now.Add(2 * time.Hour)}
+	for _, n := range []*domain.Note{&mine, &theirs} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+
+	noteIDs := func(notes []domain.Note) []uint {
+		ids := []uint{}
+		for _, n := range notes {
+			ids = append(ids, n.ID)
+		}
+		return ids
+	}
+
+	notes, total, err := testRepo.Search(1, "budget", domain.DateRange{}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, []uint{mine.ID}, noteIDs(notes))
+
+	notes, total, err = testRepo.FuzzySearch(1, "budgt", 0.1, domain.DateRange{}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, []uint{mine.ID}, noteIDs(notes))
+
+	notes, err = testRepo.GetPaginated(1, 10, 0, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{mine.ID}, noteIDs(notes))
+
+	notes, err = testRepo.GetRecent(1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{mine.ID}, noteIDs(notes))
+
+	notes, err = testRepo.GetUpcoming(1, now, now.Add(24*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{mine.ID}, noteIDs(notes))
+
+	extremes, err := testRepo.Extremes(1)
+	assert.NoError(t, err)
+	assert.Equal(t, mine.ID, extremes.Longest.ID)
+
+	categories, err := testRepo.DistinctCategories(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.CategoryCount{{Category: "Finance", Count: 1}}, categories)
+
+	stats, err := testRepo.Stats(1, time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Total)
+	assert.Equal(t, []domain.CategoryCount{{Category: "Finance", Count: 1}}, stats.ByCategory)
+	assert.WithinDuration(t, mine.MeetingDate, *stats.LatestMeeting, time.Second)
+
+	// Reminders still see everyone's notes.
+	notes, err = testRepo.GetStartingBetween(now, now.Add(24*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{mine.ID, theirs.ID}, noteIDs(notes))
+
+	// Category quotas count an owner's own notes, private ones included.
+	categories, err = testRepo.CountByCategory(2)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.CategoryCount{{Category: "Secret", Count: 1}}, categories)
+}
+
+func TestGetPaginated(t *testing.T) {
+	cleanDB(t)
+
+	for _, title := range []string{"First", "Second", "Third"} {
+		assert.NoError(t, testRepo.Create(&domain.Note{OwnerID: 1, Title: title, Content: "Some notes", MeetingDate: time.Now()}))
+	}
+
+	notes, err := testRepo.GetPaginated(1, 2, 0, false)
+	assert.NoError(t, err)
+	assert.Len(t, notes, 2)
+
+	notes, err = testRepo.GetPaginated(1, 2, 2, false)
+	assert.NoError(t, err)
+	assert.Len(t, notes, 1)
+
+	// A limit of zero means no limit, not an empty page.
+	notes, err = testRepo.GetPaginated(1, 0, 0, false)
+	assert.NoError(t, err)
+	assert.Len(t, notes, 3)
+
+	notes, err = testRepo.GetPaginated(1, 0, 1, false)
+	assert.NoError(t, err)
+	assert.Len(t, notes, 2)
+
+	// Archived notes only show up when asked for, as in the other listings.
+	assert.NoError(t, testRepo.SetArchived(notes[0].ID, true))
+	notes, err = testRepo.GetPaginated(1, 0, 0, false)
+	assert.NoError(t, err)
+	assert.Len(t, notes, 2)
+
+	notes, err = testRepo.GetPaginated(1, 0, 0, true)
+	assert.NoError(t, err)
+	assert.Len(t, notes, 3)
+}
+
+func TestGetAfterID(t *testing.T) {
+	cleanDB(t)
+
+	var ids []uint
+	for _, n := range []*domain.Note{
+		{OwnerID: 1, Title: "First", Content: "Some notes", MeetingDate: time.Now()},
+		{OwnerID: 2, Title: "Other owner", Content: "Some notes", MeetingDate: time.Now()},
+		{OwnerID: 1, Title: "Second", Content: "Some notes", MeetingDate: time.Now()},
+		{OwnerID: 1, Title: "Archived", Content: "Some notes", MeetingDate: time.Now()},
+		{OwnerID: 1, Title: "Third", Content: "Some notes", MeetingDate: time.Now()},
+		{OwnerID: 2, Title: "Shared", Content: "Some notes", MeetingDate: time.Now(), Visibility: domain.VisibilityShared},
+	} {
+		assert.NoError(t, testRepo.Create(n))
+		ids = append(ids, n.ID)
+	}
+	assert.NoError(t, testRepo.SetArchived(ids[3], true))
+
+	notes, err := testRepo.GetAfterID(1, 0, 2, false)
+	assert.NoError(t, err)
+	if assert.Len(t, notes, 2) {
+		assert.Equal(t, ids[0], notes[0].ID)
+		assert.Equal(t, ids[2], notes[1].ID)
+	}
+
+	// Others' shared notes are paged through like GET /notes lists them.
+	notes, err = testRepo.GetAfterID(1, notes[1].ID, 2, false)
+	assert.NoError(t, err)
+	if assert.Len(t, notes, 2) {
+		assert.Equal(t, ids[4], notes[0].ID)
+		assert.Equal(t, ids[5], notes[1].ID)
+	}
+
+	notes, err = testRepo.GetAfterID(1, ids[2], 10, true)
+	assert.NoError(t, err)
+	assert.Len(t, notes, 3)
+
+	notes, err = testRepo.GetAfterID(1, ids[5], 10, false)
+	assert.NoError(t, err)
+	assert.Len(t, notes, 0)
+}
+
+func TestStreamByOwner(t *testing.T) {
+	cleanDB(t)
+
+	var ids []uint
+	for _, n := range []*domain.Note{
+		{OwnerID: 1, Title: "First", Content: "Some notes", Category: "Team", MeetingDate: time.Now()},
+		{OwnerID: 2, Title: "Other owner", Content: "Some notes", MeetingDate: time.Now()},
+		{OwnerID: 1, Title: "Archived", Content: "Some notes", MeetingDate: time.Now()},
+		{OwnerID: 1, Title: "Deleted", Content: "Some notes", MeetingDate: time.Now()},
+		{OwnerID: 1, Title: "Second", Content: "Some notes", MeetingDate: time.Now()},
+	} {
+		assert.NoError(t, testRepo.Create(n))
+		ids = append(ids, n.ID)
+	}
+	assert.NoError(t, testRepo.SetArchived(ids[2], true))
+	assert.NoError(t, testRepo.Delete(ids[3]))
+
+	var streamed []domain.Note
+	err := testRepo.StreamByOwner(1, false, func(n domain.Note) error {
+		streamed = append(streamed, n)
+		return nil
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, streamed, 2) {
+		assert.Equal(t, ids[0], streamed[0].ID)
+		assert.Equal(t, "Team", streamed[0].Category)
+		assert.Equal(t, ids[4], streamed[1].ID)
+	}
+
+	var count int
+	err = testRepo.StreamByOwner(1, true, func(n domain.Note) error {
+		count++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	stop := fmt.Errorf("stop")
+	count = 0
+	err = testRepo.StreamByOwner(1, true, func(n domain.Note) error {
+		count++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, count)
+}
+
+func TestGetModifiedSince(t *testing.T) {
+	cleanDB(t)
+
+	base := time.Date(2025, time.June, 16, 9, 0, 0, 0, time.UTC)
+	var ids []uint
+	for i, n := range []*domain.Note{
+		{OwnerID: 1, Title: "Oldest", Content: "Some notes", MeetingDate: base},
+		{OwnerID: 1, Title: "Newest", Content: "Some notes", MeetingDate: base},
+		{OwnerID: 2, Title: "Other owner", Content: "Some notes", MeetingDate: base},
+		{OwnerID: 1, Title: "Middle", Content: "Some notes", MeetingDate: base},
+	} {
+		assert.NoError(t, testRepo.Create(n))
+		ids = append(ids, n.ID)
+		updatedAt := base.Add(time.Duration([]int{1, 3, 2, 2}[i]) * time.Hour)
+		assert.NoError(t, DB.Model(n).UpdateColumn("updated_at", updatedAt).Error)
+	}
+
+	notes, err := testRepo.GetModifiedSince(1, domain.ChangeCursor{Since: base.Add(time.Hour)}, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, notes, 2) {
+		assert.Equal(t, ids[3], notes[0].ID)
+		assert.Equal(t, ids[1], notes[1].ID)
+	}
+
+	// Paging resumes after the last note seen, even when the next one was
+	// changed at the same time.
+	notes, err = testRepo.GetModifiedSince(1, domain.ChangeCursor{}, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, notes, 1) {
+		assert.Equal(t, ids[0], notes[0].ID)
+	}
+	notes, err = testRepo.GetModifiedSince(1, domain.ChangeCursor{Since: base.Add(2 * time.Hour), AfterID: ids[0]}, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, notes, 1) {
+		assert.Equal(t, ids[3], notes[0].ID)
+	}
+	notes, err = testRepo.GetModifiedSince(1, domain.ChangeCursor{Since: base.Add(2 * time.Hour), AfterID: ids[3]}, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, notes, 1) {
+		assert.Equal(t, ids[1], notes[0].ID)
+	}
+
+	// Deleting a note counts as changing it.
+	checkpoint := time.Now().Add(-time.Second)
+	assert.NoError(t, testRepo.Delete(ids[0]))
+
+	notes, err = testRepo.GetModifiedSince(1, domain.ChangeCursor{Since: checkpoint}, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, notes, 1) {
+		assert.Equal(t, ids[0], notes[0].ID)
+		assert.True(t, notes[0].DeletedAt.Valid)
+	}
+
+	// So does restoring it, whichever way it comes back.
+	for _, restore := range []func() error{
+		func() error { _, err := testRepo.RestoreNotes(1, []uint{ids[0]}); return err },
+		func() error { _, err := testRepo.RestoreDeletedSince(1, checkpoint); return err },
+	} {
+		// Trash the note with a stale updated_at, so only the restore can
+		// bring it back into the feed.
+		err = DB.Unscoped().Model(&domain.Note{}).Where("id = ?", ids[0]).
+			UpdateColumns(map[string]interface{}{"deleted_at": time.Now(), "updated_at": base}).Error
+		assert.NoError(t, err)
+		notes, err = testRepo.GetModifiedSince(1, domain.ChangeCursor{Since: checkpoint}, 0)
+		assert.NoError(t, err)
+		assert.Len(t, notes, 0)
+
+		assert.NoError(t, restore())
+
+		notes, err = testRepo.GetModifiedSince(1, domain.ChangeCursor{Since: checkpoint}, 0)
+		assert.NoError(t, err)
+		if assert.Len(t, notes, 1) {
+			assert.Equal(t, ids[0], notes[0].ID)
+			assert.False(t, notes[0].DeletedAt.Valid)
+		}
+	}
+}
+
+func TestMeetingEndTime(t *testing.T) {
+	cleanDB(t)
+
+	start := time.Date(2025, time.June, 16, 9, 0, 0, 0, time.UTC)
+	end := start.Add(45 * time.Minute)
+
+	note := domain.Note{OwnerID: 1, Title: "Planning", Content: "Some notes", MeetingDate: start, DurationMinutes: 45}
+	assert.NoError(t, testRepo.Create(&note))
+	assert.True(t, end.Equal(note.EndTime))
+
+	batch := []domain.Note{{OwnerID: 1, Title: "Retro", Content: "Some notes", MeetingDate: start, DurationMinutes: 45}}
+	assert.NoError(t, testRepo.CreateBatch(batch))
+	assert.True(t, end.Equal(batch[0].EndTime))
+
+	found, err := testRepo.GetByID(note.ID)
+	assert.NoError(t, err)
+	assert.True(t, end.Equal(found.EndTime))
+
+	err = testRepo.StreamByOwner(1, false, func(n domain.Note) error {
+		assert.True(t, end.Equal(n.EndTime))
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestBackfillMeetingDurations(t *testing.T) {
+	cleanDB(t)
+
+	legacy := domain.Note{Title: "Legacy", Content: "Some notes", MeetingDate: time.Now()}
+	deleted := domain.Note{Title: "Deleted", Content: "Some notes", MeetingDate: time.Now()}
+	timed := domain.Note{Title: "Timed", Content: "Some notes", MeetingDate: time.Now(), DurationMinutes: 15}
+	for _, n := range []*domain.Note{&legacy, &deleted, &timed} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+	assert.NoError(t, testRepo.Delete(deleted.ID))
+
+	backfilled, err := BackfillMeetingDurations(DB, 30)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), backfilled)
+
+	found, err := testRepo.GetByID(legacy.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 30, found.DurationMinutes)
+	assert.Equal(t, legacy.Version, found.Version)
+
+	found, err = testRepo.GetByID(timed.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 15, found.DurationMinutes)
+
+	backfilled, err = BackfillMeetingDurations(DB, 30)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), backfilled)
+}
+
+func TestSetArchived(t *testing.T) {
+	cleanDB(t)
+
+	archived := &domain.Note{OwnerID: 1, Title: "Old", Content: "Some notes", MeetingDate: time.Now()}
+	active := &domain.Note{OwnerID: 1, Title: "Current", Content: "Some notes", MeetingDate: time.Now()}
+	assert.NoError(t, testRepo.Create(archived))
+	assert.NoError(t, testRepo.Create(active))
+
+	assert.NoError(t, testRepo.SetArchived(archived.ID, true))
+
+	notes, err := testRepo.GetAllByOwner(1, false)
+	assert.NoError(t, err)
+	assert.Len(t, notes, 1)
+	assert.Equal(t, active.ID, notes[0].ID)
+
+	notes, err = testRepo.GetAllByOwner(1, true)
+	assert.NoError(t, err)
+	assert.Len(t, notes, 2)
+
+	_, total, err := testRepo.Filter(domain.NoteFilter{ViewerID: 1}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	_, total, err = testRepo.Filter(domain.NoteFilter{IncludeArchived: true, ViewerID: 1}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	// Deleting an archived note hides it everywhere; restoring it brings it
+	// back still archived.
+	assert.NoError(t, testRepo.Delete(archived.ID))
+	notes, err = testRepo.GetAllByOwner(1, true)
+	assert.NoError(t, err)
+	assert.Len(t, notes, 1)
+
+	restored, err := testRepo.RestoreNotes(1, []uint{archived.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), restored)
+
+	note, err := testRepo.GetByID(archived.ID)
+	assert.NoError(t, err)
+	assert.True(t, note.Archived)
+	assert.Equal(t, 1, note.Version)
+}
+
+func TestArchiveOlderThan(t *testing.T) {
+	cleanDB(t)
+
+	old := &domain.Note{Title: "Old", Content: "Some notes", MeetingDate: time.Now().AddDate(-1, 0, 0)}
+	recent := &domain.Note{Title: "Recent", Content: "Some notes", MeetingDate: time.Now().AddDate(0, 0, -7)}
+	alreadyArchived := &domain.Note{Title: "Archived", Content: "Some notes", MeetingDate: time.Now().AddDate(-2, 0, 0), Archived: true}
+	trashed := &domain.Note{Title: "Trashed", Content: "Some notes", MeetingDate: time.Now().AddDate(-1, 0, 0)}
+	for _, n := range []*domain.Note{old, recent, alreadyArchived, trashed} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+	assert.NoError(t, testRepo.Delete(trashed.ID))
+
+	archived, err := testRepo.ArchiveOlderThan(time.Now().AddDate(0, -6, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), archived)
+
+	note, err := testRepo.GetByID(old.ID)
+	assert.NoError(t, err)
+	assert.True(t, note.Archived)
+
+	note, err = testRepo.GetByID(recent.ID)
+	assert.NoError(t, err)
+	assert.False(t, note.Archived)
+
+	restored, err := testRepo.RestoreNotes(0, []uint{trashed.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), restored)
+	note, err = testRepo.GetByID(trashed.ID)
+	assert.NoError(t, err)
+	assert.False(t, note.Archived)
+
+	archived, err = testRepo.ArchiveOlderThan(time.Now().AddDate(0, -6, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), archived, "only the restored note is left to archive")
+}
+
+func TestRevisions(t *testing.T) {
+	cleanDB(t)
+
+	note := &domain.Note{OwnerID: 1, Title: "Planning", Content: "one", Category: "Team", MeetingDate: time.Now()}
+	assert.NoError(t, testRepo.Create(note))
+
+	note.Content = "two"
+	assert.NoError(t, testRepo.Update(note))
+
+	renamed, err := testRepo.RenameCategory(1, "Team", "Squad")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), renamed)
+
+	revisions, err := testRepo.ListRevisions(note.ID)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 3)
+	assert.Equal(t, []int{1, 2, 3}, []int{revisions[0].Version, revisions[1].Version, revisions[2].Version})
+	assert.Equal(t, "one", revisions[0].Content)
+	assert.Equal(t, "two", revisions[1].Content)
+	assert.Equal(t, "Squad", revisions[2].Category)
+
+	stored, err := testRepo.GetByID(note.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, stored.Version, revisions[2].Version)
+
+	// A rejected update records nothing.
+	note.Version = 1
+	assert.ErrorIs(t, testRepo.Update(note), ErrVersionConflict)
+	revisions, err = testRepo.ListRevisions(note.ID)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 3)
+
+	// Purging the note takes its history with it.
+	assert.NoError(t, testRepo.Delete(note.ID))
+	_, err = testRepo.PurgeDeleted(time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	revisions, err = testRepo.ListRevisions(note.ID)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 0)
+}
+
+func TestGetUpcoming(t *testing.T) {
+	cleanDB(t)
+
+	now := time.Now()
+	for _, n := range []*domain.Note{
+		{Title: "Past", Content: "Some notes", MeetingDate: now.Add(-time.Hour)},
+		{Title: "Later", Content: "Some notes", MeetingDate: now.Add(5 * time.Hour)},
+		{Title: "Soon", Content: "Some notes", MeetingDate: now.Add(time.Hour)},
+		{Title: "Next week", Content: "Some notes", MeetingDate: now.AddDate(0, 0, 7)},
+	} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+
+	notes, err := testRepo.GetUpcoming(0, now, now.Add(24*time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, notes, 2)
+	assert.Equal(t, "Soon", notes[0].Title)
+	assert.Equal(t, "Later", notes[1].Title)
+}
+
+func TestCountFiltered(t *testing.T) {
+	cleanDB(t)
+
+	june := time.Date(2025, time.June, 10, 9, 0, 0, 0, time.UTC)
+	for _, n := range []*domain.Note{
+		{Title: "Planning", Content: "Roadmap", Category: "Team", MeetingDate: june},
+		{Title: "Planning follow-up", Content: "tbd", Category: "team", MeetingDate: june.AddDate(0, 1, 0)},
+		{Title: "Retro", Content: "What went well", MeetingDate: june},
+		{Title: "Archived planning", Content: "Old", Category: "Team", MeetingDate: june, Archived: true},
+	} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+
+	to := june.AddDate(0, 0, 1)
+	filters := []domain.NoteFilter{
+		{},
+		{Keyword: "planning"},
+		{Categories: []string{"TEAM"}},
+		{Categories: []string{"Team"}, IncludeArchived: true},
+		{ToDate: &to},
+		{UncategorizedOnly: true},
+		{Keyword: "nothing"},
+		{Keywords: []string{"planning", "retro"}},
+		{Keywords: []string{"planning", "agenda"}, KeywordMatch: domain.KeywordMatchAll},
+	}
+	for _, filter := range filters {
+		_, total, err := testRepo.Filter(filter, domain.Page{Limit: 1})
+		assert.NoError(t, err)
+
+		count, err := testRepo.CountFiltered(filter)
+		assert.NoError(t, err)
+		assert.Equal(t, total, count, "%+v", filter)
+	}
+
+	count, err := testRepo.CountFiltered(domain.NoteFilter{Categories: []string{"team"}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestFilterKeywords(t *testing.T) {
+	cleanDB(t)
+
+	for _, n := range []*domain.Note{
+		{Title: "Budget review", Content: "Q3 numbers", MeetingDate: time.Now()},
+		{Title: "Budget draft", Content: "Next year", MeetingDate: time.Now()},
+		{Title: "Q3 retro", Content: "What went well", MeetingDate: time.Now()},
+		{Title: "Standup", Content: "Nothing new", MeetingDate: time.Now()},
+	} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+
+	tests := []struct {
+		name   string
+		filter domain.NoteFilter
+		want   []string
+	}{
+		{name: "Any", filter: domain.NoteFilter{Keywords: []string{"budget", "q3"}}, want: []string{"Budget draft", "Budget review", "Q3 retro"}},
+		{name: "All", filter: domain.NoteFilter{Keywords: []string{"budget", "q3"}, KeywordMatch: domain.KeywordMatchAll}, want: []string{"Budget review"}},
+		{name: "Keyword joins Keywords", filter: domain.NoteFilter{Keyword: "budget", Keywords: []string{"next"}, KeywordMatch: domain.KeywordMatchAll}, want: []string{"Budget draft"}},
+		{name: "Single keyword", filter: domain.NoteFilter{Keyword: "standup"}, want: []string{"Standup"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notes, total, err := testRepo.Filter(tt.filter, domain.Page{})
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(tt.want)), total)
+
+			var titles []string
+			for _, n := range notes {
+				titles = append(titles, n.Title)
+			}
+			sort.Strings(titles)
+			assert.Equal(t, tt.want, titles)
+		})
+	}
+}
+
+func TestFilterCleanup(t *testing.T) {
+	cleanDB(t)
+
+	for _, n := range []*domain.Note{
+		{Title: "Planning", Content: "A full agenda and actions", Category: "Team", MeetingDate: time.Now()},
+		{Title: "Planning follow-up", Content: "tbd", MeetingDate: time.Now()},
+		{Title: "Retro", Content: "What went well and what didn't", MeetingDate: time.Now()},
+	} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+
+	notes, total, err := testRepo.Filter(domain.NoteFilter{UncategorizedOnly: true}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, notes, 2)
+
+	notes, _, err = testRepo.Filter(domain.NoteFilter{UncategorizedOnly: true, Keyword: "planning"}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Len(t, notes, 1)
+	assert.Equal(t, "Planning follow-up", notes[0].Title)
+
+	notes, _, err = testRepo.Filter(domain.NoteFilter{MaxContentLength: 5}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Len(t, notes, 1)
+	assert.Equal(t, "tbd", notes[0].Content)
+}
+
+func TestMeetingLocation(t *testing.T) {
+	cleanDB(t)
+
+	for _, n := range []*domain.Note{
+		{Title: "Standup", Content: "Notes", Location: "Room 4", MeetingDate: time.Now()},
+		{Title: "Sync", Content: "Notes", MeetingURL: "https://meet.example.com/sync", MeetingDate: time.Now()},
+		{Title: "All hands", Content: "Notes", Location: "Atrium", MeetingURL: "https://meet.example.com/all", MeetingDate: time.Now()},
+	} {
+		assert.NoError(t, testRepo.Create(n))
+	}
+
+	titles := func(mode string) []string {
+		notes, _, err := testRepo.Filter(domain.NoteFilter{MeetingMode: mode}, domain.Page{})
+		assert.NoError(t, err)
+		names := []string{}
+		for _, n := range notes {
+			names = append(names, n.Title)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	assert.Equal(t, []string{"All hands", "Sync"}, titles(domain.MeetingModeRemote))
+	assert.Equal(t, []string{"Standup"}, titles(domain.MeetingModeInPerson))
+	assert.Len(t, titles(""), 3)
+
+	stored, err := testRepo.GetByID(1)
+	if assert.NoError(t, err) {
+		stored.Location = "Room 5"
+		stored.MeetingURL = "https://meet.example.com/standup"
+		assert.NoError(t, testRepo.Update(&stored))
+	}
+	updated, err := testRepo.GetByID(stored.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Room 5", updated.Location)
+	assert.Equal(t, "https://meet.example.com/standup", updated.MeetingURL)
+}
+
+func TestAttachments(t *testing.T) {
+	cleanDB(t)
+
+	note := domain.Note{Title: "Planning", Content: "Some notes", MeetingDate: time.Now()}
+	other := domain.Note{Title: "Other", Content: "Some notes", MeetingDate: time.Now()}
+	assert.NoError(t, testRepo.Create(&note))
+	assert.NoError(t, testRepo.Create(&other))
+
+	for _, a := range []*domain.Attachment{
+		{NoteID: note.ID, Filename: "slides.pdf", URL: "https://files.example.com/slides.pdf", SizeBytes: 2048},
+		{NoteID: other.ID, Filename: "other.pdf", URL: "https://files.example.com/other.pdf"},
+		{NoteID: note.ID, Filename: "minutes.docx", URL: "https://files.example.com/minutes.docx"},
+	} {
+		assert.NoError(t, testRepo.AddAttachment(a))
+		assert.NotZero(t, a.ID)
+	}
+
+	filenames := func(noteID uint) []string {
+		attachments, err := testRepo.ListAttachments(noteID)
+		assert.NoError(t, err)
+		names := []string{}
+		for _, a := range attachments {
+			names = append(names, a.Filename)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"slides.pdf", "minutes.docx"}, filenames(note.ID))
+
+	// Deleting a note trashes its attachments, and restoring it brings them
+	// back.
+	assert.NoError(t, testRepo.Delete(note.ID))
+	assert.Empty(t, filenames(note.ID))
+	assert.Equal(t, []string{"other.pdf"}, filenames(other.ID))
+
+	_, err := testRepo.RestoreNotes(0, []uint{note.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"slides.pdf", "minutes.docx"}, filenames(note.ID))
+
+	// Purging the note removes its attachments for good.
+	assert.NoError(t, testRepo.Delete(note.ID))
+	_, err = testRepo.PurgeDeleted(time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+
+	var remaining int64
+	assert.NoError(t, DB.Unscoped().Model(&domain.Attachment{}).Count(&remaining).Error)
+	assert.Equal(t, int64(1), remaining)
+}
+
+func TestLinks(t *testing.T) {
+	cleanDB(t)
+
+	var notes [3]domain.Note
+	for i := range notes {
+		notes[i] = domain.Note{Title: fmt.Sprintf("Meeting %d", i), Content: "Some notes", MeetingDate: time.Now()}
+		assert.NoError(t, testRepo.Create(&notes[i]))
+	}
+	first, second, third := notes[0].ID, notes[1].ID, notes[2].ID
+
+	followUp := domain.NoteLink{FromNoteID: first, ToNoteID: second, Relation: domain.LinkFollowUp}
+	assert.NoError(t, testRepo.AddLink(&followUp))
+	assert.NotZero(t, followUp.ID)
+	assert.NoError(t, testRepo.AddLink(&domain.NoteLink{FromNoteID: third, ToNoteID: first, Relation: domain.LinkRelated}))
+	// The reverse of an existing link is a different link.
+	assert.NoError(t, testRepo.AddLink(&domain.NoteLink{FromNoteID: second, ToNoteID: first, Relation: domain.LinkRelated}))
+
+	assert.ErrorIs(t, testRepo.AddLink(&domain.NoteLink{FromNoteID: first, ToNoteID: second, Relation: domain.LinkRelated}), ErrDuplicateLink)
+	assert.ErrorIs(t, testRepo.AddLink(&domain.NoteLink{FromNoteID: first, ToNoteID: first, Relation: domain.LinkRelated}), ErrSelfLink)
+
+	links, err := testRepo.ListLinks(first)
+	assert.NoError(t, err)
+	assert.Len(t, links, 3)
+	assert.Equal(t, followUp.ID, links[0].ID)
+	assert.Equal(t, domain.LinkFollowUp, links[0].Relation)
+
+	// Deleting a note removes its links both ways.
+	assert.NoError(t, testRepo.Delete(first))
+	var remaining int64
+	assert.NoError(t, DB.Model(&domain.NoteLink{}).Count(&remaining).Error)
+	assert.Equal(t, int64(0), remaining)
+}
+
+func TestFilterSort(t *testing.T) {
+	cleanDB(t)
+
+	base := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+	for i, title := range []string{"Budget", "Roadmap", "Standup"} {
+		n := &domain.Note{Title: title, Content: "Notes", Category: "Team", MeetingDate: base.AddDate(0, 0, (i*2)%3)}
+		assert.NoError(t, testRepo.Create(n))
+	}
+
+	titles := func(filter domain.NoteFilter, page domain.Page) []string {
+		notes, _, err := testRepo.Filter(filter, page)
+		assert.NoError(t, err)
+		names := []string{}
+		for _, n := range notes {
+			names = append(names, n.Title)
+		}
+		return names
+	}
+
+	// Meeting days are Budget 0, Roadmap 2 and Standup 1.
+	assert.Equal(t, []string{"Roadmap", "Standup", "Budget"}, titles(domain.NoteFilter{}, domain.Page{}))
+	assert.Equal(t, []string{"Budget", "Standup", "Roadmap"}, titles(domain.NoteFilter{Sort: domain.SortMeetingDate, Order: domain.SortAsc}, domain.Page{}))
+	assert.Equal(t, []string{"Budget", "Roadmap", "Standup"}, titles(domain.NoteFilter{Sort: domain.SortTitle, Order: domain.SortAsc}, domain.Page{}))
+	assert.Equal(t, []string{"Standup", "Roadmap", "Budget"}, titles(domain.NoteFilter{Sort: domain.SortCreatedAt, Order: domain.SortDesc}, domain.Page{}))
+	assert.Equal(t, []string{"Roadmap"}, titles(domain.NoteFilter{Sort: domain.SortTitle, Order: domain.SortAsc}, domain.Page{Limit: 1, Offset: 1}))
+	assert.Equal(t, []string{"Standup", "Roadmap"}, titles(domain.NoteFilter{Categories: []string{"team"}, FromDate: &base, Keyword: "notes", Sort: domain.SortTitle, Order: domain.SortDesc}, domain.Page{Limit: 2}))
+
+	// An unknown field never reaches the query.
+	assert.Equal(t, []string{"Roadmap", "Standup", "Budget"}, titles(domain.NoteFilter{Sort: "content; DROP TABLE notes"}, domain.Page{}))
+}
+
+func TestFilterPriority(t *testing.T) {
+	cleanDB(t)
+
+	meetingDate := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+	for title, priority := range map[string]string{"Outage": domain.PriorityCritical, "Budget": domain.PriorityHigh, "Standup": "", "Social": domain.PriorityLow} {
+		assert.NoError(t, testRepo.Create(&domain.Note{Title: title, Content: "Notes", MeetingDate: meetingDate, Priority: priority}))
+	}
+
+	titles := func(filter domain.NoteFilter) []string {
+		notes, _, err := testRepo.Filter(filter, domain.Page{})
+		assert.NoError(t, err)
+		names := []string{}
+		for _, n := range notes {
+			names = append(names, n.Title)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"Standup"}, titles(domain.NoteFilter{Priority: domain.PriorityNormal}), "priority defaults to normal")
+	assert.Equal(t, []string{"Budget"}, titles(domain.NoteFilter{Priority: domain.PriorityHigh}))
+	assert.Equal(t, []string{"Outage", "Budget", "Standup", "Social"}, titles(domain.NoteFilter{Sort: domain.SortPriority, Order: domain.SortDesc}))
+	assert.Equal(t, []string{"Social", "Standup", "Budget", "Outage"}, titles(domain.NoteFilter{Sort: domain.SortPriority, Order: domain.SortAsc}))
+}
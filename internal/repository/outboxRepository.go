@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"gorm.io/gorm"
+)
+
+type OutboxRepository interface {
+	Enqueue(event domain.NoteEvent) error
+	PollDue(now time.Time, limit int) ([]domain.OutboxEvent, error)
+	MarkSent(id uint, sentAt time.Time) error
+	MarkFailed(id uint, nextAttemptAt time.Time, lastErr string) error
+}
+
+type outboxRepository struct {
+	DB *gorm.DB
+}
+
+func NewOutboxRepository(DB *gorm.DB) *outboxRepository {
+	return &outboxRepository{DB: DB}
+}
+
+// Enqueue stores event on its own. Note changes are enqueued by the note
+// repository inside their own transaction instead.
+func (r *outboxRepository) Enqueue(event domain.NoteEvent) error {
+	return enqueueEvent(r.DB, event)
+}
+
+// PollDue returns up to limit unsent events whose next attempt is due, oldest
+// first.
+func (r *outboxRepository) PollDue(now time.Time, limit int) ([]domain.OutboxEvent, error) {
+	var events []domain.OutboxEvent
+	err := r.DB.Where("sent_at IS NULL AND next_attempt_at <= ?", now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+func (r *outboxRepository) MarkSent(id uint, sentAt time.Time) error {
+	return r.DB.Model(&domain.OutboxEvent{}).Where("id = ?", id).Update("sent_at", sentAt).Error
+}
+
+// MarkFailed records a failed delivery and when to try again.
+func (r *outboxRepository) MarkFailed(id uint, nextAttemptAt time.Time, lastErr string) error {
+	return r.DB.Model(&domain.OutboxEvent{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"next_attempt_at": nextAttemptAt,
+		"last_error":      lastErr,
+	}).Error
+}
+
+// enqueueEvent writes event to the outbox using tx, due immediately.
+func enqueueEvent(tx *gorm.DB, event domain.NoteEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&domain.OutboxEvent{
+		Type:          event.Type,
+		NoteID:        event.Note.ID,
+		Payload:       string(payload),
+		NextAttemptAt: event.OccurredAt,
+	}).Error
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoteChangesWriteOutboxEvents(t *testing.T) {
+	cleanDB(t)
+	repo := NewNoteRepositoryWithOutbox(DB)
+
+	note := &domain.Note{OwnerID: 1, Title: "Planning", Content: "Agenda", Category: "Team", MeetingDate: time.Now()}
+	assert.NoError(t, repo.Create(note))
+
+	note.Title = "Planning v2"
+	assert.NoError(t, repo.Update(note))
+
+	renamed, err := repo.RenameCategory(1, "Team", "Squad")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), renamed)
+
+	imported := &domain.Note{OwnerID: 1, ExternalID: "ext-1", Title: "Imported", Content: "Agenda", MeetingDate: time.Now()}
+	assert.NoError(t, repo.Upsert(imported))
+	reimported := &domain.Note{OwnerID: 1, ExternalID: "ext-1", Title: "Imported again", Content: "Agenda", MeetingDate: time.Now()}
+	assert.NoError(t, repo.Upsert(reimported))
+
+	assert.NoError(t, repo.Delete(note.ID))
+
+	events, err := NewOutboxRepository(DB).PollDue(time.Now(), 10)
+	assert.NoError(t, err)
+
+	gotTypes := make([]domain.NoteEventType, 0, len(events))
+	for _, event := range events {
+		gotTypes = append(gotTypes, event.Type)
+	}
+	assert.Equal(t, []domain.NoteEventType{
+		domain.NoteCreated,
+		domain.NoteUpdated,
+		domain.NoteUpdated,
+		domain.NoteCreated,
+		domain.NoteUpdated,
+		domain.NoteDeleted,
+	}, gotTypes)
+
+	var updated domain.NoteEvent
+	assert.NoError(t, json.Unmarshal([]byte(events[1].Payload), &updated))
+	assert.Equal(t, "Planning v2", updated.Note.Title)
+	assert.Equal(t, 2, updated.Note.Version)
+	assert.Equal(t, note.ID, events[1].NoteID)
+
+	var recategorized domain.NoteEvent
+	assert.NoError(t, json.Unmarshal([]byte(events[2].Payload), &recategorized))
+	assert.Equal(t, "Squad", recategorized.Note.Category)
+	assert.Equal(t, 3, recategorized.Note.Version)
+}
+
+func TestNoteChangesWithoutOutbox(t *testing.T) {
+	cleanDB(t)
+
+	note := &domain.Note{OwnerID: 1, Title: "Planning", Content: "Agenda", MeetingDate: time.Now()}
+	assert.NoError(t, testRepo.Create(note))
+	assert.NoError(t, testRepo.Delete(note.ID))
+
+	events, err := NewOutboxRepository(DB).PollDue(time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, events, 0)
+}
+
+func TestOutboxPollAndMark(t *testing.T) {
+	cleanDB(t)
+	repo := NewOutboxRepository(DB)
+	now := time.Now()
+
+	for _, id := range []uint{1, 2, 3} {
+		assert.NoError(t, repo.Enqueue(domain.NoteEvent{Type: domain.NoteReminder, Note: domain.Note{ID: id}, OccurredAt: now}))
+	}
+
+	events, err := repo.PollDue(now, 2)
+	assert.NoError(t, err)
+	if !assert.Len(t, events, 2) {
+		return
+	}
+	assert.Equal(t, uint(1), events[0].NoteID)
+
+	assert.NoError(t, repo.MarkSent(events[0].ID, now))
+	assert.NoError(t, repo.MarkFailed(events[1].ID, now.Add(time.Minute), "webhook down"))
+
+	events, err = repo.PollDue(now, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, uint(3), events[0].NoteID)
+	}
+
+	events, err = repo.PollDue(now.Add(time.Minute), 10)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, uint(2), events[0].NoteID)
+		assert.Equal(t, 1, events[0].Attempts)
+		assert.Equal(t, "webhook down", events[0].LastError)
+	}
+}
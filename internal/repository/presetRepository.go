@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"gorm.io/gorm"
+)
+
+// PresetRepository stores filter presets. Every lookup is by owner, so one
+// user never sees or changes another's presets.
+type PresetRepository interface {
+	Create(p *domain.FilterPreset) error
+	GetAll(ownerID uint) ([]domain.FilterPreset, error)
+	GetByName(ownerID uint, name string) (domain.FilterPreset, error)
+	Update(p *domain.FilterPreset) error
+	Delete(ownerID uint, name string) (int64, error)
+}
+
+type presetRepository struct {
+	DB *gorm.DB
+}
+
+func NewPresetRepository(DB *gorm.DB) *presetRepository {
+	return &presetRepository{DB: DB}
+}
+
+func (r *presetRepository) Create(p *domain.FilterPreset) error {
+	return r.DB.Create(p).Error
+}
+
+func (r *presetRepository) GetAll(ownerID uint) ([]domain.FilterPreset, error) {
+	var presets []domain.FilterPreset
+	err := r.DB.Where("owner_id = ?", ownerID).Order("name").Find(&presets).Error
+	return presets, err
+}
+
+func (r *presetRepository) GetByName(ownerID uint, name string) (domain.FilterPreset, error) {
+	var preset domain.FilterPreset
+	err := r.DB.Where("owner_id = ? AND name = ?", ownerID, name).First(&preset).Error
+	return preset, err
+}
+
+func (r *presetRepository) Update(p *domain.FilterPreset) error {
+	return r.DB.Save(p).Error
+}
+
+func (r *presetRepository) Delete(ownerID uint, name string) (int64, error) {
+	result := r.DB.Where("owner_id = ? AND name = ?", ownerID, name).Delete(&domain.FilterPreset{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func cleanPresets(t *testing.T) {
+	truncate(t, "filter_presets")
+}
+
+func TestPresetCRUD(t *testing.T) {
+	cleanPresets(t)
+	presetRepo := NewPresetRepository(DB)
+
+	fromDate := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	preset := domain.FilterPreset{
+		OwnerID: 1,
+		Name:    "standups",
+		Filter: domain.NoteFilter{
+			Categories: []string{"Standup"},
+			FromDate:   &fromDate,
+		},
+	}
+
+	err := presetRepo.Create(&preset)
+	assert.NoError(t, err)
+	assert.NotZero(t, preset.ID)
+
+	err = presetRepo.Create(&domain.FilterPreset{OwnerID: 1, Name: "standups"})
+	assert.Error(t, err)
+
+	// Another owner can reuse the name without seeing the first preset.
+	other := domain.FilterPreset{OwnerID: 2, Name: "standups"}
+	assert.NoError(t, presetRepo.Create(&other))
+
+	fetched, err := presetRepo.GetByName(1, "standups")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Standup"}, fetched.Filter.Categories)
+	assert.True(t, fromDate.Equal(*fetched.Filter.FromDate))
+
+	fetched.Filter.Keyword = "blocker"
+	assert.NoError(t, presetRepo.Update(&fetched))
+
+	presets, err := presetRepo.GetAll(1)
+	assert.NoError(t, err)
+	assert.Len(t, presets, 1)
+	assert.Equal(t, "blocker", presets[0].Filter.Keyword)
+
+	deleted, err := presetRepo.Delete(1, "standups")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	fetched, err = presetRepo.GetByName(2, "standups")
+	assert.NoError(t, err)
+	assert.Equal(t, other.ID, fetched.ID)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// ReplicaResolver names the dbresolver configuration for the read replica.
+// Queries sent to it run on the primary when no resolver by that name is
+// registered, as with SQLite or when no replica is configured.
+const ReplicaResolver = "replica"
+
+// replica returns r.DB for a query that may be served by the read replica.
+// Everything else, including every read inside a transaction, stays on the
+// primary.
+func (r *noteRepository) replica() *gorm.DB {
+	return r.DB.Clauses(dbresolver.Use(ReplicaResolver))
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// TestReplicaReads uses two SQLite files standing in for a primary and a
+// replica that has not caught up, so it can tell which one served a query.
+func TestReplicaReads(t *testing.T) {
+	dir := t.TempDir()
+	primary, err := gorm.Open(sqlite.Open(filepath.Join(dir, "primary.db")), &gorm.Config{})
+	assert.NoError(t, err)
+	replica, err := gorm.Open(sqlite.Open(filepath.Join(dir, "replica.db")), &gorm.Config{})
+	assert.NoError(t, err)
+	for _, db := range []*gorm.DB{primary, replica} {
+		assert.NoError(t, db.AutoMigrate(&domain.Note{}, &domain.NoteRevision{}, &domain.Attachment{}))
+	}
+
+	stale := domain.Note{ID: 1, Title: "Stale", Content: "Replica copy", MeetingDate: time.Now(), Version: 1}
+	assert.NoError(t, replica.Create(&stale).Error)
+
+	assert.NoError(t, primary.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{sqlite.Open(filepath.Join(dir, "replica.db"))},
+	}, ReplicaResolver)))
+	repo := NewNoteRepository(primary)
+
+	note := domain.Note{Title: "Fresh", Content: "Primary copy", MeetingDate: time.Now()}
+	assert.NoError(t, repo.Create(&note))
+
+	// The reads that may lag go to the replica.
+	got, err := repo.GetByID(note.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Stale", got.Title)
+
+	all, err := repo.GetAll()
+	assert.NoError(t, err)
+	if assert.Len(t, all, 1) {
+		assert.Equal(t, "Stale", all[0].Title)
+	}
+
+	found, total, err := repo.Search(0, "copy", domain.DateRange{}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	if assert.Len(t, found, 1) {
+		assert.Equal(t, "Stale", found[0].Title)
+	}
+
+	filtered, _, err := repo.Filter(domain.NoteFilter{Keyword: "copy"}, domain.Page{})
+	assert.NoError(t, err)
+	if assert.Len(t, filtered, 1) {
+		assert.Equal(t, "Stale", filtered[0].Title)
+	}
+
+	// Everything else stays on the primary, including the version check in
+	// Update.
+	count, err := repo.CountNotes()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	note.Title = "Fresh v2"
+	assert.NoError(t, repo.Update(&note))
+	var stored domain.Note
+	assert.NoError(t, primary.Clauses(dbresolver.Write).First(&stored, note.ID).Error)
+	assert.Equal(t, "Fresh v2", stored.Title)
+}
+
+func TestReplicaReadsWithoutReplica(t *testing.T) {
+	cleanDB(t)
+
+	note := domain.Note{Title: "Planning", Content: "Agenda", MeetingDate: time.Now()}
+	assert.NoError(t, testRepo.Create(&note))
+
+	// Without a registered replica the same reads are served by the primary.
+	got, err := testRepo.GetByID(note.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Planning", got.Title)
+}
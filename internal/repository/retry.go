@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DefaultWriteRetries is how many times a write that failed with a transient
+// error is retried when no other count is configured.
+const DefaultWriteRetries = 2
+
+// retryBaseDelay is the wait before the first retry. It doubles each time.
+var retryBaseDelay = 50 * time.Millisecond
+
+// isTransient reports whether err is worth retrying: Postgres aborted the
+// transaction for a serialization failure or deadlock, or the connection
+// failed before the statement reached the server. Anything else, constraint
+// violations included, would fail the same way again. A connection lost
+// during COMMIT is not retried since the write may have landed.
+func isTransient(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "40001", // serialization_failure
+			"40P01", // deadlock_detected
+			"57P01": // admin_shutdown
+			return true
+		}
+		// Class 08 is connection_exception.
+		return strings.HasPrefix(pgErr.Code, "08")
+	}
+	return errors.Is(err, driver.ErrBadConn) || pgconn.SafeToRetry(err)
+}
+
+// withRetry runs fn, running it again up to retries more times while it
+// fails with a transient error, with exponential backoff between attempts.
+// fn must be safe to rerun, which a write in a rolled back transaction is.
+func withRetry(retries int, fn func() error) error {
+	delay := retryBaseDelay
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= retries || !isTransient(err) {
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// write runs fn with the repository's retry count. A write rejected by the
+// slug or title and meeting date index fails with ErrDuplicateSlug or
+// ErrDuplicateNote.
+func (r *noteRepository) write(fn func() error) error {
+	return translateUniqueViolation(withRetry(r.writeRetries, fn))
+}
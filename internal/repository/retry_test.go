@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, true},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, true},
+		{"connection failure", &pgconn.PgError{Code: "08006"}, true},
+		{"wrapped", fmt.Errorf("saving note: %w", &pgconn.PgError{Code: "40001"}), true},
+		{"bad connection", driver.ErrBadConn, true},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"check violation", &pgconn.PgError{Code: "23514"}, false},
+		{"version conflict", ErrVersionConflict, false},
+		{"other", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isTransient(tt.err))
+		})
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	retryBaseDelay = 0
+	transient := &pgconn.PgError{Code: "40001"}
+
+	t.Run("succeeds after transient errors", func(t *testing.T) {
+		attempts := 0
+		err := withRetry(2, func() error {
+			attempts++
+			if attempts < 3 {
+				return transient
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("gives up after the retry count", func(t *testing.T) {
+		attempts := 0
+		err := withRetry(2, func() error {
+			attempts++
+			return transient
+		})
+		assert.ErrorIs(t, err, transient)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		attempts := 0
+		violation := &pgconn.PgError{Code: "23505"}
+		err := withRetry(2, func() error {
+			attempts++
+			return violation
+		})
+		assert.ErrorIs(t, err, violation)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("zero retries runs once", func(t *testing.T) {
+		attempts := 0
+		_ = withRetry(0, func() error {
+			attempts++
+			return transient
+		})
+		assert.Equal(t, 1, attempts)
+	})
+}
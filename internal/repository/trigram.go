@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// EnsureTrigramIndexes enables pg_trgm and adds GIN trigram indexes on note
+// titles and content so FuzzySearch can use them. It does nothing on SQLite.
+// Creating the extension needs a role allowed to do so; without it
+// FuzzySearch fails and callers fall back to exact matches only.
+func EnsureTrigramIndexes(db *gorm.DB) error {
+	if dialectOf(db).sqlite {
+		return nil
+	}
+
+	for _, stmt := range []string{
+		"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+		"CREATE INDEX IF NOT EXISTS idx_notes_title_trgm ON notes USING gin (title gin_trgm_ops)",
+		"CREATE INDEX IF NOT EXISTS idx_notes_content_trgm ON notes USING gin (content gin_trgm_ops)",
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TrigramSimilarity mirrors pg_trgm's similarity(): both strings are split
+// into lower-cased words, each word is padded with two spaces in front and
+// one behind, and the result is the share of distinct trigrams the two
+// strings have in common. It backs FuzzySearch on SQLite, which has no
+// pg_trgm.
+func TrigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	common := 0
+	for t := range ta {
+		if _, ok := tb[t]; ok {
+			common++
+		}
+	}
+	return float64(common) / float64(len(ta)+len(tb)-common)
+}
+
+func trigrams(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+	return set
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrigramSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{name: "Identical", a: "standup", b: "standup", want: 1},
+		{name: "Case is ignored", a: "Standup", b: "STANDUP", want: 1},
+		// Matches SELECT similarity('Daily standup', 'stanup') in Postgres.
+		{name: "Typo", a: "Daily standup", b: "stanup", want: 0.3125},
+		{name: "Nothing in common", a: "budget", b: "xyz", want: 0},
+		{name: "Empty", a: "", b: "standup", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, TrigramSimilarity(tt.a, tt.b), 0.0001)
+		})
+	}
+}
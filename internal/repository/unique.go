@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+const (
+	slugIndex = "idx_notes_slug"
+	// titleDateIndex is the unique index EnsureTitleDateIndex creates.
+	titleDateIndex = "idx_notes_owner_title_meeting_date"
+	// legacyTitleDateIndex is the index EnsureTitleDateIndex used to create,
+	// across every owner's notes.
+	legacyTitleDateIndex = "idx_notes_title_meeting_date"
+	linkPairIndex        = "idx_note_links_pair"
+)
+
+// sqliteUniqueColumns maps the columns SQLite names when a unique index is
+// violated back to the index, since unlike Postgres it doesn't name it.
+var sqliteUniqueColumns = map[string]string{
+	"notes.slug": slugIndex,
+	"notes.owner_id, notes.title, notes.meeting_date": titleDateIndex,
+	"note_links.from_note_id, note_links.to_note_id":  linkPairIndex,
+}
+
+// EnsureTitleDateIndex adds a unique index on the owner, title and meeting
+// date of live notes, so the database rejects a second copy of a note even
+// when two requests insert it at the same moment. Trashed notes and other
+// owners' notes don't count. It replaces the older index that spanned every
+// owner, and fails if the stored notes already have duplicates.
+func EnsureTitleDateIndex(db *gorm.DB) error {
+	if err := db.Exec("DROP INDEX IF EXISTS " + legacyTitleDateIndex).Error; err != nil {
+		return err
+	}
+	return db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS " + titleDateIndex +
+		" ON notes (owner_id, title, meeting_date) WHERE deleted_at IS NULL").Error
+}
+
+// violatedIndex returns the name of the unique index err reports a violation
+// of: unique_violation (23505) for Postgres, or SQLite's equivalent. It
+// returns "" for any other error.
+func violatedIndex(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if pgErr.Code != "23505" {
+			return ""
+		}
+		return pgErr.ConstraintName
+	}
+
+	_, columns, ok := strings.Cut(err.Error(), "UNIQUE constraint failed: ")
+	if !ok {
+		return ""
+	}
+	return sqliteUniqueColumns[columns]
+}
+
+// translateUniqueViolation turns the unique violations callers can act on
+// into ErrDuplicateNote, ErrDuplicateSlug and ErrDuplicateLink, and returns
+// any other error unchanged.
+func translateUniqueViolation(err error) error {
+	switch violatedIndex(err) {
+	case titleDateIndex:
+		return ErrDuplicateNote
+	case slugIndex:
+		return ErrDuplicateSlug
+	case linkPairIndex:
+		return ErrDuplicateLink
+	}
+	return err
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestViolatedIndex(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"postgres title and date", &pgconn.PgError{Code: "23505", ConstraintName: titleDateIndex}, titleDateIndex},
+		{"postgres slug", &pgconn.PgError{Code: "23505", ConstraintName: slugIndex}, slugIndex},
+		{"wrapped", fmt.Errorf("saving note: %w", &pgconn.PgError{Code: "23505", ConstraintName: slugIndex}), slugIndex},
+		{"postgres other violation", &pgconn.PgError{Code: "23503", ConstraintName: "fk_notes_owner"}, ""},
+		{"sqlite title and date", errors.New("UNIQUE constraint failed: notes.owner_id, notes.title, notes.meeting_date"), titleDateIndex},
+		{"sqlite slug", errors.New("UNIQUE constraint failed: notes.slug"), slugIndex},
+		{"sqlite other index", errors.New("UNIQUE constraint failed: filter_presets.name"), ""},
+		{"other", errors.New("boom"), ""},
+		{"nil", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, violatedIndex(tt.err))
+		})
+	}
+}
+
+func TestDuplicateSlug(t *testing.T) {
+	cleanDB(t)
+
+	first := domain.Note{Title: "Sync", Content: "Some notes", Slug: "sync", MeetingDate: time.Now()}
+	second := domain.Note{Title: "Sync", Content: "Other notes", Slug: "sync", MeetingDate: time.Now()}
+	assert.NoError(t, testRepo.Create(&first))
+
+	err := testRepo.Create(&second)
+	assert.ErrorIs(t, err, ErrDuplicateSlug)
+	assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+}
+
+func TestTitleDateIndex(t *testing.T) {
+	cleanDB(t)
+	// An index from before duplicates were counted per owner is replaced.
+	assert.NoError(t, DB.Exec("CREATE UNIQUE INDEX "+legacyTitleDateIndex+" ON notes (title, meeting_date) WHERE deleted_at IS NULL").Error)
+	assert.NoError(t, EnsureTitleDateIndex(DB))
+	assert.NoError(t, EnsureTitleDateIndex(DB), "creating the index again is a no-op")
+	t.Cleanup(func() {
+		assert.NoError(t, DB.Exec("DROP INDEX IF EXISTS "+titleDateIndex).Error)
+	})
+
+	meeting := time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC)
+	note := func(slug string, date time.Time) domain.Note {
+		return domain.Note{Title: "Weekly sync", Content: "Some notes", Slug: slug, MeetingDate: date}
+	}
+
+	first := note("weekly-sync", meeting)
+	assert.NoError(t, testRepo.Create(&first))
+
+	duplicate := note("weekly-sync-2", meeting)
+	assert.ErrorIs(t, testRepo.Create(&duplicate), ErrDuplicateNote)
+
+	theirs := note("weekly-sync-theirs", meeting)
+	theirs.OwnerID = 2
+	assert.NoError(t, testRepo.Create(&theirs), "another owner's note with the same title and date isn't a duplicate")
+
+	nextWeek := note("weekly-sync-3", meeting.AddDate(0, 0, 7))
+	assert.NoError(t, testRepo.Create(&nextWeek))
+
+	nextWeek.MeetingDate = meeting
+	assert.ErrorIs(t, testRepo.Update(&nextWeek), ErrDuplicateNote)
+
+	// A trashed note doesn't block a new copy.
+	assert.NoError(t, testRepo.Delete(first.ID))
+	recreated := note("weekly-sync-4", meeting)
+	assert.NoError(t, testRepo.Create(&recreated))
+}
+
+func TestTitleDateIndexConcurrentCreate(t *testing.T) {
+	cleanDB(t)
+	assert.NoError(t, EnsureTitleDateIndex(DB))
+	t.Cleanup(func() {
+		assert.NoError(t, DB.Exec("DROP INDEX IF EXISTS "+titleDateIndex).Error)
+	})
+
+	meeting := time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC)
+	errs := make([]error, 5)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			n := domain.Note{Title: "Weekly sync", Content: "Some notes", Slug: fmt.Sprintf("weekly-sync-%d", i), MeetingDate: meeting}
+			errs[i] = testRepo.Create(&n)
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		if err == nil {
+			created++
+			continue
+		}
+		assert.ErrorIs(t, err, ErrDuplicateNote)
+	}
+	assert.Equal(t, 1, created)
+
+	var stored int64
+	assert.NoError(t, DB.Model(&domain.Note{}).Count(&stored).Error)
+	assert.Equal(t, int64(1), stored)
+}
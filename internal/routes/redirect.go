@@ -0,0 +1,99 @@
+package routes
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fixedPathRedirect redirects a request that matched no route to the route
+// it matches once letter case is ignored and the path is cleaned, such as
+// /Notes//Stats to /notes/stats. Static segments take the route's spelling
+// and parameters keep the request's, so /NOTES/Abc redirects to /notes/Abc.
+// When trailingSlash is set a trailing slash is dropped as well.
+//
+// gin has the same feature, RedirectFixedPath, but its lookup panics on a
+// tree with a static route beside a parameter, like /notes/stats and
+// /notes/:id, so the route table is matched here instead.
+func fixedPathRedirect(r *gin.Engine, trailingSlash bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reqPath := c.Request.URL.Path
+		// No route ends in a slash, so without trailingSlash a path that
+		// does can't be fixed.
+		if !trailingSlash && reqPath != "/" && strings.HasSuffix(reqPath, "/") {
+			return
+		}
+
+		fixed, ok := matchRoute(r.Routes(), c.Request.Method, path.Clean("/"+reqPath))
+		if !ok || fixed == reqPath {
+			return
+		}
+
+		code := http.StatusMovedPermanently
+		if c.Request.Method != http.MethodGet {
+			code = http.StatusTemporaryRedirect
+		}
+		target := *c.Request.URL
+		target.Path = fixed
+		target.RawPath = ""
+		c.Redirect(code, target.String())
+		c.Abort()
+	}
+}
+
+// matchRoute returns the path reqPath should be redirected to for the best
+// matching route of method: the one with the most static segments, the way
+// gin prefers /notes/stats to /notes/:id.
+func matchRoute(routes gin.RoutesInfo, method, reqPath string) (string, bool) {
+	segments := strings.Split(strings.Trim(reqPath, "/"), "/")
+
+	best, bestStatic := "", -1
+	for _, route := range routes {
+		if route.Method != method {
+			continue
+		}
+		fixed, static, ok := matchPattern(route.Path, segments)
+		if ok && static > bestStatic {
+			best, bestStatic = fixed, static
+		}
+	}
+	return best, bestStatic >= 0
+}
+
+// matchPattern matches segments against a gin route pattern, returning the
+// fixed path and how many of its segments were static.
+func matchPattern(pattern string, segments []string) (string, int, bool) {
+	parts := strings.Split(strings.Trim(pattern, "/"), "/")
+	fixed := make([]string, 0, len(parts))
+	static := 0
+
+	for i, part := range parts {
+		if strings.HasPrefix(part, "*") {
+			if i < len(segments) {
+				fixed = append(fixed, segments[i:]...)
+			}
+			return "/" + strings.Join(fixed, "/"), static, true
+		}
+		if i >= len(segments) {
+			return "", 0, false
+		}
+		switch {
+		case strings.HasPrefix(part, ":"):
+			if segments[i] == "" {
+				return "", 0, false
+			}
+			fixed = append(fixed, segments[i])
+		case strings.EqualFold(part, segments[i]):
+			fixed = append(fixed, part)
+			static++
+		default:
+			return "", 0, false
+		}
+	}
+	if len(segments) != len(parts) {
+		return "", 0, false
+	}
+	return "/" + strings.Join(fixed, "/"), static, true
+}
@@ -5,13 +5,95 @@ import (
 	"github.com/jt00721/meeting-notes-manager/internal/handler"
 )
 
-func SetupRoutes(r *gin.Engine, noteHandler *handler.NoteHandler) {
-	r.POST("/notes", noteHandler.CreateNoteApi)
+// Options controls what happens to a request whose path doesn't exactly
+// match a route.
+type Options struct {
+	// RedirectTrailingSlash redirects /notes/ to /notes, and the reverse
+	// when only the form with the slash is registered.
+	RedirectTrailingSlash bool
+	// RedirectFixedPath redirects a path that only differs from a route in
+	// letter case or in extra slashes and dot segments, such as /Notes or
+	// /notes//5, to that route. It is matched by fixedPathRedirect rather
+	// than gin's own option of the same name.
+	RedirectFixedPath bool
+}
+
+// DefaultOptions redirects both kinds of near miss.
+func DefaultOptions() Options {
+	return Options{RedirectTrailingSlash: true, RedirectFixedPath: true}
+}
+
+// Configure applies opts to r and answers requests that match no route, even
+// after any redirect, with a JSON 404. GET requests are redirected with 301
+// and other methods with 307, so the method and body are kept.
+func Configure(r *gin.Engine, opts Options) {
+	r.RedirectTrailingSlash = opts.RedirectTrailingSlash
+	r.RedirectFixedPath = false
+	if opts.RedirectFixedPath {
+		r.NoRoute(fixedPathRedirect(r, opts.RedirectTrailingSlash), handler.NotFoundApi)
+		return
+	}
+	r.NoRoute(handler.NotFoundApi)
+}
+
+func SetupRoutes(r *gin.Engine, noteHandler *handler.NoteHandler, healthHandler *handler.HealthHandler, presetHandler *handler.PresetHandler, templateHandler *handler.TemplateHandler, metricsHandler *handler.MetricsHandler, docsHandler *handler.DocsHandler, bodyLimit, adminOnly gin.HandlerFunc) {
+	r.GET("/health", healthHandler.HealthCheckApi)
+	r.GET("/metrics", metricsHandler.MetricsApi)
+	r.GET("/swagger/*any", docsHandler.SwaggerApi)
+
+	// Routes that accept a full note body are capped by bodyLimit.
+	r.POST("/notes", bodyLimit, noteHandler.CreateNoteApi)
+	r.POST("/notes/batch", bodyLimit, noteHandler.CreateNotesBatchApi)
+	r.POST("/notes/validate", bodyLimit, noteHandler.ValidateNoteApi)
 	r.GET("/notes", noteHandler.GetAllNotesApi)
+	r.DELETE("/notes", noteHandler.DeleteNotesApi)
+	r.GET("/notes/grouped", noteHandler.GetNotesGroupedApi)
 	r.GET("/notes/paginated", noteHandler.GetPaginatedNotesApi)
+	r.GET("/notes/recent", noteHandler.GetRecentNotesApi)
+	r.GET("/notes/upcoming", noteHandler.GetUpcomingMeetingsApi)
+	r.GET("/notes/today", noteHandler.GetTodaysMeetingsApi)
+	r.GET("/notes/extremes", noteHandler.GetNoteExtremesApi)
+	r.GET("/notes/categories", noteHandler.GetCategoriesApi)
+	r.GET("/notes/stats", noteHandler.GetNoteStatsApi)
+	r.GET("/notes/batch", noteHandler.GetNotesByIDsApi)
+	r.GET("/notes/export.ndjson", noteHandler.ExportNotesNDJSONApi)
+	r.GET("/notes/changes", noteHandler.GetNoteChangesApi)
+	// Imports are streamed row by row, so they are not held to bodyLimit.
+	r.POST("/notes/import", noteHandler.ImportNotesApi)
+	r.GET("/notes/slug/:slug", noteHandler.GetNoteBySlugApi)
 	r.GET("/notes/:id", noteHandler.GetNoteByIDApi)
-	r.PUT("/notes/:id", noteHandler.UpdateNoteApi)
+	r.PUT("/notes/:id", bodyLimit, noteHandler.UpdateNoteApi)
 	r.DELETE("/notes/:id", noteHandler.DeleteNoteApi)
+	r.POST("/notes/:id/archive", noteHandler.ArchiveNoteApi)
+	r.POST("/notes/:id/duplicate", noteHandler.DuplicateNoteApi)
+	r.GET("/notes/:id/revisions", noteHandler.ListRevisionsApi)
+	r.GET("/notes/:id/audit", noteHandler.ListAuditLogApi)
+	r.POST("/notes/:id/attachments", bodyLimit, noteHandler.AddAttachmentApi)
+	r.GET("/notes/:id/attachments", noteHandler.ListAttachmentsApi)
+	r.POST("/notes/:id/links", bodyLimit, noteHandler.AddNoteLinkApi)
+	r.GET("/notes/:id/links", noteHandler.ListNoteLinksApi)
+	r.GET("/notes/:id/related", noteHandler.GetRelatedNotesApi)
+	r.GET("/notes/:id/wordstats", noteHandler.GetNoteWordStatsApi)
+	r.GET("/notes/:id/diff", noteHandler.DiffRevisionsApi)
+	r.GET("/notes/:id/export", noteHandler.ExportNoteApi)
+	r.GET("/notes/:id/render", noteHandler.RenderNoteApi)
+	r.PUT("/notes/external/:externalID", bodyLimit, noteHandler.UpsertNoteApi)
 	r.GET("/notes/search", noteHandler.SearchNotesByKeywordApi)
 	r.GET("/notes/filter", noteHandler.FilterNotesApi)
+	r.GET("/notes/filter/count", noteHandler.CountFilteredNotesApi)
+	r.POST("/notes/trash/restore", noteHandler.RestoreNotesApi)
+	r.POST("/notes/restore", noteHandler.RestoreDeletedNotesApi)
+	// Purging and auto-archiving act on every user's notes, so only admins
+	// may do them.
+	r.POST("/notes/purge", adminOnly, noteHandler.PurgeDeletedNotesApi)
+	r.POST("/notes/auto-archive", adminOnly, noteHandler.AutoArchiveNotesApi)
+	r.POST("/notes/category/rename", noteHandler.RenameCategoryApi)
+
+	r.POST("/presets", presetHandler.CreatePresetApi)
+	r.GET("/presets", presetHandler.GetAllPresetsApi)
+	r.GET("/presets/:name", presetHandler.GetPresetApi)
+	r.PUT("/presets/:name", presetHandler.UpdatePresetApi)
+	r.DELETE("/presets/:name", presetHandler.DeletePresetApi)
+
+	r.GET("/templates/:category", templateHandler.GetTemplateApi)
 }
@@ -0,0 +1,125 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jt00721/meeting-notes-manager/docs"
+	"github.com/jt00721/meeting-notes-manager/internal/handler"
+	"github.com/jt00721/meeting-notes-manager/internal/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+// TestSwaggerSpecCoversRoutes fails when a note or preset route is added
+// without annotations, or the generated docs are stale. Run go generate
+// ./cmd/... to refresh them.
+func TestSwaggerSpecCoversRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	docsHandler, err := handler.NewDocsHandler(docs.SwaggerInfo.ReadDoc())
+	require.NoError(t, err)
+
+	r := gin.New()
+	SetupRoutes(r,
+		handler.NewNoteHandler(nil),
+		handler.NewHealthHandler(nil),
+		handler.NewPresetHandler(nil),
+		handler.NewTemplateHandler(nil),
+		handler.NewMetricsHandler(prometheus.NewRegistry()),
+		docsHandler,
+		middleware.MaxBodySize(middleware.DefaultMaxBodyBytes),
+		middleware.Admin(nil),
+	)
+
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var spec struct {
+		OpenAPI    string                                `json:"openapi"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &spec))
+
+	assert.True(t, strings.HasPrefix(spec.OpenAPI, "3."))
+	assert.Contains(t, spec.Components.Schemas, "domain.Note")
+	assert.Contains(t, spec.Components.Schemas, "domain.NoteFilter")
+
+	for _, route := range r.Routes() {
+		if !strings.HasPrefix(route.Path, "/notes") && !strings.HasPrefix(route.Path, "/presets") {
+			continue
+		}
+		path := pathParam.ReplaceAllString(route.Path, "{$1}")
+		assert.Contains(t, spec.Paths[path], strings.ToLower(route.Method), "missing %s %s", route.Method, path)
+	}
+
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestNearMissRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(opts Options) *gin.Engine {
+		r := gin.New()
+		Configure(r, opts)
+		SetupRoutes(r,
+			handler.NewNoteHandler(nil),
+			handler.NewHealthHandler(nil),
+			handler.NewPresetHandler(nil),
+			handler.NewTemplateHandler(nil),
+			handler.NewMetricsHandler(prometheus.NewRegistry()),
+			&handler.DocsHandler{},
+			middleware.MaxBodySize(middleware.DefaultMaxBodyBytes),
+			middleware.Admin(nil),
+		)
+		return r
+	}
+
+	tests := []struct {
+		name         string
+		opts         Options
+		method       string
+		path         string
+		wantCode     int
+		wantLocation string
+	}{
+		{name: "Trailing slash", opts: DefaultOptions(), method: http.MethodGet, path: "/notes/search/", wantCode: http.StatusMovedPermanently, wantLocation: "/notes/search"},
+		{name: "Trailing slash keeps the method", opts: DefaultOptions(), method: http.MethodPost, path: "/notes/validate/", wantCode: http.StatusTemporaryRedirect, wantLocation: "/notes/validate"},
+		{name: "Different case", opts: DefaultOptions(), method: http.MethodGet, path: "/Notes/Search", wantCode: http.StatusMovedPermanently, wantLocation: "/notes/search"},
+		{name: "Different case keeps parameters", opts: DefaultOptions(), method: http.MethodGet, path: "/NOTES/Slug/Weekly-Sync?x=1", wantCode: http.StatusMovedPermanently, wantLocation: "/notes/slug/Weekly-Sync?x=1"},
+		{name: "Extra slashes and case", opts: DefaultOptions(), method: http.MethodDelete, path: "//Notes//7/", wantCode: http.StatusTemporaryRedirect, wantLocation: "/notes/7"},
+		{name: "Trailing slash redirect off", opts: Options{}, method: http.MethodGet, path: "/notes/search/", wantCode: http.StatusNotFound},
+		{name: "Case redirect off", opts: Options{RedirectTrailingSlash: true}, method: http.MethodGet, path: "/Notes/Search", wantCode: http.StatusNotFound},
+		{name: "Unknown route", opts: DefaultOptions(), method: http.MethodGet, path: "/nothing/here", wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			newRouter(tt.opts).ServeHTTP(resp, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.wantCode, resp.Code)
+			if tt.wantLocation != "" {
+				assert.Equal(t, tt.wantLocation, resp.Header().Get("Location"))
+			}
+			if tt.wantCode == http.StatusNotFound {
+				assert.Equal(t, "application/json; charset=utf-8", resp.Header().Get("Content-Type"))
+				assert.JSONEq(t, `{"data":null,"meta":{},"error":{"message":"route not found"}}`, resp.Body.String())
+			}
+		})
+	}
+}
@@ -1,13 +1,19 @@
 package seed
 
 import (
+	"encoding/json"
+	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"github.com/jt00721/meeting-notes-manager/internal/domain"
 	"gorm.io/gorm"
 )
 
+// Seed inserts a small built-in set of notes. Like SeedFromFile it does
+// nothing when the notes table already has rows, so it is safe to run on
+// every start.
 func Seed(db *gorm.DB) error {
 	notes := []domain.Note{
 		{Title: "Performance Review", Content: "Went over my performance over the year with my boss", Category: "1:1", MeetingDate: time.Date(2025, time.April, 1, 13, 30, 0, 0, time.UTC)},
@@ -15,13 +21,81 @@ func Seed(db *gorm.DB) error {
 		{Title: "All-Hands Meeting", Content: "Quarterly meeting covering recent company news or updates", Category: "Company-wide", MeetingDate: time.Date(2025, time.June, 15, 10, 30, 0, 0, time.UTC)},
 	}
 
-	for _, note := range notes {
-		if err := db.Create(&note).Error; err != nil {
-			log.Println("Failed to seed note:", note.Title, "Error:", err)
-			return err
+	return seedNotes(db, notes)
+}
+
+// SeedFromFile inserts the notes listed in the JSON file at path, an array
+// of objects with title, content, category and meeting_date (RFC 3339)
+// fields. It does nothing when the notes table already has rows.
+func SeedFromFile(db *gorm.DB, path string) error {
+	notes, err := loadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return seedNotes(db, notes)
+}
+
+type seedNote struct {
+	Title       string    `json:"title"`
+	Content     string    `json:"content"`
+	Category    string    `json:"category"`
+	MeetingDate time.Time `json:"meeting_date"`
+}
+
+func loadFile(path string) ([]domain.Note, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seed file: %w", err)
+	}
+
+	var entries []seedNote
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse seed file %s: %w", path, err)
+	}
+
+	notes := make([]domain.Note, 0, len(entries))
+	for i, entry := range entries {
+		if entry.Title == "" || entry.Content == "" {
+			return nil, fmt.Errorf("seed file %s: note %d needs a title and content", path, i)
+		}
+		notes = append(notes, domain.Note{
+			Title:       entry.Title,
+			Content:     entry.Content,
+			Category:    entry.Category,
+			MeetingDate: entry.MeetingDate,
+		})
+	}
+	return notes, nil
+}
+
+// seedNotes inserts notes in one transaction unless any note, including a
+// soft-deleted one, already exists.
+func seedNotes(db *gorm.DB, notes []domain.Note) error {
+	var existing int64
+	if err := db.Unscoped().Model(&domain.Note{}).Count(&existing).Error; err != nil {
+		log.Println("Failed to check for existing notes:", err)
+		return err
+	}
+
+	if existing > 0 {
+		log.Println("Notes table is not empty, skipping seed")
+		return nil
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, note := range notes {
+			if err := tx.Create(&note).Error; err != nil {
+				log.Println("Failed to seed note:", note.Title, "Error:", err)
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	log.Println("Seeded initial notes successfully")
+	log.Println("Seeded initial notes successfully:", len(notes))
 	return nil
 }
@@ -0,0 +1,66 @@
+package seed
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		contents  string
+		wantTitle []string
+		wantErr   bool
+	}{
+		{
+			name: "Valid file",
+			contents: `[
+				{"title": "Demo Standup", "content": "Yesterday, today, blockers", "category": "Standup", "meeting_date": "2025-06-16T09:00:00Z"},
+				{"title": "Demo Retro", "content": "What went well", "meeting_date": "2025-06-20T15:00:00Z"}
+			]`,
+			wantTitle: []string{"Demo Standup", "Demo Retro"},
+		},
+		{name: "Empty list", contents: `[]`, wantTitle: []string{}},
+		{name: "Malformed JSON", contents: `[{"title": "Demo"`, wantErr: true},
+		{name: "Missing content", contents: `[{"title": "Demo"}]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "seed.json")
+			assert.NoError(t, os.WriteFile(path, []byte(tt.contents), 0o600))
+
+			notes, err := loadFile(path)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			titles := make([]string, 0, len(notes))
+			for _, n := range notes {
+				titles = append(titles, n.Title)
+			}
+			assert.Equal(t, tt.wantTitle, titles)
+		})
+	}
+
+	t.Run("Fields are mapped", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "seed.json")
+		assert.NoError(t, os.WriteFile(path, []byte(`[{"title": "Demo", "content": "Notes", "category": "1:1", "meeting_date": "2025-06-16T09:00:00Z"}]`), 0o600))
+
+		notes, err := loadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "1:1", notes[0].Category)
+		assert.True(t, notes[0].MeetingDate.Equal(time.Date(2025, 6, 16, 9, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := loadFile(filepath.Join(t.TempDir(), "missing.json"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,93 @@
+package template
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Registry holds note content templates keyed by category. Lookups ignore
+// case so "standup" and "Standup" share a template.
+type Registry struct {
+	mu        sync.RWMutex
+	templates map[string]entry
+}
+
+type entry struct {
+	category string
+	body     string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{templates: map[string]entry{}}
+}
+
+// Register adds or replaces the template for category.
+func (r *Registry) Register(category, body string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.templates[key(category)] = entry{category: strings.TrimSpace(category), body: body}
+}
+
+// Get returns the template for category and whether one is registered.
+func (r *Registry) Get(category string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.templates[key(category)]
+	return e.body, ok
+}
+
+// Categories returns the registered categories in alphabetical order.
+func (r *Registry) Categories() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	categories := make([]string, 0, len(r.templates))
+	for _, e := range r.templates {
+		categories = append(categories, e.category)
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+func key(category string) string {
+	return strings.ToLower(strings.TrimSpace(category))
+}
+
+const standupTemplate = `## Agenda
+- Yesterday
+- Today
+- Blockers
+
+## Decisions
+
+## Action Items
+`
+
+const oneOnOneTemplate = `## Agenda
+
+## Feedback
+
+## Decisions
+
+## Action Items
+`
+
+var defaultRegistry = newDefaultRegistry()
+
+func newDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.Register("Standup", standupTemplate)
+	r.Register("1:1", oneOnOneTemplate)
+	return r
+}
+
+// Default returns the shared registry that ships with the built-in
+// templates. Packages can add their own categories to it with Register.
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Register adds a template to the default registry.
+func Register(category, body string) {
+	defaultRegistry.Register(category, body)
+}
@@ -0,0 +1,43 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	r.Register("Retro", "## What went well\n")
+
+	tests := []struct {
+		name     string
+		category string
+		wantOK   bool
+	}{
+		{name: "Exact match", category: "Retro", wantOK: true},
+		{name: "Case insensitive", category: "retro", wantOK: true},
+		{name: "Surrounding whitespace", category: " RETRO ", wantOK: true},
+		{name: "Unknown category", category: "Planning", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, ok := r.Get(tt.category)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, "## What went well\n", body)
+			}
+		})
+	}
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	for _, category := range []string{"Standup", "1:1"} {
+		body, ok := Default().Get(category)
+		assert.True(t, ok, category)
+		assert.Contains(t, body, "## Action Items")
+	}
+
+	assert.Equal(t, []string{"1:1", "Standup"}, Default().Categories())
+}
@@ -0,0 +1,82 @@
+// Package textstats counts the words in note text.
+package textstats
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// WordCount is how many times a word appears in a text.
+type WordCount struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// stopwords are common English words that say nothing about what a note is
+// about.
+var stopwords = map[string]bool{}
+
+func init() {
+	for _, word := range strings.Fields(`
+		a about above after again against all am an and any are as at be
+		because been before being below between both but by can could did do
+		does doing down during each few for from further had has have having
+		he her here hers herself him himself his how i if in into is it its
+		itself just me more most my myself no nor not now of off on once only
+		or other our ours ourselves out over own same she should so some such
+		than that the their theirs them themselves then there these they this
+		those through to too under until up very was we were what when where
+		which while who whom why will with would you your yours yourself
+		yourselves dont doesnt didnt isnt arent wasnt werent wont cant
+		couldnt shouldnt wouldnt im ive id ill youre youve weve theyre
+		thats theres lets also get got via per
+	`) {
+		stopwords[word] = true
+	}
+}
+
+// Words splits text into lower-cased words. Apostrophes are dropped so
+// "don't" is one word, and any other character that is not a letter or a
+// digit separates words. Single characters are left out.
+func Words(text string) []string {
+	text = strings.NewReplacer("'", "", "’", "").Replace(strings.ToLower(text))
+
+	var words []string
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(word)) > 1 {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// TopWords returns the n words of text that appear most often, leaving out
+// stopwords, most frequent first and alphabetically among equals. An n of
+// zero or less returns every word. Text without words gives an empty list.
+func TopWords(text string, n int) []WordCount {
+	counts := map[string]int{}
+	for _, word := range Words(text) {
+		if !stopwords[word] {
+			counts[word]++
+		}
+	}
+
+	top := make([]WordCount, 0, len(counts))
+	for word, count := range counts {
+		top = append(top, WordCount{Word: word, Count: count})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Word < top[j].Word
+	})
+
+	if n > 0 && len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
@@ -0,0 +1,66 @@
+package textstats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWords(t *testing.T) {
+	assert.Equal(t,
+		[]string{"we", "dont", "ship", "on", "fridays", "q3", "budget", "über", "plan"},
+		Words("We don't ship on Fridays! Q3-budget: über-plan, a 1"),
+	)
+	assert.Nil(t, Words("  -- !! "))
+}
+
+func TestTopWords(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		n    int
+		want []WordCount
+	}{
+		{
+			name: "Most frequent first",
+			text: "Budget review. The budget is tight; review the budget again and hire.",
+			n:    10,
+			want: []WordCount{{"budget", 3}, {"review", 2}, {"hire", 1}, {"tight", 1}},
+		},
+		{
+			name: "Limited to n",
+			text: "alpha beta beta gamma gamma gamma",
+			n:    2,
+			want: []WordCount{{"gamma", 3}, {"beta", 2}},
+		},
+		{
+			name: "Zero n returns every word",
+			text: "alpha beta",
+			want: []WordCount{{"alpha", 1}, {"beta", 1}},
+		},
+		{
+			name: "Markdown punctuation is stripped",
+			text: "## **Actions**\n- [ ] *Actions* for `deploy`",
+			n:    5,
+			want: []WordCount{{"actions", 2}, {"deploy", 1}},
+		},
+		{
+			name: "Empty text",
+			text: "",
+			n:    10,
+			want: []WordCount{},
+		},
+		{
+			name: "Only stopwords",
+			text: "and the of it",
+			n:    10,
+			want: []WordCount{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TopWords(tt.text, tt.n))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Package timeutil resolves timezones and reads calendar dates in them.
+// Meeting dates are stored in UTC, but a date such as 2025-06-01 or a month
+// of stats means the day or month as the user sees it.
+package timeutil
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// DateLayout is the YYYY-MM-DD form of a calendar date.
+const DateLayout = "2006-01-02"
+
+// ErrUnknownTimezone is returned by LoadLocation for a name that isn't an
+// IANA timezone.
+var ErrUnknownTimezone = errors.New("unknown timezone")
+
+// LoadLocation returns the IANA timezone called name, such as
+// America/New_York, or fallback when name is blank. A nil fallback means
+// UTC. "Local" is rejected, since it would depend on the server's settings.
+func LoadLocation(name string, fallback *time.Location) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		if fallback == nil {
+			return time.UTC, nil
+		}
+		return fallback, nil
+	}
+	if name == "Local" {
+		return nil, ErrUnknownTimezone
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrUnknownTimezone
+	}
+	return loc, nil
+}
+
+// ParseDate reads a YYYY-MM-DD date as the start of that day in loc.
+func ParseDate(value string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(DateLayout, value, loc)
+}
+
+// StartOfDay returns midnight at the start of t's calendar day in loc.
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// StartOfMonth returns midnight on the first of t's calendar month in loc.
+func StartOfMonth(t time.Time, loc *time.Location) time.Time {
+	year, month, _ := t.In(loc).Date()
+	return time.Date(year, month, 1, 0, 0, 0, 0, loc)
+}
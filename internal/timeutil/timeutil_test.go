@@ -0,0 +1,66 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		tz       string
+		fallback *time.Location
+		want     string
+		wantErr  bool
+	}{
+		{name: "Named timezone", tz: "America/New_York", want: "America/New_York"},
+		{name: "Surrounding spaces", tz: " Europe/London ", want: "Europe/London"},
+		{name: "Blank uses the fallback", tz: "", fallback: tokyo, want: "Asia/Tokyo"},
+		{name: "Blank without a fallback is UTC", tz: " ", want: "UTC"},
+		{name: "Unknown", tz: "Mars/Olympus_Mons", wantErr: true},
+		{name: "Server local time", tz: "Local", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := LoadLocation(tt.tz, tt.fallback)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnknownTimezone)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, loc.String())
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	date, err := ParseDate("2025-06-01", newYork)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, time.June, 1, 4, 0, 0, 0, time.UTC), date.UTC())
+
+	_, err = ParseDate("2025-06-01T10:00:00Z", newYork)
+	assert.Error(t, err)
+}
+
+func TestStartOfDayAndMonth(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	// 02:30 UTC on 1 June is still 31 May in New York.
+	instant := time.Date(2025, time.June, 1, 2, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, time.May, 31, 0, 0, 0, 0, newYork), StartOfDay(instant, newYork))
+	assert.Equal(t, time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), StartOfDay(instant, time.UTC))
+	assert.Equal(t, time.Date(2025, time.May, 1, 0, 0, 0, 0, newYork), StartOfMonth(instant, newYork))
+	assert.Equal(t, time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), StartOfMonth(instant, time.UTC))
+}
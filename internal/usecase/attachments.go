@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+// AddAttachment records a's metadata against the owner's note. Only the URL
+// is stored, so it must be an absolute http or https URL the client can
+// fetch the file from.
+func (uc *noteUsecase) AddAttachment(noteID, ownerID uint, a *domain.Attachment) error {
+	if err := uc.requireOwnedNote(noteID, ownerID); err != nil {
+		if errors.Is(err, ErrNoteNotFound) {
+			return ErrNoteNotFound
+		}
+		return fmt.Errorf("failed to add attachment")
+	}
+
+	if err := validateAttachment(a); err != nil {
+		return err
+	}
+
+	a.ID = 0
+	a.NoteID = noteID
+	if err := uc.repo.AddAttachment(a); err != nil {
+		uc.logger.Error("error adding attachment", "operation", "add_attachment", "note_id", noteID, "error", err)
+		return fmt.Errorf("failed to add attachment")
+	}
+
+	uc.logger.Info("attachment added", "operation", "add_attachment", "note_id", noteID, "attachment_id", a.ID)
+	return nil
+}
+
+// ListAttachments returns the attachments on the owner's note, oldest first.
+func (uc *noteUsecase) ListAttachments(noteID, ownerID uint) ([]domain.Attachment, error) {
+	if err := uc.requireOwnedNote(noteID, ownerID); err != nil {
+		if errors.Is(err, ErrNoteNotFound) {
+			return nil, ErrNoteNotFound
+		}
+		return nil, fmt.Errorf("failed to list attachments")
+	}
+
+	attachments, err := uc.repo.ListAttachments(noteID)
+	if err != nil {
+		uc.logger.Error("error listing attachments", "operation", "list_attachments", "note_id", noteID, "error", err)
+		return nil, fmt.Errorf("failed to list attachments")
+	}
+	if attachments == nil {
+		attachments = []domain.Attachment{}
+	}
+
+	uc.logger.Info("attachments listed", "operation", "list_attachments", "note_id", noteID, "count", len(attachments))
+	return attachments, nil
+}
+
+// validateAttachment trims a's text fields and returns a
+// *ValidationError listing every field that is missing or malformed.
+func validateAttachment(a *domain.Attachment) error {
+	a.Filename = strings.TrimSpace(a.Filename)
+	a.URL = strings.TrimSpace(a.URL)
+	a.ContentType = strings.TrimSpace(a.ContentType)
+
+	var fields []FieldError
+
+	if a.Filename == "" {
+		fields = append(fields, FieldError{Field: "filename", Err: ErrEmptyFilename})
+	}
+
+	if !validHTTPURL(a.URL) {
+		fields = append(fields, FieldError{Field: "url", Err: ErrInvalidAttachmentURL})
+	}
+
+	if a.SizeBytes < 0 {
+		fields = append(fields, FieldError{Field: "size_bytes", Err: ErrInvalidAttachmentSize})
+	}
+
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// validHTTPURL reports whether raw is an absolute http or https URL.
+func validHTTPURL(raw string) bool {
+	u, err := url.ParseRequestURI(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+// audit records op against the note. before is nil on create and after is nil
+// on delete. A zero actor means the request was anonymous. Auditing never
+// fails the operation it describes; errors are only logged.
+func (uc *noteUsecase) audit(op domain.AuditOperation, noteID, actor uint, before, after *domain.Note) {
+	if uc.config.AuditLog == nil {
+		return
+	}
+
+	entry := domain.AuditLog{
+		NoteID:    noteID,
+		Operation: op,
+		Changes:   changedFields(before, after),
+	}
+	if actor != 0 {
+		entry.ActorID = &actor
+	}
+
+	if err := uc.config.AuditLog.Create(&entry); err != nil {
+		uc.logger.Error("error writing audit log", "operation", string(op), "note_id", noteID, "error", err)
+	}
+}
+
+// auditedFields are the note fields a user can edit, named as in validation
+// errors.
+var auditedFields = []string{"title", "content", "category", "meeting_date", "format", "priority", "visibility", "location", "meeting_url"}
+
+// changedFields compares the audited fields of two versions of a note. Either
+// side may be nil, in which case every field is reported. With both nil no
+// fields are.
+func changedFields(before, after *domain.Note) domain.FieldChanges {
+	from, to := auditSnapshot(before), auditSnapshot(after)
+
+	changes := domain.FieldChanges{}
+	if from == nil && to == nil {
+		return changes
+	}
+	for _, field := range auditedFields {
+		if from != nil && to != nil && from[field] == to[field] {
+			continue
+		}
+		changes[field] = domain.FieldChange{From: from[field], To: to[field]}
+	}
+	return changes
+}
+
+func auditSnapshot(n *domain.Note) map[string]interface{} {
+	if n == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"title":        n.Title,
+		"content":      n.Content,
+		"category":     n.Category,
+		"meeting_date": n.MeetingDate.UTC().Format(time.RFC3339Nano),
+		"format":       n.Format,
+		"priority":     n.Priority,
+		"visibility":   n.Visibility,
+		"location":     n.Location,
+		"meeting_url":  n.MeetingURL,
+	}
+}
+
+// ListAuditLog returns the audit trail of one of the owner's notes, oldest
+// first.
+func (uc *noteUsecase) ListAuditLog(noteID, ownerID uint) ([]domain.AuditLog, error) {
+	if err := uc.requireOwnedNote(noteID, ownerID); err != nil {
+		if errors.Is(err, ErrNoteNotFound) {
+			return nil, ErrNoteNotFound
+		}
+		return nil, fmt.Errorf("failed to list audit log")
+	}
+
+	if uc.config.AuditLog == nil {
+		return []domain.AuditLog{}, nil
+	}
+
+	entries, err := uc.config.AuditLog.ListByNote(noteID)
+	if err != nil {
+		uc.logger.Error("error listing audit log", "operation", "list_audit", "note_id", noteID, "error", err)
+		return nil, fmt.Errorf("failed to list audit log")
+	}
+	if entries == nil {
+		entries = []domain.AuditLog{}
+	}
+
+	uc.logger.Info("audit log listed", "operation", "list_audit", "note_id", noteID, "count", len(entries))
+	return entries, nil
+}
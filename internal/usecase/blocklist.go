@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// contentBlocklist finds blocked terms in note content. Terms match ignoring
+// case and only as whole words, so blocking "ass" leaves "class" and
+// "Scunthorpe"-style words alone. A term with spaces matches the words with
+// any whitespace between them.
+type contentBlocklist struct {
+	categories []blockedCategory
+}
+
+type blockedCategory struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// newContentBlocklist compiles terms, keyed by category, into a blocklist.
+// Blank terms and categories are skipped. It returns nil when nothing is left
+// to block.
+func newContentBlocklist(terms map[string][]string) *contentBlocklist {
+	names := make([]string, 0, len(terms))
+	for name := range terms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	b := &contentBlocklist{}
+	for _, name := range names {
+		category := strings.TrimSpace(name)
+		if category == "" {
+			continue
+		}
+
+		var alternatives []string
+		for _, term := range terms[name] {
+			words := strings.Fields(term)
+			if len(words) == 0 {
+				continue
+			}
+			for i, word := range words {
+				words[i] = regexp.QuoteMeta(word)
+			}
+			alternatives = append(alternatives, strings.Join(words, `\s+`))
+		}
+		if len(alternatives) == 0 {
+			continue
+		}
+
+		// Go's \b only knows ASCII word characters, so the boundaries are
+		// spelled out to keep accented and non-Latin words whole.
+		pattern := regexp.MustCompile(`(?i)(?:^|[^\pL\pN_])(?:` + strings.Join(alternatives, "|") + `)(?:[^\pL\pN_]|$)`)
+		b.categories = append(b.categories, blockedCategory{name: category, pattern: pattern})
+	}
+
+	if len(b.categories) == 0 {
+		return nil
+	}
+	return b
+}
+
+// check returns a *BlockedContentError naming the category of the first
+// blocked term found in content, or nil. A nil blocklist blocks nothing.
+func (b *contentBlocklist) check(content string) error {
+	if b == nil {
+		return nil
+	}
+	for _, c := range b.categories {
+		if c.pattern.MatchString(content) {
+			return &BlockedContentError{Category: c.name}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+	"github.com/jt00721/meeting-notes-manager/internal/repository"
+	"github.com/jt00721/meeting-notes-manager/internal/template"
+)
+
+type WeekendRule string
+
+const (
+	WeekendRuleOff    WeekendRule = "off"
+	WeekendRuleWarn   WeekendRule = "warn"
+	WeekendRuleReject WeekendRule = "reject"
+)
+
+// DefaultMeetingDurationMinutes is the meeting length given to notes saved
+// without one when Config.DefaultMeetingDurationMinutes is unset, and
+// MaxMeetingDurationMinutes is the longest a meeting may be.
+const (
+	DefaultMeetingDurationMinutes = 60
+	MaxMeetingDurationMinutes     = 24 * 60
+)
+
+type Config struct {
+	// WeekendMeetingRule controls what happens when a note's MeetingDate
+	// falls on a Saturday or Sunday.
+	WeekendMeetingRule WeekendRule
+	// Location is the timezone used for any calendar based rule.
+	Location *time.Location
+	// MeetingDatePastWindow and MeetingDateFutureWindow bound how far from
+	// now a new note's MeetingDate may be. Zero leaves that side unbounded.
+	MeetingDatePastWindow   time.Duration
+	MeetingDateFutureWindow time.Duration
+	// DefaultMeetingDateToNow fills a missing MeetingDate with the current
+	// time on create instead of rejecting the note with ErrInvalidMeetingDate.
+	// On update a missing MeetingDate keeps the stored one.
+	DefaultMeetingDateToNow bool
+	// DefaultMeetingDurationMinutes is the duration given to a note created
+	// without one. Defaults to DefaultMeetingDurationMinutes.
+	DefaultMeetingDurationMinutes int
+	// Logger receives structured log lines. Defaults to logger.Default().
+	Logger logger.Logger
+	// Templates seeds note content when CreateOptions.UseTemplate is set.
+	// Defaults to template.Default().
+	Templates *template.Registry
+	// Publisher is notified after notes are created, updated or deleted.
+	// Defaults to NopPublisher().
+	Publisher NoteEventPublisher
+	// NoteEventsInOutbox means the repository already records note.created,
+	// note.updated and note.deleted in its outbox, so Publisher is only sent
+	// the other events, such as reminders.
+	NoteEventsInOutbox bool
+	// AuditLog records who created, updated or deleted each note. Nil
+	// turns auditing off.
+	AuditLog repository.AuditRepository
+	// HardDelete says the repository removes deleted notes outright, as
+	// with repository.NoteRepositoryOptions.HardDelete. Deletions are then
+	// audited without the note's field values, which would otherwise keep a
+	// copy of what was deleted.
+	HardDelete bool
+	// FuzzySearchThreshold is the minimum trigram similarity, from 0 to 1,
+	// for a note to match a search that found nothing exactly. Defaults to
+	// 0.3, pg_trgm's own default; zero turns the fuzzy fallback off.
+	FuzzySearchThreshold float64
+	// ContentPolicy decides which HTML is kept in note content on create
+	// and update. Defaults to ContentPolicyStripAll.
+	ContentPolicy ContentPolicy
+	// SlugPolicy decides whether a note's slug follows changes to its
+	// title. Defaults to SlugPolicyPreserve.
+	SlugPolicy SlugPolicy
+	// CategoryQuotas caps how many notes each owner may have in a category,
+	// keyed by category name ignoring case. Creating, importing, upserting,
+	// updating or renaming is refused when it would take an owner over the
+	// cap; categories without an entry are unlimited.
+	CategoryQuotas map[string]int
+	// AllowedCategories, when not empty, are the only categories a note may
+	// have. They are matched ignoring case and the note takes the spelling
+	// listed here. A note without a category is still allowed. Empty
+	// allows any category.
+	AllowedCategories []string
+	// SummaryLength is how many characters of content a note's Summary
+	// keeps at most. Defaults to DefaultSummaryLength.
+	SummaryLength int
+	// MaxListSize caps how many notes GetAllNotes returns, which has no
+	// paging of its own. Defaults to DefaultMaxListSize.
+	MaxListSize int
+	// BlockedTerms lists terms, keyed by category, that note content may
+	// not contain. They match ignoring case and only as whole words; a
+	// note containing one fails validation with a *BlockedContentError
+	// naming the category. Empty turns the blocklist off.
+	BlockedTerms map[string][]string
+}
+
+func DefaultConfig() Config {
+	return Config{
+		WeekendMeetingRule:            WeekendRuleOff,
+		Location:                      time.UTC,
+		DefaultMeetingDurationMinutes: DefaultMeetingDurationMinutes,
+		Logger:                        logger.Default(),
+		Templates:                     template.Default(),
+		Publisher:                     NopPublisher(),
+		ContentPolicy:                 ContentPolicyStripAll,
+		SlugPolicy:                    SlugPolicyPreserve,
+		FuzzySearchThreshold:          0.3,
+		SummaryLength:                 DefaultSummaryLength,
+		MaxListSize:                   DefaultMaxListSize,
+	}
+}
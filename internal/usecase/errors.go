@@ -1,9 +1,105 @@
 package usecase
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var (
-	ErrEmptyTitle   = errors.New("note title cannot be empty")
-	ErrEmptyContent = errors.New("note content cannot be empty")
-	ErrNoteNotFound = errors.New("note not found")
+	ErrEmptyTitle        = errors.New("note title cannot be empty")
+	ErrEmptyContent      = errors.New("note content cannot be empty")
+	ErrNoteNotFound      = errors.New("note not found")
+	ErrNoIDs             = errors.New("at least one note ID is required")
+	ErrStaleUpdate       = errors.New("note has been modified since it was read")
+	ErrDuplicateNote     = errors.New("a note with this title and meeting date already exists")
+	ErrInvalidAge        = errors.New("purge age cannot be negative")
+	ErrInvalidArchiveAge = errors.New("archive age cannot be negative")
+	ErrFutureWindow      = errors.New("deletedAfter must be in the past")
+	ErrInvalidPage       = errors.New("limit and offset cannot be negative")
+
+	ErrRevisionNotFound = errors.New("revision not found")
+	ErrInvalidWindow    = errors.New("upcoming window must be positive")
+	ErrInvalidTimezone  = errors.New("unknown timezone")
+
+	ErrEmptyCategory = errors.New("category names cannot be empty")
+	ErrSameCategory  = errors.New("new category must differ from the old one")
+
+	ErrInvalidDateRange    = errors.New("fromDate must be before toDate")
+	ErrInvalidCreatedRange = errors.New("createdFrom must be before createdTo")
+	ErrInvalidYear         = errors.New("year must be between 1900 and 9999")
+	ErrInvalidMonth        = errors.New("month must be between 1 and 12")
+	ErrMonthWithoutYear    = errors.New("month needs a year")
+	ErrYearWithDateRange   = errors.New("year and month cannot be combined with fromDate or toDate")
+	ErrInvalidKeywordMatch = errors.New("match must be any or all")
+	ErrInvalidMeetingMode  = errors.New("mode must be remote or in_person")
+	ErrInvalidPriority     = errors.New("priority must be low, normal, high or critical")
+	ErrInvalidSort         = errors.New("sort must be meeting_date, created_at, updated_at, title or priority")
+	ErrInvalidSortOrder    = errors.New("order must be asc or desc")
+	ErrEmptyPresetName     = errors.New("preset name cannot be empty")
+	ErrPresetExists        = errors.New("preset name already exists")
+	ErrPresetNotFound      = errors.New("preset not found")
+
+	ErrWeekendMeetingDate = errors.New("meeting date falls on a weekend")
+	ErrInvalidMeetingDate = errors.New("meeting date is required")
+	ErrInvalidDuration    = errors.New("meeting duration must be between 1 minute and 24 hours")
+	ErrInvalidMeetingURL  = errors.New("meeting url must be an absolute http or https url")
+	ErrInvalidFormat      = errors.New("note format must be plaintext or markdown")
+	ErrInvalidVisibility  = errors.New("visibility must be private, shared or public")
+
+	ErrCategoryQuotaExceeded = errors.New("category has reached its note quota")
+	ErrInvalidCategory       = errors.New("category is not one of the allowed categories")
+	ErrImportRejected        = errors.New("import has invalid rows, no notes were created")
+
+	ErrEmptyFilename         = errors.New("attachment filename cannot be empty")
+	ErrInvalidAttachmentURL  = errors.New("attachment url must be an absolute http or https url")
+	ErrInvalidAttachmentSize = errors.New("attachment size cannot be negative")
+
+	ErrInvalidRelation   = errors.New("relation must be followup or related")
+	ErrSelfLink          = errors.New("a note cannot link to itself")
+	ErrLinkTargetMissing = errors.New("linked note not found")
+	ErrDuplicateLink     = errors.New("note already links to this note")
 )
+
+var ErrMeetingDateOutOfWindow = errors.New("meeting date is outside the allowed window")
+
+var ErrBlockedContent = errors.New("content contains a blocked term")
+
+// MeetingDateWindowError reports a MeetingDate outside the configured window.
+// A zero Earliest or Latest means that side of the window is unbounded.
+type MeetingDateWindowError struct {
+	MeetingDate time.Time
+	Earliest    time.Time
+	Latest      time.Time
+}
+
+func (e *MeetingDateWindowError) Error() string {
+	switch {
+	case e.MeetingDate.IsZero():
+		return "meeting date is required"
+	case !e.Earliest.IsZero() && e.MeetingDate.Before(e.Earliest):
+		return fmt.Sprintf("meeting date must not be before %s", e.Earliest.Format("2006-01-02"))
+	case !e.Latest.IsZero() && e.MeetingDate.After(e.Latest):
+		return fmt.Sprintf("meeting date must not be after %s", e.Latest.Format("2006-01-02"))
+	}
+	return ErrMeetingDateOutOfWindow.Error()
+}
+
+func (e *MeetingDateWindowError) Is(target error) bool {
+	return target == ErrMeetingDateOutOfWindow
+}
+
+// BlockedContentError reports content containing a term from the configured
+// blocklist. It names the term's category, not the term itself, so the
+// response doesn't repeat what was blocked.
+type BlockedContentError struct {
+	Category string
+}
+
+func (e *BlockedContentError) Error() string {
+	return fmt.Sprintf("content contains a blocked term (%s)", e.Category)
+}
+
+func (e *BlockedContentError) Is(target error) bool {
+	return target == ErrBlockedContent
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+// NoteEventPublisher is told about every note change after it has been
+// written. Publish must not block the request; slow deliveries belong in a
+// goroutine.
+type NoteEventPublisher interface {
+	Publish(event domain.NoteEvent)
+}
+
+type nopPublisher struct{}
+
+func (nopPublisher) Publish(domain.NoteEvent) {}
+
+// NopPublisher discards every event. It is the default publisher.
+func NopPublisher() NoteEventPublisher {
+	return nopPublisher{}
+}
+
+func (uc *noteUsecase) publish(eventType domain.NoteEventType, n domain.Note) {
+	if uc.config.NoteEventsInOutbox && isNoteChange(eventType) {
+		return
+	}
+
+	uc.config.Publisher.Publish(domain.NoteEvent{
+		Type:       eventType,
+		Note:       n,
+		OccurredAt: time.Now(),
+	})
+}
+
+// isNoteChange reports whether eventType is written by the note repository
+// itself when its outbox is enabled.
+func isNoteChange(eventType domain.NoteEventType) bool {
+	switch eventType {
+	case domain.NoteCreated, domain.NoteUpdated, domain.NoteDeleted:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+// GetNotesByCategory returns the notes userID may read keyed by category,
+// each group newest meeting first. Notes without a category are under "".
+// A positive limit keeps only the newest limit notes of each group. Like
+// GetAllNotes it groups at most MaxListSize notes, the newest, and reports
+// whether there were more.
+func (uc *noteUsecase) GetNotesByCategory(userID uint, includeArchived bool, limit int) (map[string][]domain.Note, bool, error) {
+	if limit < 0 {
+		return nil, false, ErrInvalidPage
+	}
+
+	notes, truncated, err := uc.GetAllNotes(userID, includeArchived)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get grouped notes")
+	}
+
+	groups := map[string][]domain.Note{}
+	for _, n := range notes {
+		if limit > 0 && len(groups[n.Category]) >= limit {
+			continue
+		}
+		groups[n.Category] = append(groups[n.Category], n)
+	}
+
+	uc.logger.Info("notes grouped by category", "operation", "get_grouped", "groups", len(groups), "truncated", truncated)
+	return groups, truncated, nil
+}
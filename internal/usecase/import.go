@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/repository"
+)
+
+// importBatchSize is how many valid rows ImportNotes stores per CreateBatch
+// call when the import isn't atomic.
+const importBatchSize = 100
+
+// ImportRow is one record read from an import file. Err is set instead of
+// Note when the record couldn't be parsed.
+type ImportRow struct {
+	Line int
+	Note domain.Note
+	Err  error
+}
+
+// ImportFailure says why the row on Line was not imported.
+type ImportFailure struct {
+	Line    int               `json:"line"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// ImportSummary reports what ImportNotes did with each row.
+type ImportSummary struct {
+	Imported int             `json:"imported"`
+	Failed   []ImportFailure `json:"failed"`
+}
+
+// ImportOptions adjusts a single import.
+type ImportOptions struct {
+	// Atomic stores nothing unless every row is valid. The valid notes are
+	// held in memory until the end of the file and written in one
+	// transaction.
+	Atomic bool
+}
+
+// ImportNotes reads rows from next until it returns io.EOF, validates each
+// one as a new note owned by ownerID and stores the valid ones in batches.
+// An invalid row is recorded in the summary and skipped, unless
+// opts.Atomic is set, in which case nothing is stored and
+// ErrImportRejected is returned once every row has been checked.
+//
+// Any other error from next stops the import. The summary still reports
+// the rows handled before it, which without opts.Atomic may already be
+// stored.
+func (uc *noteUsecase) ImportNotes(ownerID uint, next func() (ImportRow, error), opts ImportOptions) (ImportSummary, error) {
+	summary := ImportSummary{Failed: []ImportFailure{}}
+
+	var (
+		pending    []domain.Note
+		lines      []int
+		slugs      = map[string]bool{}
+		categories = map[string]int64{}
+	)
+
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+		if err := uc.repo.CreateBatch(pending); err != nil {
+			if opts.Atomic {
+				uc.logger.Error("error storing imported notes", "operation", "import", "first_line", lines[0], "count", len(pending), "error", err)
+				return fmt.Errorf("failed to import notes")
+			}
+			// One bad row, such as a duplicate title and date, fails the
+			// whole batch. Store its rows one at a time so only the bad
+			// ones are reported.
+			uc.logger.Warn("batch of imported notes failed, storing them one at a time", "operation", "import", "first_line", lines[0], "count", len(pending), "error", err)
+			for i := range pending {
+				if failure := uc.storeImportedNote(pending[i], lines[i]); failure != nil {
+					summary.Failed = append(summary.Failed, *failure)
+				} else {
+					summary.Imported++
+				}
+			}
+		} else {
+			for _, n := range pending {
+				uc.publish(domain.NoteCreated, n)
+				uc.audit(domain.AuditCreate, n.ID, n.OwnerID, nil, &n)
+			}
+			summary.Imported += len(pending)
+		}
+
+		pending, lines = nil, nil
+		slugs = map[string]bool{}
+		categories = map[string]int64{}
+		return nil
+	}
+
+	for {
+		row, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			uc.logger.Warn("import stopped", "operation", "import", "imported", summary.Imported, "error", err)
+			if !opts.Atomic {
+				if flushErr := flush(); flushErr != nil {
+					return summary, flushErr
+				}
+			}
+			return summary, err
+		}
+
+		n, failure := uc.prepareImportRow(ownerID, row, slugs, categories)
+		if failure != nil {
+			summary.Failed = append(summary.Failed, *failure)
+			continue
+		}
+		if len(summary.Failed) > 0 && opts.Atomic {
+			// Keep checking the rest of the file, but nothing will be stored.
+			continue
+		}
+
+		pending = append(pending, n)
+		lines = append(lines, row.Line)
+		slugs[n.Slug] = true
+		categories[strings.ToLower(n.Category)]++
+
+		if !opts.Atomic && len(pending) >= importBatchSize {
+			if err := flush(); err != nil {
+				return summary, err
+			}
+		}
+	}
+
+	if opts.Atomic && len(summary.Failed) > 0 {
+		uc.logger.Warn("atomic import rejected", "operation", "import", "failed", len(summary.Failed))
+		return summary, ErrImportRejected
+	}
+	if err := flush(); err != nil {
+		return summary, err
+	}
+
+	uc.logger.Info("notes imported", "operation", "import", "imported", summary.Imported, "failed", len(summary.Failed))
+	return summary, nil
+}
+
+// prepareImportRow turns row into a note ready to store, or says why it
+// can't be. slugs and categories describe the notes already waiting to be
+// stored, so they are counted towards slug uniqueness and quotas.
+func (uc *noteUsecase) prepareImportRow(ownerID uint, row ImportRow, slugs map[string]bool, categories map[string]int64) (domain.Note, *ImportFailure) {
+	if row.Err != nil {
+		return domain.Note{}, &ImportFailure{Line: row.Line, Message: row.Err.Error()}
+	}
+
+	n := domain.Note{
+		OwnerID:         ownerID,
+		Title:           row.Note.Title,
+		Content:         row.Note.Content,
+		Category:        row.Note.Category,
+		MeetingDate:     row.Note.MeetingDate,
+		DurationMinutes: row.Note.DurationMinutes,
+		Location:        row.Note.Location,
+		MeetingURL:      row.Note.MeetingURL,
+		Format:          row.Note.Format,
+		Priority:        row.Note.Priority,
+		Visibility:      row.Note.Visibility,
+		Version:         1,
+	}
+
+	// Imported notes are often historical, so like UpsertNote the meeting
+	// date window is not enforced.
+	uc.defaultMeetingDate(&n)
+	uc.defaultDuration(&n)
+	if err := uc.validateNote(&n, CreateOptions{Force: true}); err != nil {
+		return domain.Note{}, importFailure(row.Line, err)
+	}
+
+	if err := uc.checkCategoryQuota("import", ownerID, n.Category, categories[strings.ToLower(n.Category)]+1); err != nil {
+		if !errors.Is(err, ErrCategoryQuotaExceeded) {
+			return domain.Note{}, &ImportFailure{Line: row.Line, Message: "failed to create note"}
+		}
+		return domain.Note{}, importFailure(row.Line, err)
+	}
+
+	slug, err := uc.uniqueSlugExcept(n.Title, slugs)
+	if err != nil {
+		uc.logger.Error("error generating slug", "operation", "import", "line", row.Line, "error", err)
+		return domain.Note{}, &ImportFailure{Line: row.Line, Message: "failed to create note"}
+	}
+	n.Slug = slug
+
+	return n, nil
+}
+
+// storeImportedNote stores n, read from line, on its own, or says why it
+// couldn't be stored.
+func (uc *noteUsecase) storeImportedNote(n domain.Note, line int) *ImportFailure {
+	// A failed batch may have assigned IDs before it rolled back.
+	n.ID = 0
+	if err := uc.repo.Create(&n); err != nil {
+		if errors.Is(err, repository.ErrDuplicateNote) {
+			uc.logger.Warn("duplicate note", "operation", "import", "line", line, "title", n.Title, "meeting_date", n.MeetingDate)
+			return &ImportFailure{Line: line, Message: ErrDuplicateNote.Error()}
+		}
+		uc.logger.Error("error storing imported note", "operation", "import", "line", line, "error", err)
+		return &ImportFailure{Line: line, Message: "failed to create note"}
+	}
+
+	uc.publish(domain.NoteCreated, n)
+	uc.audit(domain.AuditCreate, n.ID, n.OwnerID, nil, &n)
+	return nil
+}
+
+func importFailure(line int, err error) *ImportFailure {
+	failure := &ImportFailure{Line: line, Message: err.Error()}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		failure.Message = "note failed validation"
+		failure.Fields = make(map[string]string, len(validationErr.Fields))
+		for _, f := range validationErr.Fields {
+			failure.Fields[f.Field] = f.Err.Error()
+		}
+	}
+	return failure
+}
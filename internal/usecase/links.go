@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/repository"
+)
+
+// AddNoteLink links the owner's note to the note link.ToNoteID, which the
+// owner must be able to read. A blank relation means related. It returns the
+// link with the note it points to.
+func (uc *noteUsecase) AddNoteLink(noteID, ownerID uint, link *domain.NoteLink) (domain.LinkedNote, error) {
+	if err := uc.requireOwnedNote(noteID, ownerID); err != nil {
+		if errors.Is(err, ErrNoteNotFound) {
+			return domain.LinkedNote{}, ErrNoteNotFound
+		}
+		return domain.LinkedNote{}, fmt.Errorf("failed to add link")
+	}
+
+	link.ID = 0
+	link.FromNoteID = noteID
+	if err := validateLink(link); err != nil {
+		return domain.LinkedNote{}, err
+	}
+
+	target, err := uc.GetNoteByID(link.ToNoteID, ownerID)
+	if err != nil {
+		if errors.Is(err, ErrNoteNotFound) {
+			return domain.LinkedNote{}, &ValidationError{Fields: []FieldError{{Field: "to_note_id", Err: ErrLinkTargetMissing}}}
+		}
+		return domain.LinkedNote{}, fmt.Errorf("failed to add link")
+	}
+
+	if err := uc.repo.AddLink(link); err != nil {
+		if errors.Is(err, repository.ErrDuplicateLink) {
+			return domain.LinkedNote{}, ErrDuplicateLink
+		}
+		uc.logger.Error("error adding link", "operation", "add_link", "note_id", noteID, "to_note_id", link.ToNoteID, "error", err)
+		return domain.LinkedNote{}, fmt.Errorf("failed to add link")
+	}
+
+	uc.logger.Info("link added", "operation", "add_link", "note_id", noteID, "to_note_id", link.ToNoteID, "link_id", link.ID)
+	return domain.LinkedNote{Link: *link, Note: target}, nil
+}
+
+// ListNoteLinks returns the links from and to a note the caller can read,
+// oldest first, each with the note at its other end. Links to notes the
+// caller can't read are left out.
+func (uc *noteUsecase) ListNoteLinks(noteID, ownerID uint) ([]domain.LinkedNote, error) {
+	if _, err := uc.GetNoteByID(noteID, ownerID); err != nil {
+		if errors.Is(err, ErrNoteNotFound) {
+			return nil, ErrNoteNotFound
+		}
+		return nil, fmt.Errorf("failed to list links")
+	}
+
+	links, err := uc.repo.ListLinks(noteID)
+	if err != nil {
+		uc.logger.Error("error listing links", "operation", "list_links", "note_id", noteID, "error", err)
+		return nil, fmt.Errorf("failed to list links")
+	}
+
+	linked := []domain.LinkedNote{}
+	if len(links) == 0 {
+		return linked, nil
+	}
+
+	ids := make([]uint, 0, len(links))
+	for _, l := range links {
+		ids = append(ids, otherEnd(l, noteID))
+	}
+	notes, err := uc.repo.GetByIDs(ids)
+	if err != nil {
+		uc.logger.Error("error loading linked notes", "operation", "list_links", "note_id", noteID, "error", err)
+		return nil, fmt.Errorf("failed to list links")
+	}
+
+	byID := make(map[uint]domain.Note, len(notes))
+	for _, n := range uc.withSummaries(notes) {
+		byID[n.ID] = n
+	}
+	for _, l := range links {
+		n, ok := byID[otherEnd(l, noteID)]
+		if !ok || !n.VisibleTo(ownerID) {
+			continue
+		}
+		linked = append(linked, domain.LinkedNote{Link: l, Note: n})
+	}
+
+	uc.logger.Info("links listed", "operation", "list_links", "note_id", noteID, "count", len(linked))
+	return linked, nil
+}
+
+// otherEnd returns the ID of the note at the end of l that isn't noteID.
+func otherEnd(l domain.NoteLink, noteID uint) uint {
+	if l.FromNoteID == noteID {
+		return l.ToNoteID
+	}
+	return l.FromNoteID
+}
+
+// validateLink normalises the relation of l, defaulting it to related, and
+// returns a *ValidationError listing every field that is missing or
+// malformed.
+func validateLink(l *domain.NoteLink) error {
+	l.Relation = strings.ToLower(strings.TrimSpace(l.Relation))
+	if l.Relation == "" {
+		l.Relation = domain.LinkRelated
+	}
+
+	var fields []FieldError
+
+	switch {
+	case l.ToNoteID == 0:
+		fields = append(fields, FieldError{Field: "to_note_id", Err: ErrLinkTargetMissing})
+	case l.ToNoteID == l.FromNoteID:
+		fields = append(fields, FieldError{Field: "to_note_id", Err: ErrSelfLink})
+	}
+
+	if !validRelation(l.Relation) {
+		fields = append(fields, FieldError{Field: "relation", Err: ErrInvalidRelation})
+	}
+
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// validRelation reports whether relation is one of domain.LinkRelations.
+func validRelation(relation string) bool {
+	for _, r := range domain.LinkRelations {
+		if relation == r {
+			return true
+		}
+	}
+	return false
+}
@@ -1,72 +1,384 @@
 package usecase
 
 import (
+	"errors"
 	"fmt"
-	"log"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
 	"github.com/jt00721/meeting-notes-manager/internal/repository"
+	"github.com/jt00721/meeting-notes-manager/internal/template"
+	"github.com/jt00721/meeting-notes-manager/internal/timeutil"
 	"gorm.io/gorm"
 )
 
+// CreateOptions adjusts validation for a single create request.
+type CreateOptions struct {
+	// Force skips the meeting date window check.
+	Force bool
+	// UseTemplate fills empty content from the category's template before
+	// the note is validated, so content is only required when the category
+	// has no template.
+	UseTemplate bool
+}
+
 type NoteUsecase interface {
 	CreateNote(n *domain.Note) error
-	GetAllNotes() ([]domain.Note, error)
-	GetPaginatedNotes(limit, offset int) ([]domain.Note, error)
-	GetNoteByID(id uint) (domain.Note, error)
+	CreateNoteWithOptions(n *domain.Note, opts CreateOptions) error
+	UpsertNote(n *domain.Note) error
+	ImportNotes(ownerID uint, next func() (ImportRow, error), opts ImportOptions) (ImportSummary, error)
+	ValidateNote(n *domain.Note, opts CreateOptions) error
+	GetAllNotes(ownerID uint, includeArchived bool) ([]domain.Note, bool, error)
+	GetNotesByCategory(userID uint, includeArchived bool, limit int) (map[string][]domain.Note, bool, error)
+	StreamNotes(ownerID uint, includeArchived bool, fn func(domain.Note) error) error
+	GetNoteChanges(ownerID uint, cursor domain.ChangeCursor, limit int) ([]domain.NoteChange, bool, error)
+	GetPaginatedNotes(viewerID uint, limit, offset int, includeArchived bool) ([]domain.Note, error)
+	GetNotesAfterID(viewerID, afterID uint, limit int, includeArchived bool) ([]domain.Note, error)
+	GetRecentNotes(viewerID uint, limit int) ([]domain.Note, error)
+	GetNoteByID(id, ownerID uint) (domain.Note, error)
+	GetNoteBySlug(slug string, ownerID uint) (domain.Note, error)
+	GetNotesByIDs(ids []uint, ownerID uint) ([]domain.Note, error)
 	UpdateNote(n *domain.Note) error
-	DeleteNote(id uint) error
-	SearchNotesByKeyword(keyword string) ([]domain.Note, error)
-	FilterNotes(filter domain.NoteFilter) ([]domain.Note, error)
+	DeleteNote(id, ownerID uint) error
+	ArchiveNote(id, ownerID uint, archived bool) error
+	DuplicateNote(id, ownerID uint) (domain.Note, error)
+	ListRevisions(noteID, ownerID uint) ([]domain.NoteRevision, error)
+	ListAuditLog(noteID, ownerID uint) ([]domain.AuditLog, error)
+	AddAttachment(noteID, ownerID uint, a *domain.Attachment) error
+	ListAttachments(noteID, ownerID uint) ([]domain.Attachment, error)
+	AddNoteLink(noteID, ownerID uint, link *domain.NoteLink) (domain.LinkedNote, error)
+	ListNoteLinks(noteID, ownerID uint) ([]domain.LinkedNote, error)
+	GetRelatedNotes(id, ownerID uint, limit int) ([]domain.Note, error)
+	GetUpcomingMeetings(viewerID uint, within time.Duration) ([]domain.Note, error)
+	GetTodaysMeetings(viewerID uint, tz string) ([]domain.Note, error)
+	GetMeetingsOnDay(viewerID uint, day time.Time) ([]domain.Note, error)
+	SendMeetingReminders(lead, interval time.Duration) (int, error)
+	DiffRevisions(noteID, ownerID uint, from, to int) (string, error)
+	SearchNotesByKeyword(viewerID uint, keyword string, dates domain.DateRange, page domain.Page) ([]domain.SearchResult, int64, error)
+	FilterNotes(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error)
+	CountFilteredNotes(filter domain.NoteFilter) (int64, error)
+	RestoreNotes(ownerID uint, ids []uint) (int64, error)
+	RestoreDeletedSince(ownerID uint, since time.Time) (int64, error)
+	RenameCategory(ownerID uint, from, to string) (int64, error)
+	GetNoteExtremes(viewerID uint) (domain.NoteExtremes, error)
+	GetCategories(viewerID uint) ([]domain.CategoryCount, error)
+	AllowedCategories() []string
+	PurgeDeletedNotes(olderThan time.Duration) (int64, error)
+	AutoArchiveNotes(olderThan time.Duration) (int64, error)
+	GetNoteStats(viewerID uint, tz string) (domain.NoteStats, error)
 }
 
 type noteUsecase struct {
-	repo repository.NoteRepository
+	repo      repository.NoteRepository
+	config    Config
+	logger    logger.Logger
+	sanitizer *contentSanitizer
+	blocklist *contentBlocklist
 }
 
 func NewNoteUsecase(r repository.NoteRepository) *noteUsecase {
-	return &noteUsecase{repo: r}
+	return NewNoteUsecaseWithConfig(r, DefaultConfig())
+}
+
+func NewNoteUsecaseWithConfig(r repository.NoteRepository, cfg Config) *noteUsecase {
+	if cfg.Location == nil {
+		cfg.Location = time.UTC
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = logger.Default()
+	}
+	if cfg.Templates == nil {
+		cfg.Templates = template.Default()
+	}
+	if cfg.Publisher == nil {
+		cfg.Publisher = NopPublisher()
+	}
+	if cfg.ContentPolicy == "" {
+		cfg.ContentPolicy = ContentPolicyStripAll
+	}
+	if cfg.SlugPolicy == "" {
+		cfg.SlugPolicy = SlugPolicyPreserve
+	}
+	if cfg.DefaultMeetingDurationMinutes <= 0 {
+		cfg.DefaultMeetingDurationMinutes = DefaultMeetingDurationMinutes
+	}
+	if cfg.SummaryLength <= 0 {
+		cfg.SummaryLength = DefaultSummaryLength
+	}
+	if cfg.MaxListSize <= 0 {
+		cfg.MaxListSize = DefaultMaxListSize
+	}
+	return &noteUsecase{
+		repo:      r,
+		config:    cfg,
+		logger:    cfg.Logger,
+		sanitizer: newContentSanitizer(cfg.ContentPolicy),
+		blocklist: newContentBlocklist(cfg.BlockedTerms),
+	}
 }
 
 func (uc *noteUsecase) CreateNote(n *domain.Note) error {
-	if n.Title == "" {
-		return ErrEmptyTitle
+	return uc.CreateNoteWithOptions(n, CreateOptions{})
+}
+
+func (uc *noteUsecase) CreateNoteWithOptions(n *domain.Note, opts CreateOptions) error {
+	uc.applyTemplate(n, opts)
+	uc.defaultMeetingDate(n)
+	uc.defaultDuration(n)
+	if err := uc.validateNote(n, opts); err != nil {
+		return err
 	}
 
-	if n.Content == "" {
-		return ErrEmptyContent
+	if err := uc.checkCategoryQuota("create", n.OwnerID, n.Category, 1); err != nil {
+		if errors.Is(err, ErrCategoryQuotaExceeded) {
+			return err
+		}
+		return fmt.Errorf("failed to create note")
 	}
 
-	if err := uc.repo.Create(n); err != nil {
-		log.Println("Error creating note:", err)
+	if err := uc.createWithSlug(n); err != nil {
+		if errors.Is(err, repository.ErrDuplicateNote) {
+			uc.logger.Warn("duplicate note", "operation", "create", "title", n.Title, "meeting_date", n.MeetingDate)
+			return ErrDuplicateNote
+		}
+		uc.logger.Error("error creating note", "operation", "create", "error", err)
 		return fmt.Errorf("failed to create note")
 	}
 
-	log.Printf("Note (%d) created successfully", n.ID)
+	uc.setSummary(n)
+	uc.logger.Info("note created", "operation", "create", "note_id", n.ID)
+	uc.publish(domain.NoteCreated, *n)
+	uc.audit(domain.AuditCreate, n.ID, n.OwnerID, nil, n)
 	return nil
 }
 
-func (uc *noteUsecase) GetAllNotes() ([]domain.Note, error) {
-	notes, err := uc.repo.GetAll()
+// applyTemplate fills n's content from its category's template when
+// opts.UseTemplate is set and the content would otherwise be empty. It runs
+// before validation, so the empty-content check only fails when there is no
+// template to fall back on. Content that is nothing but markup the sanitizer
+// strips counts as empty.
+func (uc *noteUsecase) applyTemplate(n *domain.Note, opts CreateOptions) {
+	if !opts.UseTemplate || strings.TrimSpace(uc.sanitizer.sanitize(n.Content)) != "" {
+		return
+	}
+	if body, ok := uc.config.Templates.Get(n.Category); ok {
+		n.Content = body
+	}
+}
+
+// checkCategoryQuota returns ErrCategoryQuotaExceeded when adding notes to
+// the owner's category would take it over its configured quota. Quotas are
+// per owner, so other users' notes never count. adding is how many notes are
+// about to join the category, counting any already waiting to be stored.
+// Any other error is from counting the notes and has been logged.
+func (uc *noteUsecase) checkCategoryQuota(operation string, ownerID uint, category string, adding int64) error {
+	quota, ok := uc.categoryQuota(category)
+	if !ok {
+		return nil
+	}
+
+	counts, err := uc.repo.CountByCategory(ownerID)
 	if err != nil {
-		log.Println("Error retrieving all notes:", err)
-		return nil, fmt.Errorf("failed to get notes")
+		uc.logger.Error("error counting notes for category quota", "operation", operation, "category", category, "error", err)
+		return err
 	}
 
-	sort.Slice(notes, func(i, j int) bool {
+	count := categoryCount(counts, category) + adding
+	if count > int64(quota) {
+		uc.logger.Warn("category quota reached", "operation", operation, "category", category, "quota", quota, "count", count)
+		return ErrCategoryQuotaExceeded
+	}
+	return nil
+}
+
+// categoryCount adds up the counts of category, ignoring case. Categories are
+// grouped as stored, so "standup" and "Standup" are counted separately.
+func categoryCount(counts []domain.CategoryCount, category string) int64 {
+	var count int64
+	for _, c := range counts {
+		if strings.EqualFold(c.Category, category) {
+			count += c.Count
+		}
+	}
+	return count
+}
+
+// categoryQuota looks up the quota for category, ignoring case.
+func (uc *noteUsecase) categoryQuota(category string) (int, bool) {
+	category = strings.TrimSpace(category)
+	if category == "" {
+		return 0, false
+	}
+	for name, quota := range uc.config.CategoryQuotas {
+		if strings.EqualFold(strings.TrimSpace(name), category) {
+			return quota, true
+		}
+	}
+	return 0, false
+}
+
+// DuplicateNote creates a new note from one of the owner's notes, titled
+// "Copy of <title>" and dated today. The copy gets its own ID, timestamps and
+// version and never inherits an ExternalID; the original is not modified.
+func (uc *noteUsecase) DuplicateNote(id, ownerID uint) (domain.Note, error) {
+	original, err := uc.ownedNote(id, ownerID)
+	if err != nil {
+		if errors.Is(err, ErrNoteNotFound) {
+			uc.logger.Warn("note to duplicate not found", "operation", "duplicate", "note_id", id)
+			return domain.Note{}, ErrNoteNotFound
+		}
+		return domain.Note{}, fmt.Errorf("failed to duplicate note")
+	}
+
+	duplicate := domain.Note{
+		OwnerID:         original.OwnerID,
+		Title:           "Copy of " + original.Title,
+		Content:         original.Content,
+		Category:        original.Category,
+		Format:          original.Format,
+		Priority:        original.Priority,
+		Visibility:      original.Visibility,
+		MeetingDate:     time.Now(),
+		DurationMinutes: original.DurationMinutes,
+		Location:        original.Location,
+		MeetingURL:      original.MeetingURL,
+	}
+
+	if err := uc.CreateNote(&duplicate); err != nil {
+		return domain.Note{}, err
+	}
+
+	uc.logger.Info("note duplicated", "operation", "duplicate", "note_id", id, "duplicate_id", duplicate.ID)
+	return duplicate, nil
+}
+
+// UpsertNote creates or replaces the note with n.ExternalID so imports can be
+// retried safely. Without an ExternalID it is a plain CreateNote. Imported
+// notes are often historical, so the meeting date window is not enforced.
+func (uc *noteUsecase) UpsertNote(n *domain.Note) error {
+	if n.ExternalID == "" {
+		return uc.CreateNote(n)
+	}
+
+	uc.defaultMeetingDate(n)
+	uc.defaultDuration(n)
+	if err := uc.validateNote(n, CreateOptions{Force: true}); err != nil {
+		return err
+	}
+
+	if err := uc.checkUpsertQuota(n); err != nil {
+		if errors.Is(err, ErrCategoryQuotaExceeded) {
+			return err
+		}
+		return fmt.Errorf("failed to upsert note")
+	}
+
+	// The slug is only used if this inserts a new note; an existing one
+	// keeps its own.
+	slug, err := uc.uniqueSlug(n.Title)
+	if err != nil {
+		uc.logger.Error("error generating slug", "operation", "upsert", "external_id", n.ExternalID, "error", err)
+		return fmt.Errorf("failed to upsert note")
+	}
+	n.Slug = slug
+
+	if err := uc.repo.Upsert(n); err != nil {
+		if errors.Is(err, repository.ErrDuplicateNote) {
+			uc.logger.Warn("duplicate note", "operation", "upsert", "title", n.Title, "meeting_date", n.MeetingDate)
+			return ErrDuplicateNote
+		}
+		uc.logger.Error("error upserting note", "operation", "upsert", "external_id", n.ExternalID, "error", err)
+		return fmt.Errorf("failed to upsert note")
+	}
+
+	uc.setSummary(n)
+	uc.logger.Info("note upserted", "operation", "upsert", "note_id", n.ID, "external_id", n.ExternalID)
+	if n.Version > 1 {
+		uc.publish(domain.NoteUpdated, *n)
+	} else {
+		uc.publish(domain.NoteCreated, *n)
+	}
+	return nil
+}
+
+// checkUpsertQuota checks n's category quota unless the upsert only rewrites
+// a note already counted there. A trashed note isn't counted anywhere and
+// stays trashed, so rewriting one never needs the check.
+func (uc *noteUsecase) checkUpsertQuota(n *domain.Note) error {
+	existing, err := uc.repo.GetByExternalID(n.OwnerID, n.ExternalID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return uc.checkCategoryQuota("upsert", n.OwnerID, n.Category, 1)
+	case err != nil:
+		uc.logger.Error("error loading note to upsert", "operation", "upsert", "external_id", n.ExternalID, "error", err)
+		return err
+	case existing.DeletedAt.Valid || strings.EqualFold(strings.TrimSpace(existing.Category), strings.TrimSpace(n.Category)):
+		return nil
+	}
+	return uc.checkCategoryQuota("upsert", n.OwnerID, n.Category, 1)
+}
+
+// DefaultMaxListSize is how many notes GetAllNotes returns at most when
+// Config.MaxListSize is unset.
+const DefaultMaxListSize = 1000
+
+// GetAllNotes returns the notes ownerID may read, newest meeting first: their
+// own and others' shared or public ones. Archived notes are left out unless
+// includeArchived is set. At most Config.MaxListSize notes are returned; the
+// bool reports whether there were more.
+func (uc *noteUsecase) GetAllNotes(ownerID uint, includeArchived bool) ([]domain.Note, bool, error) {
+	// One extra note tells a list that just fits from one that was cut off.
+	notes, err := uc.repo.GetAllVisible(ownerID, includeArchived, uc.config.MaxListSize+1)
+	if err != nil {
+		uc.logger.Error("error retrieving all notes", "operation", "get_all", "error", err)
+		return nil, false, fmt.Errorf("failed to get notes")
+	}
+
+	sort.SliceStable(notes, func(i, j int) bool {
 		return notes[i].MeetingDate.After(notes[j].MeetingDate)
 	})
 
-	log.Println("All notes retrieved successfully")
-	return notes, nil
+	truncated := len(notes) > uc.config.MaxListSize
+	if truncated {
+		notes = notes[:uc.config.MaxListSize]
+		uc.logger.Warn("note list truncated", "operation", "get_all", "max_list_size", uc.config.MaxListSize)
+	}
+
+	uc.logger.Info("all notes retrieved", "operation", "get_all", "count", len(notes), "truncated", truncated)
+	return uc.withSummaries(notes), truncated, nil
 }
 
-func (uc *noteUsecase) GetPaginatedNotes(limit, offset int) ([]domain.Note, error) {
-	notes, err := uc.repo.GetPaginated(limit, offset)
+// StreamNotes calls fn with each of the owner's notes in ID order without
+// loading them all at once. It stops at the first error from fn or the
+// repository; notes already passed to fn are not taken back.
+func (uc *noteUsecase) StreamNotes(ownerID uint, includeArchived bool, fn func(domain.Note) error) error {
+	count := 0
+	err := uc.repo.StreamByOwner(ownerID, includeArchived, func(n domain.Note) error {
+		if err := fn(n); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
 	if err != nil {
-		log.Println("Error retrieving paginated notes:", err)
+		uc.logger.Error("error streaming notes", "operation", "stream", "streamed", count, "error", err)
+		return fmt.Errorf("failed to stream notes")
+	}
+
+	uc.logger.Info("notes streamed", "operation", "stream", "count", count)
+	return nil
+}
+
+// GetPaginatedNotes returns limit of the notes viewerID may read, skipping
+// the first offset, newest meeting first. Archived notes are left out
+// unless includeArchived is set.
+func (uc *noteUsecase) GetPaginatedNotes(viewerID uint, limit, offset int, includeArchived bool) ([]domain.Note, error) {
+	notes, err := uc.repo.GetPaginated(viewerID, limit, offset, includeArchived)
+	if err != nil {
+		uc.logger.Error("error retrieving paginated notes", "operation", "get_paginated", "limit", limit, "offset", offset, "error", err)
 		return nil, fmt.Errorf("failed to get notes")
 	}
 
@@ -74,110 +386,773 @@ func (uc *noteUsecase) GetPaginatedNotes(limit, offset int) ([]domain.Note, erro
 		return notes[i].MeetingDate.After(notes[j].MeetingDate)
 	})
 
-	log.Println("Paginated notes retrieved successfully")
-	return notes, nil
+	uc.logger.Info("paginated notes retrieved", "operation", "get_paginated", "count", len(notes))
+	return uc.withSummaries(notes), nil
+}
+
+// GetNoteChanges returns up to limit of the owner's notes created, updated,
+// archived or deleted after cursor, oldest change first, for clients keeping
+// a copy in sync, and whether more changes follow. A limit of zero returns
+// every change. Deleted notes come back as tombstones. The UpdatedAt and ID
+// of the last change are the cursor for the next call.
+func (uc *noteUsecase) GetNoteChanges(ownerID uint, cursor domain.ChangeCursor, limit int) ([]domain.NoteChange, bool, error) {
+	if limit < 0 {
+		return nil, false, ErrInvalidPage
+	}
+
+	fetch := limit
+	if limit > 0 {
+		// One extra row says whether there is another page.
+		fetch = limit + 1
+	}
+	notes, err := uc.repo.GetModifiedSince(ownerID, cursor, fetch)
+	if err != nil {
+		uc.logger.Error("error retrieving note changes", "operation", "get_changes", "since", cursor.Since, "error", err)
+		return nil, false, fmt.Errorf("failed to get note changes")
+	}
+
+	more := limit > 0 && len(notes) > limit
+	if more {
+		notes = notes[:limit]
+	}
+
+	changes := make([]domain.NoteChange, 0, len(notes))
+	for _, n := range notes {
+		changes = append(changes, domain.NoteChange{Note: n, Deleted: n.DeletedAt.Valid})
+	}
+
+	uc.logger.Info("note changes retrieved", "operation", "get_changes", "since", cursor.Since, "count", len(changes), "more", more)
+	return changes, more, nil
+}
+
+// DefaultCursorLimit and MaxCursorLimit bound how many notes GetNotesAfterID
+// returns.
+const (
+	DefaultCursorLimit = 20
+	MaxCursorLimit     = 100
+)
+
+// GetNotesAfterID returns the next page of the notes viewerID may read after
+// the cursor afterID, in ID order: the same notes GetAllNotes lists. Pass the
+// ID of the last note returned as the next cursor; a cursor of zero starts
+// from the beginning. A limit of zero or less uses DefaultCursorLimit and
+// anything above MaxCursorLimit is capped.
+func (uc *noteUsecase) GetNotesAfterID(viewerID, afterID uint, limit int, includeArchived bool) ([]domain.Note, error) {
+	if limit <= 0 {
+		limit = DefaultCursorLimit
+	}
+	if limit > MaxCursorLimit {
+		limit = MaxCursorLimit
+	}
+
+	notes, err := uc.repo.GetAfterID(viewerID, afterID, limit, includeArchived)
+	if err != nil {
+		uc.logger.Error("error retrieving notes after cursor", "operation", "get_after_id", "after_id", afterID, "limit", limit, "error", err)
+		return nil, fmt.Errorf("failed to get notes")
+	}
+
+	if notes == nil {
+		notes = []domain.Note{}
+	}
+
+	uc.logger.Info("notes after cursor retrieved", "operation", "get_after_id", "after_id", afterID, "count", len(notes))
+	return uc.withSummaries(notes), nil
+}
+
+// DefaultRecentLimit and MaxRecentLimit bound how many notes GetRecentNotes
+// returns.
+const (
+	DefaultRecentLimit = 5
+	MaxRecentLimit     = 50
+)
+
+// GetRecentNotes returns the most recently created notes viewerID may read,
+// newest first. A limit of zero or less uses DefaultRecentLimit and anything
+// above MaxRecentLimit is capped.
+func (uc *noteUsecase) GetRecentNotes(viewerID uint, limit int) ([]domain.Note, error) {
+	if limit <= 0 {
+		limit = DefaultRecentLimit
+	}
+	if limit > MaxRecentLimit {
+		limit = MaxRecentLimit
+	}
+
+	notes, err := uc.repo.GetRecent(viewerID, limit)
+	if err != nil {
+		uc.logger.Error("error retrieving recent notes", "operation", "get_recent", "limit", limit, "error", err)
+		return nil, fmt.Errorf("failed to get notes")
+	}
+
+	if notes == nil {
+		notes = []domain.Note{}
+	}
+
+	uc.logger.Info("recent notes retrieved", "operation", "get_recent", "count", len(notes))
+	return uc.withSummaries(notes), nil
+}
+
+// GetNoteByID returns the note only if ownerID may read it. Other notes are
+// reported as not found so their existence is not leaked.
+func (uc *noteUsecase) GetNoteByID(id, ownerID uint) (domain.Note, error) {
+	return uc.findNote(id, ownerID, domain.Note.VisibleTo)
+}
+
+// ownedNote is GetNoteByID for changes and owner-only views: it finds only
+// ownerID's own notes, whatever their visibility.
+func (uc *noteUsecase) ownedNote(id, ownerID uint) (domain.Note, error) {
+	return uc.findNote(id, ownerID, func(n domain.Note, userID uint) bool {
+		return n.OwnerID == userID
+	})
+}
+
+// requireOwnedNote is ownedNote for callers that only need to know the note
+// is there: it checks existence and ownership without loading the row.
+func (uc *noteUsecase) requireOwnedNote(id, ownerID uint) error {
+	ok, err := uc.repo.Exists(id, ownerID)
+	if err != nil {
+		uc.logger.Error("error checking note exists", "operation", "exists", "note_id", id, "error", err)
+		return fmt.Errorf("failed to retrieve note")
+	}
+	if !ok {
+		return ErrNoteNotFound
+	}
+	return nil
 }
 
-func (uc *noteUsecase) GetNoteByID(id uint) (domain.Note, error) {
+// findNote loads the note with the given id if allowed says ownerID may have
+// it, and reports it as not found otherwise.
+func (uc *noteUsecase) findNote(id, ownerID uint, allowed func(n domain.Note, userID uint) bool) (domain.Note, error) {
 	note, err := uc.repo.GetByID(id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return domain.Note{}, ErrNoteNotFound
 		}
-		log.Printf("Error retrieving note with ID(%d): %v", id, err)
+		uc.logger.Error("error retrieving note", "operation", "get_by_id", "note_id", id, "error", err)
 		return domain.Note{}, fmt.Errorf("failed to retrieve note")
 	}
 
-	log.Printf("Note (%d) retrieved successfully", note.ID)
+	if !allowed(note, ownerID) {
+		uc.logger.Warn("note not available to caller", "operation", "get_by_id", "note_id", id, "owner_id", ownerID)
+		return domain.Note{}, ErrNoteNotFound
+	}
+
+	uc.logger.Info("note retrieved", "operation", "get_by_id", "note_id", note.ID)
+	uc.setSummary(&note)
 	return note, nil
 }
 
+// GetNotesByIDs looks up several notes at once and returns them in the order
+// they were requested. IDs that don't exist or that ownerID may not read are
+// left out, and repeated IDs are returned once.
+func (uc *noteUsecase) GetNotesByIDs(ids []uint, ownerID uint) ([]domain.Note, error) {
+	if len(ids) == 0 {
+		return nil, ErrNoIDs
+	}
+
+	found, err := uc.repo.GetByIDs(ids)
+	if err != nil {
+		uc.logger.Error("error retrieving notes by id", "operation", "get_by_ids", "note_ids", ids, "error", err)
+		return nil, fmt.Errorf("failed to get notes")
+	}
+
+	byID := make(map[uint]domain.Note, len(found))
+	for _, note := range found {
+		if note.VisibleTo(ownerID) {
+			byID[note.ID] = note
+		}
+	}
+
+	notes := make([]domain.Note, 0, len(byID))
+	for _, id := range ids {
+		if note, ok := byID[id]; ok {
+			notes = append(notes, note)
+			delete(byID, id)
+		}
+	}
+
+	uc.logger.Info("notes retrieved by id", "operation", "get_by_ids", "requested", len(ids), "count", len(notes))
+	return uc.withSummaries(notes), nil
+}
+
 func (uc *noteUsecase) UpdateNote(n *domain.Note) error {
-	existingNote, err := uc.GetNoteByID(n.ID)
+	existingNote, err := uc.ownedNote(n.ID, n.OwnerID)
 	if err != nil {
-		log.Println("Error retrieving note while trying to update note:", err)
-		return ErrNoteNotFound
+		if errors.Is(err, ErrNoteNotFound) {
+			uc.logger.Warn("note to update not found", "operation", "update", "note_id", n.ID)
+			return ErrNoteNotFound
+		}
+		return fmt.Errorf("failed to update note")
+	}
+
+	if n.MeetingDate.IsZero() && uc.config.DefaultMeetingDateToNow {
+		n.MeetingDate = existingNote.MeetingDate
+	}
+	// An update that doesn't name a format, priority, visibility or duration
+	// keeps the note's current one.
+	if strings.TrimSpace(n.Format) == "" {
+		n.Format = existingNote.Format
+	}
+	if strings.TrimSpace(n.Priority) == "" {
+		n.Priority = existingNote.Priority
+	}
+	if strings.TrimSpace(n.Visibility) == "" {
+		n.Visibility = existingNote.Visibility
+	}
+	if n.DurationMinutes == 0 {
+		n.DurationMinutes = existingNote.DurationMinutes
+		uc.defaultDuration(n)
 	}
 
-	if n.Title == "" {
-		return ErrEmptyTitle
+	// Updates are not held to the meeting date window, only to the field
+	// and weekday rules.
+	if err := uc.validateNote(n, CreateOptions{Force: true}); err != nil {
+		return err
 	}
 
-	if n.Content == "" {
-		return ErrEmptyContent
+	if n.Version != existingNote.Version {
+		uc.logger.Warn("stale note update", "operation", "update", "note_id", n.ID, "version", n.Version, "stored_version", existingNote.Version)
+		return ErrStaleUpdate
 	}
 
+	// A note moving into a capped category counts against it; one staying
+	// put is already counted.
+	if !strings.EqualFold(strings.TrimSpace(n.Category), strings.TrimSpace(existingNote.Category)) {
+		if err := uc.checkCategoryQuota("update", n.OwnerID, n.Category, 1); err != nil {
+			if errors.Is(err, ErrCategoryQuotaExceeded) {
+				return err
+			}
+			return fmt.Errorf("failed to update note")
+		}
+	}
+
+	before := existingNote
 	existingNote.Title = n.Title
 	existingNote.Content = n.Content
 	existingNote.Category = n.Category
 	existingNote.MeetingDate = n.MeetingDate
+	existingNote.Format = n.Format
+	existingNote.Priority = n.Priority
+	existingNote.Visibility = n.Visibility
+	existingNote.DurationMinutes = n.DurationMinutes
+	existingNote.Location = n.Location
+	existingNote.MeetingURL = n.MeetingURL
+	existingNote.SetEndTime()
+	if err := uc.refreshSlug(&existingNote, n.Title); err != nil {
+		uc.logger.Error("error generating slug", "operation", "update", "note_id", n.ID, "error", err)
+		return fmt.Errorf("failed to update note")
+	}
 
 	err = uc.repo.Update(&existingNote)
 	if err != nil {
-		log.Printf("Error updating note with ID(%d): %v", n.ID, err)
+		if errors.Is(err, repository.ErrVersionConflict) {
+			uc.logger.Warn("stale note update", "operation", "update", "note_id", n.ID, "version", n.Version)
+			return ErrStaleUpdate
+		}
+		if errors.Is(err, repository.ErrDuplicateNote) {
+			uc.logger.Warn("duplicate note", "operation", "update", "note_id", n.ID)
+			return ErrDuplicateNote
+		}
+		uc.logger.Error("error updating note", "operation", "update", "note_id", n.ID, "error", err)
 		return fmt.Errorf("failed to update note")
 	}
 
-	log.Printf("Note (%d) updated successfully", n.ID)
+	// n may have been decoded from a request body, so replace anything the
+	// client can't change with what is stored.
+	n.Version = existingNote.Version
+	n.Slug = existingNote.Slug
+	n.CreatedAt = existingNote.CreatedAt
+	n.EndTime = existingNote.EndTime
+	uc.setSummary(n, &existingNote)
+
+	uc.logger.Info("note updated", "operation", "update", "note_id", n.ID)
+	uc.publish(domain.NoteUpdated, existingNote)
+	uc.audit(domain.AuditUpdate, n.ID, n.OwnerID, &before, &existingNote)
 	return nil
 }
 
-func (uc *noteUsecase) DeleteNote(id uint) error {
-	if _, err := uc.GetNoteByID(id); err != nil {
-		log.Println("Error: Tried to delete non-existing note with ID:", id)
+func (uc *noteUsecase) DeleteNote(id, ownerID uint) error {
+	note, err := uc.ownedNote(id, ownerID)
+	if err != nil {
+		uc.logger.Warn("note to delete not found", "operation", "delete", "note_id", id)
 		return ErrNoteNotFound
 	}
 
-	err := uc.repo.Delete(id)
+	err = uc.repo.Delete(id)
 	if err != nil {
-		log.Println("Error deleting note:", err)
+		uc.logger.Error("error deleting note", "operation", "delete", "note_id", id, "error", err)
 		return fmt.Errorf("failed to delete note")
 	}
 
-	log.Println("Note deleted successfully")
+	uc.logger.Info("note deleted", "operation", "delete", "note_id", id)
+	uc.publish(domain.NoteDeleted, note)
+	if uc.config.HardDelete {
+		uc.audit(domain.AuditDelete, id, ownerID, nil, nil)
+	} else {
+		uc.audit(domain.AuditDelete, id, ownerID, &note, nil)
+	}
 	return nil
 }
 
-func (uc *noteUsecase) SearchNotesByKeyword(keyword string) ([]domain.Note, error) {
+// ArchiveNote archives or unarchives one of the owner's notes. Archiving is
+// separate from deletion; an archived note can still be fetched by ID,
+// updated and deleted.
+func (uc *noteUsecase) ArchiveNote(id, ownerID uint, archived bool) error {
+	if err := uc.requireOwnedNote(id, ownerID); err != nil {
+		if errors.Is(err, ErrNoteNotFound) {
+			uc.logger.Warn("note to archive not found", "operation", "archive", "note_id", id)
+			return ErrNoteNotFound
+		}
+		return fmt.Errorf("failed to archive note")
+	}
+
+	if err := uc.repo.SetArchived(id, archived); err != nil {
+		uc.logger.Error("error archiving note", "operation", "archive", "note_id", id, "error", err)
+		return fmt.Errorf("failed to archive note")
+	}
+
+	uc.logger.Info("note archive state changed", "operation", "archive", "note_id", id, "archived", archived)
+	return nil
+}
+
+// SearchNotesByKeyword returns one page of the matching notes viewerID may
+// read held within dates, newest meeting first, and the total number of
+// matches across all pages.
+func (uc *noteUsecase) SearchNotesByKeyword(viewerID uint, keyword string, dates domain.DateRange, page domain.Page) ([]domain.SearchResult, int64, error) {
 	if strings.TrimSpace(keyword) == "" {
-		return nil, fmt.Errorf("search keyword cannot be empty")
+		return nil, 0, fmt.Errorf("search keyword cannot be empty")
+	}
+
+	if page.Limit < 0 || page.Offset < 0 {
+		return nil, 0, ErrInvalidPage
+	}
+
+	if err := validateFilterRanges(domain.NoteFilter{FromDate: dates.From, ToDate: dates.To}); err != nil {
+		return nil, 0, err
 	}
 
-	searchResult, err := uc.repo.Search(keyword)
+	notes, total, err := uc.repo.Search(viewerID, keyword, dates, page)
 	if err != nil {
-		log.Printf("Error searching for notes with keyword (%s): %v", keyword, err)
-		return nil, fmt.Errorf("failed to find notes")
+		uc.logger.Error("error searching notes", "operation", "search", "keyword", keyword, "error", err)
+		return nil, 0, fmt.Errorf("failed to find notes")
 	}
 
-	sort.Slice(searchResult, func(i, j int) bool {
-		return searchResult[i].MeetingDate.After(searchResult[j].MeetingDate)
-	})
+	if total == 0 && uc.config.FuzzySearchThreshold > 0 {
+		return uc.fuzzySearch(viewerID, keyword, dates, page)
+	}
+
+	searchResult := make([]domain.SearchResult, 0, len(notes))
+	for _, note := range uc.withSummaries(notes) {
+		searchResult = append(searchResult, domain.SearchResult{
+			Note:    note,
+			Snippet: buildSnippet(note.Title, note.Content, keyword),
+		})
+	}
 
-	log.Println("Successful Search")
-	return searchResult, nil
+	uc.logger.Info("search completed", "operation", "search", "count", len(searchResult), "total", total)
+	return searchResult, total, nil
 }
 
-func (uc *noteUsecase) FilterNotes(filter domain.NoteFilter) ([]domain.Note, error) {
+// fuzzySearch finds notes similar to keyword, most similar first, for when it
+// matched nothing exactly. Fuzzy search is a fallback, so if it fails the
+// empty exact result is returned instead.
+func (uc *noteUsecase) fuzzySearch(viewerID uint, keyword string, dates domain.DateRange, page domain.Page) ([]domain.SearchResult, int64, error) {
+	notes, total, err := uc.repo.FuzzySearch(viewerID, keyword, uc.config.FuzzySearchThreshold, dates, page)
+	if err != nil {
+		uc.logger.Warn("fuzzy search failed", "operation", "search", "keyword", keyword, "error", err)
+		return []domain.SearchResult{}, 0, nil
+	}
+
+	searchResult := make([]domain.SearchResult, 0, len(notes))
+	for _, note := range uc.withSummaries(notes) {
+		searchResult = append(searchResult, domain.SearchResult{Note: note, Fuzzy: true})
+	}
+
+	uc.logger.Info("fuzzy search completed", "operation", "search", "count", len(searchResult), "total", total)
+	return searchResult, total, nil
+}
+
+// FilterNotes returns one page of matching notes, newest meeting first, and
+// the total number of matches across all pages.
+func (uc *noteUsecase) FilterNotes(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error) {
+	if err := uc.prepareFilter(&filter); err != nil {
+		return nil, 0, err
+	}
+
+	if page.Limit < 0 || page.Offset < 0 {
+		return nil, 0, ErrInvalidPage
+	}
+
+	filterResults, total, err := uc.repo.Filter(filter, page)
+	if err != nil {
+		uc.logger.Error("error filtering notes", "operation", "filter", "error", err)
+		return nil, 0, fmt.Errorf("failed to filter notes")
+	}
+
+	uc.logger.Info("filter completed", "operation", "filter", "count", len(filterResults), "total", total)
+	return uc.withSummaries(filterResults), total, nil
+}
+
+// CountFilteredNotes returns how many notes FilterNotes would match across
+// all pages, without loading them.
+func (uc *noteUsecase) CountFilteredNotes(filter domain.NoteFilter) (int64, error) {
+	if err := uc.prepareFilter(&filter); err != nil {
+		return 0, err
+	}
+
+	count, err := uc.repo.CountFiltered(filter)
+	if err != nil {
+		uc.logger.Error("error counting filtered notes", "operation", "filter_count", "error", err)
+		return 0, fmt.Errorf("failed to count notes")
+	}
+
+	uc.logger.Info("filter counted", "operation", "filter_count", "total", count)
+	return count, nil
+}
+
+// prepareFilter trims the keywords and categories, drops blank ones, checks
+// the keyword match, meeting mode and sort order and turns a year and month
+// into a date range, then checks the date ranges.
+func (uc *noteUsecase) prepareFilter(filter *domain.NoteFilter) error {
 	filter.Keyword = strings.TrimSpace(filter.Keyword)
 
-	filter.Category = strings.TrimSpace(filter.Category)
+	var keywords []string
+	for _, keyword := range filter.Keywords {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	filter.Keywords = keywords
+
+	switch filter.KeywordMatch = strings.ToLower(strings.TrimSpace(filter.KeywordMatch)); filter.KeywordMatch {
+	case "":
+		filter.KeywordMatch = domain.KeywordMatchAny
+	case domain.KeywordMatchAny, domain.KeywordMatchAll:
+	default:
+		return ErrInvalidKeywordMatch
+	}
 
-	if filter.FromDate != nil && filter.ToDate != nil {
-		if filter.FromDate.After(*filter.ToDate) {
-			return nil, fmt.Errorf("fromDate must be before toDate")
+	switch filter.MeetingMode = strings.ToLower(strings.TrimSpace(filter.MeetingMode)); filter.MeetingMode {
+	case "", domain.MeetingModeRemote, domain.MeetingModeInPerson:
+	default:
+		return ErrInvalidMeetingMode
+	}
+
+	if filter.Priority = strings.ToLower(strings.TrimSpace(filter.Priority)); filter.Priority != "" && domain.PriorityRank(filter.Priority) < 0 {
+		return ErrInvalidPriority
+	}
+
+	if err := prepareSort(filter); err != nil {
+		return err
+	}
+
+	categories := make([]string, 0, len(filter.Categories))
+	for _, category := range filter.Categories {
+		if category = strings.TrimSpace(category); category != "" {
+			categories = append(categories, category)
 		}
 	}
+	filter.Categories = categories
+
+	if err := uc.applyYearMonth(filter); err != nil {
+		return err
+	}
+	return validateFilterRanges(*filter)
+}
+
+// prepareSort checks the filter's sort field and direction and fills in the
+// defaults: meeting date when no field is given, and then newest first for
+// dates and A to Z for titles when no direction is.
+func prepareSort(filter *domain.NoteFilter) error {
+	switch filter.Sort = strings.ToLower(strings.TrimSpace(filter.Sort)); filter.Sort {
+	case "":
+		filter.Sort = domain.SortMeetingDate
+	case domain.SortMeetingDate, domain.SortCreatedAt, domain.SortUpdatedAt, domain.SortTitle, domain.SortPriority:
+	default:
+		return ErrInvalidSort
+	}
 
-	filterResults, err := uc.repo.Filter(filter)
+	switch filter.Order = strings.ToLower(strings.TrimSpace(filter.Order)); filter.Order {
+	case "":
+		filter.Order = domain.SortDesc
+		if filter.Sort == domain.SortTitle {
+			filter.Order = domain.SortAsc
+		}
+	case domain.SortAsc, domain.SortDesc:
+	default:
+		return ErrInvalidSortOrder
+	}
+	return nil
+}
+
+// RestoreNotes takes the owner's notes with the given IDs out of the trash.
+// IDs of other owners' notes are skipped, as are unknown ones.
+func (uc *noteUsecase) RestoreNotes(ownerID uint, ids []uint) (int64, error) {
+	if len(ids) == 0 {
+		return 0, ErrNoIDs
+	}
+
+	restored, err := uc.repo.RestoreNotes(ownerID, ids)
 	if err != nil {
-		log.Printf("Error filtering for notes: %v", err)
-		return nil, fmt.Errorf("failed to filter notes")
+		uc.logger.Error("error restoring notes", "operation", "restore", "note_ids", ids, "error", err)
+		return 0, fmt.Errorf("failed to restore notes")
 	}
 
-	sort.Slice(filterResults, func(i, j int) bool {
-		return filterResults[i].MeetingDate.After(filterResults[j].MeetingDate)
-	})
+	uc.logger.Info("notes restored", "operation", "restore", "requested", len(ids), "restored", restored)
+	return restored, nil
+}
+
+// RestoreDeletedSince restores every note of the owner moved to the trash
+// after since, to undo an accidental bulk delete. Notes trashed earlier, and
+// other owners' notes, are left alone.
+func (uc *noteUsecase) RestoreDeletedSince(ownerID uint, since time.Time) (int64, error) {
+	if since.After(time.Now()) {
+		return 0, ErrFutureWindow
+	}
+
+	restored, err := uc.repo.RestoreDeletedSince(ownerID, since)
+	if err != nil {
+		uc.logger.Error("error restoring deleted notes", "operation", "restore_since", "deleted_after", since, "error", err)
+		return 0, fmt.Errorf("failed to restore notes")
+	}
 
-	log.Println("Successful Filter")
-	return filterResults, nil
+	uc.logger.Info("deleted notes restored", "operation", "restore_since", "deleted_after", since, "restored", restored)
+	return restored, nil
+}
+
+// RenameCategory moves the owner's notes in category from to category to.
+// Other owners' notes keep their category.
+func (uc *noteUsecase) RenameCategory(ownerID uint, from, to string) (int64, error) {
+	from = strings.TrimSpace(from)
+	to = strings.TrimSpace(to)
+
+	if from == "" || to == "" {
+		return 0, ErrEmptyCategory
+	}
+
+	if from == to {
+		return 0, ErrSameCategory
+	}
+
+	to, ok := uc.allowedCategory(to)
+	if !ok {
+		uc.logger.Warn("rename to a category that isn't allowed", "operation", "rename_category", "from", from, "to", to)
+		return 0, ErrInvalidCategory
+	}
+
+	if err := uc.checkRenameQuota(ownerID, from, to); err != nil {
+		if errors.Is(err, ErrCategoryQuotaExceeded) {
+			return 0, err
+		}
+		return 0, fmt.Errorf("failed to rename category")
+	}
+
+	renamed, err := uc.repo.RenameCategory(ownerID, from, to)
+	if err != nil {
+		uc.logger.Error("error renaming category", "operation", "rename_category", "from", from, "to", to, "error", err)
+		return 0, fmt.Errorf("failed to rename category")
+	}
+
+	uc.logger.Info("category renamed", "operation", "rename_category", "from", from, "to", to, "renamed", renamed)
+	return renamed, nil
+}
+
+// checkRenameQuota checks that moving the owner's notes in from into to
+// keeps to within its quota. Renaming only the case of a category moves
+// nothing between quotas.
+func (uc *noteUsecase) checkRenameQuota(ownerID uint, from, to string) error {
+	if strings.EqualFold(from, to) {
+		return nil
+	}
+	if _, ok := uc.categoryQuota(to); !ok {
+		return nil
+	}
+
+	counts, err := uc.repo.CountByCategory(ownerID)
+	if err != nil {
+		uc.logger.Error("error counting notes for category quota", "operation", "rename_category", "category", from, "error", err)
+		return err
+	}
+
+	// RenameCategory matches from exactly, so only that spelling moves.
+	var moving int64
+	for _, c := range counts {
+		if c.Category == from {
+			moving = c.Count
+		}
+	}
+	if moving == 0 {
+		return nil
+	}
+	return uc.checkCategoryQuota("rename_category", ownerID, to, moving)
+}
+
+func (uc *noteUsecase) GetNoteExtremes(viewerID uint) (domain.NoteExtremes, error) {
+	extremes, err := uc.repo.Extremes(viewerID)
+	if err != nil {
+		uc.logger.Error("error retrieving note extremes", "operation", "extremes", "error", err)
+		return domain.NoteExtremes{}, fmt.Errorf("failed to get note extremes")
+	}
+
+	uc.logger.Info("note extremes retrieved", "operation", "extremes")
+	return extremes, nil
+}
+
+func (uc *noteUsecase) GetCategories(viewerID uint) ([]domain.CategoryCount, error) {
+	categories, err := uc.repo.DistinctCategories(viewerID)
+	if err != nil {
+		uc.logger.Error("error retrieving categories", "operation", "categories", "error", err)
+		return nil, fmt.Errorf("failed to get categories")
+	}
+
+	uc.logger.Info("categories retrieved", "operation", "categories", "count", len(categories))
+	return categories, nil
+}
+
+// PurgeDeletedNotes permanently removes notes that were soft-deleted more
+// than olderThan ago.
+func (uc *noteUsecase) PurgeDeletedNotes(olderThan time.Duration) (int64, error) {
+	if olderThan < 0 {
+		return 0, ErrInvalidAge
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	purged, err := uc.repo.PurgeDeleted(cutoff)
+	if err != nil {
+		uc.logger.Error("error purging deleted notes", "operation", "purge", "cutoff", cutoff, "error", err)
+		return 0, fmt.Errorf("failed to purge deleted notes")
+	}
+
+	uc.logger.Info("deleted notes purged", "operation", "purge", "cutoff", cutoff, "purged", purged)
+	return purged, nil
+}
+
+// AutoArchiveNotes archives notes whose meeting was more than olderThan ago.
+// Notes that are already archived are left alone.
+func (uc *noteUsecase) AutoArchiveNotes(olderThan time.Duration) (int64, error) {
+	if olderThan < 0 {
+		return 0, ErrInvalidArchiveAge
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	archived, err := uc.repo.ArchiveOlderThan(cutoff)
+	if err != nil {
+		uc.logger.Error("error auto-archiving notes", "operation", "auto_archive", "cutoff", cutoff, "error", err)
+		return 0, fmt.Errorf("failed to archive old notes")
+	}
+
+	uc.logger.Info("old notes archived", "operation", "auto_archive", "cutoff", cutoff, "archived", archived)
+	return archived, nil
+}
+
+// GetNoteStats summarises the live notes viewerID may read, grouping
+// meetings by calendar month in the IANA timezone tz. An empty tz means the
+// configured timezone.
+func (uc *noteUsecase) GetNoteStats(viewerID uint, tz string) (domain.NoteStats, error) {
+	loc, err := timeutil.LoadLocation(tz, uc.config.Location)
+	if err != nil {
+		return domain.NoteStats{}, ErrInvalidTimezone
+	}
+
+	stats, err := uc.repo.Stats(viewerID, loc)
+	if err != nil {
+		uc.logger.Error("error retrieving note stats", "operation", "stats", "error", err)
+		return domain.NoteStats{}, fmt.Errorf("failed to get note stats")
+	}
+
+	uc.logger.Info("note stats retrieved", "operation", "stats", "total", stats.Total)
+	return stats, nil
+}
+
+// applyYearMonth turns a filter's Year and Month into the meeting date range
+// they stand for, in the filter's timezone or else the configured one.
+func (uc *noteUsecase) applyYearMonth(filter *domain.NoteFilter) error {
+	loc, err := timeutil.LoadLocation(filter.Timezone, uc.config.Location)
+	if err != nil {
+		return ErrInvalidTimezone
+	}
+	if filter.Year == 0 && filter.Month == 0 {
+		return nil
+	}
+
+	switch {
+	case filter.FromDate != nil || filter.ToDate != nil:
+		return ErrYearWithDateRange
+	case filter.Year == 0:
+		return ErrMonthWithoutYear
+	case filter.Year < 1900 || filter.Year > 9999:
+		return ErrInvalidYear
+	case filter.Month < 0 || filter.Month > 12:
+		return ErrInvalidMonth
+	}
+
+	from := time.Date(filter.Year, time.January, 1, 0, 0, 0, 0, loc)
+	to := from.AddDate(1, 0, 0)
+	if filter.Month != 0 {
+		from = time.Date(filter.Year, time.Month(filter.Month), 1, 0, 0, 0, 0, loc)
+		to = from.AddDate(0, 1, 0)
+	}
+	// ToDate is inclusive. Postgres keeps microseconds, so this is the last
+	// instant it can store before the next period starts.
+	to = to.Add(-time.Microsecond)
+
+	filter.FromDate = &from
+	filter.ToDate = &to
+	return nil
+}
+
+// validateFilterRanges rejects a meeting date or created date range whose
+// start is after its end. Open-ended ranges are always valid.
+func validateFilterRanges(filter domain.NoteFilter) error {
+	if filter.FromDate != nil && filter.ToDate != nil && filter.FromDate.After(*filter.ToDate) {
+		return ErrInvalidDateRange
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && filter.CreatedFrom.After(*filter.CreatedTo) {
+		return ErrInvalidCreatedRange
+	}
+	return nil
+}
+
+// checkMeetingWeekday applies the configured weekend rule to the note's
+// MeetingDate, either rejecting it or attaching a non-blocking warning.
+func (uc *noteUsecase) checkMeetingWeekday(n *domain.Note) error {
+	if uc.config.WeekendMeetingRule == WeekendRuleOff || n.MeetingDate.IsZero() {
+		return nil
+	}
+
+	switch n.MeetingDate.In(uc.config.Location).Weekday() {
+	case time.Saturday, time.Sunday:
+	default:
+		return nil
+	}
+
+	if uc.config.WeekendMeetingRule == WeekendRuleReject {
+		return ErrWeekendMeetingDate
+	}
+
+	n.Warnings = append(n.Warnings, ErrWeekendMeetingDate.Error())
+	return nil
+}
+
+// checkMeetingDateWindow rejects meeting dates outside the configured window
+// around now. An unconfigured window allows any date.
+func (uc *noteUsecase) checkMeetingDateWindow(n *domain.Note) error {
+	if uc.config.MeetingDatePastWindow <= 0 && uc.config.MeetingDateFutureWindow <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	windowErr := &MeetingDateWindowError{MeetingDate: n.MeetingDate}
+	if uc.config.MeetingDatePastWindow > 0 {
+		windowErr.Earliest = now.Add(-uc.config.MeetingDatePastWindow)
+	}
+	if uc.config.MeetingDateFutureWindow > 0 {
+		windowErr.Latest = now.Add(uc.config.MeetingDateFutureWindow)
+	}
+
+	if n.MeetingDate.IsZero() ||
+		(!windowErr.Earliest.IsZero() && n.MeetingDate.Before(windowErr.Earliest)) ||
+		(!windowErr.Latest.IsZero() && n.MeetingDate.After(windowErr.Latest)) {
+		return windowErr
+	}
+	return nil
 }
@@ -2,11 +2,18 @@ package usecase_test
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/repository"
+	"github.com/jt00721/meeting-notes-manager/internal/repository/memory"
+	"github.com/jt00721/meeting-notes-manager/internal/template"
 	"github.com/jt00721/meeting-notes-manager/internal/usecase"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -14,14 +21,48 @@ import (
 
 type mockNoteRepository struct {
 	notes       []domain.Note
+	trash       []domain.Note
+	revisions   []domain.NoteRevision
+	attachments []domain.Attachment
+	links       []domain.NoteLink
 	forceDBFail bool
+	// fuzzy is what FuzzySearch returns, standing in for trigram matching.
+	// fuzzyThreshold records the threshold it was called with.
+	fuzzy          []domain.Note
+	fuzzyFail      bool
+	fuzzyThreshold float64
+	purgeCutoff    time.Time
+	archiveCutoff  time.Time
+	// updated records every note passed to Update.
+	updated []domain.Note
+	// batches records the size of every CreateBatch call; batchFail makes
+	// them fail. createFail makes Create fail.
+	batches    []int
+	batchFail  bool
+	createFail bool
 }
 
 func (m *mockNoteRepository) Create(n *domain.Note) error {
+	if m.createFail {
+		return errors.New("db error")
+	}
 	m.notes = append(m.notes, *n)
 	return nil
 }
 
+// CreateBatch implements repository.NoteRepository.
+func (m *mockNoteRepository) CreateBatch(notes []domain.Note) error {
+	m.batches = append(m.batches, len(notes))
+	if m.batchFail {
+		return errors.New("db error")
+	}
+	for i := range notes {
+		notes[i].ID = uint(len(m.notes) + 1)
+		m.notes = append(m.notes, notes[i])
+	}
+	return nil
+}
+
 // GetAll implements repository.NoteRepository.
 func (m *mockNoteRepository) GetAll() ([]domain.Note, error) {
 	if m.forceDBFail {
@@ -30,6 +71,74 @@ func (m *mockNoteRepository) GetAll() ([]domain.Note, error) {
 	return m.notes, nil
 }
 
+// GetAllByOwner implements repository.NoteRepository.
+func (m *mockNoteRepository) GetAllVisible(userID uint, includeArchived bool, limit int) ([]domain.Note, error) {
+	if m.forceDBFail {
+		return []domain.Note{}, errors.New("db error")
+	}
+
+	notes := make([]domain.Note, 0)
+	for _, note := range m.notes {
+		if note.VisibleTo(userID) && (includeArchived || !note.Archived) {
+			notes = append(notes, note)
+		}
+	}
+	if limit > 0 && len(notes) > limit {
+		notes = notes[:limit]
+	}
+	return notes, nil
+}
+
+func (m *mockNoteRepository) GetAllByOwner(ownerID uint, includeArchived bool) ([]domain.Note, error) {
+	if m.forceDBFail {
+		return []domain.Note{}, errors.New("db error")
+	}
+
+	notes := make([]domain.Note, 0)
+	for _, note := range m.notes {
+		if note.OwnerID == ownerID && (includeArchived || !note.Archived) {
+			notes = append(notes, note)
+		}
+	}
+	return notes, nil
+}
+
+// StreamByOwner implements repository.NoteRepository.
+func (m *mockNoteRepository) StreamByOwner(ownerID uint, includeArchived bool, fn func(domain.Note) error) error {
+	if m.forceDBFail {
+		return errors.New("db error")
+	}
+
+	for _, note := range m.notes {
+		if note.OwnerID == ownerID && (includeArchived || !note.Archived) {
+			if err := fn(note); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetModifiedSince implements repository.NoteRepository.
+func (m *mockNoteRepository) GetModifiedSince(ownerID uint, cursor domain.ChangeCursor, limit int) ([]domain.Note, error) {
+	if m.forceDBFail {
+		return nil, errors.New("db error")
+	}
+
+	var notes []domain.Note
+	for _, note := range m.notes {
+		after := note.UpdatedAt.After(cursor.Since) ||
+			(cursor.AfterID != 0 && note.UpdatedAt.Equal(cursor.Since) && note.ID > cursor.AfterID)
+		if note.OwnerID == ownerID && after {
+			notes = append(notes, note)
+		}
+	}
+	if limit > 0 && len(notes) > limit {
+		notes = notes[:limit]
+	}
+	return notes, nil
+}
+
 // GetByID implements repository.NoteRepository.
 func (m *mockNoteRepository) GetByID(id uint) (domain.Note, error) {
 	// 1. Simulate hardcoded error (like db failure)
@@ -48,16 +157,35 @@ func (m *mockNoteRepository) GetByID(id uint) (domain.Note, error) {
 	return domain.Note{}, gorm.ErrRecordNotFound
 }
 
+// Exists implements repository.NoteRepository.
+func (m *mockNoteRepository) Exists(id, ownerID uint) (bool, error) {
+	if id == 3 {
+		return false, errors.New("db error")
+	}
+
+	for _, n := range m.notes {
+		if n.ID == id {
+			return n.OwnerID == ownerID, nil
+		}
+	}
+	return false, nil
+}
+
 // GetPaginated implements repository.NoteRepository.
-func (m *mockNoteRepository) GetPaginated(limit int, offset int) ([]domain.Note, error) {
+func (m *mockNoteRepository) GetPaginated(viewerID uint, limit int, offset int, includeArchived bool) ([]domain.Note, error) {
 	panic("unimplemented")
 }
 
 // Update implements repository.NoteRepository.
 func (m *mockNoteRepository) Update(n *domain.Note) error {
+	m.updated = append(m.updated, *n)
 	if n.ID == 999 {
 		return errors.New("db error")
 	}
+	if n.ID == 998 {
+		return repository.ErrVersionConflict
+	}
+	n.Version++
 	return nil
 }
 
@@ -70,37 +198,210 @@ func (m *mockNoteRepository) Delete(id uint) error {
 	for _, note := range m.notes {
 		if note.ID != id {
 			newNotes = append(newNotes, note)
+		} else {
+			m.trash = append(m.trash, note)
 		}
 	}
 	m.notes = newNotes
 	return nil
 }
 
+// GetUpcoming implements repository.NoteRepository.
+func (m *mockNoteRepository) GetUpcoming(viewerID uint, from, to time.Time) ([]domain.Note, error) {
+	upcoming, err := m.GetStartingBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var visible []domain.Note
+	for _, note := range upcoming {
+		if note.VisibleTo(viewerID) {
+			visible = append(visible, note)
+		}
+	}
+	return visible, nil
+}
+
+// GetStartingBetween implements repository.NoteRepository.
+func (m *mockNoteRepository) GetStartingBetween(from, to time.Time) ([]domain.Note, error) {
+	if m.forceDBFail {
+		return nil, errors.New("db error")
+	}
+
+	var upcoming []domain.Note
+	for _, note := range m.notes {
+		if !note.Archived && !note.MeetingDate.Before(from) && note.MeetingDate.Before(to) {
+			upcoming = append(upcoming, note)
+		}
+	}
+	sort.Slice(upcoming, func(i, j int) bool {
+		return upcoming[i].MeetingDate.Before(upcoming[j].MeetingDate)
+	})
+	return upcoming, nil
+}
+
+// ListRevisions implements repository.NoteRepository.
+func (m *mockNoteRepository) ListRevisions(noteID uint) ([]domain.NoteRevision, error) {
+	if m.forceDBFail {
+		return nil, errors.New("db error")
+	}
+
+	var revisions []domain.NoteRevision
+	for _, r := range m.revisions {
+		if r.NoteID == noteID {
+			revisions = append(revisions, r)
+		}
+	}
+	return revisions, nil
+}
+
+// AddAttachment implements repository.NoteRepository.
+func (m *mockNoteRepository) AddAttachment(a *domain.Attachment) error {
+	if m.forceDBFail {
+		return errors.New("db error")
+	}
+	a.ID = uint(len(m.attachments) + 1)
+	m.attachments = append(m.attachments, *a)
+	return nil
+}
+
+// ListAttachments implements repository.NoteRepository.
+func (m *mockNoteRepository) ListAttachments(noteID uint) ([]domain.Attachment, error) {
+	if m.forceDBFail {
+		return nil, errors.New("db error")
+	}
+
+	var attachments []domain.Attachment
+	for _, a := range m.attachments {
+		if a.NoteID == noteID {
+			attachments = append(attachments, a)
+		}
+	}
+	return attachments, nil
+}
+
+// AddLink implements repository.NoteRepository.
+func (m *mockNoteRepository) AddLink(l *domain.NoteLink) error {
+	if m.forceDBFail {
+		return errors.New("db error")
+	}
+	for _, existing := range m.links {
+		if existing.FromNoteID == l.FromNoteID && existing.ToNoteID == l.ToNoteID {
+			return repository.ErrDuplicateLink
+		}
+	}
+	l.ID = uint(len(m.links) + 1)
+	m.links = append(m.links, *l)
+	return nil
+}
+
+// ListLinks implements repository.NoteRepository.
+func (m *mockNoteRepository) ListLinks(noteID uint) ([]domain.NoteLink, error) {
+	if m.forceDBFail {
+		return nil, errors.New("db error")
+	}
+
+	var links []domain.NoteLink
+	for _, l := range m.links {
+		if l.FromNoteID == noteID || l.ToNoteID == noteID {
+			links = append(links, l)
+		}
+	}
+	return links, nil
+}
+
+// SetArchived implements repository.NoteRepository.
+func (m *mockNoteRepository) SetArchived(id uint, archived bool) error {
+	if m.forceDBFail {
+		return errors.New("db error")
+	}
+
+	for i := range m.notes {
+		if m.notes[i].ID == id {
+			m.notes[i].Archived = archived
+		}
+	}
+	return nil
+}
+
+// ArchiveOlderThan implements repository.NoteRepository.
+func (m *mockNoteRepository) ArchiveOlderThan(cutoff time.Time) (int64, error) {
+	if m.forceDBFail {
+		return 0, errors.New("db error")
+	}
+	m.archiveCutoff = cutoff
+
+	var archived int64
+	for i := range m.notes {
+		if !m.notes[i].Archived && m.notes[i].MeetingDate.Before(cutoff) {
+			m.notes[i].Archived = true
+			archived++
+		}
+	}
+	return archived, nil
+}
+
 // Search implements repository.NoteRepository.
-func (m *mockNoteRepository) Search(keyword string) ([]domain.Note, error) {
-	panic("unimplemented")
+func (m *mockNoteRepository) Search(viewerID uint, keyword string, dates domain.DateRange, page domain.Page) ([]domain.Note, int64, error) {
+	if m.forceDBFail {
+		return nil, 0, errors.New("db error")
+	}
+
+	var result []domain.Note
+	keyword = strings.ToLower(keyword)
+	for _, note := range m.notes {
+		if note.VisibleTo(viewerID) && dates.Contains(note.MeetingDate) && (strings.Contains(strings.ToLower(note.Title), keyword) ||
+			strings.Contains(strings.ToLower(note.Content), keyword)) {
+			result = append(result, note)
+		}
+	}
+	return paginate(result, page)
+}
+
+// FuzzySearch implements repository.NoteRepository.
+func (m *mockNoteRepository) FuzzySearch(viewerID uint, keyword string, threshold float64, dates domain.DateRange, page domain.Page) ([]domain.Note, int64, error) {
+	m.fuzzyThreshold = threshold
+	if m.forceDBFail || m.fuzzyFail {
+		return nil, 0, errors.New("db error")
+	}
+	return paginate(m.fuzzy, page)
 }
 
 // Filter implements repository.NoteRepository.
-func (m *mockNoteRepository) Filter(filter domain.NoteFilter) ([]domain.Note, error) {
+func (m *mockNoteRepository) Filter(filter domain.NoteFilter, page domain.Page) ([]domain.Note, int64, error) {
 	if m.forceDBFail {
-		return nil, errors.New("db error")
+		return nil, 0, errors.New("db error")
 	}
 
 	var result []domain.Note
 	for _, note := range m.notes {
-		match := true
+		match := filter.IncludeArchived || !note.Archived
 
-		if filter.Keyword != "" {
-			keyword := strings.ToLower(filter.Keyword)
-			if !strings.Contains(strings.ToLower(note.Title), keyword) &&
-				!strings.Contains(strings.ToLower(note.Content), keyword) {
+		if keywords := filter.AllKeywords(); len(keywords) > 0 {
+			found := 0
+			for _, keyword := range keywords {
+				keyword = strings.ToLower(keyword)
+				if strings.Contains(strings.ToLower(note.Title), keyword) ||
+					strings.Contains(strings.ToLower(note.Content), keyword) {
+					found++
+				}
+			}
+			if found == 0 || (filter.KeywordMatch == domain.KeywordMatchAll && found < len(keywords)) {
 				match = false
 			}
 		}
 
-		if filter.Category != "" && note.Category != filter.Category {
-			match = false
+		if len(filter.Categories) > 0 {
+			found := false
+			for _, category := range filter.Categories {
+				if strings.EqualFold(note.Category, strings.TrimSpace(category)) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				match = false
+			}
 		}
 
 		if filter.FromDate != nil && note.MeetingDate.Before(*filter.FromDate) {
@@ -111,72 +412,449 @@ func (m *mockNoteRepository) Filter(filter domain.NoteFilter) ([]domain.Note, er
 			match = false
 		}
 
+		if filter.CreatedFrom != nil && note.CreatedAt.Before(*filter.CreatedFrom) {
+			match = false
+		}
+
+		if filter.CreatedTo != nil && note.CreatedAt.After(*filter.CreatedTo) {
+			match = false
+		}
+
+		if filter.UncategorizedOnly && note.Category != "" {
+			match = false
+		}
+
+		if filter.MaxContentLength > 0 && len([]rune(note.Content)) > filter.MaxContentLength {
+			match = false
+		}
+
 		if match {
 			result = append(result, note)
 		}
 	}
 
-	return result, nil
+	return paginate(result, page)
 }
 
-func TestCreateNote(t *testing.T) {
-	tests := []struct {
-		name        string
-		input       domain.Note
-		wantErr     bool
-		errContains error
-	}{
-		{
-			name: "valid note",
-			input: domain.Note{
-				Title:   "Team Meeting",
-				Content: "Discussed sprint planning",
-			},
-			wantErr: false,
-		},
-		{
-			name: "empty title",
-			input: domain.Note{
-				Title:   "",
-				Content: "Discussed sprint planning",
-			},
-			wantErr:     true,
-			errContains: usecase.ErrEmptyTitle,
-		},
-		{
-			name: "empty content",
-			input: domain.Note{
-				Title:   "Team Meeting",
-				Content: "",
-			},
-			wantErr:     true,
-			errContains: usecase.ErrEmptyContent,
-		},
+// paginate mimics the repository's ORDER BY meeting_date DESC LIMIT/OFFSET.
+func paginate(notes []domain.Note, page domain.Page) ([]domain.Note, int64, error) {
+	sort.Slice(notes, func(i, j int) bool {
+		return notes[i].MeetingDate.After(notes[j].MeetingDate)
+	})
+
+	total := int64(len(notes))
+	if page.Offset >= len(notes) {
+		return []domain.Note{}, total, nil
+	}
+	notes = notes[page.Offset:]
+	if page.Limit > 0 && page.Limit < len(notes) {
+		notes = notes[:page.Limit]
 	}
+	return notes, total, nil
+}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mockRepo := &mockNoteRepository{}
-			noteUC := usecase.NewNoteUsecase(mockRepo)
-			err := noteUC.CreateNote(&tt.input)
+// GetAfterID implements repository.NoteRepository. m.notes is assumed to be
+// in ID order.
+func (m *mockNoteRepository) GetAfterID(viewerID, afterID uint, limit int, includeArchived bool) ([]domain.Note, error) {
+	if m.forceDBFail {
+		return nil, errors.New("db error")
+	}
 
-			if tt.wantErr {
-				assert.Error(t, err)
-				assert.Contains(t, err.Error(), tt.errContains.Error())
-				assert.Len(t, mockRepo.notes, 0)
-			} else {
-				assert.NoError(t, err)
-				assert.Len(t, mockRepo.notes, 1)
+	var notes []domain.Note
+	for _, note := range m.notes {
+		if len(notes) == limit {
+			break
+		}
+		if note.VisibleTo(viewerID) && note.ID > afterID && (includeArchived || !note.Archived) {
+			notes = append(notes, note)
+		}
+	}
+	return notes, nil
+}
+
+// GetRecent implements repository.NoteRepository. Notes are treated as
+// created in ID order.
+func (m *mockNoteRepository) GetRecent(viewerID uint, limit int) ([]domain.Note, error) {
+	if m.forceDBFail {
+		return nil, errors.New("db error")
+	}
+
+	var notes []domain.Note
+	for i := len(m.notes) - 1; i >= 0 && len(notes) < limit; i-- {
+		notes = append(notes, m.notes[i])
+	}
+	return notes, nil
+}
+
+// Upsert implements repository.NoteRepository.
+func (m *mockNoteRepository) Upsert(n *domain.Note) error {
+	if m.forceDBFail {
+		return errors.New("db error")
+	}
+
+	for i := range m.notes {
+		if m.notes[i].ExternalID == n.ExternalID {
+			m.notes[i].Title = n.Title
+			m.notes[i].Content = n.Content
+			m.notes[i].Category = n.Category
+			m.notes[i].MeetingDate = n.MeetingDate
+			m.notes[i].Version++
+			*n = m.notes[i]
+			return nil
+		}
+	}
+	n.ID = uint(len(m.notes) + 1)
+	m.notes = append(m.notes, *n)
+	return nil
+}
+
+// CountNotes implements repository.NoteRepository.
+func (m *mockNoteRepository) CountNotes() (int64, error) {
+	if m.forceDBFail {
+		return 0, errors.New("db error")
+	}
+	return int64(len(m.notes)), nil
+}
+
+// RenameCategory implements repository.NoteRepository.
+func (m *mockNoteRepository) RenameCategory(ownerID uint, from, to string) (int64, error) {
+	if m.forceDBFail {
+		return 0, errors.New("db error")
+	}
+
+	var renamed int64
+	for i := range m.notes {
+		if m.notes[i].OwnerID == ownerID && m.notes[i].Category == from {
+			m.notes[i].Category = to
+			renamed++
+		}
+	}
+	return renamed, nil
+}
+
+// GetByIDs implements repository.NoteRepository.
+func (m *mockNoteRepository) GetByIDs(ids []uint) ([]domain.Note, error) {
+	if m.forceDBFail {
+		return nil, errors.New("db error")
+	}
+
+	var notes []domain.Note
+	for _, n := range m.notes {
+		for _, id := range ids {
+			if n.ID == id {
+				notes = append(notes, n)
+				break
 			}
-		})
+		}
 	}
+	return notes, nil
 }
 
-func TestGetAllNotes(t *testing.T) {
-	tests := []struct {
-		name        string
-		setupRepo   func() usecase.NoteUsecase
-		wantErr     bool
+// CountFiltered implements repository.NoteRepository.
+func (m *mockNoteRepository) CountFiltered(filter domain.NoteFilter) (int64, error) {
+	_, total, err := m.Filter(filter, domain.Page{})
+	return total, err
+}
+
+// GetBySlug implements repository.NoteRepository.
+func (m *mockNoteRepository) GetBySlug(slug string) (domain.Note, error) {
+	if m.forceDBFail {
+		return domain.Note{}, errors.New("db error")
+	}
+	for _, n := range m.notes {
+		if n.Slug == slug {
+			return n, nil
+		}
+	}
+	return domain.Note{}, gorm.ErrRecordNotFound
+}
+
+// GetByExternalID implements repository.NoteRepository.
+func (m *mockNoteRepository) GetByExternalID(ownerID uint, externalID string) (domain.Note, error) {
+	if m.forceDBFail {
+		return domain.Note{}, errors.New("db error")
+	}
+	for _, n := range m.notes {
+		if n.OwnerID == ownerID && n.ExternalID == externalID {
+			return n, nil
+		}
+	}
+	return domain.Note{}, gorm.ErrRecordNotFound
+}
+
+// SlugsWithPrefix implements repository.NoteRepository.
+func (m *mockNoteRepository) SlugsWithPrefix(base string) ([]string, error) {
+	if m.forceDBFail {
+		return nil, errors.New("db error")
+	}
+	var slugs []string
+	for _, n := range append(m.notes, m.trash...) {
+		if n.Slug == base || strings.HasPrefix(n.Slug, base+"-") {
+			slugs = append(slugs, n.Slug)
+		}
+	}
+	return slugs, nil
+}
+
+// RestoreNotes implements repository.NoteRepository.
+func (m *mockNoteRepository) RestoreNotes(ownerID uint, ids []uint) (int64, error) {
+	if m.forceDBFail {
+		return 0, errors.New("db error")
+	}
+
+	var restored int64
+	remaining := make([]domain.Note, 0)
+	for _, note := range m.trash {
+		found := false
+		for _, id := range ids {
+			if note.ID == id && note.OwnerID == ownerID {
+				found = true
+				break
+			}
+		}
+
+		if found {
+			m.notes = append(m.notes, note)
+			restored++
+		} else {
+			remaining = append(remaining, note)
+		}
+	}
+	m.trash = remaining
+	return restored, nil
+}
+
+// RestoreDeletedSince implements repository.NoteRepository.
+func (m *mockNoteRepository) RestoreDeletedSince(ownerID uint, since time.Time) (int64, error) {
+	if m.forceDBFail {
+		return 0, errors.New("db error")
+	}
+
+	var restored int64
+	remaining := make([]domain.Note, 0)
+	for _, note := range m.trash {
+		if note.OwnerID == ownerID && note.DeletedAt.Valid && note.DeletedAt.Time.After(since) {
+			m.notes = append(m.notes, note)
+			restored++
+		} else {
+			remaining = append(remaining, note)
+		}
+	}
+	m.trash = remaining
+	return restored, nil
+}
+
+// Extremes implements repository.NoteRepository.
+func (m *mockNoteRepository) Extremes(viewerID uint) (domain.NoteExtremes, error) {
+	if m.forceDBFail {
+		return domain.NoteExtremes{}, errors.New("db error")
+	}
+
+	var extremes domain.NoteExtremes
+	for _, note := range m.notes {
+		length := len(note.Content)
+		if extremes.Longest == nil || length > extremes.Longest.Length {
+			extremes.Longest = &domain.NoteLength{ID: note.ID, Title: note.Title, Length: length}
+		}
+		if length > 0 && (extremes.Shortest == nil || length < extremes.Shortest.Length) {
+			extremes.Shortest = &domain.NoteLength{ID: note.ID, Title: note.Title, Length: length}
+		}
+	}
+	return extremes, nil
+}
+
+// DistinctCategories implements repository.NoteRepository.
+func (m *mockNoteRepository) DistinctCategories(viewerID uint) ([]domain.CategoryCount, error) {
+	return m.countCategories(func(note domain.Note) bool { return note.VisibleTo(viewerID) })
+}
+
+// CountByCategory implements repository.NoteRepository.
+func (m *mockNoteRepository) CountByCategory(ownerID uint) ([]domain.CategoryCount, error) {
+	return m.countCategories(func(note domain.Note) bool { return note.OwnerID == ownerID })
+}
+
+func (m *mockNoteRepository) countCategories(keep func(domain.Note) bool) ([]domain.CategoryCount, error) {
+	if m.forceDBFail {
+		return nil, errors.New("db error")
+	}
+
+	counts := map[string]int64{}
+	for _, note := range m.notes {
+		if note.Category != "" && keep(note) {
+			counts[note.Category]++
+		}
+	}
+
+	categories := make([]domain.CategoryCount, 0, len(counts))
+	for category, count := range counts {
+		categories = append(categories, domain.CategoryCount{Category: category, Count: count})
+	}
+	return categories, nil
+}
+
+// PurgeDeleted implements repository.NoteRepository.
+func (m *mockNoteRepository) PurgeDeleted(cutoff time.Time) (int64, error) {
+	if m.forceDBFail {
+		return 0, errors.New("db error")
+	}
+	m.purgeCutoff = cutoff
+
+	var purged int64
+	remaining := make([]domain.Note, 0)
+	for _, note := range m.trash {
+		if note.DeletedAt.Valid && note.DeletedAt.Time.Before(cutoff) {
+			purged++
+			continue
+		}
+		remaining = append(remaining, note)
+	}
+	m.trash = remaining
+	return purged, nil
+}
+
+// Stats implements repository.NoteRepository.
+func (m *mockNoteRepository) Stats(viewerID uint, loc *time.Location) (domain.NoteStats, error) {
+	if m.forceDBFail {
+		return domain.NoteStats{}, errors.New("db error")
+	}
+	return domain.NoteStats{Total: int64(len(m.notes))}, nil
+}
+
+func TestCreateNote(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       domain.Note
+		wantErr     bool
+		errContains error
+	}{
+		{
+			name: "valid note",
+			input: domain.Note{
+				Title:       "Team Meeting",
+				Content:     "Discussed sprint planning",
+				MeetingDate: time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC),
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing meeting date",
+			input: domain.Note{
+				Title:   "Team Meeting",
+				Content: "Discussed sprint planning",
+			},
+			wantErr:     true,
+			errContains: usecase.ErrInvalidMeetingDate,
+		},
+		{
+			name: "empty title",
+			input: domain.Note{
+				Title:   "",
+				Content: "Discussed sprint planning",
+			},
+			wantErr:     true,
+			errContains: usecase.ErrEmptyTitle,
+		},
+		{
+			name: "empty content",
+			input: domain.Note{
+				Title:   "Team Meeting",
+				Content: "",
+			},
+			wantErr:     true,
+			errContains: usecase.ErrEmptyContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+			err := noteUC.CreateNote(&tt.input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains.Error())
+				assert.Len(t, mockRepo.notes, 0)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, mockRepo.notes, 1)
+			}
+		})
+	}
+}
+
+func TestCreateNoteDuplicateTitleDate(t *testing.T) {
+	repo := memory.NewNoteRepositoryWithOptions(memory.NoteRepositoryOptions{UniqueTitleDate: true})
+	noteUC := usecase.NewNoteUsecase(repo)
+	monday := time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC)
+
+	first := domain.Note{Title: "Team Meeting", Content: "Sprint planning", MeetingDate: monday}
+	assert.NoError(t, noteUC.CreateNote(&first))
+
+	second := domain.Note{Title: "Team Meeting", Content: "Sprint planning again", MeetingDate: monday}
+	assert.ErrorIs(t, noteUC.CreateNote(&second), usecase.ErrDuplicateNote)
+
+	nextWeek := domain.Note{Title: "Team Meeting", Content: "Sprint review", MeetingDate: monday.AddDate(0, 0, 7)}
+	assert.NoError(t, noteUC.CreateNote(&nextWeek))
+
+	nextWeek.Title = "Team Meeting"
+	nextWeek.MeetingDate = monday
+	assert.ErrorIs(t, noteUC.UpdateNote(&nextWeek), usecase.ErrDuplicateNote)
+
+	imported := domain.Note{ExternalID: "crm-1", Title: "Team Meeting", Content: "Sprint planning", MeetingDate: monday}
+	assert.ErrorIs(t, noteUC.UpsertNote(&imported), usecase.ErrDuplicateNote)
+}
+
+func TestCreateNoteConcurrently(t *testing.T) {
+	monday := time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC)
+
+	// createAll creates the same note from several goroutines at once and
+	// returns each call's error.
+	createAll := func(noteUC usecase.NoteUsecase) []error {
+		errs := make([]error, 5)
+		var wg sync.WaitGroup
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = noteUC.CreateNote(&domain.Note{Title: "Team Meeting", Content: "Sprint planning", MeetingDate: monday})
+			}(i)
+		}
+		wg.Wait()
+		return errs
+	}
+
+	t.Run("unique title and date lets one through", func(t *testing.T) {
+		repo := memory.NewNoteRepositoryWithOptions(memory.NoteRepositoryOptions{UniqueTitleDate: true})
+
+		created := 0
+		for _, err := range createAll(usecase.NewNoteUsecase(repo)) {
+			if err == nil {
+				created++
+				continue
+			}
+			assert.ErrorIs(t, err, usecase.ErrDuplicateNote)
+		}
+		assert.Equal(t, 1, created)
+	})
+
+	t.Run("without it every note gets its own slug", func(t *testing.T) {
+		repo := memory.NewNoteRepository()
+
+		for _, err := range createAll(usecase.NewNoteUsecase(repo)) {
+			assert.NoError(t, err)
+		}
+		slugs, err := repo.SlugsWithPrefix("team-meeting")
+		assert.NoError(t, err)
+		assert.Len(t, slugs, 5)
+	})
+}
+
+func TestGetAllNotes(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupRepo   func() usecase.NoteUsecase
+		wantErr     bool
 		errContains error
 	}{
 		{
@@ -249,7 +927,7 @@ func TestGetAllNotes(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			noteUC := tt.setupRepo()
-			notes, err := noteUC.GetAllNotes()
+			notes, _, err := noteUC.GetAllNotes(0, false)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -302,7 +980,7 @@ func TestGetNoteByID(t *testing.T) {
 				}},
 			}
 			noteUC := usecase.NewNoteUsecase(mockRepo)
-			note, err := noteUC.GetNoteByID(tt.input)
+			note, err := noteUC.GetNoteByID(tt.input, 0)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -315,303 +993,699 @@ func TestGetNoteByID(t *testing.T) {
 	}
 }
 
-func TestUpdateNote(t *testing.T) {
+func TestUpsertNote(t *testing.T) {
+	meetingDate := time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC)
+
 	tests := []struct {
 		name        string
-		noteID      uint
 		input       domain.Note
-		setupRepo   func() usecase.NoteUsecase
+		forceDBFail bool
+		wantLen     int
+		wantVersion int
 		wantErr     bool
 		errContains error
 	}{
 		{
-			name:   "valid update",
-			noteID: 1,
-			input: domain.Note{
-				ID:          1,
-				Title:       "Team Standup",
-				Content:     "Discussed issues that may affect other teams",
-				Category:    "Standup",
-				MeetingDate: time.Date(2025, time.June, 15, 10, 30, 0, 0, time.UTC),
-			},
-			setupRepo: func() usecase.NoteUsecase {
-				mockRepo := &mockNoteRepository{
-					notes: []domain.Note{{
-						ID:          1,
-						Title:       "Update Meeting Title",
-						Content:     "Update Meeting Content",
-						Category:    "Team Meeting",
-						MeetingDate: time.Date(2025, time.October, 12, 11, 30, 0, 0, time.UTC),
-					}}}
-				return usecase.NewNoteUsecase(mockRepo)
-			},
-			wantErr: false,
+			name:    "New external id is inserted",
+			input:   domain.Note{ExternalID: "ext-2", Title: "Imported", Content: "Content", MeetingDate: meetingDate},
+			wantLen: 2,
 		},
 		{
-			name:   "missing title",
-			noteID: 1,
-			input: domain.Note{
-				ID:          1,
-				Title:       "",
-				Content:     "Discussed pay rise",
-				Category:    "1:1",
-				MeetingDate: time.Date(2025, time.September, 15, 10, 30, 0, 0, time.UTC),
-			},
-			setupRepo: func() usecase.NoteUsecase {
-				mockRepo := &mockNoteRepository{
-					notes: []domain.Note{{
-						ID:          1,
-						Title:       "Update Meeting Title",
-						Content:     "Update Meeting Content",
-						Category:    "Team Meeting",
-						MeetingDate: time.Date(2025, time.October, 12, 11, 30, 0, 0, time.UTC),
-					}}}
-				return usecase.NewNoteUsecase(mockRepo)
-			},
+			name:        "Existing external id is updated",
+			input:       domain.Note{ExternalID: "ext-1", Title: "Re-imported", Content: "Content", MeetingDate: meetingDate},
+			wantLen:     1,
+			wantVersion: 2,
+		},
+		{
+			name:    "Empty external id creates a note",
+			input:   domain.Note{Title: "Native", Content: "Content", MeetingDate: meetingDate},
+			wantLen: 2,
+		},
+		{
+			name:        "Empty title",
+			input:       domain.Note{ExternalID: "ext-1", Content: "Content", MeetingDate: meetingDate},
+			wantLen:     1,
 			wantErr:     true,
 			errContains: usecase.ErrEmptyTitle,
 		},
 		{
-			name:   "missing content",
-			noteID: 1,
-			input: domain.Note{
-				ID:          1,
-				Title:       "All-Hands",
-				Content:     "",
-				Category:    "Company-wide",
-				MeetingDate: time.Date(2025, time.August, 15, 10, 30, 0, 0, time.UTC),
-			},
-			setupRepo: func() usecase.NoteUsecase {
-				mockRepo := &mockNoteRepository{
-					notes: []domain.Note{{
-						ID:          1,
-						Title:       "Update Meeting Title",
-						Content:     "Update Meeting Content",
-						Category:    "Team Meeting",
-						MeetingDate: time.Date(2025, time.October, 12, 11, 30, 0, 0, time.UTC),
-					}}}
-				return usecase.NewNoteUsecase(mockRepo)
-			},
+			name:        "Empty content",
+			input:       domain.Note{ExternalID: "ext-1", Title: "Imported", MeetingDate: meetingDate},
+			wantLen:     1,
 			wantErr:     true,
 			errContains: usecase.ErrEmptyContent,
 		},
 		{
-			name:   "Note doesn't exist",
-			noteID: 4,
-			input: domain.Note{
-				ID:          4,
-				Title:       "All-Hands",
-				Content:     "Discussed issues that may affect other teams",
-				Category:    "Company-wide",
-				MeetingDate: time.Date(2025, time.August, 15, 10, 30, 0, 0, time.UTC),
-			},
-			setupRepo: func() usecase.NoteUsecase {
-				mockRepo := &mockNoteRepository{
-					notes: []domain.Note{{
-						ID:          1,
-						Title:       "Update Meeting Title",
-						Content:     "Update Meeting Content",
-						Category:    "Team Meeting",
-						MeetingDate: time.Date(2025, time.October, 12, 11, 30, 0, 0, time.UTC),
-					}}}
-				return usecase.NewNoteUsecase(mockRepo)
-			},
+			name:        "Repo error",
+			input:       domain.Note{ExternalID: "ext-2", Title: "Imported", Content: "Content", MeetingDate: meetingDate},
+			forceDBFail: true,
+			wantLen:     1,
 			wantErr:     true,
-			errContains: usecase.ErrNoteNotFound,
+			errContains: errors.New("failed to upsert note"),
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{
+				notes: []domain.Note{
+					{ID: 1, ExternalID: "ext-1", Title: "Original", Content: "Content", MeetingDate: meetingDate, Version: 1},
+				},
+				forceDBFail: tt.forceDBFail,
+			}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+
+			err := noteUC.UpsertNote(&tt.input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains.Error())
+			} else {
+				assert.NoError(t, err)
+				if tt.wantVersion > 0 {
+					assert.Equal(t, tt.wantVersion, tt.input.Version)
+					assert.Equal(t, "Re-imported", mockRepo.notes[0].Title)
+				}
+			}
+			assert.Len(t, mockRepo.notes, tt.wantLen)
+		})
+	}
+}
+
+type recordingPublisher struct {
+	events []domain.NoteEvent
+}
+
+func (p *recordingPublisher) Publish(event domain.NoteEvent) {
+	p.events = append(p.events, event)
+}
+
+func TestNoteEventsPublished(t *testing.T) {
+	meetingDate := time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		forceDBFail bool
+		run         func(uc usecase.NoteUsecase) error
+		wantEvents  []domain.NoteEventType
+	}{
 		{
-			name:   "repo fails",
-			noteID: 999,
-			input: domain.Note{
-				ID:          999,
-				Title:       "All-Hands",
-				Content:     "Discussed issues that may affect other teams",
-				Category:    "Company-wide",
-				MeetingDate: time.Date(2025, time.August, 15, 10, 30, 0, 0, time.UTC),
+			name: "Create",
+			run: func(uc usecase.NoteUsecase) error {
+				return uc.CreateNote(&domain.Note{Title: "New", Content: "Content", MeetingDate: meetingDate})
 			},
-			setupRepo: func() usecase.NoteUsecase {
-				mockRepo := &mockNoteRepository{
-					notes: []domain.Note{{
-						ID:          999,
-						Title:       "Update Meeting Title",
-						Content:     "Update Meeting Content",
-						Category:    "Team Meeting",
-						MeetingDate: time.Date(2025, time.October, 12, 11, 30, 0, 0, time.UTC),
-					}}}
-				return usecase.NewNoteUsecase(mockRepo)
+			wantEvents: []domain.NoteEventType{domain.NoteCreated},
+		},
+		{
+			name: "Update",
+			run: func(uc usecase.NoteUsecase) error {
+				return uc.UpdateNote(&domain.Note{ID: 1, Title: "Edited", Content: "Content", MeetingDate: meetingDate, Version: 1})
 			},
+			wantEvents: []domain.NoteEventType{domain.NoteUpdated},
+		},
+		{
+			name: "Delete",
+			run: func(uc usecase.NoteUsecase) error {
+				return uc.DeleteNote(1, 0)
+			},
+			wantEvents: []domain.NoteEventType{domain.NoteDeleted},
+		},
+		{
+			name: "Upsert of existing external id",
+			run: func(uc usecase.NoteUsecase) error {
+				return uc.UpsertNote(&domain.Note{ExternalID: "ext-1", Title: "Edited", Content: "Content", MeetingDate: meetingDate})
+			},
+			wantEvents: []domain.NoteEventType{domain.NoteUpdated},
+		},
+		{
+			name: "Validation error",
+			run: func(uc usecase.NoteUsecase) error {
+				return uc.CreateNote(&domain.Note{Content: "Content", MeetingDate: meetingDate})
+			},
+		},
+		{
+			name: "Note not found",
+			run: func(uc usecase.NoteUsecase) error {
+				return uc.DeleteNote(99, 0)
+			},
+		},
+		{
+			name:        "Repo error",
+			forceDBFail: true,
+			run: func(uc usecase.NoteUsecase) error {
+				return uc.DeleteNote(1, 0)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{
+				notes:       []domain.Note{{ID: 1, ExternalID: "ext-1", Title: "Old", Content: "Content", MeetingDate: meetingDate, Version: 1}},
+				forceDBFail: tt.forceDBFail,
+			}
+			publisher := &recordingPublisher{}
+			cfg := usecase.DefaultConfig()
+			cfg.Publisher = publisher
+			noteUC := usecase.NewNoteUsecaseWithConfig(mockRepo, cfg)
+
+			err := tt.run(noteUC)
+
+			gotEvents := make([]domain.NoteEventType, 0, len(publisher.events))
+			for _, event := range publisher.events {
+				gotEvents = append(gotEvents, event.Type)
+			}
+			if tt.wantEvents == nil {
+				assert.Error(t, err)
+				assert.Empty(t, gotEvents)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantEvents, gotEvents)
+			}
+		})
+	}
+}
+
+func TestNoteEventsInOutbox(t *testing.T) {
+	now := time.Now()
+	publisher := &recordingPublisher{}
+	cfg := usecase.DefaultConfig()
+	cfg.Publisher = publisher
+	cfg.NoteEventsInOutbox = true
+	noteUC := usecase.NewNoteUsecaseWithConfig(&mockNoteRepository{notes: []domain.Note{
+		{ID: 1, Title: "Planning", Content: "Content", MeetingDate: now.Add(65 * time.Minute), Version: 1},
+	}}, cfg)
+
+	assert.NoError(t, noteUC.CreateNote(&domain.Note{Title: "New", Content: "Content", MeetingDate: now}))
+	assert.NoError(t, noteUC.UpdateNote(&domain.Note{ID: 1, Title: "Edited", Content: "Content", MeetingDate: now.Add(65 * time.Minute), Version: 1}))
+	_, err := noteUC.SendMeetingReminders(time.Hour, 15*time.Minute)
+	assert.NoError(t, err)
+
+	// The repository records note changes itself, so only the reminder is
+	// published.
+	if assert.Len(t, publisher.events, 1) {
+		assert.Equal(t, domain.NoteReminder, publisher.events[0].Type)
+	}
+}
+
+func TestGetRecentNotes(t *testing.T) {
+	manyNotes := make([]domain.Note, 0, 60)
+	for i := 1; i <= 60; i++ {
+		manyNotes = append(manyNotes, domain.Note{ID: uint(i), Title: "Note", Content: "Content"})
+	}
+
+	tests := []struct {
+		name        string
+		notes       []domain.Note
+		limit       int
+		forceDBFail bool
+		wantLen     int
+		wantFirstID uint
+		wantErr     bool
+		errContains error
+	}{
+		{
+			name:        "Explicit limit",
+			notes:       manyNotes,
+			limit:       3,
+			wantLen:     3,
+			wantFirstID: 60,
+		},
+		{
+			name:        "Zero limit uses default",
+			notes:       manyNotes,
+			wantLen:     usecase.DefaultRecentLimit,
+			wantFirstID: 60,
+		},
+		{
+			name:        "Limit is capped",
+			notes:       manyNotes,
+			limit:       1000,
+			wantLen:     usecase.MaxRecentLimit,
+			wantFirstID: 60,
+		},
+		{
+			name:    "No notes returns empty slice",
+			limit:   5,
+			wantLen: 0,
+		},
+		{
+			name:        "Repo error",
+			limit:       5,
+			forceDBFail: true,
 			wantErr:     true,
-			errContains: errors.New("failed to update note"),
+			errContains: errors.New("failed to get notes"),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			noteUC := tt.setupRepo()
+			mockRepo := &mockNoteRepository{notes: tt.notes, forceDBFail: tt.forceDBFail}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
 
-			err := noteUC.UpdateNote(&tt.input)
+			notes, err := noteUC.GetRecentNotes(0, tt.limit)
 
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errContains.Error())
-			} else {
-				assert.NoError(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, notes)
+			assert.Len(t, notes, tt.wantLen)
+			if tt.wantLen > 0 {
+				assert.Equal(t, tt.wantFirstID, notes[0].ID)
 			}
 		})
 	}
 }
 
-func TestDeleteNote(t *testing.T) {
+func TestGetNotesAfterID(t *testing.T) {
+	manyNotes := make([]domain.Note, 0, 150)
+	for i := 1; i <= 150; i++ {
+		manyNotes = append(manyNotes, domain.Note{ID: uint(i), OwnerID: 1, Title: "Note", Content: "Content"})
+	}
+	manyNotes[1].Archived = true
+	manyNotes[2].OwnerID = 2
+	manyNotes[4].OwnerID, manyNotes[4].Visibility = 2, domain.VisibilityShared
+
+	tests := []struct {
+		name            string
+		afterID         uint
+		limit           int
+		includeArchived bool
+		forceDBFail     bool
+		wantIDs         []uint
+		wantLen         int
+		wantErr         bool
+	}{
+		{name: "First page skips archived and others' private notes", limit: 3, wantIDs: []uint{1, 4, 5}},
+		{name: "Archived included", limit: 3, includeArchived: true, wantIDs: []uint{1, 2, 4}},
+		{name: "After cursor", afterID: 147, limit: 10, wantIDs: []uint{148, 149, 150}},
+		{name: "Past the end", afterID: 150, limit: 10, wantIDs: []uint{}},
+		{name: "Zero limit uses default", wantLen: usecase.DefaultCursorLimit},
+		{name: "Limit is capped", limit: 1000, wantLen: usecase.MaxCursorLimit},
+		{name: "Repo error", limit: 5, forceDBFail: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{notes: manyNotes, forceDBFail: tt.forceDBFail}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+
+			notes, err := noteUC.GetNotesAfterID(1, tt.afterID, tt.limit, tt.includeArchived)
+
+			if tt.wantErr {
+				assert.EqualError(t, err, "failed to get notes")
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, notes)
+			if tt.wantIDs == nil {
+				assert.Len(t, notes, tt.wantLen)
+				return
+			}
+			ids := make([]uint, 0, len(notes))
+			for _, n := range notes {
+				ids = append(ids, n.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestGetNotesByIDs(t *testing.T) {
 	tests := []struct {
 		name        string
-		input       uint
-		setupRepo   func(repo **mockNoteRepository) usecase.NoteUsecase
+		ids         []uint
+		ownerID     uint
+		forceDBFail bool
+		wantIDs     []uint
 		wantErr     bool
 		errContains error
-		expectLen   *int // only used for success case
 	}{
 		{
-			name:  "valid ID",
-			input: 1,
-			setupRepo: func(repo **mockNoteRepository) usecase.NoteUsecase {
-				*repo = &mockNoteRepository{
-					notes: []domain.Note{{
-						ID:      1,
-						Title:   "Valid",
-						Content: "Exists",
-					}},
-				}
-				return usecase.NewNoteUsecase(*repo)
-			},
-			wantErr: false,
+			name:    "Requested order is preserved",
+			ids:     []uint{4, 1, 2},
+			wantIDs: []uint{4, 1, 2},
 		},
 		{
-			name:  "missing ID",
-			input: 0,
-			setupRepo: func(repo **mockNoteRepository) usecase.NoteUsecase {
-				*repo = &mockNoteRepository{
-					notes: []domain.Note{{
-						ID:      1,
-						Title:   "Valid",
-						Content: "Exists",
-					}},
-				}
-				return usecase.NewNoteUsecase(*repo)
-			},
+			name:    "Missing ids are skipped",
+			ids:     []uint{2, 99, 1},
+			wantIDs: []uint{2, 1},
+		},
+		{
+			name:    "Repeated ids are returned once",
+			ids:     []uint{1, 1, 2},
+			wantIDs: []uint{1, 2},
+		},
+		{
+			name:    "Other owners' notes are skipped",
+			ids:     []uint{1, 5},
+			ownerID: 7,
+			wantIDs: []uint{5},
+		},
+		{
+			name:        "No ids",
 			wantErr:     true,
-			errContains: usecase.ErrNoteNotFound,
+			errContains: usecase.ErrNoIDs,
 		},
 		{
-			name:  "repo error",
-			input: 1,
-			setupRepo: func(repo **mockNoteRepository) usecase.NoteUsecase {
-				*repo = &mockNoteRepository{
-					notes: []domain.Note{{
-						ID:      1,
-						Title:   "Valid",
-						Content: "Exists",
-					}},
-					forceDBFail: true,
-				}
-				return usecase.NewNoteUsecase(*repo)
-			},
+			name:        "Repo error",
+			ids:         []uint{1},
+			forceDBFail: true,
 			wantErr:     true,
-			errContains: errors.New("failed to delete note"),
+			errContains: errors.New("failed to get notes"),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var repo *mockNoteRepository
-			noteUC := tt.setupRepo(&repo)
+			mockRepo := &mockNoteRepository{
+				notes: []domain.Note{
+					{ID: 1, Title: "Note 1", Content: "Content"},
+					{ID: 2, Title: "Note 2", Content: "Content"},
+					{ID: 4, Title: "Note 4", Content: "Content"},
+					{ID: 5, Title: "Note 5", Content: "Content", OwnerID: 7},
+				},
+				forceDBFail: tt.forceDBFail,
+			}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
 
-			err := noteUC.DeleteNote(tt.input)
+			notes, err := noteUC.GetNotesByIDs(tt.ids, tt.ownerID)
 
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errContains.Error())
 			} else {
 				assert.NoError(t, err)
-				assert.Len(t, repo.notes, 0)
+				gotIDs := make([]uint, 0, len(notes))
+				for _, n := range notes {
+					gotIDs = append(gotIDs, n.ID)
+				}
+				assert.Equal(t, tt.wantIDs, gotIDs)
 			}
 		})
 	}
 }
 
-func TestFilterNotes(t *testing.T) {
-	validFromDate := time.Date(2025, time.January, 12, 11, 30, 0, 0, time.UTC)
-	validToDate := time.Date(2025, time.June, 12, 11, 30, 0, 0, time.UTC)
+func TestUpdateNote(t *testing.T) {
 	tests := []struct {
 		name        string
-		input       domain.NoteFilter
+		noteID      uint
+		input       domain.Note
 		setupRepo   func() usecase.NoteUsecase
-		wantLen     int
 		wantErr     bool
 		errContains error
 	}{
 		{
-			name: "Valid: keyword only",
-			input: domain.NoteFilter{
-				Keyword:  "Title",
-				Category: "",
-				FromDate: nil,
-				ToDate:   nil,
+			name:   "valid update",
+			noteID: 1,
+			input: domain.Note{
+				ID:          1,
+				Title:       "Team Standup",
+				Content:     "Discussed issues that may affect other teams",
+				Category:    "Standup",
+				MeetingDate: time.Date(2025, time.June, 15, 10, 30, 0, 0, time.UTC),
 			},
 			setupRepo: func() usecase.NoteUsecase {
 				mockRepo := &mockNoteRepository{
 					notes: []domain.Note{{
 						ID:          1,
-						Title:       "Test Meeting Title",
-						Content:     "Test Meeting Content",
+						Title:       "Update Meeting Title",
+						Content:     "Update Meeting Content",
 						Category:    "Team Meeting",
-						MeetingDate: time.Date(2025, time.February, 12, 11, 30, 0, 0, time.UTC),
-					},
-						{
-							ID:          2,
-							Title:       "Test Meeting 2",
-							Content:     "Test Meeting Content: Title",
-							Category:    "Team Meeting",
-							MeetingDate: time.Date(2025, time.March, 12, 11, 30, 0, 0, time.UTC),
-						},
-						{
-							ID:          3,
-							Title:       "Test Meeting 3",
-							Content:     "Test Meeting Content",
-							Category:    "1:1",
-							MeetingDate: time.Date(2025, time.December, 12, 11, 30, 0, 0, time.UTC),
-						},
-					}}
+						MeetingDate: time.Date(2025, time.October, 12, 11, 30, 0, 0, time.UTC),
+					}}}
 				return usecase.NewNoteUsecase(mockRepo)
 			},
-			wantLen: 2,
 			wantErr: false,
 		},
 		{
-			name: "Valid: category only",
-			input: domain.NoteFilter{
-				Keyword:  "",
-				Category: "1:1",
-				FromDate: nil,
-				ToDate:   nil,
+			name:   "missing title",
+			noteID: 1,
+			input: domain.Note{
+				ID:          1,
+				Title:       "",
+				Content:     "Discussed pay rise",
+				Category:    "1:1",
+				MeetingDate: time.Date(2025, time.September, 15, 10, 30, 0, 0, time.UTC),
 			},
 			setupRepo: func() usecase.NoteUsecase {
 				mockRepo := &mockNoteRepository{
 					notes: []domain.Note{{
 						ID:          1,
-						Title:       "Test Meeting Title",
-						Content:     "Test Meeting Content",
+						Title:       "Update Meeting Title",
+						Content:     "Update Meeting Content",
 						Category:    "Team Meeting",
-						MeetingDate: time.Date(2025, time.February, 12, 11, 30, 0, 0, time.UTC),
-					},
-						{
-							ID:          2,
-							Title:       "Test Meeting 2",
-							Content:     "Test Meeting Content: Title",
-							Category:    "Team Meeting",
-							MeetingDate: time.Date(2025, time.March, 12, 11, 30, 0, 0, time.UTC),
-						},
-						{
-							ID:          3,
+						MeetingDate: time.Date(2025, time.October, 12, 11, 30, 0, 0, time.UTC),
+					}}}
+				return usecase.NewNoteUsecase(mockRepo)
+			},
+			wantErr:     true,
+			errContains: usecase.ErrEmptyTitle,
+		},
+		{
+			name:   "missing content",
+			noteID: 1,
+			input: domain.Note{
+				ID:          1,
+				Title:       "All-Hands",
+				Content:     "",
+				Category:    "Company-wide",
+				MeetingDate: time.Date(2025, time.August, 15, 10, 30, 0, 0, time.UTC),
+			},
+			setupRepo: func() usecase.NoteUsecase {
+				mockRepo := &mockNoteRepository{
+					notes: []domain.Note{{
+						ID:          1,
+						Title:       "Update Meeting Title",
+						Content:     "Update Meeting Content",
+						Category:    "Team Meeting",
+						MeetingDate: time.Date(2025, time.October, 12, 11, 30, 0, 0, time.UTC),
+					}}}
+				return usecase.NewNoteUsecase(mockRepo)
+			},
+			wantErr:     true,
+			errContains: usecase.ErrEmptyContent,
+		},
+		{
+			name:   "Note doesn't exist",
+			noteID: 4,
+			input: domain.Note{
+				ID:          4,
+				Title:       "All-Hands",
+				Content:     "Discussed issues that may affect other teams",
+				Category:    "Company-wide",
+				MeetingDate: time.Date(2025, time.August, 15, 10, 30, 0, 0, time.UTC),
+			},
+			setupRepo: func() usecase.NoteUsecase {
+				mockRepo := &mockNoteRepository{
+					notes: []domain.Note{{
+						ID:          1,
+						Title:       "Update Meeting Title",
+						Content:     "Update Meeting Content",
+						Category:    "Team Meeting",
+						MeetingDate: time.Date(2025, time.October, 12, 11, 30, 0, 0, time.UTC),
+					}}}
+				return usecase.NewNoteUsecase(mockRepo)
+			},
+			wantErr:     true,
+			errContains: usecase.ErrNoteNotFound,
+		},
+		{
+			name:   "repo fails",
+			noteID: 999,
+			input: domain.Note{
+				ID:          999,
+				Title:       "All-Hands",
+				Content:     "Discussed issues that may affect other teams",
+				Category:    "Company-wide",
+				MeetingDate: time.Date(2025, time.August, 15, 10, 30, 0, 0, time.UTC),
+			},
+			setupRepo: func() usecase.NoteUsecase {
+				mockRepo := &mockNoteRepository{
+					notes: []domain.Note{{
+						ID:          999,
+						Title:       "Update Meeting Title",
+						Content:     "Update Meeting Content",
+						Category:    "Team Meeting",
+						MeetingDate: time.Date(2025, time.October, 12, 11, 30, 0, 0, time.UTC),
+					}}}
+				return usecase.NewNoteUsecase(mockRepo)
+			},
+			wantErr:     true,
+			errContains: errors.New("failed to update note"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noteUC := tt.setupRepo()
+
+			err := noteUC.UpdateNote(&tt.input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDeleteNote(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       uint
+		setupRepo   func(repo **mockNoteRepository) usecase.NoteUsecase
+		wantErr     bool
+		errContains error
+		expectLen   *int // only used for success case
+	}{
+		{
+			name:  "valid ID",
+			input: 1,
+			setupRepo: func(repo **mockNoteRepository) usecase.NoteUsecase {
+				*repo = &mockNoteRepository{
+					notes: []domain.Note{{
+						ID:      1,
+						Title:   "Valid",
+						Content: "Exists",
+					}},
+				}
+				return usecase.NewNoteUsecase(*repo)
+			},
+			wantErr: false,
+		},
+		{
+			name:  "missing ID",
+			input: 0,
+			setupRepo: func(repo **mockNoteRepository) usecase.NoteUsecase {
+				*repo = &mockNoteRepository{
+					notes: []domain.Note{{
+						ID:      1,
+						Title:   "Valid",
+						Content: "Exists",
+					}},
+				}
+				return usecase.NewNoteUsecase(*repo)
+			},
+			wantErr:     true,
+			errContains: usecase.ErrNoteNotFound,
+		},
+		{
+			name:  "repo error",
+			input: 1,
+			setupRepo: func(repo **mockNoteRepository) usecase.NoteUsecase {
+				*repo = &mockNoteRepository{
+					notes: []domain.Note{{
+						ID:      1,
+						Title:   "Valid",
+						Content: "Exists",
+					}},
+					forceDBFail: true,
+				}
+				return usecase.NewNoteUsecase(*repo)
+			},
+			wantErr:     true,
+			errContains: errors.New("failed to delete note"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var repo *mockNoteRepository
+			noteUC := tt.setupRepo(&repo)
+
+			err := noteUC.DeleteNote(tt.input, 0)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, repo.notes, 0)
+			}
+		})
+	}
+}
+
+func TestFilterNotes(t *testing.T) {
+	validFromDate := time.Date(2025, time.January, 12, 11, 30, 0, 0, time.UTC)
+	validToDate := time.Date(2025, time.June, 12, 11, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		input       domain.NoteFilter
+		setupRepo   func() usecase.NoteUsecase
+		wantLen     int
+		wantErr     bool
+		errContains error
+	}{
+		{
+			name: "Valid: keyword only",
+			input: domain.NoteFilter{
+				Keyword:    "Title",
+				Categories: nil,
+				FromDate:   nil,
+				ToDate:     nil,
+			},
+			setupRepo: func() usecase.NoteUsecase {
+				mockRepo := &mockNoteRepository{
+					notes: []domain.Note{{
+						ID:          1,
+						Title:       "Test Meeting Title",
+						Content:     "Test Meeting Content",
+						Category:    "Team Meeting",
+						MeetingDate: time.Date(2025, time.February, 12, 11, 30, 0, 0, time.UTC),
+					},
+						{
+							ID:          2,
+							Title:       "Test Meeting 2",
+							Content:     "Test Meeting Content: Title",
+							Category:    "Team Meeting",
+							MeetingDate: time.Date(2025, time.March, 12, 11, 30, 0, 0, time.UTC),
+						},
+						{
+							ID:          3,
+							Title:       "Test Meeting 3",
+							Content:     "Test Meeting Content",
+							Category:    "1:1",
+							MeetingDate: time.Date(2025, time.December, 12, 11, 30, 0, 0, time.UTC),
+						},
+					}}
+				return usecase.NewNoteUsecase(mockRepo)
+			},
+			wantLen: 2,
+			wantErr: false,
+		},
+		{
+			name: "Valid: category only",
+			input: domain.NoteFilter{
+				Keyword:    "",
+				Categories: []string{"1:1"},
+				FromDate:   nil,
+				ToDate:     nil,
+			},
+			setupRepo: func() usecase.NoteUsecase {
+				mockRepo := &mockNoteRepository{
+					notes: []domain.Note{{
+						ID:          1,
+						Title:       "Test Meeting Title",
+						Content:     "Test Meeting Content",
+						Category:    "Team Meeting",
+						MeetingDate: time.Date(2025, time.February, 12, 11, 30, 0, 0, time.UTC),
+					},
+						{
+							ID:          2,
+							Title:       "Test Meeting 2",
+							Content:     "Test Meeting Content: Title",
+							Category:    "Team Meeting",
+							MeetingDate: time.Date(2025, time.March, 12, 11, 30, 0, 0, time.UTC),
+						},
+						{
+							ID:          3,
 							Title:       "Test Meeting 3",
 							Content:     "Test Meeting Content",
 							Category:    "1:1",
@@ -623,13 +1697,30 @@ func TestFilterNotes(t *testing.T) {
 			wantLen: 1,
 			wantErr: false,
 		},
+		{
+			name: "Valid: multiple categories",
+			input: domain.NoteFilter{
+				Categories: []string{"1:1", " Standup ", ""},
+			},
+			setupRepo: func() usecase.NoteUsecase {
+				mockRepo := &mockNoteRepository{
+					notes: []domain.Note{
+						{ID: 1, Title: "Team Meeting", Content: "Content", Category: "Team Meeting"},
+						{ID: 2, Title: "Daily Standup", Content: "Content", Category: "Standup"},
+						{ID: 3, Title: "Catch up", Content: "Content", Category: "1:1"},
+					}}
+				return usecase.NewNoteUsecase(mockRepo)
+			},
+			wantLen: 2,
+			wantErr: false,
+		},
 		{
 			name: "Valid: Full filter",
 			input: domain.NoteFilter{
-				Keyword:  "Title",
-				Category: "Team Meeting",
-				FromDate: &validFromDate,
-				ToDate:   &validToDate,
+				Keyword:    "Title",
+				Categories: []string{"Team Meeting"},
+				FromDate:   &validFromDate,
+				ToDate:     &validToDate,
 			},
 			setupRepo: func() usecase.NoteUsecase {
 				mockRepo := &mockNoteRepository{
@@ -663,10 +1754,10 @@ func TestFilterNotes(t *testing.T) {
 		{
 			name: "Invalid: bad date range",
 			input: domain.NoteFilter{
-				Keyword:  "",
-				Category: "",
-				FromDate: &validToDate,
-				ToDate:   &validFromDate,
+				Keyword:    "",
+				Categories: nil,
+				FromDate:   &validToDate,
+				ToDate:     &validFromDate,
 			},
 			setupRepo: func() usecase.NoteUsecase {
 				mockRepo := &mockNoteRepository{
@@ -683,19 +1774,67 @@ func TestFilterNotes(t *testing.T) {
 			errContains: errors.New("fromDate must be before toDate"),
 		},
 		{
-			name: "Repo fails",
+			name: "Valid: mixed-case category",
 			input: domain.NoteFilter{
-				Keyword:  "Title",
-				Category: "Team meeting",
-				FromDate: &validFromDate,
-				ToDate:   &validToDate,
+				Categories: []string{" sTaNdUp "},
 			},
 			setupRepo: func() usecase.NoteUsecase {
 				mockRepo := &mockNoteRepository{
-					notes: []domain.Note{{
-						ID:          999,
-						Title:       "Update Meeting Title",
-						Content:     "Update Meeting Content",
+					notes: []domain.Note{
+						{ID: 1, Title: "Daily", Content: "Content", Category: "Standup"},
+						{ID: 2, Title: "Catch up", Content: "Content", Category: "1:1"},
+					}}
+				return usecase.NewNoteUsecase(mockRepo)
+			},
+			wantLen: 1,
+		},
+		{
+			name: "Invalid: bad created range",
+			input: domain.NoteFilter{
+				CreatedFrom: &validToDate,
+				CreatedTo:   &validFromDate,
+			},
+			setupRepo: func() usecase.NoteUsecase {
+				return usecase.NewNoteUsecase(&mockNoteRepository{})
+			},
+			wantErr:     true,
+			errContains: usecase.ErrInvalidCreatedRange,
+		},
+		{
+			name: "Valid: meeting and created ranges combined",
+			input: domain.NoteFilter{
+				FromDate:    &validFromDate,
+				ToDate:      &validToDate,
+				CreatedFrom: &validFromDate,
+				CreatedTo:   &validToDate,
+			},
+			setupRepo: func() usecase.NoteUsecase {
+				inRange := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+				outOfRange := time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC)
+				mockRepo := &mockNoteRepository{
+					notes: []domain.Note{
+						{ID: 1, Title: "Both in range", Content: "Content", MeetingDate: inRange, CreatedAt: inRange},
+						{ID: 2, Title: "Entered late", Content: "Content", MeetingDate: inRange, CreatedAt: outOfRange},
+						{ID: 3, Title: "Meeting late", Content: "Content", MeetingDate: outOfRange, CreatedAt: inRange},
+					}}
+				return usecase.NewNoteUsecase(mockRepo)
+			},
+			wantLen: 1,
+		},
+		{
+			name: "Repo fails",
+			input: domain.NoteFilter{
+				Keyword:    "Title",
+				Categories: []string{"Team meeting"},
+				FromDate:   &validFromDate,
+				ToDate:     &validToDate,
+			},
+			setupRepo: func() usecase.NoteUsecase {
+				mockRepo := &mockNoteRepository{
+					notes: []domain.Note{{
+						ID:          999,
+						Title:       "Update Meeting Title",
+						Content:     "Update Meeting Content",
 						Category:    "Team Meeting",
 						MeetingDate: time.Date(2025, time.October, 12, 11, 30, 0, 0, time.UTC),
 					}},
@@ -709,10 +1848,10 @@ func TestFilterNotes(t *testing.T) {
 		{
 			name: "No results",
 			input: domain.NoteFilter{
-				Keyword:  "Title",
-				Category: "Team meeting",
-				FromDate: &validFromDate,
-				ToDate:   &validToDate,
+				Keyword:    "Title",
+				Categories: []string{"Team meeting"},
+				FromDate:   &validFromDate,
+				ToDate:     &validToDate,
 			},
 			setupRepo: func() usecase.NoteUsecase {
 				mockRepo := &mockNoteRepository{
@@ -729,7 +1868,7 @@ func TestFilterNotes(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			noteUC := tt.setupRepo()
 
-			searchResults, err := noteUC.FilterNotes(tt.input)
+			searchResults, _, err := noteUC.FilterNotes(tt.input, domain.Page{})
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -741,3 +1880,3483 @@ func TestFilterNotes(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateNote(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      domain.Note
+		pastWindow time.Duration
+		wantFields []string
+	}{
+		{
+			name:  "Valid note",
+			input: domain.Note{Title: "Team Meeting", Content: "Discussed sprint planning", MeetingDate: time.Now()},
+		},
+		{
+			name:       "Every failing field is reported",
+			input:      domain.Note{},
+			wantFields: []string{"title", "content", "meeting_date"},
+		},
+		{
+			name:       "Meeting date outside window",
+			input:      domain.Note{Title: "Team Meeting", MeetingDate: time.Now().AddDate(-2, 0, 0)},
+			pastWindow: 365 * 24 * time.Hour,
+			wantFields: []string{"content", "meeting_date"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{}
+			cfg := usecase.DefaultConfig()
+			cfg.MeetingDatePastWindow = tt.pastWindow
+			noteUC := usecase.NewNoteUsecaseWithConfig(mockRepo, cfg)
+
+			err := noteUC.ValidateNote(&tt.input, usecase.CreateOptions{})
+
+			assert.Len(t, mockRepo.notes, 0)
+			if tt.wantFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *usecase.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			gotFields := make([]string, 0, len(validationErr.Fields))
+			for _, f := range validationErr.Fields {
+				gotFields = append(gotFields, f.Field)
+			}
+			assert.Equal(t, tt.wantFields, gotFields)
+		})
+	}
+}
+
+func TestCreateNoteReportsAllFields(t *testing.T) {
+	noteUC := usecase.NewNoteUsecase(&mockNoteRepository{})
+
+	err := noteUC.CreateNote(&domain.Note{MeetingDate: time.Now()})
+
+	assert.ErrorIs(t, err, usecase.ErrEmptyTitle)
+	assert.ErrorIs(t, err, usecase.ErrEmptyContent)
+}
+
+func TestUpdateNoteReportsAllFields(t *testing.T) {
+	mockRepo := &mockNoteRepository{notes: []domain.Note{{ID: 1, Title: "Old", Content: "Old", MeetingDate: time.Now(), Version: 1}}}
+	noteUC := usecase.NewNoteUsecase(mockRepo)
+
+	err := noteUC.UpdateNote(&domain.Note{ID: 1, MeetingDate: time.Now(), Version: 1})
+
+	var validationErr *usecase.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Len(t, validationErr.Fields, 2)
+	assert.ErrorIs(t, err, usecase.ErrEmptyTitle)
+	assert.ErrorIs(t, err, usecase.ErrEmptyContent)
+	assert.Equal(t, "Old", mockRepo.notes[0].Title)
+}
+
+func TestCreateNoteWeekendRule(t *testing.T) {
+	weekday := time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC) // Monday
+	weekend := time.Date(2025, time.June, 15, 10, 30, 0, 0, time.UTC) // Sunday
+	// Late Friday in UTC is already Saturday in Auckland.
+	fridayUTC := time.Date(2025, time.June, 13, 20, 0, 0, 0, time.UTC)
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		rule         usecase.WeekendRule
+		location     *time.Location
+		meetingDate  time.Time
+		wantErr      bool
+		errContains  error
+		wantWarnings int
+	}{
+		{
+			name:        "Warn: weekday",
+			rule:        usecase.WeekendRuleWarn,
+			meetingDate: weekday,
+		},
+		{
+			name:         "Warn: weekend",
+			rule:         usecase.WeekendRuleWarn,
+			meetingDate:  weekend,
+			wantWarnings: 1,
+		},
+		{
+			name:        "Reject: weekday",
+			rule:        usecase.WeekendRuleReject,
+			meetingDate: weekday,
+		},
+		{
+			name:        "Reject: weekend",
+			rule:        usecase.WeekendRuleReject,
+			meetingDate: weekend,
+			wantErr:     true,
+			errContains: usecase.ErrWeekendMeetingDate,
+		},
+		{
+			name:        "Reject: weekend in configured timezone",
+			rule:        usecase.WeekendRuleReject,
+			location:    auckland,
+			meetingDate: fridayUTC,
+			wantErr:     true,
+			errContains: usecase.ErrWeekendMeetingDate,
+		},
+		{
+			name:        "Off: weekend",
+			rule:        usecase.WeekendRuleOff,
+			meetingDate: weekend,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{}
+			cfg := usecase.DefaultConfig()
+			cfg.WeekendMeetingRule = tt.rule
+			if tt.location != nil {
+				cfg.Location = tt.location
+			}
+			noteUC := usecase.NewNoteUsecaseWithConfig(mockRepo, cfg)
+
+			note := domain.Note{
+				Title:       "Team Meeting",
+				Content:     "Discussed sprint planning",
+				MeetingDate: tt.meetingDate,
+			}
+			err := noteUC.CreateNote(&note)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.errContains)
+				assert.Len(t, mockRepo.notes, 0)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, mockRepo.notes, 1)
+				assert.Len(t, note.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestRestoreDeletedSince(t *testing.T) {
+	now := time.Now()
+	trash := func() []domain.Note {
+		return []domain.Note{
+			{ID: 1, OwnerID: 7, Title: "Deleted last week", DeletedAt: gorm.DeletedAt{Time: now.Add(-7 * 24 * time.Hour), Valid: true}},
+			{ID: 2, OwnerID: 7, Title: "Deleted just now", DeletedAt: gorm.DeletedAt{Time: now.Add(-time.Minute), Valid: true}},
+			{ID: 4, OwnerID: 8, Title: "Theirs, deleted just now", DeletedAt: gorm.DeletedAt{Time: now.Add(-time.Minute), Valid: true}},
+		}
+	}
+
+	t.Run("Restores notes deleted in the window", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{trash: trash()}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+
+		restored, err := noteUC.RestoreDeletedSince(7, now.Add(-time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), restored)
+		assert.Len(t, mockRepo.trash, 2)
+		assert.Equal(t, uint(1), mockRepo.trash[0].ID)
+		assert.Equal(t, uint(4), mockRepo.trash[1].ID, "another owner's note")
+	})
+
+	t.Run("Future timestamp", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{trash: trash()})
+
+		_, err := noteUC.RestoreDeletedSince(7, now.Add(time.Hour))
+		assert.ErrorIs(t, err, usecase.ErrFutureWindow)
+	})
+
+	t.Run("Repo error", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{forceDBFail: true})
+
+		_, err := noteUC.RestoreDeletedSince(7, now.Add(-time.Hour))
+		assert.EqualError(t, err, "failed to restore notes")
+	})
+}
+
+func TestRestoreNotes(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        []uint
+		forceDBFail  bool
+		wantRestored int64
+		wantErr      bool
+		errContains  error
+	}{
+		{
+			name:         "Mix of restorable and non-restorable IDs",
+			input:        []uint{1, 2, 3, 42},
+			wantRestored: 2,
+		},
+		{
+			name:         "No restorable IDs",
+			input:        []uint{3, 42},
+			wantRestored: 0,
+		},
+		{
+			name:         "Another owner's note",
+			input:        []uint{4},
+			wantRestored: 0,
+		},
+		{
+			name:        "No IDs",
+			input:       []uint{},
+			wantErr:     true,
+			errContains: usecase.ErrNoIDs,
+		},
+		{
+			name:        "Repo error",
+			input:       []uint{1},
+			forceDBFail: true,
+			wantErr:     true,
+			errContains: errors.New("failed to restore notes"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{
+				notes: []domain.Note{{ID: 3, OwnerID: 7, Title: "Active", Content: "Not deleted"}},
+				trash: []domain.Note{
+					{ID: 1, OwnerID: 7, Title: "Deleted 1", Content: "In trash"},
+					{ID: 2, OwnerID: 7, Title: "Deleted 2", Content: "In trash"},
+					{ID: 4, OwnerID: 8, Title: "Theirs", Content: "In trash"},
+				},
+				forceDBFail: tt.forceDBFail,
+			}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+
+			restored, err := noteUC.RestoreNotes(7, tt.input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantRestored, restored)
+				assert.Len(t, mockRepo.notes, 1+int(tt.wantRestored))
+			}
+		})
+	}
+}
+
+func TestGetNoteExtremes(t *testing.T) {
+	tests := []struct {
+		name        string
+		forceDBFail bool
+		wantErr     bool
+		errContains error
+	}{
+		{
+			name: "Valid extremes",
+		},
+		{
+			name:        "Repo error",
+			forceDBFail: true,
+			wantErr:     true,
+			errContains: errors.New("failed to get note extremes"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{
+				notes: []domain.Note{
+					{ID: 1, Title: "Short", Content: "abc"},
+					{ID: 2, Title: "Long", Content: "abcdefghij"},
+				},
+				forceDBFail: tt.forceDBFail,
+			}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+
+			extremes, err := noteUC.GetNoteExtremes(0)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, uint(2), extremes.Longest.ID)
+				assert.Equal(t, uint(1), extremes.Shortest.ID)
+			}
+		})
+	}
+}
+
+func TestCreateNoteMeetingDateWindow(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		pastWindow  time.Duration
+		meetingDate time.Time
+		force       bool
+		wantErr     bool
+	}{
+		{
+			name:        "Unconfigured window allows old date",
+			meetingDate: now.AddDate(-5, 0, 0),
+		},
+		{
+			name:        "In window",
+			pastWindow:  365 * 24 * time.Hour,
+			meetingDate: now.AddDate(0, -1, 0),
+		},
+		{
+			name:        "Past window",
+			pastWindow:  365 * 24 * time.Hour,
+			meetingDate: now.AddDate(-2, 0, 0),
+			wantErr:     true,
+		},
+		{
+			name:        "Forced past window",
+			pastWindow:  365 * 24 * time.Hour,
+			meetingDate: now.AddDate(-2, 0, 0),
+			force:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{}
+			cfg := usecase.DefaultConfig()
+			cfg.MeetingDatePastWindow = tt.pastWindow
+			noteUC := usecase.NewNoteUsecaseWithConfig(mockRepo, cfg)
+
+			note := domain.Note{
+				Title:       "Team Meeting",
+				Content:     "Discussed sprint planning",
+				MeetingDate: tt.meetingDate,
+			}
+			err := noteUC.CreateNoteWithOptions(&note, usecase.CreateOptions{Force: tt.force})
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, usecase.ErrMeetingDateOutOfWindow)
+				var windowErr *usecase.MeetingDateWindowError
+				assert.ErrorAs(t, err, &windowErr)
+				assert.Len(t, mockRepo.notes, 0)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, mockRepo.notes, 1)
+			}
+		})
+	}
+}
+
+func TestDefaultMeetingDateToNow(t *testing.T) {
+	stored := time.Date(2025, time.October, 12, 11, 30, 0, 0, time.UTC)
+
+	t.Run("Create fills missing date with now", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{}
+		cfg := usecase.DefaultConfig()
+		cfg.DefaultMeetingDateToNow = true
+		noteUC := usecase.NewNoteUsecaseWithConfig(mockRepo, cfg)
+
+		before := time.Now()
+		note := domain.Note{Title: "Team Meeting", Content: "Discussed sprint planning"}
+		err := noteUC.CreateNote(&note)
+
+		assert.NoError(t, err)
+		assert.False(t, note.MeetingDate.Before(before))
+		assert.False(t, note.MeetingDate.After(time.Now()))
+	})
+
+	t.Run("Update keeps stored date", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{
+			notes: []domain.Note{{ID: 1, Title: "Old", Content: "Old", MeetingDate: stored}},
+		}
+		cfg := usecase.DefaultConfig()
+		cfg.DefaultMeetingDateToNow = true
+		noteUC := usecase.NewNoteUsecaseWithConfig(mockRepo, cfg)
+
+		note := domain.Note{ID: 1, Title: "New", Content: "New"}
+		err := noteUC.UpdateNote(&note)
+
+		assert.NoError(t, err)
+		assert.Equal(t, stored, note.MeetingDate)
+		assert.Equal(t, stored, mockRepo.notes[0].MeetingDate)
+	})
+
+	t.Run("Update rejects missing date by default", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{
+			notes: []domain.Note{{ID: 1, Title: "Old", Content: "Old", MeetingDate: stored}},
+		}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+
+		err := noteUC.UpdateNote(&domain.Note{ID: 1, Title: "New", Content: "New"})
+
+		assert.ErrorIs(t, err, usecase.ErrInvalidMeetingDate)
+	})
+}
+
+func TestCreateNoteUseTemplate(t *testing.T) {
+	templates := template.NewRegistry()
+	templates.Register("Standup", "## Agenda\n")
+
+	tests := []struct {
+		name        string
+		category    string
+		content     string
+		useTemplate bool
+		wantContent string
+		wantErr     error
+	}{
+		{
+			name:        "Empty content seeded from template",
+			category:    "Standup",
+			useTemplate: true,
+			wantContent: "## Agenda",
+		},
+		{
+			name:        "Blank content seeded from template",
+			category:    "Standup",
+			content:     "  \n ",
+			useTemplate: true,
+			wantContent: "## Agenda",
+		},
+		{
+			name:        "Markup-only content seeded from template",
+			category:    "Standup",
+			content:     "<p> </p>",
+			useTemplate: true,
+			wantContent: "## Agenda",
+		},
+		{
+			name:        "Existing content is kept",
+			category:    "Standup",
+			content:     "Already written",
+			useTemplate: true,
+			wantContent: "Already written",
+		},
+		{
+			name:        "No template for category",
+			category:    "Retro",
+			useTemplate: true,
+			wantErr:     usecase.ErrEmptyContent,
+		},
+		{
+			name:     "Template not requested",
+			category: "Standup",
+			wantErr:  usecase.ErrEmptyContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{}
+			cfg := usecase.DefaultConfig()
+			cfg.Templates = templates
+			noteUC := usecase.NewNoteUsecaseWithConfig(mockRepo, cfg)
+
+			note := domain.Note{Title: "Daily sync", Content: tt.content, Category: tt.category, MeetingDate: time.Now()}
+			err := noteUC.CreateNoteWithOptions(&note, usecase.CreateOptions{UseTemplate: tt.useTemplate})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Len(t, mockRepo.notes, 0)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantContent, mockRepo.notes[0].Content)
+			}
+		})
+	}
+
+	// A dry run with the same options agrees with the create.
+	t.Run("Validate with template", func(t *testing.T) {
+		cfg := usecase.DefaultConfig()
+		cfg.Templates = templates
+		noteUC := usecase.NewNoteUsecaseWithConfig(&mockNoteRepository{}, cfg)
+
+		note := domain.Note{Title: "Daily sync", Category: "Standup", MeetingDate: time.Now()}
+		assert.NoError(t, noteUC.ValidateNote(&note, usecase.CreateOptions{UseTemplate: true}))
+		assert.Equal(t, "## Agenda", note.Content)
+
+		note = domain.Note{Title: "Daily sync", Category: "Standup", MeetingDate: time.Now()}
+		assert.ErrorIs(t, noteUC.ValidateNote(&note, usecase.CreateOptions{}), usecase.ErrEmptyContent)
+	})
+}
+
+func TestRenameCategory(t *testing.T) {
+	tests := []struct {
+		name        string
+		from        string
+		to          string
+		forceDBFail bool
+		wantRenamed int64
+		wantErr     bool
+		errContains error
+	}{
+		{
+			name:        "Valid rename",
+			from:        "OldTeam",
+			to:          "NewTeam",
+			wantRenamed: 2,
+		},
+		{
+			name:        "No matching notes",
+			from:        "Nobody",
+			to:          "NewTeam",
+			wantRenamed: 0,
+		},
+		{
+			name:        "Empty from",
+			from:        " ",
+			to:          "NewTeam",
+			wantErr:     true,
+			errContains: usecase.ErrEmptyCategory,
+		},
+		{
+			name:        "Empty to",
+			from:        "OldTeam",
+			wantErr:     true,
+			errContains: usecase.ErrEmptyCategory,
+		},
+		{
+			name:        "Same category",
+			from:        "OldTeam",
+			to:          " OldTeam ",
+			wantErr:     true,
+			errContains: usecase.ErrSameCategory,
+		},
+		{
+			name:        "Repo error",
+			from:        "OldTeam",
+			to:          "NewTeam",
+			forceDBFail: true,
+			wantErr:     true,
+			errContains: errors.New("failed to rename category"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{
+				notes: []domain.Note{
+					{ID: 1, OwnerID: 7, Title: "Sync 1", Content: "Content", Category: "OldTeam"},
+					{ID: 2, OwnerID: 7, Title: "Sync 2", Content: "Content", Category: "OldTeam"},
+					{ID: 4, OwnerID: 7, Title: "Catch up", Content: "Content", Category: "1:1"},
+					{ID: 5, OwnerID: 8, Title: "Their sync", Content: "Content", Category: "OldTeam"},
+				},
+				forceDBFail: tt.forceDBFail,
+			}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+
+			renamed, err := noteUC.RenameCategory(7, tt.from, tt.to)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantRenamed, renamed)
+				assert.Equal(t, "1:1", mockRepo.notes[2].Category)
+				assert.Equal(t, "OldTeam", mockRepo.notes[3].Category, "another owner's note")
+			}
+		})
+	}
+}
+
+func TestGetCategories(t *testing.T) {
+	tests := []struct {
+		name        string
+		forceDBFail bool
+		wantLen     int
+		wantErr     bool
+		errContains error
+	}{
+		{
+			name:    "Valid categories",
+			wantLen: 2,
+		},
+		{
+			name:        "Repo error",
+			forceDBFail: true,
+			wantErr:     true,
+			errContains: errors.New("failed to get categories"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{
+				notes: []domain.Note{
+					{ID: 1, Title: "Standup 1", Content: "Content", Category: "Standup"},
+					{ID: 2, Title: "Standup 2", Content: "Content", Category: "Standup"},
+					{ID: 3, Title: "Catch up", Content: "Content", Category: "1:1"},
+					{ID: 4, Title: "Uncategorised", Content: "Content"},
+				},
+				forceDBFail: tt.forceDBFail,
+			}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+
+			categories, err := noteUC.GetCategories(0)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, categories, tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestUpdateNotePreservesCreatedAt(t *testing.T) {
+	createdAt := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+	mockRepo := &mockNoteRepository{notes: []domain.Note{
+		{ID: 1, OwnerID: 7, Title: "Title", Content: "Content", MeetingDate: createdAt, Version: 1, CreatedAt: createdAt},
+	}}
+	noteUC := usecase.NewNoteUsecase(mockRepo)
+
+	// A note built from a request body carries whatever the client sent.
+	input := domain.Note{ID: 1, OwnerID: 7, Title: "Edited", Content: "Edited", MeetingDate: createdAt, Version: 1, CreatedAt: time.Now()}
+	assert.NoError(t, noteUC.UpdateNote(&input))
+
+	if assert.Len(t, mockRepo.updated, 1) {
+		assert.Equal(t, uint(1), mockRepo.updated[0].ID)
+		assert.Equal(t, createdAt, mockRepo.updated[0].CreatedAt)
+	}
+	assert.Equal(t, uint(1), input.ID)
+	assert.Equal(t, createdAt, input.CreatedAt)
+}
+
+func TestUpdateNoteVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		noteID      uint
+		version     int
+		wantErr     bool
+		wantVersion int
+	}{
+		{
+			name:        "Matching version",
+			noteID:      1,
+			version:     2,
+			wantVersion: 3,
+		},
+		{
+			name:    "Stale version",
+			noteID:  1,
+			version: 1,
+			wantErr: true,
+		},
+		{
+			name:    "Concurrent update in repository",
+			noteID:  998,
+			version: 2,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{
+				notes: []domain.Note{
+					{ID: 1, Title: "Title", Content: "Content", Version: 2},
+					{ID: 998, Title: "Title", Content: "Content", Version: 2},
+				},
+			}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+
+			input := domain.Note{ID: tt.noteID, Title: "Edited", Content: "Edited", MeetingDate: time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC), Version: tt.version}
+			err := noteUC.UpdateNote(&input)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, usecase.ErrStaleUpdate)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantVersion, input.Version)
+			}
+		})
+	}
+}
+
+func TestPurgeDeletedNotes(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		olderThan   time.Duration
+		forceDBFail bool
+		wantPurged  int64
+		wantErr     bool
+		errContains error
+	}{
+		{
+			name:       "Purges only notes deleted before cutoff",
+			olderThan:  30 * 24 * time.Hour,
+			wantPurged: 1,
+		},
+		{
+			name:       "Zero age purges all deleted notes",
+			olderThan:  0,
+			wantPurged: 2,
+		},
+		{
+			name:        "Negative age",
+			olderThan:   -time.Hour,
+			wantErr:     true,
+			errContains: usecase.ErrInvalidAge,
+		},
+		{
+			name:        "Repo error",
+			olderThan:   time.Hour,
+			forceDBFail: true,
+			wantErr:     true,
+			errContains: errors.New("failed to purge deleted notes"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{
+				notes: []domain.Note{{ID: 1, Title: "Active", Content: "Not deleted"}},
+				trash: []domain.Note{
+					{ID: 2, Title: "Old", Content: "Deleted long ago", DeletedAt: gorm.DeletedAt{Time: now.AddDate(0, -2, 0), Valid: true}},
+					{ID: 3, Title: "Recent", Content: "Deleted yesterday", DeletedAt: gorm.DeletedAt{Time: now.AddDate(0, 0, -1), Valid: true}},
+				},
+				forceDBFail: tt.forceDBFail,
+			}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+
+			purged, err := noteUC.PurgeDeletedNotes(tt.olderThan)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantPurged, purged)
+				assert.Len(t, mockRepo.notes, 1)
+				assert.WithinDuration(t, now.Add(-tt.olderThan), mockRepo.purgeCutoff, time.Minute)
+			}
+		})
+	}
+}
+
+func TestAutoArchiveNotes(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		olderThan    time.Duration
+		forceDBFail  bool
+		wantArchived int64
+		wantErr      bool
+		errContains  error
+	}{
+		{
+			name:         "Archives only meetings before cutoff",
+			olderThan:    180 * 24 * time.Hour,
+			wantArchived: 1,
+		},
+		{
+			name:         "Zero age archives every past meeting",
+			olderThan:    0,
+			wantArchived: 2,
+		},
+		{
+			name:        "Negative age",
+			olderThan:   -time.Hour,
+			wantErr:     true,
+			errContains: usecase.ErrInvalidArchiveAge,
+		},
+		{
+			name:        "Repo error",
+			olderThan:   time.Hour,
+			forceDBFail: true,
+			wantErr:     true,
+			errContains: errors.New("failed to archive old notes"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{
+				notes: []domain.Note{
+					{ID: 1, Title: "Old", Content: "Last year", MeetingDate: now.AddDate(-1, 0, 0)},
+					{ID: 2, Title: "Recent", Content: "Last week", MeetingDate: now.AddDate(0, 0, -7)},
+					{ID: 3, Title: "Already archived", Content: "Long ago", MeetingDate: now.AddDate(-2, 0, 0), Archived: true},
+					{ID: 4, Title: "Upcoming", Content: "Next week", MeetingDate: now.AddDate(0, 0, 7)},
+				},
+				forceDBFail: tt.forceDBFail,
+			}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+
+			archived, err := noteUC.AutoArchiveNotes(tt.olderThan)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantArchived, archived)
+				assert.False(t, mockRepo.notes[3].Archived)
+				assert.WithinDuration(t, now.Add(-tt.olderThan), mockRepo.archiveCutoff, time.Minute)
+			}
+		})
+	}
+}
+
+func TestGetNoteStats(t *testing.T) {
+	tests := []struct {
+		name        string
+		notes       []domain.Note
+		forceDBFail bool
+		wantTotal   int64
+		wantErr     bool
+		errContains error
+	}{
+		{
+			name:      "Valid stats",
+			notes:     []domain.Note{{ID: 1}, {ID: 2}},
+			wantTotal: 2,
+		},
+		{
+			name:      "Empty DB",
+			wantTotal: 0,
+		},
+		{
+			name:        "Repo error",
+			forceDBFail: true,
+			wantErr:     true,
+			errContains: errors.New("failed to get note stats"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{notes: tt.notes, forceDBFail: tt.forceDBFail}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+
+			stats, err := noteUC.GetNoteStats(0, "")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTotal, stats.Total)
+			}
+		})
+	}
+}
+
+func TestSearchNotesByKeyword(t *testing.T) {
+	tests := []struct {
+		name         string
+		keyword      string
+		forceDBFail  bool
+		wantSnippets []string
+		wantErr      bool
+		errContains  error
+	}{
+		{
+			name:    "Matches in content and title",
+			keyword: "BUDGET",
+			wantSnippets: []string{
+				"Final **budget** approved",
+				"**Budget** sync",
+			},
+		},
+		{
+			name:        "Empty keyword",
+			keyword:     " ",
+			wantErr:     true,
+			errContains: errors.New("search keyword cannot be empty"),
+		},
+		{
+			name:        "Repo error",
+			keyword:     "budget",
+			forceDBFail: true,
+			wantErr:     true,
+			errContains: errors.New("failed to find notes"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{
+				notes: []domain.Note{
+					{ID: 1, Title: "Budget sync", Content: "Went over numbers", MeetingDate: time.Date(2025, time.May, 1, 9, 0, 0, 0, time.UTC)},
+					{ID: 2, Title: "Planning", Content: "Final budget approved", MeetingDate: time.Date(2025, time.June, 1, 9, 0, 0, 0, time.UTC)},
+					{ID: 3, Title: "Standup", Content: "Sprint items", MeetingDate: time.Date(2025, time.July, 1, 9, 0, 0, 0, time.UTC)},
+				},
+				forceDBFail: tt.forceDBFail,
+			}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+
+			results, _, err := noteUC.SearchNotesByKeyword(0, tt.keyword, domain.DateRange{}, domain.Page{})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains.Error())
+			} else {
+				assert.NoError(t, err)
+				snippets := make([]string, 0, len(results))
+				for _, r := range results {
+					snippets = append(snippets, r.Snippet)
+				}
+				assert.Equal(t, tt.wantSnippets, snippets)
+			}
+		})
+	}
+}
+
+func TestSearchNotesByKeywordPage(t *testing.T) {
+	tests := []struct {
+		name      string
+		page      domain.Page
+		wantIDs   []uint
+		wantTotal int64
+		wantErr   error
+	}{
+		{name: "No page returns everything", page: domain.Page{}, wantIDs: []uint{3, 2, 1}, wantTotal: 3},
+		{name: "First page", page: domain.Page{Limit: 2}, wantIDs: []uint{3, 2}, wantTotal: 3},
+		{name: "Second page", page: domain.Page{Limit: 2, Offset: 2}, wantIDs: []uint{1}, wantTotal: 3},
+		{name: "Offset past the end", page: domain.Page{Limit: 2, Offset: 5}, wantIDs: []uint{}, wantTotal: 3},
+		{name: "Negative limit", page: domain.Page{Limit: -1}, wantErr: usecase.ErrInvalidPage},
+		{name: "Negative offset", page: domain.Page{Offset: -1}, wantErr: usecase.ErrInvalidPage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{
+				notes: []domain.Note{
+					{ID: 1, Title: "Budget 1", Content: "Content", MeetingDate: time.Date(2025, time.May, 1, 9, 0, 0, 0, time.UTC)},
+					{ID: 2, Title: "Budget 2", Content: "Content", MeetingDate: time.Date(2025, time.June, 1, 9, 0, 0, 0, time.UTC)},
+					{ID: 3, Title: "Budget 3", Content: "Content", MeetingDate: time.Date(2025, time.July, 1, 9, 0, 0, 0, time.UTC)},
+				},
+			}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+
+			results, total, err := noteUC.SearchNotesByKeyword(0, "budget", domain.DateRange{}, tt.page)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTotal, total)
+			gotIDs := make([]uint, 0, len(results))
+			for _, r := range results {
+				gotIDs = append(gotIDs, r.Note.ID)
+			}
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
+
+func TestSearchNotesByKeywordDateRange(t *testing.T) {
+	may := time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC)
+	june := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		dates   domain.DateRange
+		wantIDs []uint
+		wantErr error
+	}{
+		{name: "Open range", dates: domain.DateRange{}, wantIDs: []uint{3, 2, 1}},
+		{name: "From only", dates: domain.DateRange{From: &june}, wantIDs: []uint{3, 2}},
+		{name: "Both ends", dates: domain.DateRange{From: &may, To: &june}, wantIDs: []uint{2, 1}},
+		{name: "From after to", dates: domain.DateRange{From: &june, To: &may}, wantErr: usecase.ErrInvalidDateRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{
+				notes: []domain.Note{
+					{ID: 1, Title: "Budget 1", Content: "Content", MeetingDate: may},
+					{ID: 2, Title: "Budget 2", Content: "Content", MeetingDate: june},
+					{ID: 3, Title: "Budget 3", Content: "Content", MeetingDate: time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+
+			results, _, err := noteUC.SearchNotesByKeyword(0, "budget", tt.dates, domain.Page{})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			gotIDs := make([]uint, 0, len(results))
+			for _, r := range results {
+				gotIDs = append(gotIDs, r.Note.ID)
+			}
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
+
+func TestSearchNotesByKeywordVisibility(t *testing.T) {
+	mockRepo := &mockNoteRepository{
+		notes: []domain.Note{
+			{ID: 1, OwnerID: 7, Title: "Budget mine", Content: "Content", Visibility: domain.VisibilityPrivate},
+			{ID: 2, OwnerID: 8, Title: "Budget theirs", Content: "Content", Visibility: domain.VisibilityPrivate},
+			{ID: 3, OwnerID: 8, Title: "Budget shared", Content: "Content", Visibility: domain.VisibilityShared},
+		},
+	}
+	noteUC := usecase.NewNoteUsecase(mockRepo)
+
+	results, total, err := noteUC.SearchNotesByKeyword(7, "budget", domain.DateRange{}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	gotIDs := make([]uint, 0, len(results))
+	for _, r := range results {
+		gotIDs = append(gotIDs, r.Note.ID)
+	}
+	assert.ElementsMatch(t, []uint{1, 3}, gotIDs)
+}
+
+func TestNoteOwnerScope(t *testing.T) {
+	newUC := func() (usecase.NoteUsecase, *mockNoteRepository) {
+		mockRepo := &mockNoteRepository{
+			notes: []domain.Note{
+				{ID: 1, OwnerID: 7, Title: "Mine", Content: "Content"},
+				{ID: 2, OwnerID: 8, Title: "Theirs", Content: "Content"},
+			},
+		}
+		return usecase.NewNoteUsecase(mockRepo), mockRepo
+	}
+
+	t.Run("GetAllNotes only returns own notes", func(t *testing.T) {
+		noteUC, _ := newUC()
+		notes, _, err := noteUC.GetAllNotes(7, false)
+		assert.NoError(t, err)
+		assert.Len(t, notes, 1)
+		assert.Equal(t, uint(1), notes[0].ID)
+	})
+
+	t.Run("GetNoteByID hides other owner's note", func(t *testing.T) {
+		noteUC, _ := newUC()
+		_, err := noteUC.GetNoteByID(2, 7)
+		assert.ErrorIs(t, err, usecase.ErrNoteNotFound)
+	})
+
+	t.Run("UpdateNote hides other owner's note", func(t *testing.T) {
+		noteUC, _ := newUC()
+		err := noteUC.UpdateNote(&domain.Note{ID: 2, OwnerID: 7, Title: "Edited", Content: "Edited"})
+		assert.ErrorIs(t, err, usecase.ErrNoteNotFound)
+	})
+
+	t.Run("DeleteNote hides other owner's note", func(t *testing.T) {
+		noteUC, mockRepo := newUC()
+		err := noteUC.DeleteNote(2, 7)
+		assert.ErrorIs(t, err, usecase.ErrNoteNotFound)
+		assert.Len(t, mockRepo.notes, 2)
+	})
+}
+
+func TestGetAllNotesMaxListSize(t *testing.T) {
+	notes := []domain.Note{
+		{ID: 1, Title: "First", MeetingDate: time.Date(2025, time.June, 1, 9, 0, 0, 0, time.UTC)},
+		{ID: 2, Title: "Second", MeetingDate: time.Date(2025, time.June, 2, 9, 0, 0, 0, time.UTC)},
+		{ID: 4, Title: "Third", MeetingDate: time.Date(2025, time.June, 3, 9, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name          string
+		maxListSize   int
+		wantCount     int
+		wantTruncated bool
+	}{
+		{name: "Under the cap", maxListSize: 5, wantCount: 3},
+		{name: "Exactly at the cap", maxListSize: 3, wantCount: 3},
+		{name: "Over the cap", maxListSize: 2, wantCount: 2, wantTruncated: true},
+		{name: "Unset uses the default", maxListSize: 0, wantCount: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := usecase.DefaultConfig()
+			cfg.MaxListSize = tt.maxListSize
+			noteUC := usecase.NewNoteUsecaseWithConfig(&mockNoteRepository{notes: append([]domain.Note(nil), notes...)}, cfg)
+
+			got, truncated, err := noteUC.GetAllNotes(0, false)
+			assert.NoError(t, err)
+			assert.Len(t, got, tt.wantCount)
+			assert.Equal(t, tt.wantTruncated, truncated)
+		})
+	}
+}
+
+func TestGetNotesByCategory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, time.June, d, 9, 0, 0, 0, time.UTC) }
+	notes := []domain.Note{
+		{ID: 1, Title: "Old standup", Category: "Standup", MeetingDate: day(1)},
+		{ID: 2, Title: "Planning", Category: "Planning", MeetingDate: day(2)},
+		{ID: 4, Title: "New standup", Category: "Standup", MeetingDate: day(5)},
+		{ID: 5, Title: "Loose note", MeetingDate: day(3)},
+		{ID: 6, Title: "Mid standup", Category: "Standup", MeetingDate: day(4)},
+		{ID: 7, OwnerID: 9, Title: "Someone else's", Category: "Standup", MeetingDate: day(6)},
+	}
+	titles := func(group []domain.Note) []string {
+		var got []string
+		for _, n := range group {
+			got = append(got, n.Title)
+		}
+		return got
+	}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  map[string][]string
+	}{
+		{
+			name: "Every note",
+			want: map[string][]string{
+				"Standup":  {"New standup", "Mid standup", "Old standup"},
+				"Planning": {"Planning"},
+				"":         {"Loose note"},
+			},
+		},
+		{
+			name:  "Limit per group",
+			limit: 2,
+			want: map[string][]string{
+				"Standup":  {"New standup", "Mid standup"},
+				"Planning": {"Planning"},
+				"":         {"Loose note"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: append([]domain.Note(nil), notes...)})
+
+			groups, truncated, err := noteUC.GetNotesByCategory(0, false, tt.limit)
+			assert.NoError(t, err)
+			assert.False(t, truncated)
+			assert.Len(t, groups, len(tt.want))
+			for category, want := range tt.want {
+				assert.Equal(t, want, titles(groups[category]), category)
+			}
+		})
+	}
+
+	t.Run("Negative limit", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes})
+		_, _, err := noteUC.GetNotesByCategory(0, false, -1)
+		assert.ErrorIs(t, err, usecase.ErrInvalidPage)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{forceDBFail: true})
+		_, _, err := noteUC.GetNotesByCategory(0, false, 0)
+		assert.EqualError(t, err, "failed to get grouped notes")
+	})
+}
+
+func TestNoteVisibility(t *testing.T) {
+	newUC := func() (usecase.NoteUsecase, *mockNoteRepository) {
+		mockRepo := &mockNoteRepository{
+			notes: []domain.Note{
+				{ID: 1, OwnerID: 8, Title: "Private", Content: "Content", Visibility: domain.VisibilityPrivate, Version: 1},
+				{ID: 2, OwnerID: 8, Title: "Shared", Content: "Content", Visibility: domain.VisibilityShared, Version: 1},
+				{ID: 4, OwnerID: 8, Title: "Public", Content: "Content", Visibility: domain.VisibilityPublic, Version: 1},
+			},
+		}
+		return usecase.NewNoteUsecase(mockRepo), mockRepo
+	}
+
+	tests := []struct {
+		name    string
+		id      uint
+		userID  uint
+		wantErr error
+	}{
+		{name: "Owner sees private note", id: 1, userID: 8},
+		{name: "Private note is hidden from others", id: 1, userID: 7, wantErr: usecase.ErrNoteNotFound},
+		{name: "Shared note is visible to signed-in users", id: 2, userID: 7},
+		{name: "Shared note is hidden from anonymous callers", id: 2, userID: 0, wantErr: usecase.ErrNoteNotFound},
+		{name: "Public note is visible to anonymous callers", id: 4, userID: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noteUC, _ := newUC()
+			_, err := noteUC.GetNoteByID(tt.id, tt.userID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("GetAllNotes returns visible notes", func(t *testing.T) {
+		noteUC, _ := newUC()
+		notes, _, err := noteUC.GetAllNotes(7, false)
+		assert.NoError(t, err)
+		assert.Len(t, notes, 2)
+	})
+
+	t.Run("Only the owner can change a public note", func(t *testing.T) {
+		noteUC, mockRepo := newUC()
+		err := noteUC.UpdateNote(&domain.Note{ID: 4, OwnerID: 7, Title: "Edited", Content: "Edited", Version: 1})
+		assert.ErrorIs(t, err, usecase.ErrNoteNotFound)
+		assert.ErrorIs(t, noteUC.DeleteNote(4, 7), usecase.ErrNoteNotFound)
+		assert.Len(t, mockRepo.notes, 3)
+	})
+
+	t.Run("Defaults to private", func(t *testing.T) {
+		noteUC, _ := newUC()
+		note := domain.Note{Title: "New", Content: "Content", MeetingDate: time.Now()}
+		assert.NoError(t, noteUC.CreateNote(&note))
+		assert.Equal(t, domain.VisibilityPrivate, note.Visibility)
+	})
+
+	t.Run("Unknown visibility", func(t *testing.T) {
+		noteUC, _ := newUC()
+		note := domain.Note{Title: "New", Content: "Content", Visibility: "friends", MeetingDate: time.Now()}
+		err := noteUC.CreateNote(&note)
+		assert.ErrorIs(t, err, usecase.ErrInvalidVisibility)
+		var validationErr *usecase.ValidationError
+		if assert.ErrorAs(t, err, &validationErr) {
+			assert.Equal(t, "visibility", validationErr.Fields[0].Field)
+		}
+	})
+}
+
+func TestArchiveNote(t *testing.T) {
+	newRepo := func() *mockNoteRepository {
+		return &mockNoteRepository{notes: []domain.Note{
+			{ID: 1, OwnerID: 7, Title: "Old", Content: "Some notes", MeetingDate: time.Now()},
+			{ID: 2, OwnerID: 7, Title: "Current", Content: "Some notes", MeetingDate: time.Now()},
+		}}
+	}
+
+	t.Run("Archived notes are hidden by default", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(newRepo())
+		assert.NoError(t, noteUC.ArchiveNote(1, 7, true))
+
+		notes, _, err := noteUC.GetAllNotes(7, false)
+		assert.NoError(t, err)
+		assert.Len(t, notes, 1)
+		assert.Equal(t, uint(2), notes[0].ID)
+
+		notes, _, err = noteUC.GetAllNotes(7, true)
+		assert.NoError(t, err)
+		assert.Len(t, notes, 2)
+
+		_, total, err := noteUC.FilterNotes(domain.NoteFilter{}, domain.Page{})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+
+		_, total, err = noteUC.FilterNotes(domain.NoteFilter{IncludeArchived: true}, domain.Page{})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+
+		note, err := noteUC.GetNoteByID(1, 7)
+		assert.NoError(t, err)
+		assert.True(t, note.Archived)
+	})
+
+	t.Run("Unarchive", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(newRepo())
+		assert.NoError(t, noteUC.ArchiveNote(1, 7, true))
+		assert.NoError(t, noteUC.ArchiveNote(1, 7, false))
+
+		notes, _, err := noteUC.GetAllNotes(7, false)
+		assert.NoError(t, err)
+		assert.Len(t, notes, 2)
+	})
+
+	t.Run("Archived note can be deleted and restored", func(t *testing.T) {
+		mockRepo := newRepo()
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+		assert.NoError(t, noteUC.ArchiveNote(1, 7, true))
+		assert.NoError(t, noteUC.DeleteNote(1, 7))
+
+		notes, _, err := noteUC.GetAllNotes(7, true)
+		assert.NoError(t, err)
+		assert.Len(t, notes, 1)
+
+		err = noteUC.ArchiveNote(1, 7, false)
+		assert.ErrorIs(t, err, usecase.ErrNoteNotFound)
+
+		restored, err := noteUC.RestoreNotes(7, []uint{1})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), restored)
+
+		note, err := noteUC.GetNoteByID(1, 7)
+		assert.NoError(t, err)
+		assert.True(t, note.Archived)
+	})
+
+	t.Run("Other owner", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(newRepo())
+		err := noteUC.ArchiveNote(1, 8, true)
+		assert.ErrorIs(t, err, usecase.ErrNoteNotFound)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockRepo := newRepo()
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+		mockRepo.forceDBFail = true
+		err := noteUC.ArchiveNote(1, 7, true)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, usecase.ErrNoteNotFound)
+	})
+
+	t.Run("Existence check error", func(t *testing.T) {
+		mockRepo := newRepo()
+		mockRepo.notes = append(mockRepo.notes, domain.Note{ID: 3, OwnerID: 7, Title: "Broken"})
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+		err := noteUC.ArchiveNote(3, 7, true)
+		assert.EqualError(t, err, "failed to archive note")
+		assert.False(t, mockRepo.notes[2].Archived)
+	})
+}
+
+func TestListRevisions(t *testing.T) {
+	note := domain.Note{ID: 1, OwnerID: 7, Title: "Planning", Content: "three", Category: "Team", Version: 3}
+	stored := []domain.NoteRevision{
+		{NoteID: 1, Version: 1, Title: "Planning", Content: "one"},
+		{NoteID: 1, Version: 2, Title: "Planning", Content: "two"},
+		{NoteID: 2, Version: 1, Title: "Other", Content: "other"},
+	}
+
+	tests := []struct {
+		name         string
+		revisions    []domain.NoteRevision
+		ownerID      uint
+		forceDBFail  bool
+		wantVersions []int
+		wantErr      error
+	}{
+		{
+			name:         "Current state appended when missing",
+			revisions:    stored,
+			ownerID:      7,
+			wantVersions: []int{1, 2, 3},
+		},
+		{
+			name:         "Current state already stored",
+			revisions:    append(stored, domain.NoteRevision{NoteID: 1, Version: 3, Content: "three"}),
+			ownerID:      7,
+			wantVersions: []int{1, 2, 3},
+		},
+		{
+			name:         "Note without stored history",
+			ownerID:      7,
+			wantVersions: []int{3},
+		},
+		{
+			name:    "Other owner",
+			ownerID: 8,
+			wantErr: usecase.ErrNoteNotFound,
+		},
+		{
+			name:        "Repository error",
+			ownerID:     7,
+			forceDBFail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{notes: []domain.Note{note}, revisions: tt.revisions, forceDBFail: tt.forceDBFail}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+
+			revisions, err := noteUC.ListRevisions(1, tt.ownerID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if tt.forceDBFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			versions := make([]int, 0, len(revisions))
+			for _, r := range revisions {
+				versions = append(versions, r.Version)
+			}
+			assert.Equal(t, tt.wantVersions, versions)
+			assert.Equal(t, "three", revisions[len(revisions)-1].Content)
+		})
+	}
+}
+
+func TestDiffRevisions(t *testing.T) {
+	mockRepo := &mockNoteRepository{
+		notes: []domain.Note{{ID: 1, OwnerID: 7, Content: "agenda\nactions: none\nnext steps", Version: 3}},
+		revisions: []domain.NoteRevision{
+			{NoteID: 1, Version: 1, Content: "agenda"},
+			{NoteID: 1, Version: 2, Content: "agenda\nactions: tbd"},
+		},
+	}
+	noteUC := usecase.NewNoteUsecase(mockRepo)
+
+	t.Run("Between stored and current", func(t *testing.T) {
+		got, err := noteUC.DiffRevisions(1, 7, 2, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, "  agenda\n- actions: tbd\n+ actions: none\n+ next steps\n", got)
+	})
+
+	t.Run("Unknown revision", func(t *testing.T) {
+		_, err := noteUC.DiffRevisions(1, 7, 1, 9)
+		assert.ErrorIs(t, err, usecase.ErrRevisionNotFound)
+	})
+
+	t.Run("Other owner", func(t *testing.T) {
+		_, err := noteUC.DiffRevisions(1, 8, 1, 2)
+		assert.ErrorIs(t, err, usecase.ErrNoteNotFound)
+	})
+}
+
+func TestGetRelatedNotes(t *testing.T) {
+	base := time.Date(2025, 6, 16, 10, 0, 0, 0, time.UTC)
+	notes := []domain.Note{
+		{ID: 1, OwnerID: 7, Title: "Sprint planning", Category: "Planning", MeetingDate: base},
+		{ID: 2, OwnerID: 7, Title: "Roadmap", Category: "planning", MeetingDate: base.AddDate(0, 0, -10)},
+		{ID: 3, OwnerID: 7, Title: "Quarterly", Category: "Planning", MeetingDate: base.AddDate(0, 0, 2)},
+		{ID: 4, OwnerID: 7, Title: "Sprint retro", Category: "Retro", MeetingDate: base.AddDate(0, 0, 1)},
+		{ID: 5, OwnerID: 8, Title: "Other owner", Category: "Planning", MeetingDate: base},
+		{ID: 6, OwnerID: 7, Title: "Archived", Category: "Planning", MeetingDate: base, Archived: true},
+		{ID: 10, OwnerID: 7, Title: "Sprint review notes", MeetingDate: base},
+		{ID: 11, OwnerID: 7, Title: "Sprint review", Category: "Review", MeetingDate: base.AddDate(0, 1, 0)},
+		{ID: 12, OwnerID: 7, Title: "Sprint kickoff", MeetingDate: base.AddDate(0, 0, 3)},
+		{ID: 13, OwnerID: 7, Title: "Budget", MeetingDate: base},
+	}
+
+	tests := []struct {
+		name    string
+		id      uint
+		ownerID uint
+		limit   int
+		wantIDs []uint
+		wantErr error
+	}{
+		{name: "Same category by proximity", id: 1, ownerID: 7, wantIDs: []uint{3, 2}},
+		{name: "Limit applied", id: 1, ownerID: 7, limit: 1, wantIDs: []uint{3}},
+		{name: "Title overlap without category", id: 10, ownerID: 7, wantIDs: []uint{11, 1, 4, 12}},
+		{name: "Nothing related", id: 13, ownerID: 7, wantIDs: []uint{}},
+		{name: "Other owner", id: 1, ownerID: 8, wantErr: usecase.ErrNoteNotFound},
+		{name: "Unknown note", id: 99, ownerID: 7, wantErr: usecase.ErrNoteNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes})
+
+			related, err := noteUC.GetRelatedNotes(tt.id, tt.ownerID, tt.limit)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			ids := make([]uint, 0, len(related))
+			for _, n := range related {
+				ids = append(ids, n.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestGetUpcomingMeetings(t *testing.T) {
+	now := time.Now()
+	notes := []domain.Note{
+		{ID: 1, Title: "Past", MeetingDate: now.Add(-time.Hour)},
+		{ID: 2, Title: "Later today", MeetingDate: now.Add(5 * time.Hour)},
+		{ID: 3, Title: "Soon", MeetingDate: now.Add(time.Hour)},
+		{ID: 4, Title: "Next week", MeetingDate: now.AddDate(0, 0, 7)},
+		{ID: 5, Title: "Archived", MeetingDate: now.Add(2 * time.Hour), Archived: true},
+	}
+
+	tests := []struct {
+		name        string
+		within      time.Duration
+		forceDBFail bool
+		wantIDs     []uint
+		wantErr     error
+	}{
+		{name: "Soonest first", within: 24 * time.Hour, wantIDs: []uint{3, 2}},
+		{name: "Narrow window", within: 2 * time.Hour, wantIDs: []uint{3}},
+		{name: "Zero window", within: 0, wantErr: usecase.ErrInvalidWindow},
+		{name: "Repository error", within: time.Hour, forceDBFail: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes, forceDBFail: tt.forceDBFail})
+
+			upcoming, err := noteUC.GetUpcomingMeetings(0, tt.within)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if tt.forceDBFail {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			ids := make([]uint, 0, len(upcoming))
+			for _, n := range upcoming {
+				ids = append(ids, n.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestSendMeetingReminders(t *testing.T) {
+	now := time.Now()
+	publisher := &recordingPublisher{}
+	cfg := usecase.DefaultConfig()
+	cfg.Publisher = publisher
+	noteUC := usecase.NewNoteUsecaseWithConfig(&mockNoteRepository{notes: []domain.Note{
+		{ID: 1, Title: "In window", MeetingDate: now.Add(65 * time.Minute)},
+		{ID: 2, Title: "Too soon", MeetingDate: now.Add(30 * time.Minute)},
+		{ID: 3, Title: "Too late", MeetingDate: now.Add(2 * time.Hour)},
+	}}, cfg)
+
+	sent, err := noteUC.SendMeetingReminders(time.Hour, 15*time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Len(t, publisher.events, 1)
+	assert.Equal(t, domain.NoteReminder, publisher.events[0].Type)
+	assert.Equal(t, uint(1), publisher.events[0].Note.ID)
+
+	_, err = noteUC.SendMeetingReminders(time.Hour, 0)
+	assert.ErrorIs(t, err, usecase.ErrInvalidWindow)
+}
+
+func TestNoteNormalization(t *testing.T) {
+	meetingDate := time.Now()
+
+	tests := []struct {
+		name         string
+		input        domain.Note
+		wantTitle    string
+		wantContent  string
+		wantCategory string
+		wantErrs     []error
+	}{
+		{
+			name:         "Fields trimmed and title collapsed",
+			input:        domain.Note{Title: "  Sprint \t  planning\n", Content: "\n  Line one\n  Line two  \n", Category: " Planning ", MeetingDate: meetingDate},
+			wantTitle:    "Sprint planning",
+			wantContent:  "Line one\n  Line two",
+			wantCategory: "Planning",
+		},
+		{
+			name:     "Whitespace-only title and content are empty",
+			input:    domain.Note{Title: "   ", Content: "\n\t ", MeetingDate: meetingDate},
+			wantErrs: []error{usecase.ErrEmptyTitle, usecase.ErrEmptyContent},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" on create", func(t *testing.T) {
+			mockRepo := &mockNoteRepository{}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+			note := tt.input
+
+			err := noteUC.CreateNote(&note)
+
+			if tt.wantErrs != nil {
+				for _, want := range tt.wantErrs {
+					assert.ErrorIs(t, err, want)
+				}
+				assert.Len(t, mockRepo.notes, 0)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTitle, note.Title)
+			assert.Equal(t, tt.wantContent, note.Content)
+			assert.Equal(t, tt.wantCategory, note.Category)
+		})
+
+		t.Run(tt.name+" on update", func(t *testing.T) {
+			mockRepo := &mockNoteRepository{notes: []domain.Note{{ID: 1, Title: "Old", Content: "Old", MeetingDate: meetingDate, Version: 1}}}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+			note := tt.input
+			note.ID = 1
+			note.Version = 1
+
+			err := noteUC.UpdateNote(&note)
+
+			if tt.wantErrs != nil {
+				for _, want := range tt.wantErrs {
+					assert.ErrorIs(t, err, want)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTitle, note.Title)
+			assert.Equal(t, tt.wantContent, note.Content)
+			assert.Equal(t, tt.wantCategory, note.Category)
+		})
+	}
+}
+
+func TestNoteFormat(t *testing.T) {
+	meetingDate := time.Now()
+
+	tests := []struct {
+		name       string
+		format     string
+		wantFormat string
+		wantErr    error
+	}{
+		{name: "Defaults to plaintext", format: "", wantFormat: domain.NoteFormatPlaintext},
+		{name: "Markdown", format: "markdown", wantFormat: domain.NoteFormatMarkdown},
+		{name: "Case and whitespace ignored", format: " Markdown ", wantFormat: domain.NoteFormatMarkdown},
+		{name: "Unknown format", format: "html", wantErr: usecase.ErrInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+			note := domain.Note{Title: "Planning", Content: "Agenda", Format: tt.format, MeetingDate: meetingDate}
+
+			err := noteUC.CreateNote(&note)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				var validationErr *usecase.ValidationError
+				if assert.ErrorAs(t, err, &validationErr) {
+					assert.Equal(t, "format", validationErr.Fields[0].Field)
+				}
+				assert.Len(t, mockRepo.notes, 0)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantFormat, note.Format)
+		})
+	}
+
+	t.Run("Update without a format keeps the stored one", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{notes: []domain.Note{{ID: 1, Title: "Old", Content: "Old", Format: domain.NoteFormatMarkdown, MeetingDate: meetingDate, Version: 1}}}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+		note := domain.Note{ID: 1, Title: "New", Content: "New", MeetingDate: meetingDate, Version: 1}
+
+		assert.NoError(t, noteUC.UpdateNote(&note))
+		assert.Equal(t, domain.NoteFormatMarkdown, note.Format)
+	})
+
+	t.Run("Update can change the format", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{notes: []domain.Note{{ID: 1, Title: "Old", Content: "Old", Format: domain.NoteFormatMarkdown, MeetingDate: meetingDate, Version: 1}}}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+		note := domain.Note{ID: 1, Title: "New", Content: "New", Format: "plaintext", MeetingDate: meetingDate, Version: 1}
+
+		assert.NoError(t, noteUC.UpdateNote(&note))
+		assert.Equal(t, domain.NoteFormatPlaintext, note.Format)
+	})
+
+	t.Run("Update rejects an unknown format", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{notes: []domain.Note{{ID: 1, Title: "Old", Content: "Old", MeetingDate: meetingDate, Version: 1}}}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+		note := domain.Note{ID: 1, Title: "New", Content: "New", Format: "rtf", MeetingDate: meetingDate, Version: 1}
+
+		assert.ErrorIs(t, noteUC.UpdateNote(&note), usecase.ErrInvalidFormat)
+	})
+}
+
+func TestNotePriority(t *testing.T) {
+	meetingDate := time.Now()
+
+	tests := []struct {
+		name         string
+		priority     string
+		wantPriority string
+		wantErr      error
+	}{
+		{name: "Defaults to normal", priority: "", wantPriority: domain.PriorityNormal},
+		{name: "Critical", priority: "critical", wantPriority: domain.PriorityCritical},
+		{name: "Case and whitespace ignored", priority: " High ", wantPriority: domain.PriorityHigh},
+		{name: "Unknown priority", priority: "urgent", wantErr: usecase.ErrInvalidPriority},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+			note := domain.Note{Title: "Planning", Content: "Agenda", Priority: tt.priority, MeetingDate: meetingDate}
+
+			err := noteUC.CreateNote(&note)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				var validationErr *usecase.ValidationError
+				if assert.ErrorAs(t, err, &validationErr) {
+					assert.Equal(t, "priority", validationErr.Fields[0].Field)
+				}
+				assert.Len(t, mockRepo.notes, 0)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPriority, note.Priority)
+		})
+	}
+
+	t.Run("Update without a priority keeps the stored one", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{notes: []domain.Note{{ID: 1, Title: "Old", Content: "Old", Priority: domain.PriorityHigh, MeetingDate: meetingDate, Version: 1}}}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+		note := domain.Note{ID: 1, Title: "New", Content: "New", MeetingDate: meetingDate, Version: 1}
+
+		assert.NoError(t, noteUC.UpdateNote(&note))
+		assert.Equal(t, domain.PriorityHigh, note.Priority)
+	})
+
+	t.Run("Update rejects an unknown priority", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{notes: []domain.Note{{ID: 1, Title: "Old", Content: "Old", MeetingDate: meetingDate, Version: 1}}}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+		note := domain.Note{ID: 1, Title: "New", Content: "New", Priority: "p1", MeetingDate: meetingDate, Version: 1}
+
+		assert.ErrorIs(t, noteUC.UpdateNote(&note), usecase.ErrInvalidPriority)
+	})
+
+	t.Run("Filter and sort", func(t *testing.T) {
+		repo := memory.NewNoteRepository()
+		for title, priority := range map[string]string{"Outage": "critical", "Standup": "", "Social": "low"} {
+			note := domain.Note{Title: title, Content: "Notes", Priority: priority, MeetingDate: meetingDate}
+			assert.NoError(t, repo.Create(&note))
+		}
+		noteUC := usecase.NewNoteUsecase(repo)
+
+		notes, _, err := noteUC.FilterNotes(domain.NoteFilter{Sort: "priority"}, domain.Page{})
+		assert.NoError(t, err)
+		var titles []string
+		for _, n := range notes {
+			titles = append(titles, n.Title)
+		}
+		assert.Equal(t, []string{"Outage", "Standup", "Social"}, titles, "highest priority first by default")
+
+		notes, _, err = noteUC.FilterNotes(domain.NoteFilter{Priority: " LOW "}, domain.Page{})
+		assert.NoError(t, err)
+		if assert.Len(t, notes, 1) {
+			assert.Equal(t, "Social", notes[0].Title)
+		}
+
+		_, _, err = noteUC.FilterNotes(domain.NoteFilter{Priority: "urgent"}, domain.Page{})
+		assert.ErrorIs(t, err, usecase.ErrInvalidPriority)
+	})
+}
+
+func TestNoteSlugs(t *testing.T) {
+	meetingDate := time.Now()
+
+	t.Run("Slugify", func(t *testing.T) {
+		tests := []struct {
+			title string
+			want  string
+		}{
+			{title: "Q3 Planning Kickoff", want: "q3-planning-kickoff"},
+			{title: "  Café retro – “wins”!  ", want: "cafe-retro-wins"},
+			{title: "Straße & Ærø", want: "strasse-aero"},
+			{title: "日本 sync", want: "sync"},
+			{title: "日本", want: "note"},
+			{title: strings.Repeat("word ", 30), want: strings.TrimSuffix(strings.Repeat("word-", 16), "-")},
+		}
+		for _, tt := range tests {
+			mockRepo := &mockNoteRepository{}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+			note := domain.Note{Title: tt.title, Content: "Agenda", MeetingDate: meetingDate}
+
+			assert.NoError(t, noteUC.CreateNote(&note))
+			assert.Equal(t, tt.want, note.Slug, tt.title)
+		}
+	})
+
+	t.Run("Collisions get a numeric suffix", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{trash: []domain.Note{{ID: 9, Slug: "standup-2"}}}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+
+		var slugs []string
+		for i := 0; i < 3; i++ {
+			note := domain.Note{Title: "Standup", Content: "Agenda", MeetingDate: meetingDate}
+			assert.NoError(t, noteUC.CreateNote(&note))
+			slugs = append(slugs, note.Slug)
+		}
+
+		assert.Equal(t, []string{"standup", "standup-3", "standup-4"}, slugs, "trashed notes keep their slug")
+	})
+
+	t.Run("Client slugs are ignored", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{})
+		note := domain.Note{Title: "Standup", Content: "Agenda", Slug: "custom", MeetingDate: meetingDate}
+
+		assert.NoError(t, noteUC.CreateNote(&note))
+		assert.Equal(t, "standup", note.Slug)
+	})
+
+	updateTests := []struct {
+		name     string
+		policy   usecase.SlugPolicy
+		stored   string
+		title    string
+		wantSlug string
+	}{
+		{name: "Preserved on title change", policy: usecase.SlugPolicyPreserve, stored: "standup", title: "Retro", wantSlug: "standup"},
+		{name: "Regenerated on title change", policy: usecase.SlugPolicyRegenerate, stored: "standup", title: "Retro", wantSlug: "retro"},
+		{name: "Regenerate keeps a matching slug", policy: usecase.SlugPolicyRegenerate, stored: "standup-2", title: "Standup!", wantSlug: "standup-2"},
+		{name: "Regenerate avoids other notes", policy: usecase.SlugPolicyRegenerate, stored: "standup", title: "Planning", wantSlug: "planning-2"},
+		{name: "Note without a slug gets one", policy: usecase.SlugPolicyPreserve, stored: "", title: "Standup", wantSlug: "standup"},
+	}
+	for _, tt := range updateTests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := usecase.DefaultConfig()
+			cfg.SlugPolicy = tt.policy
+			mockRepo := &mockNoteRepository{notes: []domain.Note{
+				{ID: 1, Title: "Standup", Content: "Agenda", Slug: tt.stored, MeetingDate: meetingDate, Version: 1},
+				{ID: 2, Title: "Planning", Content: "Agenda", Slug: "planning", MeetingDate: meetingDate, Version: 1},
+			}}
+			noteUC := usecase.NewNoteUsecaseWithConfig(mockRepo, cfg)
+			note := domain.Note{ID: 1, Title: tt.title, Content: "Agenda", MeetingDate: meetingDate, Version: 1}
+
+			assert.NoError(t, noteUC.UpdateNote(&note))
+			assert.Equal(t, tt.wantSlug, note.Slug)
+		})
+	}
+
+	t.Run("Get by slug", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: []domain.Note{{ID: 1, OwnerID: 7, Slug: "standup"}}})
+
+		note, err := noteUC.GetNoteBySlug("standup", 7)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), note.ID)
+
+		_, err = noteUC.GetNoteBySlug("standup", 8)
+		assert.ErrorIs(t, err, usecase.ErrNoteNotFound)
+
+		_, err = noteUC.GetNoteBySlug("missing", 7)
+		assert.ErrorIs(t, err, usecase.ErrNoteNotFound)
+
+		_, err = noteUC.GetNoteBySlug("", 7)
+		assert.ErrorIs(t, err, usecase.ErrNoteNotFound)
+	})
+}
+
+func TestDuplicateNote(t *testing.T) {
+	meetingDate := time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC)
+	original := domain.Note{
+		ID:          1,
+		OwnerID:     7,
+		Title:       "Planning",
+		Content:     "Agenda",
+		Category:    "Team",
+		Format:      domain.NoteFormatMarkdown,
+		MeetingDate: meetingDate,
+		Version:     4,
+		ExternalID:  "ext-1",
+		Archived:    true,
+	}
+
+	t.Run("Copy is a fresh note dated today", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{notes: []domain.Note{original}}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+
+		duplicate, err := noteUC.DuplicateNote(1, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Copy of Planning", duplicate.Title)
+		assert.Equal(t, "Agenda", duplicate.Content)
+		assert.Equal(t, "Team", duplicate.Category)
+		assert.Equal(t, domain.NoteFormatMarkdown, duplicate.Format)
+		assert.Equal(t, uint(7), duplicate.OwnerID)
+		assert.Empty(t, duplicate.ExternalID)
+		assert.False(t, duplicate.Archived)
+		assert.WithinDuration(t, time.Now(), duplicate.MeetingDate, time.Minute)
+		assert.Len(t, mockRepo.notes, 2)
+		assert.Equal(t, original, mockRepo.notes[0])
+	})
+
+	t.Run("Other owner", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{notes: []domain.Note{original}}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+
+		_, err := noteUC.DuplicateNote(1, 8)
+
+		assert.ErrorIs(t, err, usecase.ErrNoteNotFound)
+		assert.Len(t, mockRepo.notes, 1)
+	})
+}
+
+func TestStreamNotes(t *testing.T) {
+	notes := []domain.Note{
+		{ID: 1, OwnerID: 7, Title: "Planning"},
+		{ID: 2, OwnerID: 8, Title: "Someone else's"},
+		{ID: 3, OwnerID: 7, Title: "Archived", Archived: true},
+		{ID: 4, OwnerID: 7, Title: "Retro"},
+	}
+
+	t.Run("Streams the owner's notes", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes})
+
+		var ids []uint
+		err := noteUC.StreamNotes(7, false, func(n domain.Note) error {
+			ids = append(ids, n.ID)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{1, 4}, ids)
+	})
+
+	t.Run("Stops at the first callback error", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes})
+
+		var ids []uint
+		err := noteUC.StreamNotes(7, true, func(n domain.Note) error {
+			ids = append(ids, n.ID)
+			if len(ids) == 2 {
+				return errors.New("client went away")
+			}
+			return nil
+		})
+
+		assert.EqualError(t, err, "failed to stream notes")
+		assert.Equal(t, []uint{1, 3}, ids)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes, forceDBFail: true})
+
+		err := noteUC.StreamNotes(7, false, func(n domain.Note) error { return nil })
+
+		assert.EqualError(t, err, "failed to stream notes")
+	})
+}
+
+func TestGetNoteChanges(t *testing.T) {
+	since := time.Date(2025, time.June, 16, 9, 0, 0, 0, time.UTC)
+	notes := []domain.Note{
+		{ID: 1, OwnerID: 7, Title: "Unchanged", UpdatedAt: since},
+		{ID: 2, OwnerID: 7, Title: "Edited", UpdatedAt: since.Add(time.Minute)},
+		{ID: 3, OwnerID: 7, Title: "Deleted", UpdatedAt: since.Add(2 * time.Minute), DeletedAt: gorm.DeletedAt{Time: since.Add(2 * time.Minute), Valid: true}},
+		{ID: 4, OwnerID: 8, Title: "Someone else's", UpdatedAt: since.Add(time.Minute)},
+	}
+
+	t.Run("Marks deleted notes as tombstones", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes})
+
+		changes, more, err := noteUC.GetNoteChanges(7, domain.ChangeCursor{Since: since}, 0)
+
+		assert.NoError(t, err)
+		assert.False(t, more)
+		if assert.Len(t, changes, 2) {
+			assert.Equal(t, uint(2), changes[0].ID)
+			assert.False(t, changes[0].Deleted)
+			assert.Equal(t, uint(3), changes[1].ID)
+			assert.True(t, changes[1].Deleted)
+		}
+	})
+
+	t.Run("No changes", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes})
+
+		changes, _, err := noteUC.GetNoteChanges(7, domain.ChangeCursor{Since: since.Add(time.Hour)}, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.NoteChange{}, changes)
+	})
+
+	t.Run("Pages through changes", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes})
+
+		changes, more, err := noteUC.GetNoteChanges(7, domain.ChangeCursor{}, 2)
+		assert.NoError(t, err)
+		assert.True(t, more)
+		if assert.Len(t, changes, 2) {
+			assert.Equal(t, uint(1), changes[0].ID)
+			assert.Equal(t, uint(2), changes[1].ID)
+		}
+
+		last := changes[1]
+		changes, more, err = noteUC.GetNoteChanges(7, domain.ChangeCursor{Since: last.UpdatedAt, AfterID: last.ID}, 2)
+		assert.NoError(t, err)
+		assert.False(t, more)
+		if assert.Len(t, changes, 1) {
+			assert.Equal(t, uint(3), changes[0].ID)
+		}
+	})
+
+	t.Run("Negative limit", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes})
+
+		_, _, err := noteUC.GetNoteChanges(7, domain.ChangeCursor{}, -1)
+
+		assert.ErrorIs(t, err, usecase.ErrInvalidPage)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes, forceDBFail: true})
+
+		_, _, err := noteUC.GetNoteChanges(7, domain.ChangeCursor{Since: since}, 0)
+
+		assert.EqualError(t, err, "failed to get note changes")
+	})
+}
+
+// importRows returns a row source for ImportNotes that yields rows and then
+// io.EOF, or err if it is set.
+func importRows(rows []usecase.ImportRow, err error) func() (usecase.ImportRow, error) {
+	return func() (usecase.ImportRow, error) {
+		if len(rows) == 0 {
+			if err != nil {
+				return usecase.ImportRow{}, err
+			}
+			return usecase.ImportRow{}, io.EOF
+		}
+		row := rows[0]
+		rows = rows[1:]
+		return row, nil
+	}
+}
+
+func TestImportNotes(t *testing.T) {
+	meetingDate := time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC)
+	valid := func(line int, title string) usecase.ImportRow {
+		return usecase.ImportRow{Line: line, Note: domain.Note{ID: 99, OwnerID: 99, Title: title, Content: "Notes", MeetingDate: meetingDate}}
+	}
+
+	t.Run("Invalid rows are skipped", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+
+		summary, err := noteUC.ImportNotes(7, importRows([]usecase.ImportRow{
+			valid(2, "Planning"),
+			{Line: 3, Note: domain.Note{Content: "No title", MeetingDate: meetingDate}},
+			{Line: 4, Err: errors.New("wrong number of fields")},
+			valid(5, "Planning"),
+		}, nil), usecase.ImportOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, summary.Imported)
+		assert.Equal(t, []usecase.ImportFailure{
+			{Line: 3, Message: "note failed validation", Fields: map[string]string{"title": "note title cannot be empty"}},
+			{Line: 4, Message: "wrong number of fields"},
+		}, summary.Failed)
+
+		if assert.Len(t, mockRepo.notes, 2) {
+			assert.Equal(t, uint(7), mockRepo.notes[0].OwnerID)
+			assert.Equal(t, 1, mockRepo.notes[0].Version)
+			assert.Equal(t, "planning", mockRepo.notes[0].Slug)
+			assert.Equal(t, "planning-2", mockRepo.notes[1].Slug, "slugs are unique within a batch")
+		}
+	})
+
+	t.Run("Stored in batches", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+
+		rows := make([]usecase.ImportRow, 0, 250)
+		for i := 0; i < 250; i++ {
+			rows = append(rows, valid(i+2, fmt.Sprintf("Note %d", i)))
+		}
+		summary, err := noteUC.ImportNotes(7, importRows(rows, nil), usecase.ImportOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 250, summary.Imported)
+		assert.Equal(t, []int{100, 100, 50}, mockRepo.batches)
+	})
+
+	t.Run("Atomic import with an invalid row stores nothing", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+
+		summary, err := noteUC.ImportNotes(7, importRows([]usecase.ImportRow{
+			valid(2, "Planning"),
+			{Line: 3, Err: errors.New("invalid JSON")},
+			valid(4, "Retro"),
+			{Line: 5, Note: domain.Note{Title: "No content", MeetingDate: meetingDate}},
+		}, nil), usecase.ImportOptions{Atomic: true})
+
+		assert.ErrorIs(t, err, usecase.ErrImportRejected)
+		assert.Equal(t, 0, summary.Imported)
+		assert.Len(t, summary.Failed, 2, "every invalid row is reported")
+		assert.Empty(t, mockRepo.batches)
+	})
+
+	t.Run("Atomic import stores everything in one batch", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+
+		rows := make([]usecase.ImportRow, 0, 150)
+		for i := 0; i < 150; i++ {
+			rows = append(rows, valid(i+2, "Note"))
+		}
+		summary, err := noteUC.ImportNotes(7, importRows(rows, nil), usecase.ImportOptions{Atomic: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 150, summary.Imported)
+		assert.Equal(t, []int{150}, mockRepo.batches)
+	})
+
+	t.Run("Failed batch marks its rows", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{batchFail: true, createFail: true}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+
+		summary, err := noteUC.ImportNotes(7, importRows([]usecase.ImportRow{valid(2, "Planning"), valid(3, "Retro")}, nil), usecase.ImportOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, summary.Imported)
+		assert.Equal(t, []usecase.ImportFailure{
+			{Line: 2, Message: "failed to create note"},
+			{Line: 3, Message: "failed to create note"},
+		}, summary.Failed)
+	})
+
+	t.Run("Duplicate fails only its own row", func(t *testing.T) {
+		repo := memory.NewNoteRepositoryWithOptions(memory.NoteRepositoryOptions{UniqueTitleDate: true})
+		noteUC := usecase.NewNoteUsecase(repo)
+		assert.NoError(t, repo.Create(&domain.Note{OwnerID: 7, Title: "Planning", Content: "Notes", MeetingDate: meetingDate}))
+
+		summary, err := noteUC.ImportNotes(7, importRows([]usecase.ImportRow{
+			valid(2, "Retro"),
+			valid(3, "Planning"),
+			valid(4, "Standup"),
+		}, nil), usecase.ImportOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, summary.Imported)
+		assert.Equal(t, []usecase.ImportFailure{{Line: 3, Message: usecase.ErrDuplicateNote.Error()}}, summary.Failed)
+		notes, _ := repo.GetAllByOwner(7, false)
+		assert.Len(t, notes, 3)
+	})
+
+	t.Run("Read error keeps what was imported", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+		readErr := errors.New("connection reset")
+
+		summary, err := noteUC.ImportNotes(7, importRows([]usecase.ImportRow{valid(2, "Planning")}, readErr), usecase.ImportOptions{})
+
+		assert.ErrorIs(t, err, readErr)
+		assert.Equal(t, 1, summary.Imported)
+		assert.Len(t, mockRepo.notes, 1)
+	})
+
+	t.Run("Quota counts rows in the same batch", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{}
+		cfg := usecase.DefaultConfig()
+		cfg.CategoryQuotas = map[string]int{"Standup": 1}
+		noteUC := usecase.NewNoteUsecaseWithConfig(mockRepo, cfg)
+
+		first, second := valid(2, "Monday"), valid(3, "Tuesday")
+		first.Note.Category, second.Note.Category = "Standup", "standup"
+		summary, err := noteUC.ImportNotes(7, importRows([]usecase.ImportRow{first, second}, nil), usecase.ImportOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, summary.Imported)
+		assert.Equal(t, []usecase.ImportFailure{{Line: 3, Message: usecase.ErrCategoryQuotaExceeded.Error()}}, summary.Failed)
+	})
+}
+
+func TestCreateNoteCategoryQuota(t *testing.T) {
+	meetingDate := time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC)
+	existing := []domain.Note{
+		{ID: 1, Title: "Standup 1", Content: "a", Category: "Standup", MeetingDate: meetingDate},
+		{ID: 2, Title: "Standup 2", Content: "b", Category: "standup", MeetingDate: meetingDate},
+		{ID: 3, Title: "Retro", Content: "c", Category: "Retro", MeetingDate: meetingDate},
+	}
+
+	tests := []struct {
+		name      string
+		owner     uint
+		category  string
+		quotas    map[string]int
+		forceFail bool
+		wantErr   error
+	}{
+		{name: "Under quota", category: "Retro", quotas: map[string]int{"Retro": 2}},
+		{name: "Quota reached across spellings", category: "STANDUP", quotas: map[string]int{"Standup": 2}, wantErr: usecase.ErrCategoryQuotaExceeded},
+		{name: "Other owners' notes don't count", owner: 2, category: "Standup", quotas: map[string]int{"Standup": 2}},
+		{name: "Unlisted category is unlimited", category: "Planning", quotas: map[string]int{"Standup": 0}},
+		{name: "Uncategorized is unlimited", quotas: map[string]int{"Standup": 0}},
+		{name: "Count fails", category: "Standup", quotas: map[string]int{"Standup": 5}, forceFail: true, wantErr: errors.New("failed to create note")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{notes: append([]domain.Note(nil), existing...), forceDBFail: tt.forceFail}
+			cfg := usecase.DefaultConfig()
+			cfg.CategoryQuotas = tt.quotas
+			noteUC := usecase.NewNoteUsecaseWithConfig(mockRepo, cfg)
+
+			note := &domain.Note{OwnerID: tt.owner, Title: "New", Content: "d", Category: tt.category, MeetingDate: meetingDate}
+			err := noteUC.CreateNote(note)
+
+			switch {
+			case tt.wantErr == nil:
+				assert.NoError(t, err)
+				assert.Len(t, mockRepo.notes, len(existing)+1)
+			case errors.Is(tt.wantErr, usecase.ErrCategoryQuotaExceeded):
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Len(t, mockRepo.notes, len(existing))
+			default:
+				assert.EqualError(t, err, tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func TestCategoryQuotaOnOtherWrites(t *testing.T) {
+	meetingDate := time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC)
+
+	// Owner 7 has one note in the capped Standup category and two in Retro.
+	// Owner 8's Standup note doesn't count against owner 7.
+	setup := func(t *testing.T) (usecase.NoteUsecase, *domain.Note, *domain.Note) {
+		repo := memory.NewNoteRepository()
+		standup := &domain.Note{OwnerID: 7, Title: "Monday", Content: "a", Category: "Standup", MeetingDate: meetingDate, ExternalID: "standup-1", Version: 1}
+		retro := &domain.Note{OwnerID: 7, Title: "Retro", Content: "b", Category: "Retro", MeetingDate: meetingDate, ExternalID: "retro-1", Version: 1}
+		for _, n := range []*domain.Note{
+			standup,
+			retro,
+			{OwnerID: 7, Title: "Retro 2", Content: "c", Category: "Retro", MeetingDate: meetingDate, Version: 1},
+			{OwnerID: 8, Title: "Theirs", Content: "d", Category: "Standup", MeetingDate: meetingDate, Version: 1},
+		} {
+			assert.NoError(t, repo.Create(n))
+		}
+
+		cfg := usecase.DefaultConfig()
+		cfg.CategoryQuotas = map[string]int{"Standup": 2}
+		return usecase.NewNoteUsecaseWithConfig(repo, cfg), standup, retro
+	}
+
+	t.Run("Update into a full category", func(t *testing.T) {
+		noteUC, _, retro := setup(t)
+		assert.NoError(t, noteUC.CreateNote(&domain.Note{OwnerID: 7, Title: "Tuesday", Content: "e", Category: "Standup", MeetingDate: meetingDate}))
+
+		moved := *retro
+		moved.Category = "standup"
+		assert.ErrorIs(t, noteUC.UpdateNote(&moved), usecase.ErrCategoryQuotaExceeded)
+	})
+
+	t.Run("Update into a category with room", func(t *testing.T) {
+		noteUC, _, retro := setup(t)
+
+		moved := *retro
+		moved.Category = "Standup"
+		assert.NoError(t, noteUC.UpdateNote(&moved))
+	})
+
+	t.Run("Update within a full category", func(t *testing.T) {
+		noteUC, standup, _ := setup(t)
+		assert.NoError(t, noteUC.CreateNote(&domain.Note{OwnerID: 7, Title: "Tuesday", Content: "e", Category: "Standup", MeetingDate: meetingDate}))
+
+		edited := *standup
+		edited.Title = "Monday standup"
+		assert.NoError(t, noteUC.UpdateNote(&edited))
+	})
+
+	t.Run("Upsert inserting into a full category", func(t *testing.T) {
+		noteUC, _, _ := setup(t)
+		assert.NoError(t, noteUC.CreateNote(&domain.Note{OwnerID: 7, Title: "Tuesday", Content: "e", Category: "Standup", MeetingDate: meetingDate}))
+
+		imported := &domain.Note{OwnerID: 7, ExternalID: "standup-2", Title: "Wednesday", Content: "f", Category: "Standup", MeetingDate: meetingDate}
+		assert.ErrorIs(t, noteUC.UpsertNote(imported), usecase.ErrCategoryQuotaExceeded)
+	})
+
+	t.Run("Upsert rewriting a note in a full category", func(t *testing.T) {
+		noteUC, _, _ := setup(t)
+		assert.NoError(t, noteUC.CreateNote(&domain.Note{OwnerID: 7, Title: "Tuesday", Content: "e", Category: "Standup", MeetingDate: meetingDate}))
+
+		imported := &domain.Note{OwnerID: 7, ExternalID: "standup-1", Title: "Monday", Content: "changed", Category: "Standup", MeetingDate: meetingDate}
+		assert.NoError(t, noteUC.UpsertNote(imported))
+		assert.Equal(t, 2, imported.Version)
+	})
+
+	t.Run("Upsert moving a note into a full category", func(t *testing.T) {
+		noteUC, _, _ := setup(t)
+		assert.NoError(t, noteUC.CreateNote(&domain.Note{OwnerID: 7, Title: "Tuesday", Content: "e", Category: "Standup", MeetingDate: meetingDate}))
+
+		imported := &domain.Note{OwnerID: 7, ExternalID: "retro-1", Title: "Retro", Content: "b", Category: "Standup", MeetingDate: meetingDate}
+		assert.ErrorIs(t, noteUC.UpsertNote(imported), usecase.ErrCategoryQuotaExceeded)
+	})
+
+	t.Run("Rename into a category without room for every note", func(t *testing.T) {
+		noteUC, _, _ := setup(t)
+
+		renamed, err := noteUC.RenameCategory(7, "Retro", "Standup")
+		assert.ErrorIs(t, err, usecase.ErrCategoryQuotaExceeded)
+		assert.Equal(t, int64(0), renamed)
+	})
+
+	t.Run("Rename into a category with room", func(t *testing.T) {
+		noteUC, _, _ := setup(t)
+		assert.NoError(t, noteUC.CreateNote(&domain.Note{OwnerID: 7, Title: "Solo", Content: "e", Category: "Planning", MeetingDate: meetingDate}))
+
+		renamed, err := noteUC.RenameCategory(7, "Planning", "Standup")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), renamed)
+	})
+
+	t.Run("Renaming the case of a full category", func(t *testing.T) {
+		noteUC, _, _ := setup(t)
+		assert.NoError(t, noteUC.CreateNote(&domain.Note{OwnerID: 7, Title: "Tuesday", Content: "e", Category: "Standup", MeetingDate: meetingDate}))
+
+		renamed, err := noteUC.RenameCategory(7, "Standup", "STANDUP")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), renamed)
+	})
+}
+
+func TestAllowedCategories(t *testing.T) {
+	meetingDate := time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC)
+	allowed := []string{"Standup", "1:1"}
+
+	t.Run("Create", func(t *testing.T) {
+		tests := []struct {
+			name         string
+			allowed      []string
+			category     string
+			wantCategory string
+			wantErr      error
+		}{
+			{name: "Allowed", allowed: allowed, category: "1:1", wantCategory: "1:1"},
+			{name: "Takes the canonical spelling", allowed: allowed, category: " standup ", wantCategory: "Standup"},
+			{name: "Uncategorized", allowed: allowed},
+			{name: "Not allowed", allowed: allowed, category: "Standyp", wantErr: usecase.ErrInvalidCategory},
+			{name: "No allowlist", category: "Standyp", wantCategory: "Standyp"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				cfg := usecase.DefaultConfig()
+				cfg.AllowedCategories = tt.allowed
+				noteUC := usecase.NewNoteUsecaseWithConfig(&mockNoteRepository{}, cfg)
+
+				note := &domain.Note{Title: "New", Content: "Notes", Category: tt.category, MeetingDate: meetingDate}
+				err := noteUC.CreateNote(note)
+
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+					var validationErr *usecase.ValidationError
+					if assert.ErrorAs(t, err, &validationErr) {
+						assert.Equal(t, "category", validationErr.Fields[0].Field)
+					}
+					return
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantCategory, note.Category)
+			})
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		cfg := usecase.DefaultConfig()
+		cfg.AllowedCategories = allowed
+		mockRepo := &mockNoteRepository{notes: []domain.Note{
+			{ID: 1, Title: "Sync", Content: "Notes", Category: "Standup", MeetingDate: meetingDate, Version: 1},
+		}}
+		noteUC := usecase.NewNoteUsecaseWithConfig(mockRepo, cfg)
+
+		err := noteUC.UpdateNote(&domain.Note{ID: 1, Title: "Sync", Content: "Notes", Category: "Standyp", MeetingDate: meetingDate, Version: 1})
+
+		assert.ErrorIs(t, err, usecase.ErrInvalidCategory)
+		assert.Len(t, mockRepo.updated, 0)
+	})
+
+	t.Run("Rename", func(t *testing.T) {
+		cfg := usecase.DefaultConfig()
+		cfg.AllowedCategories = allowed
+		noteUC := usecase.NewNoteUsecaseWithConfig(&mockNoteRepository{}, cfg)
+
+		_, err := noteUC.RenameCategory(7, "Standyp", "Retro")
+		assert.ErrorIs(t, err, usecase.ErrInvalidCategory)
+
+		_, err = noteUC.RenameCategory(7, "Standyp", "STANDUP")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Listed", func(t *testing.T) {
+		cfg := usecase.DefaultConfig()
+		cfg.AllowedCategories = allowed
+		assert.Equal(t, allowed, usecase.NewNoteUsecaseWithConfig(&mockNoteRepository{}, cfg).AllowedCategories())
+		assert.Nil(t, usecase.NewNoteUsecase(&mockNoteRepository{}).AllowedCategories())
+	})
+}
+
+func TestBlockedTerms(t *testing.T) {
+	meetingDate := time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC)
+	blocked := map[string][]string{
+		"profanity":    {"ass", "darn"},
+		"confidential": {"project  x"},
+	}
+
+	tests := []struct {
+		name         string
+		blocked      map[string][]string
+		content      string
+		wantCategory string
+	}{
+		{name: "Blocked term", blocked: blocked, content: "What a DARN mess.", wantCategory: "profanity"},
+		{name: "Blocked phrase across whitespace", blocked: blocked, content: "Status of Project\nX", wantCategory: "confidential"},
+		{name: "Blocked term inside markup", blocked: blocked, content: "<p>darn</p>", wantCategory: "profanity"},
+		{name: "Innocent substring", blocked: blocked, content: "Class of the Scunthorpe passes, darned socks"},
+		{name: "Accented neighbour", blocked: blocked, content: "Réassure the team"},
+		{name: "Blocklist off", content: "What a darn mess."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := usecase.DefaultConfig()
+			cfg.BlockedTerms = tt.blocked
+			noteUC := usecase.NewNoteUsecaseWithConfig(&mockNoteRepository{}, cfg)
+
+			note := &domain.Note{Title: "Review", Content: tt.content, MeetingDate: meetingDate}
+			err := noteUC.CreateNote(note)
+
+			if tt.wantCategory == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, usecase.ErrBlockedContent)
+			var blockedErr *usecase.BlockedContentError
+			if assert.ErrorAs(t, err, &blockedErr) {
+				assert.Equal(t, tt.wantCategory, blockedErr.Category)
+				assert.NotContains(t, err.Error(), "darn")
+			}
+			var validationErr *usecase.ValidationError
+			if assert.ErrorAs(t, err, &validationErr) {
+				assert.Equal(t, "content", validationErr.Fields[0].Field)
+			}
+		})
+	}
+
+	t.Run("Update", func(t *testing.T) {
+		cfg := usecase.DefaultConfig()
+		cfg.BlockedTerms = blocked
+		mockRepo := &mockNoteRepository{notes: []domain.Note{
+			{ID: 1, Title: "Sync", Content: "Notes", MeetingDate: meetingDate, Version: 1},
+		}}
+		noteUC := usecase.NewNoteUsecaseWithConfig(mockRepo, cfg)
+
+		err := noteUC.UpdateNote(&domain.Note{ID: 1, Title: "Sync", Content: "darn", MeetingDate: meetingDate, Version: 1})
+		assert.ErrorIs(t, err, usecase.ErrBlockedContent)
+		assert.Equal(t, "Notes", mockRepo.notes[0].Content)
+	})
+}
+
+func TestMeetingDuration(t *testing.T) {
+	meetingDate := time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC)
+
+	t.Run("Create", func(t *testing.T) {
+		tests := []struct {
+			name         string
+			configured   int
+			duration     int
+			wantDuration int
+			wantErr      error
+		}{
+			{name: "Built-in default", wantDuration: usecase.DefaultMeetingDurationMinutes},
+			{name: "Configured default", configured: 30, wantDuration: 30},
+			{name: "Explicit duration", configured: 30, duration: 90, wantDuration: 90},
+			{name: "Longest allowed", duration: usecase.MaxMeetingDurationMinutes, wantDuration: usecase.MaxMeetingDurationMinutes},
+			{name: "Negative", duration: -15, wantErr: usecase.ErrInvalidDuration},
+			{name: "Too long", duration: usecase.MaxMeetingDurationMinutes + 1, wantErr: usecase.ErrInvalidDuration},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				cfg := usecase.DefaultConfig()
+				cfg.DefaultMeetingDurationMinutes = tt.configured
+				noteUC := usecase.NewNoteUsecaseWithConfig(&mockNoteRepository{}, cfg)
+
+				note := &domain.Note{Title: "Sync", Content: "Notes", MeetingDate: meetingDate, DurationMinutes: tt.duration}
+				err := noteUC.CreateNote(note)
+
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+					return
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantDuration, note.DurationMinutes)
+			})
+		}
+	})
+
+	t.Run("Update keeps the stored duration", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{notes: []domain.Note{
+			{ID: 1, Title: "Sync", Content: "Notes", MeetingDate: meetingDate, DurationMinutes: 45, Version: 1},
+		}}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+
+		note := &domain.Note{ID: 1, Title: "Sync", Content: "Edited", MeetingDate: meetingDate, Version: 1}
+		err := noteUC.UpdateNote(note)
+
+		assert.NoError(t, err)
+		if assert.Len(t, mockRepo.updated, 1) {
+			assert.Equal(t, 45, mockRepo.updated[0].DurationMinutes)
+		}
+		assert.Equal(t, meetingDate.Add(45*time.Minute), note.EndTime)
+	})
+
+	t.Run("Update changes the duration", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{notes: []domain.Note{
+			{ID: 1, Title: "Sync", Content: "Notes", MeetingDate: meetingDate, DurationMinutes: 45, Version: 1},
+		}}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+
+		note := &domain.Note{ID: 1, Title: "Sync", Content: "Notes", MeetingDate: meetingDate, DurationMinutes: 20, Version: 1}
+		err := noteUC.UpdateNote(note)
+
+		assert.NoError(t, err)
+		if assert.Len(t, mockRepo.updated, 1) {
+			assert.Equal(t, 20, mockRepo.updated[0].DurationMinutes)
+		}
+		assert.Equal(t, meetingDate.Add(20*time.Minute), note.EndTime)
+	})
+}
+
+func TestMeetingLocation(t *testing.T) {
+	meetingDate := time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		url     string
+		wantURL string
+		wantErr error
+	}{
+		{name: "In person", wantURL: ""},
+		{name: "Remote", url: " https://meet.example.com/abc ", wantURL: "https://meet.example.com/abc"},
+		{name: "Not absolute", url: "meet.example.com/abc", wantErr: usecase.ErrInvalidMeetingURL},
+		{name: "Wrong scheme", url: "ftp://meet.example.com/abc", wantErr: usecase.ErrInvalidMeetingURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noteUC := usecase.NewNoteUsecase(&mockNoteRepository{})
+
+			note := &domain.Note{Title: "Sync", Content: "Notes", MeetingDate: meetingDate, Location: " Room 4 ", MeetingURL: tt.url}
+			err := noteUC.CreateNote(note)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				var validationErr *usecase.ValidationError
+				if assert.ErrorAs(t, err, &validationErr) {
+					assert.Equal(t, "meeting_url", validationErr.Fields[0].Field)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "Room 4", note.Location)
+			assert.Equal(t, tt.wantURL, note.MeetingURL)
+		})
+	}
+
+	t.Run("Update replaces the location", func(t *testing.T) {
+		mockRepo := &mockNoteRepository{notes: []domain.Note{
+			{ID: 1, Title: "Sync", Content: "Notes", MeetingDate: meetingDate, Location: "Room 4", Version: 1},
+		}}
+		noteUC := usecase.NewNoteUsecase(mockRepo)
+
+		note := &domain.Note{ID: 1, Title: "Sync", Content: "Notes", MeetingDate: meetingDate, MeetingURL: "https://meet.example.com/abc", Version: 1}
+		assert.NoError(t, noteUC.UpdateNote(note))
+		if assert.Len(t, mockRepo.updated, 1) {
+			assert.Equal(t, "", mockRepo.updated[0].Location)
+			assert.Equal(t, "https://meet.example.com/abc", mockRepo.updated[0].MeetingURL)
+		}
+	})
+
+	t.Run("Filter mode", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{})
+
+		_, _, err := noteUC.FilterNotes(domain.NoteFilter{MeetingMode: " Remote "}, domain.Page{})
+		assert.NoError(t, err)
+
+		_, _, err = noteUC.FilterNotes(domain.NoteFilter{MeetingMode: "hybrid"}, domain.Page{})
+		assert.ErrorIs(t, err, usecase.ErrInvalidMeetingMode)
+	})
+}
+
+func TestFilterNotesSort(t *testing.T) {
+	repo := memory.NewNoteRepository()
+	for i, title := range []string{"Beta", "alpha", "Gamma"} {
+		note := domain.Note{Title: title, Content: "sync notes", MeetingDate: time.Date(2025, time.March, 3-i, 10, 0, 0, 0, time.UTC)}
+		assert.NoError(t, repo.Create(&note))
+	}
+	noteUC := usecase.NewNoteUsecase(repo)
+
+	titles := func(filter domain.NoteFilter) []string {
+		notes, _, err := noteUC.FilterNotes(filter, domain.Page{})
+		assert.NoError(t, err)
+		var titles []string
+		for _, n := range notes {
+			titles = append(titles, n.Title)
+		}
+		return titles
+	}
+
+	assert.Equal(t, []string{"Beta", "alpha", "Gamma"}, titles(domain.NoteFilter{}), "newest meeting first by default")
+	assert.Equal(t, []string{"Gamma", "alpha", "Beta"}, titles(domain.NoteFilter{Sort: "meeting_date", Order: "asc"}))
+	assert.Equal(t, []string{"Beta", "Gamma", "alpha"}, titles(domain.NoteFilter{Sort: " Title "}), "titles default to ascending")
+	assert.Equal(t, []string{"alpha", "Gamma", "Beta"}, titles(domain.NoteFilter{Sort: "title", Order: "DESC"}))
+
+	from := time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []string{"Beta", "alpha"}, titles(domain.NoteFilter{Keyword: "sync", FromDate: &from, Sort: "title"}), "sorting composes with the other conditions")
+
+	_, _, err := noteUC.FilterNotes(domain.NoteFilter{Sort: "content"}, domain.Page{})
+	assert.ErrorIs(t, err, usecase.ErrInvalidSort)
+
+	_, _, err = noteUC.FilterNotes(domain.NoteFilter{Sort: "title", Order: "up"}, domain.Page{})
+	assert.ErrorIs(t, err, usecase.ErrInvalidSortOrder)
+}
+
+func TestFilterNotesYearMonth(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+
+	notes := []domain.Note{
+		{ID: 1, MeetingDate: time.Date(2025, time.May, 31, 23, 59, 59, 0, time.UTC)},
+		{ID: 2, MeetingDate: time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, MeetingDate: time.Date(2025, time.June, 30, 23, 59, 59, 999999000, time.UTC)},
+		{ID: 4, MeetingDate: time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 5, MeetingDate: time.Date(2024, time.December, 31, 12, 0, 0, 0, time.UTC)},
+		{ID: 6, MeetingDate: time.Date(2025, time.December, 31, 23, 0, 0, 0, time.UTC)},
+	}
+	from := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		location *time.Location
+		filter   domain.NoteFilter
+		wantIDs  []uint
+		wantErr  error
+	}{
+		{name: "Month", filter: domain.NoteFilter{Year: 2025, Month: 6}, wantIDs: []uint{2, 3}},
+		{name: "Whole year", filter: domain.NoteFilter{Year: 2025}, wantIDs: []uint{1, 2, 3, 4, 6}},
+		{name: "Month in the configured timezone", location: tokyo, filter: domain.NoteFilter{Year: 2025, Month: 6}, wantIDs: []uint{1, 2}},
+		{name: "Month in the requested timezone", filter: domain.NoteFilter{Year: 2025, Month: 6, Timezone: "Asia/Tokyo"}, wantIDs: []uint{1, 2}},
+		{name: "Requested timezone beats the configured one", location: tokyo, filter: domain.NoteFilter{Year: 2025, Month: 6, Timezone: "UTC"}, wantIDs: []uint{2, 3}},
+		{name: "Unknown timezone", filter: domain.NoteFilter{Year: 2025, Timezone: "Mars/Olympus_Mons"}, wantErr: usecase.ErrInvalidTimezone},
+		{name: "Month out of range", filter: domain.NoteFilter{Year: 2025, Month: 13}, wantErr: usecase.ErrInvalidMonth},
+		{name: "Negative month", filter: domain.NoteFilter{Year: 2025, Month: -1}, wantErr: usecase.ErrInvalidMonth},
+		{name: "Year out of range", filter: domain.NoteFilter{Year: 25}, wantErr: usecase.ErrInvalidYear},
+		{name: "Month without year", filter: domain.NoteFilter{Month: 6}, wantErr: usecase.ErrMonthWithoutYear},
+		{name: "Combined with fromDate", filter: domain.NoteFilter{Year: 2025, FromDate: &from}, wantErr: usecase.ErrYearWithDateRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := usecase.DefaultConfig()
+			if tt.location != nil {
+				cfg.Location = tt.location
+			}
+			noteUC := usecase.NewNoteUsecaseWithConfig(&mockNoteRepository{notes: notes}, cfg)
+
+			results, _, err := noteUC.FilterNotes(tt.filter, domain.Page{})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			ids := make([]uint, 0, len(results))
+			for _, n := range results {
+				ids = append(ids, n.ID)
+			}
+			sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestGetNoteStatsTimezone(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	repo := memory.NewNoteRepository()
+	// 02:30 UTC on 1 June is still 31 May in New York.
+	assert.NoError(t, repo.Create(&domain.Note{Title: "Late call", MeetingDate: time.Date(2025, time.June, 1, 2, 30, 0, 0, time.UTC)}))
+
+	tests := []struct {
+		name      string
+		location  *time.Location
+		tz        string
+		wantMonth time.Time
+		wantErr   error
+	}{
+		{name: "UTC by default", wantMonth: time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "Requested timezone", tz: "America/New_York", wantMonth: time.Date(2025, time.May, 1, 0, 0, 0, 0, newYork)},
+		{name: "Configured timezone", location: newYork, wantMonth: time.Date(2025, time.May, 1, 0, 0, 0, 0, newYork)},
+		{name: "Unknown timezone", tz: "Mars/Olympus_Mons", wantErr: usecase.ErrInvalidTimezone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := usecase.DefaultConfig()
+			if tt.location != nil {
+				cfg.Location = tt.location
+			}
+			noteUC := usecase.NewNoteUsecaseWithConfig(repo, cfg)
+
+			stats, err := noteUC.GetNoteStats(0, tt.tz)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, stats.ByMonth, 1)
+			assert.True(t, tt.wantMonth.Equal(stats.ByMonth[0].Month))
+		})
+	}
+}
+
+func TestCountFilteredNotes(t *testing.T) {
+	notes := []domain.Note{
+		{ID: 1, Title: "Planning", Category: "Team", MeetingDate: time.Date(2025, time.June, 2, 9, 0, 0, 0, time.UTC)},
+		{ID: 2, Title: "Retro", Category: " team", MeetingDate: time.Date(2025, time.June, 9, 9, 0, 0, 0, time.UTC)},
+		{ID: 3, Title: "Planning", MeetingDate: time.Date(2025, time.July, 1, 9, 0, 0, 0, time.UTC)},
+	}
+	from := time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		filter    domain.NoteFilter
+		forceFail bool
+		want      int64
+		wantErr   error
+	}{
+		{name: "Everything", want: 3},
+		{name: "Keyword and blank categories", filter: domain.NoteFilter{Keyword: " planning ", Categories: []string{"", "team"}}, want: 1},
+		{name: "Year and month", filter: domain.NoteFilter{Year: 2025, Month: 6}, want: 2},
+		{name: "Invalid date range", filter: domain.NoteFilter{FromDate: &from, ToDate: &to}, wantErr: usecase.ErrInvalidDateRange},
+		{name: "Year with a date range", filter: domain.NoteFilter{Year: 2025, ToDate: &to}, wantErr: usecase.ErrYearWithDateRange},
+		{name: "Repository error", forceFail: true, wantErr: errors.New("failed to count notes")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes, forceDBFail: tt.forceFail})
+
+			count, err := noteUC.CountFilteredNotes(tt.filter)
+
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, count)
+		})
+	}
+}
+
+func TestFilterNotesCleanup(t *testing.T) {
+	notes := []domain.Note{
+		{ID: 1, Title: "Planning", Content: "A full agenda and actions", Category: "Team"},
+		{ID: 2, Title: "Planning follow-up", Content: "tbd"},
+		{ID: 3, Title: "Retro", Content: "What went well and what didn't"},
+		{ID: 4, Title: "Sync", Content: "ok", Category: "Team"},
+	}
+
+	tests := []struct {
+		name    string
+		filter  domain.NoteFilter
+		wantIDs []uint
+	}{
+		{name: "Uncategorized only", filter: domain.NoteFilter{UncategorizedOnly: true}, wantIDs: []uint{2, 3}},
+		{name: "Short content", filter: domain.NoteFilter{MaxContentLength: 5}, wantIDs: []uint{2, 4}},
+		{name: "Composes with keyword", filter: domain.NoteFilter{UncategorizedOnly: true, Keyword: "planning"}, wantIDs: []uint{2}},
+		{name: "Both cleanup filters", filter: domain.NoteFilter{UncategorizedOnly: true, MaxContentLength: 5}, wantIDs: []uint{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes})
+
+			results, _, err := noteUC.FilterNotes(tt.filter, domain.Page{})
+
+			assert.NoError(t, err)
+			ids := make([]uint, 0, len(results))
+			for _, n := range results {
+				ids = append(ids, n.ID)
+			}
+			sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestFilterNotesKeywords(t *testing.T) {
+	notes := []domain.Note{
+		{ID: 1, Title: "Budget review", Content: "Q3 numbers"},
+		{ID: 2, Title: "Budget draft", Content: "Next year"},
+		{ID: 3, Title: "Q3 retro", Content: "What went well"},
+	}
+
+	tests := []struct {
+		name    string
+		filter  domain.NoteFilter
+		wantIDs []uint
+		wantErr error
+	}{
+		{name: "Defaults to any", filter: domain.NoteFilter{Keywords: []string{"budget", "q3"}}, wantIDs: []uint{1, 2, 3}},
+		{name: "All", filter: domain.NoteFilter{Keywords: []string{"budget", "q3"}, KeywordMatch: " ALL "}, wantIDs: []uint{1}},
+		{name: "Blank keywords dropped", filter: domain.NoteFilter{Keywords: []string{" budget ", "", "  "}, KeywordMatch: domain.KeywordMatchAll}, wantIDs: []uint{1, 2}},
+		{name: "Invalid match", filter: domain.NoteFilter{Keywords: []string{"budget"}, KeywordMatch: "some"}, wantErr: usecase.ErrInvalidKeywordMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes})
+
+			results, _, err := noteUC.FilterNotes(tt.filter, domain.Page{})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			ids := make([]uint, 0, len(results))
+			for _, n := range results {
+				ids = append(ids, n.ID)
+			}
+			sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestContentSanitization(t *testing.T) {
+	meetingDate := time.Now()
+
+	tests := []struct {
+		name        string
+		policy      usecase.ContentPolicy
+		content     string
+		wantContent string
+		wantErr     error
+	}{
+		{
+			name:        "Plain text is unchanged",
+			content:     "Q&A: is 2 < 3? Yes -> ship it",
+			wantContent: "Q&A: is 2 < 3? Yes -> ship it",
+		},
+		{
+			name:        "Strip all removes tags and scripts",
+			content:     `<p onclick="steal()">Q&A <b>notes</b></p><script>alert(1)</script>`,
+			wantContent: "Q&A notes",
+		},
+		{
+			name:        "Strip all keeps escaped markup escaped",
+			content:     "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>",
+			wantContent: "&lt;script&gt;alert(1)&lt;/script&gt;",
+		},
+		{
+			name:    "Content that was only a script is empty",
+			content: "<script>alert(1)</script>",
+			wantErr: usecase.ErrEmptyContent,
+		},
+		{
+			name:        "Safe formatting keeps formatting",
+			policy:      usecase.ContentPolicySafeFormatting,
+			content:     `<p onclick="steal()">Agreed <b>next steps</b></p><ul><li>Ship</li></ul><script>alert(1)</script>`,
+			wantContent: "<p>Agreed <b>next steps</b></p><ul><li>Ship</li></ul>",
+		},
+		{
+			name:        "Safe formatting leaves plain text unchanged",
+			policy:      usecase.ContentPolicySafeFormatting,
+			content:     "Q&A: is 2 < 3?",
+			wantContent: "Q&A: is 2 < 3?",
+		},
+	}
+
+	for _, tt := range tests {
+		cfg := usecase.DefaultConfig()
+		if tt.policy != "" {
+			cfg.ContentPolicy = tt.policy
+		}
+
+		t.Run(tt.name+" on create", func(t *testing.T) {
+			mockRepo := &mockNoteRepository{}
+			noteUC := usecase.NewNoteUsecaseWithConfig(mockRepo, cfg)
+			note := domain.Note{Title: "Sync", Content: tt.content, MeetingDate: meetingDate}
+
+			err := noteUC.CreateNote(&note)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Len(t, mockRepo.notes, 0)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantContent, mockRepo.notes[0].Content)
+		})
+
+		t.Run(tt.name+" on update", func(t *testing.T) {
+			mockRepo := &mockNoteRepository{notes: []domain.Note{{ID: 1, Title: "Old", Content: "Old", MeetingDate: meetingDate, Version: 1}}}
+			noteUC := usecase.NewNoteUsecaseWithConfig(mockRepo, cfg)
+			note := domain.Note{ID: 1, Title: "Sync", Content: tt.content, MeetingDate: meetingDate, Version: 1}
+
+			err := noteUC.UpdateNote(&note)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantContent, note.Content)
+		})
+	}
+}
+
+func TestAddAttachment(t *testing.T) {
+	note := domain.Note{ID: 1, OwnerID: 7, Title: "Planning", Content: "Agenda"}
+
+	tests := []struct {
+		name       string
+		noteID     uint
+		ownerID    uint
+		attachment domain.Attachment
+		wantFields []string
+		wantErr    error
+	}{
+		{
+			name:       "Valid attachment",
+			noteID:     1,
+			ownerID:    7,
+			attachment: domain.Attachment{Filename: "  slides.pdf ", URL: "https://files.example.com/slides.pdf", SizeBytes: 2048, ContentType: "application/pdf"},
+		},
+		{
+			name:       "Missing note",
+			noteID:     2,
+			ownerID:    7,
+			attachment: domain.Attachment{Filename: "slides.pdf", URL: "https://files.example.com/slides.pdf"},
+			wantErr:    usecase.ErrNoteNotFound,
+		},
+		{
+			name:       "Other owner",
+			noteID:     1,
+			ownerID:    8,
+			attachment: domain.Attachment{Filename: "slides.pdf", URL: "https://files.example.com/slides.pdf"},
+			wantErr:    usecase.ErrNoteNotFound,
+		},
+		{
+			name:       "Relative URL",
+			noteID:     1,
+			ownerID:    7,
+			attachment: domain.Attachment{Filename: "slides.pdf", URL: "/files/slides.pdf"},
+			wantFields: []string{"url"},
+			wantErr:    usecase.ErrInvalidAttachmentURL,
+		},
+		{
+			name:       "Unsupported scheme",
+			noteID:     1,
+			ownerID:    7,
+			attachment: domain.Attachment{Filename: "slides.pdf", URL: "ftp://files.example.com/slides.pdf"},
+			wantFields: []string{"url"},
+			wantErr:    usecase.ErrInvalidAttachmentURL,
+		},
+		{
+			name:       "Every field invalid",
+			noteID:     1,
+			ownerID:    7,
+			attachment: domain.Attachment{Filename: " ", URL: "not a url", SizeBytes: -1},
+			wantFields: []string{"filename", "url", "size_bytes"},
+			wantErr:    usecase.ErrEmptyFilename,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{notes: []domain.Note{note}}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+
+			attachment := tt.attachment
+			err := noteUC.AddAttachment(tt.noteID, tt.ownerID, &attachment)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, mockRepo.attachments)
+				if tt.wantFields != nil {
+					var validationErr *usecase.ValidationError
+					assert.ErrorAs(t, err, &validationErr)
+					var fields []string
+					for _, f := range validationErr.Fields {
+						fields = append(fields, f.Field)
+					}
+					assert.Equal(t, tt.wantFields, fields)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, uint(1), attachment.ID)
+			assert.Equal(t, uint(1), attachment.NoteID)
+			assert.Equal(t, "slides.pdf", attachment.Filename)
+			assert.Len(t, mockRepo.attachments, 1)
+		})
+	}
+}
+
+func TestListAttachments(t *testing.T) {
+	note := domain.Note{ID: 1, OwnerID: 7, Title: "Planning", Content: "Agenda"}
+	stored := []domain.Attachment{
+		{ID: 1, NoteID: 1, Filename: "slides.pdf"},
+		{ID: 2, NoteID: 2, Filename: "other.pdf"},
+		{ID: 3, NoteID: 1, Filename: "minutes.docx"},
+	}
+
+	t.Run("Lists the note's attachments", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: []domain.Note{note}, attachments: stored})
+
+		attachments, err := noteUC.ListAttachments(1, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Attachment{stored[0], stored[2]}, attachments)
+	})
+
+	t.Run("Empty list rather than nil", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: []domain.Note{note}})
+
+		attachments, err := noteUC.ListAttachments(1, 7)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, attachments)
+		assert.Empty(t, attachments)
+	})
+
+	t.Run("Other owner", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: []domain.Note{note}, attachments: stored})
+
+		_, err := noteUC.ListAttachments(1, 8)
+
+		assert.ErrorIs(t, err, usecase.ErrNoteNotFound)
+	})
+}
+
+func TestAddNoteLink(t *testing.T) {
+	notes := []domain.Note{
+		{ID: 1, OwnerID: 7, Title: "Planning", Content: "Agenda"},
+		{ID: 2, OwnerID: 7, Title: "Planning follow-up", Content: "Actions"},
+		{ID: 4, OwnerID: 8, Title: "Someone else's", Content: "Private"},
+		{ID: 5, OwnerID: 8, Title: "Team wiki", Content: "Public", Visibility: domain.VisibilityPublic},
+	}
+
+	tests := []struct {
+		name       string
+		noteID     uint
+		link       domain.NoteLink
+		wantFields []string
+		wantErr    error
+	}{
+		{name: "Follow-up", noteID: 1, link: domain.NoteLink{ToNoteID: 2, Relation: " FollowUp "}},
+		{name: "Public note of another owner", noteID: 1, link: domain.NoteLink{ToNoteID: 5, Relation: "related"}},
+		{name: "Missing note", noteID: 9, link: domain.NoteLink{ToNoteID: 2}, wantErr: usecase.ErrNoteNotFound},
+		{name: "Note of another owner", noteID: 4, link: domain.NoteLink{ToNoteID: 2}, wantErr: usecase.ErrNoteNotFound},
+		{name: "Self-link", noteID: 1, link: domain.NoteLink{ToNoteID: 1}, wantFields: []string{"to_note_id"}, wantErr: usecase.ErrSelfLink},
+		{name: "Missing target", noteID: 1, link: domain.NoteLink{ToNoteID: 9}, wantFields: []string{"to_note_id"}, wantErr: usecase.ErrLinkTargetMissing},
+		{name: "Private target of another owner", noteID: 1, link: domain.NoteLink{ToNoteID: 4}, wantFields: []string{"to_note_id"}, wantErr: usecase.ErrLinkTargetMissing},
+		{name: "Unknown relation", noteID: 1, link: domain.NoteLink{ToNoteID: 2, Relation: "blocks"}, wantFields: []string{"relation"}, wantErr: usecase.ErrInvalidRelation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{notes: notes}
+			noteUC := usecase.NewNoteUsecase(mockRepo)
+
+			link := tt.link
+			linked, err := noteUC.AddNoteLink(tt.noteID, 7, &link)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, mockRepo.links)
+				if tt.wantFields != nil {
+					var validationErr *usecase.ValidationError
+					assert.ErrorAs(t, err, &validationErr)
+					var fields []string
+					for _, f := range validationErr.Fields {
+						fields = append(fields, f.Field)
+					}
+					assert.Equal(t, tt.wantFields, fields)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, uint(1), linked.Link.ID)
+			assert.Equal(t, tt.noteID, linked.Link.FromNoteID)
+			assert.Equal(t, tt.link.ToNoteID, linked.Note.ID)
+			assert.Equal(t, strings.ToLower(strings.TrimSpace(tt.link.Relation)), linked.Link.Relation)
+			assert.Len(t, mockRepo.links, 1)
+		})
+	}
+
+	t.Run("Blank relation means related", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes})
+
+		linked, err := noteUC.AddNoteLink(1, 7, &domain.NoteLink{ToNoteID: 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.LinkRelated, linked.Link.Relation)
+	})
+
+	t.Run("Duplicate link", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes})
+
+		_, err := noteUC.AddNoteLink(1, 7, &domain.NoteLink{ToNoteID: 2, Relation: "followup"})
+		assert.NoError(t, err)
+		_, err = noteUC.AddNoteLink(1, 7, &domain.NoteLink{ToNoteID: 2, Relation: "related"})
+
+		assert.ErrorIs(t, err, usecase.ErrDuplicateLink)
+	})
+}
+
+func TestListNoteLinks(t *testing.T) {
+	notes := []domain.Note{
+		{ID: 1, OwnerID: 7, Title: "Planning", Content: "Agenda for the quarter"},
+		{ID: 2, OwnerID: 7, Title: "Planning follow-up", Content: "Actions from planning"},
+		{ID: 4, OwnerID: 8, Title: "Kickoff", Content: "Kickoff notes", Visibility: domain.VisibilityPublic},
+		{ID: 5, OwnerID: 8, Title: "Private", Content: "Not for 7"},
+	}
+	links := []domain.NoteLink{
+		{ID: 1, FromNoteID: 1, ToNoteID: 2, Relation: domain.LinkFollowUp},
+		{ID: 2, FromNoteID: 4, ToNoteID: 1, Relation: domain.LinkRelated},
+		{ID: 3, FromNoteID: 5, ToNoteID: 1, Relation: domain.LinkRelated},
+		{ID: 4, FromNoteID: 2, ToNoteID: 4, Relation: domain.LinkRelated},
+	}
+
+	t.Run("Links both ways with summaries", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes, links: links})
+
+		linked, err := noteUC.ListNoteLinks(1, 7)
+
+		assert.NoError(t, err)
+		assert.Len(t, linked, 2)
+		assert.Equal(t, links[0], linked[0].Link)
+		assert.Equal(t, uint(2), linked[0].Note.ID)
+		assert.Equal(t, "Actions from planning", linked[0].Note.Summary)
+		assert.Equal(t, links[1], linked[1].Link)
+		assert.Equal(t, uint(4), linked[1].Note.ID)
+	})
+
+	t.Run("Leaves out notes the caller can't read", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes, links: links})
+
+		linked, err := noteUC.ListNoteLinks(4, 9)
+
+		assert.NoError(t, err)
+		assert.Empty(t, linked)
+	})
+
+	t.Run("Empty list rather than nil", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes})
+
+		linked, err := noteUC.ListNoteLinks(1, 7)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, linked)
+		assert.Empty(t, linked)
+	})
+
+	t.Run("Note the caller can't read", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes, links: links})
+
+		_, err := noteUC.ListNoteLinks(5, 7)
+
+		assert.ErrorIs(t, err, usecase.ErrNoteNotFound)
+	})
+}
+
+func TestGetMeetingsOnDay(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+
+	// 02:30 UTC on 10 June is still 9 June in New York and already late
+	// morning in Tokyo.
+	notes := []domain.Note{
+		{ID: 1, Title: "Near midnight", MeetingDate: time.Date(2025, time.June, 10, 2, 30, 0, 0, time.UTC)},
+		{ID: 2, Title: "Midday", MeetingDate: time.Date(2025, time.June, 10, 12, 0, 0, 0, time.UTC)},
+		{ID: 3, Title: "Evening", MeetingDate: time.Date(2025, time.June, 10, 23, 0, 0, 0, time.UTC)},
+		{ID: 4, Title: "Archived", MeetingDate: time.Date(2025, time.June, 10, 13, 0, 0, 0, time.UTC), Archived: true},
+	}
+
+	tests := []struct {
+		name    string
+		day     time.Time
+		wantIDs []uint
+	}{
+		{name: "UTC", day: time.Date(2025, time.June, 10, 8, 0, 0, 0, time.UTC), wantIDs: []uint{1, 2, 3}},
+		{name: "New York same date", day: time.Date(2025, time.June, 10, 8, 0, 0, 0, newYork), wantIDs: []uint{2, 3}},
+		{name: "New York previous date", day: time.Date(2025, time.June, 9, 21, 0, 0, 0, newYork), wantIDs: []uint{1}},
+		{name: "Tokyo same date", day: time.Date(2025, time.June, 10, 8, 0, 0, 0, tokyo), wantIDs: []uint{1, 2}},
+		{name: "Tokyo next date", day: time.Date(2025, time.June, 11, 8, 0, 0, 0, tokyo), wantIDs: []uint{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: notes})
+
+			meetings, err := noteUC.GetMeetingsOnDay(0, tt.day)
+
+			assert.NoError(t, err)
+			ids := make([]uint, 0, len(meetings))
+			for _, n := range meetings {
+				ids = append(ids, n.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+
+	t.Run("Repository error", func(t *testing.T) {
+		noteUC := usecase.NewNoteUsecase(&mockNoteRepository{forceDBFail: true})
+
+		_, err := noteUC.GetMeetingsOnDay(0, time.Now())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetTodaysMeetings(t *testing.T) {
+	now := time.Now()
+	noteUC := usecase.NewNoteUsecase(&mockNoteRepository{notes: []domain.Note{{ID: 1, Title: "Now", MeetingDate: now}}})
+
+	t.Run("Defaults to UTC", func(t *testing.T) {
+		meetings, err := noteUC.GetTodaysMeetings(0, "")
+		assert.NoError(t, err)
+		assert.Len(t, meetings, 1)
+	})
+
+	t.Run("Named timezone", func(t *testing.T) {
+		meetings, err := noteUC.GetTodaysMeetings(0, "America/New_York")
+		assert.NoError(t, err)
+		assert.Len(t, meetings, 1)
+	})
+
+	t.Run("Unknown timezone", func(t *testing.T) {
+		_, err := noteUC.GetTodaysMeetings(0, "Mars/Olympus_Mons")
+		assert.ErrorIs(t, err, usecase.ErrInvalidTimezone)
+	})
+}
+
+type mockAuditRepository struct {
+	entries []domain.AuditLog
+	fail    bool
+}
+
+func (m *mockAuditRepository) Create(entry *domain.AuditLog) error {
+	if m.fail {
+		return errors.New("db error")
+	}
+	entry.ID = uint(len(m.entries) + 1)
+	m.entries = append(m.entries, *entry)
+	return nil
+}
+
+func (m *mockAuditRepository) ListByNote(noteID uint) ([]domain.AuditLog, error) {
+	if m.fail {
+		return nil, errors.New("db error")
+	}
+
+	var entries []domain.AuditLog
+	for _, e := range m.entries {
+		if e.NoteID == noteID {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+func TestAuditLog(t *testing.T) {
+	meetingDate := time.Date(2025, time.June, 10, 9, 0, 0, 0, time.UTC)
+	audit := &mockAuditRepository{}
+	cfg := usecase.DefaultConfig()
+	cfg.AuditLog = audit
+	noteUC := usecase.NewNoteUsecaseWithConfig(&mockNoteRepository{notes: []domain.Note{
+		{ID: 1, OwnerID: 7, Title: "Planning", Content: "Agenda", Category: "Team", Format: domain.NoteFormatPlaintext, Priority: domain.PriorityNormal, Visibility: domain.VisibilityPrivate, MeetingDate: meetingDate, Version: 1},
+	}}, cfg)
+
+	assert.NoError(t, noteUC.CreateNote(&domain.Note{Title: "Anonymous", Content: "Agenda", MeetingDate: meetingDate}))
+	assert.NoError(t, noteUC.UpdateNote(&domain.Note{ID: 1, OwnerID: 7, Title: "Planning v2", Content: "Agenda", Category: "Team", MeetingDate: meetingDate, Version: 1}))
+	assert.NoError(t, noteUC.DeleteNote(1, 7))
+
+	if !assert.Len(t, audit.entries, 3) {
+		return
+	}
+
+	created := audit.entries[0]
+	assert.Equal(t, domain.AuditCreate, created.Operation)
+	assert.Nil(t, created.ActorID, "anonymous requests have no actor")
+	assert.Equal(t, domain.FieldChange{From: nil, To: "Anonymous"}, created.Changes["title"])
+	assert.Len(t, created.Changes, 9)
+
+	updated := audit.entries[1]
+	assert.Equal(t, domain.AuditUpdate, updated.Operation)
+	if assert.NotNil(t, updated.ActorID) {
+		assert.Equal(t, uint(7), *updated.ActorID)
+	}
+	assert.Equal(t, domain.FieldChanges{
+		"title": {From: "Planning", To: "Planning v2"},
+	}, updated.Changes)
+
+	deleted := audit.entries[2]
+	assert.Equal(t, domain.AuditDelete, deleted.Operation)
+	assert.Equal(t, uint(1), deleted.NoteID)
+	assert.Len(t, deleted.Changes, 9)
+	assert.NotNil(t, deleted.Changes["title"].From)
+	assert.Nil(t, deleted.Changes["title"].To)
+}
+
+func TestAuditLogHardDelete(t *testing.T) {
+	audit := &mockAuditRepository{}
+	cfg := usecase.DefaultConfig()
+	cfg.AuditLog = audit
+	cfg.HardDelete = true
+	noteUC := usecase.NewNoteUsecaseWithConfig(&mockNoteRepository{notes: []domain.Note{
+		{ID: 1, OwnerID: 7, Title: "Planning", Content: "Agenda", MeetingDate: time.Now(), Version: 1},
+	}}, cfg)
+
+	assert.NoError(t, noteUC.DeleteNote(1, 7))
+
+	if assert.Len(t, audit.entries, 1) {
+		assert.Equal(t, domain.AuditDelete, audit.entries[0].Operation)
+		assert.Equal(t, domain.FieldChanges{}, audit.entries[0].Changes, "a hard delete keeps no copy of the note")
+	}
+}
+
+func TestAuditLogFailureIsNotFatal(t *testing.T) {
+	cfg := usecase.DefaultConfig()
+	cfg.AuditLog = &mockAuditRepository{fail: true}
+	mockRepo := &mockNoteRepository{}
+	noteUC := usecase.NewNoteUsecaseWithConfig(mockRepo, cfg)
+
+	err := noteUC.CreateNote(&domain.Note{Title: "Planning", Content: "Agenda", MeetingDate: time.Now()})
+
+	assert.NoError(t, err)
+	assert.Len(t, mockRepo.notes, 1)
+}
+
+func TestListAuditLog(t *testing.T) {
+	note := domain.Note{ID: 1, OwnerID: 7, Title: "Planning", Content: "Agenda"}
+	stored := []domain.AuditLog{
+		{ID: 1, NoteID: 1, Operation: domain.AuditCreate},
+		{ID: 2, NoteID: 2, Operation: domain.AuditCreate},
+		{ID: 3, NoteID: 1, Operation: domain.AuditUpdate},
+	}
+
+	tests := []struct {
+		name    string
+		audit   *mockAuditRepository
+		ownerID uint
+		wantIDs []uint
+		wantErr error
+	}{
+		{name: "Note's entries", audit: &mockAuditRepository{entries: stored}, ownerID: 7, wantIDs: []uint{1, 3}},
+		{name: "Auditing off", ownerID: 7, wantIDs: []uint{}},
+		{name: "Other owner", audit: &mockAuditRepository{entries: stored}, ownerID: 8, wantErr: usecase.ErrNoteNotFound},
+		{name: "Repository error", audit: &mockAuditRepository{fail: true}, ownerID: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := usecase.DefaultConfig()
+			if tt.audit != nil {
+				cfg.AuditLog = tt.audit
+			}
+			noteUC := usecase.NewNoteUsecaseWithConfig(&mockNoteRepository{notes: []domain.Note{note}}, cfg)
+
+			entries, err := noteUC.ListAuditLog(1, tt.ownerID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if tt.wantIDs == nil {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			ids := make([]uint, 0, len(entries))
+			for _, e := range entries {
+				ids = append(ids, e.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestSearchNotesByKeywordFuzzyFallback(t *testing.T) {
+	notes := []domain.Note{
+		{ID: 1, Title: "Daily standup", Content: "Blockers"},
+		{ID: 2, Title: "Budget", Content: "Numbers"},
+	}
+	fuzzy := []domain.Note{notes[0]}
+
+	tests := []struct {
+		name          string
+		keyword       string
+		threshold     float64
+		fuzzyFail     bool
+		wantIDs       []uint
+		wantFuzzy     bool
+		wantThreshold float64
+	}{
+		{name: "Exact match skips fuzzy search", keyword: "budget", threshold: 0.3, wantIDs: []uint{2}},
+		{name: "No exact match falls back", keyword: "stanup", threshold: 0.4, wantIDs: []uint{1}, wantFuzzy: true, wantThreshold: 0.4},
+		{name: "Fallback turned off", keyword: "stanup", threshold: 0, wantIDs: []uint{}},
+		{name: "Fuzzy failure returns no results", keyword: "stanup", threshold: 0.3, fuzzyFail: true, wantIDs: []uint{}, wantThreshold: 0.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockNoteRepository{notes: notes, fuzzy: fuzzy, fuzzyFail: tt.fuzzyFail}
+			cfg := usecase.DefaultConfig()
+			cfg.FuzzySearchThreshold = tt.threshold
+			noteUC := usecase.NewNoteUsecaseWithConfig(mockRepo, cfg)
+
+			results, total, err := noteUC.SearchNotesByKeyword(0, tt.keyword, domain.DateRange{}, domain.Page{})
+
+			assert.NoError(t, err)
+			ids := make([]uint, 0, len(results))
+			for _, r := range results {
+				ids = append(ids, r.Note.ID)
+				assert.Equal(t, tt.wantFuzzy, r.Fuzzy)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, int64(len(tt.wantIDs)), total)
+			assert.Equal(t, tt.wantThreshold, mockRepo.fuzzyThreshold)
+		})
+	}
+}
+
+func TestNoteSummaries(t *testing.T) {
+	repo := memory.NewNoteRepository()
+	cfg := usecase.DefaultConfig()
+	cfg.SummaryLength = 12
+	noteUC := usecase.NewNoteUsecaseWithConfig(repo, cfg)
+	monday := time.Date(2025, time.June, 16, 10, 30, 0, 0, time.UTC)
+
+	note := domain.Note{Title: "Planning", Content: "Agreed on the roadmap", MeetingDate: monday}
+	assert.NoError(t, noteUC.CreateNote(&note))
+	assert.Equal(t, "Agreed on...", note.Summary)
+
+	note.Content = "Budget"
+	assert.NoError(t, noteUC.UpdateNote(&note))
+	assert.Equal(t, "Budget", note.Summary)
+
+	found, err := noteUC.GetNoteByID(note.ID, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "Budget", found.Summary)
+
+	filtered, _, err := noteUC.FilterNotes(domain.NoteFilter{}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "Budget", filtered[0].Summary)
+
+	results, _, err := noteUC.SearchNotesByKeyword(0, "budget", domain.DateRange{}, domain.Page{})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "Budget", results[0].Note.Summary)
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+	"github.com/jt00721/meeting-notes-manager/internal/repository"
+	"gorm.io/gorm"
+)
+
+// PresetUsecase manages each user's filter presets. CreatePreset and
+// UpdatePreset act on p.OwnerID's presets; the rest take the owner.
+type PresetUsecase interface {
+	CreatePreset(p *domain.FilterPreset) error
+	GetAllPresets(ownerID uint) ([]domain.FilterPreset, error)
+	GetPreset(ownerID uint, name string) (domain.FilterPreset, error)
+	UpdatePreset(p *domain.FilterPreset) error
+	DeletePreset(ownerID uint, name string) error
+}
+
+type presetUsecase struct {
+	repo   repository.PresetRepository
+	logger logger.Logger
+}
+
+func NewPresetUsecase(r repository.PresetRepository) *presetUsecase {
+	return NewPresetUsecaseWithLogger(r, logger.Default())
+}
+
+func NewPresetUsecaseWithLogger(r repository.PresetRepository, l logger.Logger) *presetUsecase {
+	return &presetUsecase{repo: r, logger: l}
+}
+
+func (uc *presetUsecase) CreatePreset(p *domain.FilterPreset) error {
+	if err := validatePreset(p); err != nil {
+		return err
+	}
+
+	if _, err := uc.repo.GetByName(p.OwnerID, p.Name); err == nil {
+		return ErrPresetExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		uc.logger.Error("error checking preset name", "operation", "create_preset", "preset", p.Name, "error", err)
+		return fmt.Errorf("failed to create preset")
+	}
+
+	if err := uc.repo.Create(p); err != nil {
+		uc.logger.Error("error creating preset", "operation", "create_preset", "preset", p.Name, "error", err)
+		return fmt.Errorf("failed to create preset")
+	}
+
+	uc.logger.Info("preset created", "operation", "create_preset", "preset", p.Name)
+	return nil
+}
+
+func (uc *presetUsecase) GetAllPresets(ownerID uint) ([]domain.FilterPreset, error) {
+	presets, err := uc.repo.GetAll(ownerID)
+	if err != nil {
+		uc.logger.Error("error retrieving presets", "operation", "get_presets", "error", err)
+		return nil, fmt.Errorf("failed to get presets")
+	}
+	return presets, nil
+}
+
+func (uc *presetUsecase) GetPreset(ownerID uint, name string) (domain.FilterPreset, error) {
+	preset, err := uc.repo.GetByName(ownerID, strings.TrimSpace(name))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.FilterPreset{}, ErrPresetNotFound
+		}
+		uc.logger.Error("error retrieving preset", "operation", "get_preset", "preset", name, "error", err)
+		return domain.FilterPreset{}, fmt.Errorf("failed to get preset")
+	}
+	return preset, nil
+}
+
+// UpdatePreset replaces the filter of p.OwnerID's preset named p.Name.
+func (uc *presetUsecase) UpdatePreset(p *domain.FilterPreset) error {
+	if err := validatePreset(p); err != nil {
+		return err
+	}
+
+	existing, err := uc.GetPreset(p.OwnerID, p.Name)
+	if err != nil {
+		return err
+	}
+
+	existing.Filter = p.Filter
+	if err := uc.repo.Update(&existing); err != nil {
+		uc.logger.Error("error updating preset", "operation", "update_preset", "preset", p.Name, "error", err)
+		return fmt.Errorf("failed to update preset")
+	}
+
+	*p = existing
+	uc.logger.Info("preset updated", "operation", "update_preset", "preset", p.Name)
+	return nil
+}
+
+func (uc *presetUsecase) DeletePreset(ownerID uint, name string) error {
+	deleted, err := uc.repo.Delete(ownerID, strings.TrimSpace(name))
+	if err != nil {
+		uc.logger.Error("error deleting preset", "operation", "delete_preset", "preset", name, "error", err)
+		return fmt.Errorf("failed to delete preset")
+	}
+	if deleted == 0 {
+		return ErrPresetNotFound
+	}
+
+	uc.logger.Info("preset deleted", "operation", "delete_preset", "preset", name)
+	return nil
+}
+
+func validatePreset(p *domain.FilterPreset) error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return ErrEmptyPresetName
+	}
+
+	return validateFilterRanges(p.Filter)
+}
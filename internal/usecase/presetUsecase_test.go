@@ -0,0 +1,190 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type mockPresetRepository struct {
+	presets     []domain.FilterPreset
+	forceDBFail bool
+}
+
+func (m *mockPresetRepository) Create(p *domain.FilterPreset) error {
+	if m.forceDBFail {
+		return errors.New("db error")
+	}
+	p.ID = uint(len(m.presets) + 1)
+	m.presets = append(m.presets, *p)
+	return nil
+}
+
+func (m *mockPresetRepository) GetAll(ownerID uint) ([]domain.FilterPreset, error) {
+	if m.forceDBFail {
+		return nil, errors.New("db error")
+	}
+	var presets []domain.FilterPreset
+	for _, p := range m.presets {
+		if p.OwnerID == ownerID {
+			presets = append(presets, p)
+		}
+	}
+	return presets, nil
+}
+
+func (m *mockPresetRepository) GetByName(ownerID uint, name string) (domain.FilterPreset, error) {
+	if m.forceDBFail {
+		return domain.FilterPreset{}, errors.New("db error")
+	}
+	for _, p := range m.presets {
+		if p.OwnerID == ownerID && p.Name == name {
+			return p, nil
+		}
+	}
+	return domain.FilterPreset{}, gorm.ErrRecordNotFound
+}
+
+func (m *mockPresetRepository) Update(p *domain.FilterPreset) error {
+	for i := range m.presets {
+		if m.presets[i].ID == p.ID {
+			m.presets[i] = *p
+		}
+	}
+	return nil
+}
+
+func (m *mockPresetRepository) Delete(ownerID uint, name string) (int64, error) {
+	remaining := make([]domain.FilterPreset, 0)
+	for _, p := range m.presets {
+		if p.OwnerID != ownerID || p.Name != name {
+			remaining = append(remaining, p)
+		}
+	}
+	deleted := int64(len(m.presets) - len(remaining))
+	m.presets = remaining
+	return deleted, nil
+}
+
+func TestCreatePreset(t *testing.T) {
+	fromDate := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		input       domain.FilterPreset
+		forceDBFail bool
+		wantErr     bool
+		errContains error
+	}{
+		{
+			name:  "Valid preset",
+			input: domain.FilterPreset{Name: " one-on-ones ", Filter: domain.NoteFilter{Categories: []string{"1:1"}}},
+		},
+		{
+			name:        "Duplicate name",
+			input:       domain.FilterPreset{Name: "standups"},
+			wantErr:     true,
+			errContains: usecase.ErrPresetExists,
+		},
+		{
+			name:        "Empty name",
+			input:       domain.FilterPreset{Name: "  "},
+			wantErr:     true,
+			errContains: usecase.ErrEmptyPresetName,
+		},
+		{
+			name:        "Illogical date range",
+			input:       domain.FilterPreset{Name: "backwards", Filter: domain.NoteFilter{FromDate: &fromDate, ToDate: &toDate}},
+			wantErr:     true,
+			errContains: usecase.ErrInvalidDateRange,
+		},
+		{
+			name:        "Repo error",
+			input:       domain.FilterPreset{Name: "new"},
+			forceDBFail: true,
+			wantErr:     true,
+			errContains: errors.New("failed to create preset"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockPresetRepository{
+				presets:     []domain.FilterPreset{{ID: 1, Name: "standups"}},
+				forceDBFail: tt.forceDBFail,
+			}
+			presetUC := usecase.NewPresetUsecase(mockRepo)
+
+			err := presetUC.CreatePreset(&tt.input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains.Error())
+				assert.Len(t, mockRepo.presets, 1)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, mockRepo.presets, 2)
+				assert.Equal(t, "one-on-ones", mockRepo.presets[1].Name)
+			}
+		})
+	}
+}
+
+func TestGetUpdateDeletePreset(t *testing.T) {
+	newUC := func() (usecase.PresetUsecase, *mockPresetRepository) {
+		mockRepo := &mockPresetRepository{
+			presets: []domain.FilterPreset{{ID: 1, Name: "standups", Filter: domain.NoteFilter{Categories: []string{"Standup"}}}},
+		}
+		return usecase.NewPresetUsecase(mockRepo), mockRepo
+	}
+
+	t.Run("Get unknown preset", func(t *testing.T) {
+		presetUC, _ := newUC()
+		_, err := presetUC.GetPreset(0, "missing")
+		assert.ErrorIs(t, err, usecase.ErrPresetNotFound)
+	})
+
+	t.Run("Update replaces filter", func(t *testing.T) {
+		presetUC, mockRepo := newUC()
+		err := presetUC.UpdatePreset(&domain.FilterPreset{Name: "standups", Filter: domain.NoteFilter{Keyword: "blocker"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "blocker", mockRepo.presets[0].Filter.Keyword)
+		assert.Empty(t, mockRepo.presets[0].Filter.Categories)
+	})
+
+	t.Run("Update unknown preset", func(t *testing.T) {
+		presetUC, _ := newUC()
+		err := presetUC.UpdatePreset(&domain.FilterPreset{Name: "missing"})
+		assert.ErrorIs(t, err, usecase.ErrPresetNotFound)
+	})
+
+	t.Run("Delete preset", func(t *testing.T) {
+		presetUC, mockRepo := newUC()
+		assert.NoError(t, presetUC.DeletePreset(0, "standups"))
+		assert.Len(t, mockRepo.presets, 0)
+		assert.ErrorIs(t, presetUC.DeletePreset(0, "standups"), usecase.ErrPresetNotFound)
+	})
+
+	t.Run("Presets are per owner", func(t *testing.T) {
+		presetUC, mockRepo := newUC()
+		assert.NoError(t, presetUC.CreatePreset(&domain.FilterPreset{OwnerID: 7, Name: "standups"}))
+		assert.Len(t, mockRepo.presets, 2)
+
+		_, err := presetUC.GetPreset(8, "standups")
+		assert.ErrorIs(t, err, usecase.ErrPresetNotFound)
+		err = presetUC.UpdatePreset(&domain.FilterPreset{OwnerID: 8, Name: "standups"})
+		assert.ErrorIs(t, err, usecase.ErrPresetNotFound)
+		assert.ErrorIs(t, presetUC.DeletePreset(8, "standups"), usecase.ErrPresetNotFound)
+
+		presets, err := presetUC.GetAllPresets(7)
+		assert.NoError(t, err)
+		assert.Len(t, presets, 1)
+		assert.Equal(t, uint(7), presets[0].OwnerID)
+	})
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+// DefaultRelatedLimit and MaxRelatedLimit bound how many notes
+// GetRelatedNotes returns.
+const (
+	DefaultRelatedLimit = 5
+	MaxRelatedLimit     = 50
+)
+
+// GetRelatedNotes returns up to limit of the owner's other notes that look
+// related to note id. Notes in the same category (matched case-insensitively)
+// are related; when the note has no category, notes sharing a title word are
+// used instead, most shared words first. Ties go to the note whose meeting is
+// closest in time. Archived and deleted notes are never returned.
+func (uc *noteUsecase) GetRelatedNotes(id, ownerID uint, limit int) ([]domain.Note, error) {
+	if limit <= 0 {
+		limit = DefaultRelatedLimit
+	}
+	if limit > MaxRelatedLimit {
+		limit = MaxRelatedLimit
+	}
+
+	note, err := uc.ownedNote(id, ownerID)
+	if err != nil {
+		if errors.Is(err, ErrNoteNotFound) {
+			return nil, ErrNoteNotFound
+		}
+		return nil, fmt.Errorf("failed to get related notes")
+	}
+
+	candidates, err := uc.repo.GetAllByOwner(ownerID, false)
+	if err != nil {
+		uc.logger.Error("error retrieving related notes", "operation", "get_related", "note_id", id, "error", err)
+		return nil, fmt.Errorf("failed to get related notes")
+	}
+
+	category := strings.TrimSpace(note.Category)
+	titleWords := titleWordSet(note.Title)
+
+	type scored struct {
+		note  domain.Note
+		score int
+	}
+	var related []scored
+	for _, candidate := range candidates {
+		if candidate.ID == note.ID {
+			continue
+		}
+
+		if category != "" {
+			if strings.EqualFold(strings.TrimSpace(candidate.Category), category) {
+				related = append(related, scored{note: candidate})
+			}
+			continue
+		}
+
+		if shared := sharedWords(titleWords, titleWordSet(candidate.Title)); shared > 0 {
+			related = append(related, scored{note: candidate, score: shared})
+		}
+	}
+
+	sort.SliceStable(related, func(i, j int) bool {
+		if related[i].score != related[j].score {
+			return related[i].score > related[j].score
+		}
+		di := absDuration(related[i].note.MeetingDate.Sub(note.MeetingDate))
+		dj := absDuration(related[j].note.MeetingDate.Sub(note.MeetingDate))
+		if di != dj {
+			return di < dj
+		}
+		return related[i].note.ID < related[j].note.ID
+	})
+
+	if len(related) > limit {
+		related = related[:limit]
+	}
+
+	notes := make([]domain.Note, 0, len(related))
+	for _, r := range related {
+		notes = append(notes, r.note)
+	}
+
+	uc.logger.Info("related notes retrieved", "operation", "get_related", "note_id", id, "count", len(notes))
+	return uc.withSummaries(notes), nil
+}
+
+// titleWordSet lower-cases a title and splits it into words, ignoring
+// punctuation and words too short to say much.
+func titleWordSet(title string) map[string]bool {
+	words := map[string]bool{}
+	for _, word := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		if len([]rune(word)) >= 3 {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+func sharedWords(a, b map[string]bool) int {
+	shared := 0
+	for word := range a {
+		if b[word] {
+			shared++
+		}
+	}
+	return shared
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
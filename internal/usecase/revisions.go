@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jt00721/meeting-notes-manager/internal/diff"
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+// ListRevisions returns the owner's note history, oldest first. Notes written
+// before revisions were recorded have no stored history, so the current
+// state is appended whenever it is missing.
+func (uc *noteUsecase) ListRevisions(noteID, ownerID uint) ([]domain.NoteRevision, error) {
+	note, err := uc.ownedNote(noteID, ownerID)
+	if err != nil {
+		if errors.Is(err, ErrNoteNotFound) {
+			return nil, ErrNoteNotFound
+		}
+		return nil, fmt.Errorf("failed to list revisions")
+	}
+
+	revisions, err := uc.repo.ListRevisions(noteID)
+	if err != nil {
+		uc.logger.Error("error listing revisions", "operation", "list_revisions", "note_id", noteID, "error", err)
+		return nil, fmt.Errorf("failed to list revisions")
+	}
+
+	if len(revisions) == 0 || revisions[len(revisions)-1].Version < note.Version {
+		revisions = append(revisions, domain.NoteRevision{
+			NoteID:    note.ID,
+			Version:   note.Version,
+			Title:     note.Title,
+			Content:   note.Content,
+			Category:  note.Category,
+			CreatedAt: note.UpdatedAt,
+		})
+	}
+
+	uc.logger.Info("revisions listed", "operation", "list_revisions", "note_id", noteID, "count", len(revisions))
+	return revisions, nil
+}
+
+// DiffRevisions returns a line-based diff of the note's content going from
+// version from to version to.
+func (uc *noteUsecase) DiffRevisions(noteID, ownerID uint, from, to int) (string, error) {
+	revisions, err := uc.ListRevisions(noteID, ownerID)
+	if err != nil {
+		return "", err
+	}
+
+	fromRev, ok := findRevision(revisions, from)
+	if !ok {
+		return "", ErrRevisionNotFound
+	}
+	toRev, ok := findRevision(revisions, to)
+	if !ok {
+		return "", ErrRevisionNotFound
+	}
+
+	return diff.Format(diff.Lines(fromRev.Content, toRev.Content)), nil
+}
+
+func findRevision(revisions []domain.NoteRevision, version int) (domain.NoteRevision, bool) {
+	for _, r := range revisions {
+		if r.Version == version {
+			return r, true
+		}
+	}
+	return domain.NoteRevision{}, false
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"html"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// ContentPolicy controls which HTML survives in a note's Content.
+type ContentPolicy string
+
+const (
+	// ContentPolicyStripAll removes every tag, leaving plain text.
+	ContentPolicyStripAll ContentPolicy = "strip_all"
+	// ContentPolicySafeFormatting keeps formatting such as paragraphs,
+	// lists, emphasis and links, and removes scripts, styles and event
+	// handler attributes.
+	ContentPolicySafeFormatting ContentPolicy = "safe_formatting"
+)
+
+// markup matches an HTML tag or comment. Content without one is plain text
+// and is never rewritten, so a stray "<" or "&" is kept as typed.
+var markup = regexp.MustCompile(`<(?:[a-zA-Z][^<>]*|/[a-zA-Z][^<>]*|!--.*?--)>`)
+
+type contentSanitizer struct {
+	policy   *bluemonday.Policy
+	stripAll bool
+}
+
+func newContentSanitizer(p ContentPolicy) *contentSanitizer {
+	if p == ContentPolicySafeFormatting {
+		return &contentSanitizer{policy: bluemonday.UGCPolicy()}
+	}
+	return &contentSanitizer{policy: bluemonday.StrictPolicy(), stripAll: true}
+}
+
+// sanitize applies the policy to content that contains markup.
+func (s *contentSanitizer) sanitize(content string) string {
+	if !markup.MatchString(content) {
+		return content
+	}
+
+	clean := s.policy.Sanitize(content)
+	if !s.stripAll {
+		return clean
+	}
+
+	// The strict policy escapes the text it keeps. Undo that so "<b>Q&A</b>"
+	// becomes "Q&A", unless unescaping would bring back a tag.
+	if text := html.UnescapeString(clean); !markup.MatchString(text) {
+		return text
+	}
+	return clean
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/repository"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+)
+
+// SlugPolicy decides what happens to a note's slug when its title changes.
+type SlugPolicy string
+
+const (
+	// SlugPolicyPreserve keeps the slug a note was created with, so links
+	// to it keep working.
+	SlugPolicyPreserve SlugPolicy = "preserve"
+	// SlugPolicyRegenerate derives a new slug from the new title.
+	SlugPolicyRegenerate SlugPolicy = "regenerate"
+)
+
+const (
+	maxSlugLength = 80
+	// fallbackSlug is used for titles with no ASCII letters or digits left.
+	fallbackSlug = "note"
+)
+
+// slugLetters spells out Latin letters that don't decompose into an ASCII
+// letter and a mark.
+var slugLetters = strings.NewReplacer(
+	"ß", "ss", "æ", "ae", "œ", "oe", "ø", "o", "đ", "d", "ð", "d", "ł", "l", "þ", "th", "ı", "i",
+)
+
+// stripMarks returns a transformer that removes accents. A chain keeps state
+// between calls, so each slugify needs its own.
+func stripMarks() transform.Transformer {
+	return transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+}
+
+// slugify turns a title into lowercase ASCII words joined by hyphens.
+// Accented letters lose their accents and anything else outside a-z and 0-9
+// separates words, so "Café Q3 – Kick-off!" becomes "cafe-q3-kick-off".
+func slugify(title string) string {
+	folded, _, err := transform.String(stripMarks(), slugLetters.Replace(strings.ToLower(title)))
+	if err != nil {
+		folded = title
+	}
+
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(folded) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+			continue
+		}
+		hyphen = true
+	}
+
+	slug := b.String()
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+		if i := strings.LastIndexByte(slug, '-'); i > 0 {
+			slug = slug[:i]
+		}
+	}
+	if slug == "" {
+		return fallbackSlug
+	}
+	return slug
+}
+
+// slugMatches reports whether slug is base or base with a numeric suffix, as
+// handed out by uniqueSlug.
+func slugMatches(slug, base string) bool {
+	if slug == base {
+		return true
+	}
+	suffix, ok := strings.CutPrefix(slug, base+"-")
+	if !ok {
+		return false
+	}
+	_, err := strconv.Atoi(suffix)
+	return err == nil
+}
+
+// uniqueSlug derives a slug from title that no other note uses, adding "-2",
+// "-3" and so on to the base slug as needed.
+func (uc *noteUsecase) uniqueSlug(title string) (string, error) {
+	return uc.uniqueSlugExcept(title, nil)
+}
+
+// uniqueSlugExcept is uniqueSlug that also avoids the slugs in reserved,
+// for notes that have one but aren't stored yet.
+func (uc *noteUsecase) uniqueSlugExcept(title string, reserved map[string]bool) (string, error) {
+	base := slugify(title)
+
+	taken, err := uc.repo.SlugsWithPrefix(base)
+	if err != nil {
+		return "", err
+	}
+
+	used := make(map[string]bool, len(taken)+len(reserved))
+	for _, slug := range taken {
+		used[slug] = true
+	}
+	for slug := range reserved {
+		used[slug] = true
+	}
+
+	slug := base
+	for n := 2; used[slug]; n++ {
+		slug = base + "-" + strconv.Itoa(n)
+	}
+	return slug, nil
+}
+
+// createSlugAttempts is how many slugs createWithSlug tries before giving up.
+const createSlugAttempts = 3
+
+// createWithSlug gives n a unique slug and stores it. A note with the same
+// title created at the same moment can take the slug between the two steps,
+// so on ErrDuplicateSlug it picks a slug again and retries.
+func (uc *noteUsecase) createWithSlug(n *domain.Note) error {
+	original := *n
+	for attempt := 1; ; attempt++ {
+		slug, err := uc.uniqueSlug(n.Title)
+		if err != nil {
+			return fmt.Errorf("generating slug: %w", err)
+		}
+		n.Slug = slug
+
+		err = uc.repo.Create(n)
+		if !errors.Is(err, repository.ErrDuplicateSlug) || attempt == createSlugAttempts {
+			return err
+		}
+		uc.logger.Warn("note slug taken, retrying", "operation", "create", "slug", slug)
+		*n = original
+	}
+}
+
+// refreshSlug gives an updated note a slug if it has none, or a new one when
+// the policy says to follow title changes.
+func (uc *noteUsecase) refreshSlug(stored *domain.Note, title string) error {
+	if stored.Slug != "" && (uc.config.SlugPolicy != SlugPolicyRegenerate || slugMatches(stored.Slug, slugify(title))) {
+		return nil
+	}
+
+	slug, err := uc.uniqueSlug(title)
+	if err != nil {
+		return err
+	}
+	stored.Slug = slug
+	return nil
+}
+
+// GetNoteBySlug returns the note with the given slug if ownerID may read it.
+func (uc *noteUsecase) GetNoteBySlug(slug string, ownerID uint) (domain.Note, error) {
+	if slug == "" {
+		return domain.Note{}, ErrNoteNotFound
+	}
+
+	note, err := uc.repo.GetBySlug(slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Note{}, ErrNoteNotFound
+		}
+		uc.logger.Error("error retrieving note by slug", "operation", "get_by_slug", "slug", slug, "error", err)
+		return domain.Note{}, fmt.Errorf("failed to retrieve note")
+	}
+
+	if !note.VisibleTo(ownerID) {
+		uc.logger.Warn("note not visible to caller", "operation", "get_by_slug", "slug", slug, "owner_id", ownerID)
+		return domain.Note{}, ErrNoteNotFound
+	}
+
+	uc.logger.Info("note retrieved", "operation", "get_by_slug", "note_id", note.ID)
+	uc.setSummary(&note)
+	return note, nil
+}
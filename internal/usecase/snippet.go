@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// snippetContext is the number of characters kept on each side of a match.
+	snippetContext = 15
+	highlightOpen  = "**"
+	highlightClose = "**"
+	ellipsis       = "..."
+)
+
+// buildSnippet returns a short excerpt around the first case-insensitive
+// match of keyword, checking content before title. The matched text keeps
+// its original casing and is wrapped in highlight markers. It returns "" when
+// neither field contains the keyword.
+func buildSnippet(title, content, keyword string) string {
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" {
+		return ""
+	}
+
+	for _, text := range []string{content, title} {
+		if snippet, ok := snippetAround(text, keyword); ok {
+			return snippet
+		}
+	}
+	return ""
+}
+
+func snippetAround(text, keyword string) (string, bool) {
+	runes := []rune(text)
+	needle := []rune(keyword)
+
+	start := indexFold(runes, needle)
+	if start < 0 {
+		return "", false
+	}
+	end := start + len(needle)
+
+	from := start - snippetContext
+	if from < 0 {
+		from = 0
+	}
+	to := end + snippetContext
+	if to > len(runes) {
+		to = len(runes)
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString(ellipsis)
+	}
+	b.WriteString(string(runes[from:start]))
+	b.WriteString(highlightOpen)
+	b.WriteString(string(runes[start:end]))
+	b.WriteString(highlightClose)
+	b.WriteString(string(runes[end:to]))
+	if to < len(runes) {
+		b.WriteString(ellipsis)
+	}
+	return b.String(), true
+}
+
+// indexFold is a rune based, case-insensitive index so offsets stay valid
+// for the original text.
+func indexFold(haystack, needle []rune) int {
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		match := true
+		for j, r := range needle {
+			if unicode.ToLower(haystack[i+j]) != unicode.ToLower(r) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSnippet(t *testing.T) {
+	tests := []struct {
+		name    string
+		title   string
+		content string
+		keyword string
+		want    string
+	}{
+		{
+			name:    "Match in short content",
+			title:   "Standup",
+			content: "Discussed the budget",
+			keyword: "budget",
+			want:    "Discussed the **budget**",
+		},
+		{
+			name:    "Case-insensitive match keeps original casing",
+			title:   "Standup",
+			content: "Discussed the Budget today",
+			keyword: "BUDGET",
+			want:    "Discussed the **Budget** today",
+		},
+		{
+			name:    "Long content is trimmed around match",
+			title:   "Planning",
+			content: "We spent most of the meeting talking about the roadmap for next quarter and hiring",
+			keyword: "roadmap",
+			want:    "...king about the **roadmap** for next quart...",
+		},
+		{
+			name:    "Keyword only in title",
+			title:   "Quarterly Review",
+			content: "Went over numbers",
+			keyword: "review",
+			want:    "Quarterly **Review**",
+		},
+		{
+			name:    "Content match preferred over title",
+			title:   "Budget sync",
+			content: "Final budget approved",
+			keyword: "budget",
+			want:    "Final **budget** approved",
+		},
+		{
+			name:    "No match",
+			title:   "Standup",
+			content: "Sprint items",
+			keyword: "budget",
+			want:    "",
+		},
+		{
+			name:    "Multi-byte characters",
+			title:   "Réunion",
+			content: "Café meeting résumé",
+			keyword: "RÉSUMÉ",
+			want:    "Café meeting **résumé**",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, buildSnippet(tt.title, tt.content, tt.keyword))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+// DefaultSummaryLength is how many characters of content a note's Summary
+// keeps when Config.SummaryLength is unset.
+const DefaultSummaryLength = 200
+
+// summarize returns the start of content for list views: whitespace runs,
+// line breaks included, become single spaces, and content longer than limit
+// characters is cut at the last word boundary within the limit and ends in an
+// ellipsis. A single word longer than limit is cut mid-word.
+func summarize(content string, limit int) string {
+	runes := []rune(strings.Join(strings.Fields(content), " "))
+	if len(runes) <= limit {
+		return string(runes)
+	}
+
+	cut := limit
+	if runes[limit] != ' ' {
+		for i := limit - 1; i > 0; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+	}
+	return strings.TrimRight(string(runes[:cut]), " ") + ellipsis
+}
+
+// setSummary fills in the Summary of each note.
+func (uc *noteUsecase) setSummary(notes ...*domain.Note) {
+	for _, n := range notes {
+		n.Summary = summarize(n.Content, uc.config.SummaryLength)
+	}
+}
+
+// withSummaries fills in the Summary of each note in notes and returns it.
+func (uc *noteUsecase) withSummaries(notes []domain.Note) []domain.Note {
+	for i := range notes {
+		uc.setSummary(&notes[i])
+	}
+	return notes
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		limit   int
+		want    string
+	}{
+		{
+			name:    "Short content is kept whole",
+			content: "Agreed on the roadmap",
+			limit:   200,
+			want:    "Agreed on the roadmap",
+		},
+		{
+			name:    "Content exactly at the limit is kept whole",
+			content: "Agreed on the roadmap",
+			limit:   21,
+			want:    "Agreed on the roadmap",
+		},
+		{
+			name:    "Long content is cut at a word boundary",
+			content: "Agreed on the roadmap for next quarter",
+			limit:   16,
+			want:    "Agreed on the...",
+		},
+		{
+			name:    "Limit falling on a space keeps the whole word before it",
+			content: "Agreed on the roadmap",
+			limit:   13,
+			want:    "Agreed on the...",
+		},
+		{
+			name:    "Line breaks and repeated spaces collapse",
+			content: "  Agenda:\n\n- budget\t\t- hiring  ",
+			limit:   200,
+			want:    "Agenda: - budget - hiring",
+		},
+		{
+			name:    "Multibyte characters count as one",
+			content: "Café résumé naïve coöperate",
+			limit:   12,
+			want:    "Café résumé...",
+		},
+		{
+			name:    "A single long word is cut mid-word",
+			content: "Supercalifragilistic",
+			limit:   5,
+			want:    "Super...",
+		},
+		{
+			name:    "Empty content",
+			content: "",
+			limit:   200,
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, summarize(tt.content, tt.limit))
+		})
+	}
+}
+
+func TestSummarizeDefaultLength(t *testing.T) {
+	content := strings.Repeat("word ", 100)
+
+	summary := summarize(content, DefaultSummaryLength)
+	assert.True(t, strings.HasSuffix(summary, ellipsis))
+	assert.LessOrEqual(t, len([]rune(strings.TrimSuffix(summary, ellipsis))), DefaultSummaryLength)
+	assert.False(t, strings.HasSuffix(strings.TrimSuffix(summary, ellipsis), " "))
+}
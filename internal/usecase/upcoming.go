@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/timeutil"
+)
+
+// GetUpcomingMeetings returns the notes viewerID may read whose meeting is
+// between now and now+within, soonest first.
+func (uc *noteUsecase) GetUpcomingMeetings(viewerID uint, within time.Duration) ([]domain.Note, error) {
+	if within <= 0 {
+		return nil, ErrInvalidWindow
+	}
+
+	now := time.Now()
+	notes, err := uc.repo.GetUpcoming(viewerID, now, now.Add(within))
+	if err != nil {
+		uc.logger.Error("error retrieving upcoming meetings", "operation", "get_upcoming", "error", err)
+		return nil, fmt.Errorf("failed to get upcoming meetings")
+	}
+
+	uc.logger.Info("upcoming meetings retrieved", "operation", "get_upcoming", "count", len(notes))
+	return uc.withSummaries(notes), nil
+}
+
+// GetTodaysMeetings returns the notes viewerID may read whose meeting falls
+// on the current calendar day in the IANA timezone tz, earliest first. An
+// empty tz means the configured timezone.
+func (uc *noteUsecase) GetTodaysMeetings(viewerID uint, tz string) ([]domain.Note, error) {
+	loc, err := timeutil.LoadLocation(tz, uc.config.Location)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+
+	return uc.GetMeetingsOnDay(viewerID, time.Now().In(loc))
+}
+
+// GetMeetingsOnDay returns the notes viewerID may read whose meeting falls on
+// day's calendar date in day's location, earliest first. The same meeting
+// can fall on different days in different locations.
+func (uc *noteUsecase) GetMeetingsOnDay(viewerID uint, day time.Time) ([]domain.Note, error) {
+	start := timeutil.StartOfDay(day, day.Location())
+	// AddDate rather than 24 hours, so days with a DST change are covered.
+	end := start.AddDate(0, 0, 1)
+
+	notes, err := uc.repo.GetUpcoming(viewerID, start, end)
+	if err != nil {
+		uc.logger.Error("error retrieving meetings for day", "operation", "get_meetings_on_day", "day", start.Format("2006-01-02"), "location", day.Location().String(), "error", err)
+		return nil, fmt.Errorf("failed to get meetings for day")
+	}
+
+	uc.logger.Info("meetings for day retrieved", "operation", "get_meetings_on_day", "day", start.Format("2006-01-02"), "location", day.Location().String(), "count", len(notes))
+	return uc.withSummaries(notes), nil
+}
+
+// SendMeetingReminders publishes a note.reminder event for every meeting
+// starting between now+lead and now+lead+interval. Called once per interval,
+// the windows line up end to end so each meeting is reminded about once.
+func (uc *noteUsecase) SendMeetingReminders(lead, interval time.Duration) (int, error) {
+	if lead < 0 || interval <= 0 {
+		return 0, ErrInvalidWindow
+	}
+
+	from := time.Now().Add(lead)
+	notes, err := uc.repo.GetStartingBetween(from, from.Add(interval))
+	if err != nil {
+		uc.logger.Error("error retrieving meetings to remind", "operation", "remind", "error", err)
+		return 0, fmt.Errorf("failed to send meeting reminders")
+	}
+
+	for _, note := range notes {
+		uc.publish(domain.NoteReminder, note)
+	}
+
+	if len(notes) > 0 {
+		uc.logger.Info("meeting reminders sent", "operation", "remind", "count", len(notes))
+	}
+	return len(notes), nil
+}
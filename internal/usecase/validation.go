@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+)
+
+// FieldError is a single failed check on a note field.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+// ValidationError lists every field of a note that failed validation. It
+// unwraps to the individual errors, so errors.Is(err, ErrEmptyTitle) and
+// errors.As(err, &windowErr) keep working.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		messages = append(messages, f.Err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
+
+// ValidateNote runs the checks CreateNoteWithOptions would with opts without
+// saving anything. It may fill in a missing MeetingDate, template content and
+// add Warnings, exactly as a real create would.
+func (uc *noteUsecase) ValidateNote(n *domain.Note, opts CreateOptions) error {
+	uc.applyTemplate(n, opts)
+	uc.defaultMeetingDate(n)
+	uc.defaultDuration(n)
+	return uc.validateNote(n, opts)
+}
+
+func (uc *noteUsecase) defaultMeetingDate(n *domain.Note) {
+	if n.MeetingDate.IsZero() && uc.config.DefaultMeetingDateToNow {
+		n.MeetingDate = time.Now()
+	}
+}
+
+// defaultDuration gives a note without a duration the configured default.
+func (uc *noteUsecase) defaultDuration(n *domain.Note) {
+	if n.DurationMinutes == 0 {
+		n.DurationMinutes = uc.config.DefaultMeetingDurationMinutes
+	}
+}
+
+// normalizeNote trims surrounding whitespace from the title, content,
+// category, format, priority, visibility, location and meeting URL, and
+// collapses runs of whitespace inside the title to one space. A missing
+// format means plain text, a missing priority normal and a missing
+// visibility private.
+func normalizeNote(n *domain.Note) {
+	n.Title = strings.Join(strings.Fields(n.Title), " ")
+	n.Content = strings.TrimSpace(n.Content)
+	n.Category = strings.TrimSpace(n.Category)
+	n.Format = strings.ToLower(strings.TrimSpace(n.Format))
+	n.Priority = strings.ToLower(strings.TrimSpace(n.Priority))
+	n.Visibility = strings.ToLower(strings.TrimSpace(n.Visibility))
+	n.Location = strings.TrimSpace(n.Location)
+	n.MeetingURL = strings.TrimSpace(n.MeetingURL)
+	if n.Format == "" {
+		n.Format = domain.NoteFormatPlaintext
+	}
+	if n.Priority == "" {
+		n.Priority = domain.PriorityNormal
+	}
+	if n.Visibility == "" {
+		n.Visibility = domain.VisibilityPrivate
+	}
+}
+
+// AllowedCategories returns the categories notes are limited to, or nil when
+// any category is allowed.
+func (uc *noteUsecase) AllowedCategories() []string {
+	if len(uc.config.AllowedCategories) == 0 {
+		return nil
+	}
+	return append([]string(nil), uc.config.AllowedCategories...)
+}
+
+// allowedCategory returns the configured spelling of category and whether
+// notes may use it. Blank categories and every category when there is no
+// allowlist are returned unchanged.
+func (uc *noteUsecase) allowedCategory(category string) (string, bool) {
+	if category == "" || len(uc.config.AllowedCategories) == 0 {
+		return category, true
+	}
+	for _, allowed := range uc.config.AllowedCategories {
+		if strings.EqualFold(allowed, category) {
+			return allowed, true
+		}
+	}
+	return category, false
+}
+
+// validateNote normalizes n and sanitizes its content, then collects every
+// failing check on it rather than stopping at the first, returning nil or a
+// *ValidationError. A title or content of only whitespace counts as empty, as
+// does content that was nothing but disallowed HTML.
+func (uc *noteUsecase) validateNote(n *domain.Note, opts CreateOptions) error {
+	normalizeNote(n)
+	n.Content = strings.TrimSpace(uc.sanitizer.sanitize(n.Content))
+
+	var fields []FieldError
+
+	if n.Title == "" {
+		fields = append(fields, FieldError{Field: "title", Err: ErrEmptyTitle})
+	}
+
+	if n.Content == "" {
+		fields = append(fields, FieldError{Field: "content", Err: ErrEmptyContent})
+	} else if err := uc.blocklist.check(n.Content); err != nil {
+		fields = append(fields, FieldError{Field: "content", Err: err})
+	}
+
+	if n.Format != domain.NoteFormatPlaintext && n.Format != domain.NoteFormatMarkdown {
+		fields = append(fields, FieldError{Field: "format", Err: ErrInvalidFormat})
+	}
+
+	if domain.PriorityRank(n.Priority) < 0 {
+		fields = append(fields, FieldError{Field: "priority", Err: ErrInvalidPriority})
+	}
+
+	switch n.Visibility {
+	case domain.VisibilityPrivate, domain.VisibilityShared, domain.VisibilityPublic:
+	default:
+		fields = append(fields, FieldError{Field: "visibility", Err: ErrInvalidVisibility})
+	}
+
+	if n.DurationMinutes <= 0 || n.DurationMinutes > MaxMeetingDurationMinutes {
+		fields = append(fields, FieldError{Field: "duration_minutes", Err: ErrInvalidDuration})
+	}
+
+	if n.MeetingURL != "" && !validHTTPURL(n.MeetingURL) {
+		fields = append(fields, FieldError{Field: "meeting_url", Err: ErrInvalidMeetingURL})
+	}
+
+	if category, ok := uc.allowedCategory(n.Category); ok {
+		n.Category = category
+	} else {
+		fields = append(fields, FieldError{Field: "category", Err: ErrInvalidCategory})
+	}
+
+	if n.MeetingDate.IsZero() {
+		fields = append(fields, FieldError{Field: "meeting_date", Err: ErrInvalidMeetingDate})
+	} else {
+		if !opts.Force {
+			if err := uc.checkMeetingDateWindow(n); err != nil {
+				fields = append(fields, FieldError{Field: "meeting_date", Err: err})
+			}
+		}
+
+		if err := uc.checkMeetingWeekday(n); err != nil {
+			fields = append(fields, FieldError{Field: "meeting_date", Err: err})
+		}
+	}
+
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
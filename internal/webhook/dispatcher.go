@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+)
+
+const (
+	DefaultMaxAttempts = 3
+	DefaultBackoff     = time.Second
+)
+
+// Dispatcher POSTs every note event as JSON to each of its URLs. Deliveries
+// run in the background and are retried with a doubling backoff until one
+// gets a 2xx response or MaxAttempts is reached.
+type Dispatcher struct {
+	URLs        []string
+	Client      *http.Client
+	MaxAttempts int
+	Backoff     time.Duration
+	Logger      logger.Logger
+
+	wg sync.WaitGroup
+}
+
+func NewDispatcher(urls []string) *Dispatcher {
+	return NewDispatcherWithLogger(urls, logger.Default())
+}
+
+func NewDispatcherWithLogger(urls []string, l logger.Logger) *Dispatcher {
+	return &Dispatcher{
+		URLs:        urls,
+		Client:      &http.Client{Timeout: 10 * time.Second},
+		MaxAttempts: DefaultMaxAttempts,
+		Backoff:     DefaultBackoff,
+		Logger:      l,
+	}
+}
+
+// Publish implements usecase.NoteEventPublisher. It returns immediately.
+func (d *Dispatcher) Publish(event domain.NoteEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		d.Logger.Error("error encoding webhook payload", "operation", "webhook", "event", event.Type, "error", err)
+		return
+	}
+
+	for _, url := range d.URLs {
+		d.wg.Add(1)
+		go func(url string) {
+			defer d.wg.Done()
+			d.deliver(url, event, payload)
+		}(url)
+	}
+}
+
+// Deliver POSTs event to every URL once and waits for the results. It returns
+// an error if any URL failed, leaving retries to the caller. It is used by the
+// outbox relay, which re-sends the event to every URL on the next attempt.
+func (d *Dispatcher) Deliver(event domain.NoteEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	var errs []error
+	for _, url := range d.URLs {
+		if err := d.post(url, payload); err != nil {
+			d.Logger.Warn("webhook delivery failed", "operation", "webhook", "event", event.Type, "note_id", event.Note.ID, "url", url, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", url, err))
+			continue
+		}
+		d.Logger.Info("webhook delivered", "operation", "webhook", "event", event.Type, "note_id", event.Note.ID, "url", url)
+	}
+	return errors.Join(errs...)
+}
+
+// Wait blocks until every delivery started so far has finished.
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) deliver(url string, event domain.NoteEvent, payload []byte) {
+	backoff := d.Backoff
+	for attempt := 1; attempt <= d.MaxAttempts; attempt++ {
+		err := d.post(url, payload)
+		if err == nil {
+			d.Logger.Info("webhook delivered", "operation", "webhook", "event", event.Type, "note_id", event.Note.ID, "url", url, "attempt", attempt)
+			return
+		}
+
+		d.Logger.Warn("webhook delivery failed", "operation", "webhook", "event", event.Type, "note_id", event.Note.ID, "url", url, "attempt", attempt, "error", err)
+		if attempt < d.MaxAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	d.Logger.Error("webhook gave up", "operation", "webhook", "event", event.Type, "note_id", event.Note.ID, "url", url, "attempts", d.MaxAttempts)
+}
+
+func (d *Dispatcher) post(url string, payload []byte) error {
+	resp, err := d.Client.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jt00721/meeting-notes-manager/internal/domain"
+	"github.com/jt00721/meeting-notes-manager/internal/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatcherPublish(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int32
+		wantAttempts int32
+		wantReceived bool
+	}{
+		{name: "Delivered first time", failures: 0, wantAttempts: 1, wantReceived: true},
+		{name: "Retried after failure", failures: 2, wantAttempts: 3, wantReceived: true},
+		{name: "Gives up after max attempts", failures: 5, wantAttempts: 3, wantReceived: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			var received domain.NoteEvent
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attempts, 1) <= tt.failures {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				body, _ := io.ReadAll(r.Body)
+				json.Unmarshal(body, &received)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			dispatcher := NewDispatcherWithLogger([]string{server.URL}, logger.New(io.Discard))
+			dispatcher.Backoff = time.Millisecond
+
+			dispatcher.Publish(domain.NoteEvent{Type: domain.NoteCreated, Note: domain.Note{ID: 7, Title: "Retro"}})
+			dispatcher.Wait()
+
+			assert.Equal(t, tt.wantAttempts, atomic.LoadInt32(&attempts))
+			if tt.wantReceived {
+				assert.Equal(t, domain.NoteCreated, received.Type)
+				assert.Equal(t, uint(7), received.Note.ID)
+			}
+		})
+	}
+}
+
+func TestDispatcherDeliver(t *testing.T) {
+	var received int32
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&received, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ok.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	event := domain.NoteEvent{Type: domain.NoteUpdated, Note: domain.Note{ID: 7}}
+
+	dispatcher := NewDispatcherWithLogger([]string{ok.URL}, logger.New(io.Discard))
+	assert.NoError(t, dispatcher.Deliver(event))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&received))
+
+	// Every URL is tried once, with no retries, and any failure is reported.
+	dispatcher = NewDispatcherWithLogger([]string{failing.URL, ok.URL}, logger.New(io.Discard))
+	err := dispatcher.Deliver(event)
+	assert.ErrorContains(t, err, "unexpected status 503")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&received))
+}